@@ -125,7 +125,7 @@ echo "ROOT_CA_KEY  = $ROOT_CA_KEY"
 
 if [[ "$ROOTCA" == "YES" && ("$FORCE" == "YES" || ! -f ${ROOT_CA_KEY}) ]]; then echo "*** generating ${ROOT_CA_CERT/.pem/''}"
     trusty-tool --hsm-cfg=${HSM_CONFIG} \
-        csr gencert --self-sign \
+        csr self-sign \
         --ca-config=${CA_CONFIG} \
         --profile=ROOT \
         --csr-profile ${CSR_DIR}/${PREFIX}root_ca.json \
@@ -136,7 +136,7 @@ fi
 if [[ "$CA1" == "YES" && ("$FORCE" == "YES" || ! -f ${OUT_DIR}/${PREFIX}issuer1_ca-key.pem) ]]; then
     echo "*** generating CA1 cert"
     trusty-tool --hsm-cfg=${HSM_CONFIG} \
-        csr gencert \
+        csr intermediate \
         --ca-config=${CA_CONFIG} \
         --profile=L1_CA \
         --csr-profile ${CSR_DIR}/${PREFIX}issuer1_ca.json \
@@ -149,7 +149,7 @@ fi
 if [[ "$CA2" == "YES" && ("$FORCE" == "YES" || ! -f ${OUT_DIR}/${PREFIX}issuer2_ca-key.pem) ]]; then
     echo "*** generating CA2 cert"
     trusty-tool --hsm-cfg=${HSM_CONFIG} \
-        csr gencert \
+        csr intermediate \
         --ca-config=${CA_CONFIG} \
         --profile=L2_CA \
         --csr-profile ${CSR_DIR}/${PREFIX}issuer2_ca.json \
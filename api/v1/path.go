@@ -51,3 +51,149 @@ const (
 	// PathForAuthGithubCallback is auth callback for github
 	PathForAuthGithubCallback = "/v1/auth/github/callback"
 )
+
+// CA service API
+const (
+	// PathForAIA is base path for the issuer certificates
+	PathForAIA = "/v1/certs"
+
+	// PathForAIAByID returns the certificate of the issuer,
+	// the ID is Subject Key ID of the issuer with optional extension:
+	// .crt, .cer or .der for DER, .pem for PEM, .p7c or .p7b for PKCS#7 chain
+	//
+	// Verbs: GET
+	// Response: the certificate
+	// Content-Type: application/pkix-cert, application/x-pem-file or application/pkcs7-mime
+	PathForAIAByID = "/v1/certs/:issuer_id"
+
+	// PathForCRL is base path for the CRL
+	PathForCRL = "/v1/crl"
+
+	// PathForCRLByID returns the CRL of the issuer,
+	// the ID is Subject Key ID of the issuer with optional .crl suffix
+	//
+	// Verbs: GET
+	// Response: CRL in DER format
+	// Content-Type: application/pkix-crl
+	PathForCRLByID = "/v1/crl/:issuer_id"
+
+	// PathForOCSP is OCSP responder end-point, RFC 6960
+	//
+	// Verbs: POST
+	// Request: DER encoded OCSPRequest
+	// Content-Type: application/ocsp-request
+	// Response: DER encoded OCSPResponse
+	// Content-Type: application/ocsp-response
+	PathForOCSP = "/v1/ocsp"
+
+	// PathForOCSPGet is OCSP responder end-point, RFC 6960,
+	// the request is url-encoding of base-64 encoding of DER encoded OCSPRequest
+	//
+	// Verbs: GET
+	// Response: DER encoded OCSPResponse
+	// Content-Type: application/ocsp-response
+	PathForOCSPGet = "/v1/ocsp/*request"
+)
+
+// ACME service API, RFC 8555
+const (
+	// PathForACME is base path for the ACME service
+	PathForACME = "/v1/acme"
+
+	// PathForACMEDirectory returns ACME directory object
+	//
+	// Verbs: GET
+	// Response: ACME directory
+	PathForACMEDirectory = "/v1/acme/directory"
+
+	// PathForACMENewNonce returns a fresh nonce in Replay-Nonce header
+	//
+	// Verbs: HEAD, GET
+	PathForACMENewNonce = "/v1/acme/new-nonce"
+
+	// PathForACMENewAccount creates a new account,
+	// or returns the existing account for the key
+	//
+	// Verbs: POST
+	// Content-Type: application/jose+json
+	PathForACMENewAccount = "/v1/acme/new-account"
+
+	// PathForACMEAccount returns or updates the account
+	//
+	// Verbs: POST
+	// Content-Type: application/jose+json
+	PathForACMEAccount = "/v1/acme/account/:id"
+
+	// PathForACMEAccountOrders returns the list of the account's orders
+	//
+	// Verbs: POST
+	// Content-Type: application/jose+json
+	PathForACMEAccountOrders = "/v1/acme/account/:id/orders"
+
+	// PathForACMENewOrder creates a new order
+	//
+	// Verbs: POST
+	// Content-Type: application/jose+json
+	PathForACMENewOrder = "/v1/acme/new-order"
+
+	// PathForACMEOrder returns the order
+	//
+	// Verbs: POST
+	// Content-Type: application/jose+json
+	PathForACMEOrder = "/v1/acme/order/:id"
+
+	// PathForACMEOrderFinalize finalizes the order with CSR
+	//
+	// Verbs: POST
+	// Content-Type: application/jose+json
+	PathForACMEOrderFinalize = "/v1/acme/order/:id/finalize"
+
+	// PathForACMEAuthz returns the authorization
+	//
+	// Verbs: POST
+	// Content-Type: application/jose+json
+	PathForACMEAuthz = "/v1/acme/authz/:id"
+
+	// PathForACMEChallenge returns the challenge,
+	// or starts the validation
+	//
+	// Verbs: POST
+	// Content-Type: application/jose+json
+	PathForACMEChallenge = "/v1/acme/challenge/:id"
+
+	// PathForACMECertificate returns the issued certificate chain
+	//
+	// Verbs: POST
+	// Content-Type: application/jose+json
+	// Response: PEM encoded certificate chain
+	// Content-Type: application/pem-certificate-chain
+	PathForACMECertificate = "/v1/acme/cert/:id"
+)
+
+// EST service API, RFC 7030
+const (
+	// PathForEST is base path for the EST service
+	PathForEST = "/.well-known/est"
+
+	// PathForESTOperation performs EST operation,
+	// the path is {op} for the default label, or {label}/{op},
+	// where the operation is one of cacerts, simpleenroll, simplereenroll or csrattrs
+	//
+	// Verbs: GET, POST
+	PathForESTOperation = "/.well-known/est/*op"
+)
+
+// SCEP service API, RFC 8894
+const (
+	// PathForSCEP performs SCEP operation specified by operation query parameter:
+	// GetCACert, GetCACaps or PKIOperation
+	//
+	// Verbs: GET, POST
+	PathForSCEP = "/v1/scep"
+
+	// PathForSCEPChallenge creates one-time challenge password for SCEP enrollment
+	//
+	// Verbs: POST
+	// Response: v1.SCEPChallengeResponse
+	PathForSCEPChallenge = "/v1/scep/challenge"
+)
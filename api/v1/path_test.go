@@ -17,4 +17,30 @@ func TestPaths(t *testing.T) {
 	assert.Equal(t, "/v1/auth/url", v1.PathForAuthURL)
 	assert.Equal(t, "/v1/auth/github", v1.PathForAuthGithub)
 	assert.Equal(t, "/v1/auth/github/callback", v1.PathForAuthGithubCallback)
+
+	assert.Equal(t, "/v1/certs", v1.PathForAIA)
+	assert.Equal(t, "/v1/certs/:issuer_id", v1.PathForAIAByID)
+	assert.Equal(t, "/v1/crl", v1.PathForCRL)
+	assert.Equal(t, "/v1/crl/:issuer_id", v1.PathForCRLByID)
+	assert.Equal(t, "/v1/ocsp", v1.PathForOCSP)
+	assert.Equal(t, "/v1/ocsp/*request", v1.PathForOCSPGet)
+
+	assert.Equal(t, "/v1/acme", v1.PathForACME)
+	assert.Equal(t, "/v1/acme/directory", v1.PathForACMEDirectory)
+	assert.Equal(t, "/v1/acme/new-nonce", v1.PathForACMENewNonce)
+	assert.Equal(t, "/v1/acme/new-account", v1.PathForACMENewAccount)
+	assert.Equal(t, "/v1/acme/account/:id", v1.PathForACMEAccount)
+	assert.Equal(t, "/v1/acme/account/:id/orders", v1.PathForACMEAccountOrders)
+	assert.Equal(t, "/v1/acme/new-order", v1.PathForACMENewOrder)
+	assert.Equal(t, "/v1/acme/order/:id", v1.PathForACMEOrder)
+	assert.Equal(t, "/v1/acme/order/:id/finalize", v1.PathForACMEOrderFinalize)
+	assert.Equal(t, "/v1/acme/authz/:id", v1.PathForACMEAuthz)
+	assert.Equal(t, "/v1/acme/challenge/:id", v1.PathForACMEChallenge)
+	assert.Equal(t, "/v1/acme/cert/:id", v1.PathForACMECertificate)
+
+	assert.Equal(t, "/.well-known/est", v1.PathForEST)
+	assert.Equal(t, "/.well-known/est/*op", v1.PathForESTOperation)
+
+	assert.Equal(t, "/v1/scep", v1.PathForSCEP)
+	assert.Equal(t, "/v1/scep/challenge", v1.PathForSCEPChallenge)
 }
@@ -0,0 +1,9 @@
+package v1
+
+import "time"
+
+// SCEPChallengeResponse provides response for SCEP challenge request
+type SCEPChallengeResponse struct {
+	Challenge string    `json:"challenge"`
+	Expires   time.Time `json:"expires"`
+}
@@ -12,11 +12,28 @@
 		X509Name
 		X509Subject
 		CertProfileInfoRequest
+		CAConstraint
+		CSRAllowedFields
+		KeyPolicy
+		CertificatePolicyQualifier
+		CertificatePolicy
 		CertProfileInfo
 		CertificateBundle
+		Extension
+		LintFinding
+		CertificatePreview
 		IssuerInfo
 		IssuersInfoResponse
 		CreateCertificateRequest
+		Certificate
+		RevokeCertificateRequest
+		RevokedCertificate
+		BlockKeyRequest
+		BlockedKey
+		CreateEnrollmentTokenRequest
+		EnrollmentToken
+		EnrollmentTokensResponse
+		RevokeEnrollmentTokenRequest
 		EmptyRequest
 		ServerVersion
 		ServerStatus
@@ -77,6 +94,52 @@ func (x EncodingFormat) String() string {
 }
 func (EncodingFormat) EnumDescriptor() ([]byte, []int) { return fileDescriptorPkix, []int{0} }
 
+// Reason specifies Certificate Revocation Reason from RFC 5280
+type Reason int32
+
+const (
+	Reason_UNSPECIFIED            Reason = 0
+	Reason_KEY_COMPROMISE         Reason = 1
+	Reason_CA_COMPROMISE          Reason = 2
+	Reason_AFFILIATION_CHANGED    Reason = 3
+	Reason_SUPERSEDED             Reason = 4
+	Reason_CESSATION_OF_OPERATION Reason = 5
+	Reason_CERTIFICATE_HOLD       Reason = 6
+	Reason_REMOVE_FROM_CRL        Reason = 8
+	Reason_PRIVILEGE_WITHDRAWN    Reason = 9
+	Reason_AA_COMPROMISE          Reason = 10
+)
+
+var Reason_name = map[int32]string{
+	0:  "UNSPECIFIED",
+	1:  "KEY_COMPROMISE",
+	2:  "CA_COMPROMISE",
+	3:  "AFFILIATION_CHANGED",
+	4:  "SUPERSEDED",
+	5:  "CESSATION_OF_OPERATION",
+	6:  "CERTIFICATE_HOLD",
+	8:  "REMOVE_FROM_CRL",
+	9:  "PRIVILEGE_WITHDRAWN",
+	10: "AA_COMPROMISE",
+}
+var Reason_value = map[string]int32{
+	"UNSPECIFIED":            0,
+	"KEY_COMPROMISE":         1,
+	"CA_COMPROMISE":          2,
+	"AFFILIATION_CHANGED":    3,
+	"SUPERSEDED":             4,
+	"CESSATION_OF_OPERATION": 5,
+	"CERTIFICATE_HOLD":       6,
+	"REMOVE_FROM_CRL":        8,
+	"PRIVILEGE_WITHDRAWN":    9,
+	"AA_COMPROMISE":          10,
+}
+
+func (x Reason) String() string {
+	return proto.EnumName(Reason_name, int32(x))
+}
+func (Reason) EnumDescriptor() ([]byte, []int) { return fileDescriptorPkix, []int{1} }
+
 // X509Name specifies X509 Name
 type X509Name struct {
 	Country            string `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
@@ -183,17 +246,225 @@ func (m *CertProfileInfoRequest) GetProfile() string {
 	return ""
 }
 
+// CAConstraint specifies various CA constraints on the signed certificate
+type CAConstraint struct {
+	IsCa           bool  `protobuf:"varint,1,opt,name=is_ca,json=isCa,proto3" json:"is_ca,omitempty"`
+	MaxPathLen     int32 `protobuf:"varint,2,opt,name=max_path_len,json=maxPathLen,proto3" json:"max_path_len,omitempty"`
+	MaxPathLenZero bool  `protobuf:"varint,3,opt,name=max_path_len_zero,json=maxPathLenZero,proto3" json:"max_path_len_zero,omitempty"`
+}
+
+func (m *CAConstraint) Reset()                    { *m = CAConstraint{} }
+func (m *CAConstraint) String() string            { return proto.CompactTextString(m) }
+func (*CAConstraint) ProtoMessage()               {}
+func (*CAConstraint) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{3} }
+
+func (m *CAConstraint) GetIsCa() bool {
+	if m != nil {
+		return m.IsCa
+	}
+	return false
+}
+
+func (m *CAConstraint) GetMaxPathLen() int32 {
+	if m != nil {
+		return m.MaxPathLen
+	}
+	return 0
+}
+
+func (m *CAConstraint) GetMaxPathLenZero() bool {
+	if m != nil {
+		return m.MaxPathLenZero
+	}
+	return false
+}
+
+// CSRAllowedFields provides booleans for fields in the CSR,
+// that may be copied from the CSR into the signed certificate.
+type CSRAllowedFields struct {
+	Subject bool `protobuf:"varint,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Dns     bool `protobuf:"varint,2,opt,name=dns,proto3" json:"dns,omitempty"`
+	Ip      bool `protobuf:"varint,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	Email   bool `protobuf:"varint,4,opt,name=email,proto3" json:"email,omitempty"`
+	Uris    bool `protobuf:"varint,5,opt,name=uris,proto3" json:"uris,omitempty"`
+}
+
+func (m *CSRAllowedFields) Reset()                    { *m = CSRAllowedFields{} }
+func (m *CSRAllowedFields) String() string            { return proto.CompactTextString(m) }
+func (*CSRAllowedFields) ProtoMessage()               {}
+func (*CSRAllowedFields) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{4} }
+
+func (m *CSRAllowedFields) GetSubject() bool {
+	if m != nil {
+		return m.Subject
+	}
+	return false
+}
+
+func (m *CSRAllowedFields) GetDns() bool {
+	if m != nil {
+		return m.Dns
+	}
+	return false
+}
+
+func (m *CSRAllowedFields) GetIp() bool {
+	if m != nil {
+		return m.Ip
+	}
+	return false
+}
+
+func (m *CSRAllowedFields) GetEmail() bool {
+	if m != nil {
+		return m.Email
+	}
+	return false
+}
+
+func (m *CSRAllowedFields) GetUris() bool {
+	if m != nil {
+		return m.Uris
+	}
+	return false
+}
+
+// KeyPolicy specifies allowed public keys
+type KeyPolicy struct {
+	// Algorithms specifies a list of allowed key algorithms: RSA, ECDSA, Ed25519
+	Algorithms []string `protobuf:"bytes,1,rep,name=algorithms" json:"algorithms,omitempty"`
+	// MinRsaSize specifies the minimum size of RSA key in bits
+	MinRsaSize int32 `protobuf:"varint,2,opt,name=min_rsa_size,json=minRsaSize,proto3" json:"min_rsa_size,omitempty"`
+	// Curves specifies a list of allowed ECDSA curves
+	Curves []string `protobuf:"bytes,3,rep,name=curves" json:"curves,omitempty"`
+	// MatchKeyUsage specifies that key usages must be applicable for the key type
+	MatchKeyUsage bool `protobuf:"varint,4,opt,name=match_key_usage,json=matchKeyUsage,proto3" json:"match_key_usage,omitempty"`
+}
+
+func (m *KeyPolicy) Reset()                    { *m = KeyPolicy{} }
+func (m *KeyPolicy) String() string            { return proto.CompactTextString(m) }
+func (*KeyPolicy) ProtoMessage()               {}
+func (*KeyPolicy) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{5} }
+
+func (m *KeyPolicy) GetAlgorithms() []string {
+	if m != nil {
+		return m.Algorithms
+	}
+	return nil
+}
+
+func (m *KeyPolicy) GetMinRsaSize() int32 {
+	if m != nil {
+		return m.MinRsaSize
+	}
+	return 0
+}
+
+func (m *KeyPolicy) GetCurves() []string {
+	if m != nil {
+		return m.Curves
+	}
+	return nil
+}
+
+func (m *KeyPolicy) GetMatchKeyUsage() bool {
+	if m != nil {
+		return m.MatchKeyUsage
+	}
+	return false
+}
+
+// CertificatePolicyQualifier represents a single qualifier from an ASN.1
+// PolicyInformation structure.
+type CertificatePolicyQualifier struct {
+	Type  string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *CertificatePolicyQualifier) Reset()                    { *m = CertificatePolicyQualifier{} }
+func (m *CertificatePolicyQualifier) String() string            { return proto.CompactTextString(m) }
+func (*CertificatePolicyQualifier) ProtoMessage()               {}
+func (*CertificatePolicyQualifier) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{6} }
+
+func (m *CertificatePolicyQualifier) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *CertificatePolicyQualifier) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// CertificatePolicy represents the ASN.1 PolicyInformation structure
+type CertificatePolicy struct {
+	Id         string                        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Qualifiers []*CertificatePolicyQualifier `protobuf:"bytes,2,rep,name=qualifiers" json:"qualifiers,omitempty"`
+}
+
+func (m *CertificatePolicy) Reset()                    { *m = CertificatePolicy{} }
+func (m *CertificatePolicy) String() string            { return proto.CompactTextString(m) }
+func (*CertificatePolicy) ProtoMessage()               {}
+func (*CertificatePolicy) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{7} }
+
+func (m *CertificatePolicy) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CertificatePolicy) GetQualifiers() []*CertificatePolicyQualifier {
+	if m != nil {
+		return m.Qualifiers
+	}
+	return nil
+}
+
 // CertProfileInfo is the response for an Profile Info API request
 type CertProfileInfo struct {
+	// Issuer specifies the label of the Issuer, resolved for the profile
 	Issuer string   `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`
 	Usage  []string `protobuf:"bytes,2,rep,name=usage" json:"usage,omitempty"`
 	Expiry string   `protobuf:"bytes,3,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// Profile specifies the name of the profile
+	Profile      string        `protobuf:"bytes,4,opt,name=profile,proto3" json:"profile,omitempty"`
+	Description  string        `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Backdate     string        `protobuf:"bytes,6,opt,name=backdate,proto3" json:"backdate,omitempty"`
+	CaConstraint *CAConstraint `protobuf:"bytes,7,opt,name=ca_constraint,json=caConstraint" json:"ca_constraint,omitempty"`
+	OcspNoCheck  bool          `protobuf:"varint,8,opt,name=ocsp_no_check,json=ocspNoCheck,proto3" json:"ocsp_no_check,omitempty"`
+	// AllowedNames specifies a RegExp to check for allowed CN
+	AllowedNames string `protobuf:"bytes,9,opt,name=allowed_names,json=allowedNames,proto3" json:"allowed_names,omitempty"`
+	// AllowedDns specifies a RegExp to check for allowed DNS names
+	AllowedDns string `protobuf:"bytes,10,opt,name=allowed_dns,json=allowedDns,proto3" json:"allowed_dns,omitempty"`
+	// AllowedEmail specifies a RegExp to check for allowed emails
+	AllowedEmail string `protobuf:"bytes,11,opt,name=allowed_email,json=allowedEmail,proto3" json:"allowed_email,omitempty"`
+	// AllowedFields specifies fields that are copied from CSR,
+	// if not specified, then all the fields are allowed
+	AllowedFields *CSRAllowedFields `protobuf:"bytes,12,opt,name=allowed_fields,json=allowedFields" json:"allowed_fields,omitempty"`
+	// AllowedExtensions specifies the list of OIDs
+	AllowedExtensions []string             `protobuf:"bytes,13,rep,name=allowed_extensions,json=allowedExtensions" json:"allowed_extensions,omitempty"`
+	Policies          []*CertificatePolicy `protobuf:"bytes,14,rep,name=policies" json:"policies,omitempty"`
+	// AllowedUri specifies a RegExp to check for allowed URIs
+	AllowedUri string `protobuf:"bytes,15,opt,name=allowed_uri,json=allowedUri,proto3" json:"allowed_uri,omitempty"`
+	// SpiffeTrustDomain specifies the trust domain for SPIFFE X.509-SVID profile
+	SpiffeTrustDomain string `protobuf:"bytes,16,opt,name=spiffe_trust_domain,json=spiffeTrustDomain,proto3" json:"spiffe_trust_domain,omitempty"`
+	// AllowedKeys specifies the policy for public keys,
+	// if not specified, then all keys are allowed
+	AllowedKeys *KeyPolicy `protobuf:"bytes,17,opt,name=allowed_keys,json=allowedKeys" json:"allowed_keys,omitempty"`
+	// RejectSharedKeys specifies to reject keys, that are already certified
+	// for another owner and not expired
+	RejectSharedKeys bool `protobuf:"varint,18,opt,name=reject_shared_keys,json=rejectSharedKeys,proto3" json:"reject_shared_keys,omitempty"`
 }
 
 func (m *CertProfileInfo) Reset()                    { *m = CertProfileInfo{} }
 func (m *CertProfileInfo) String() string            { return proto.CompactTextString(m) }
 func (*CertProfileInfo) ProtoMessage()               {}
-func (*CertProfileInfo) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{3} }
+func (*CertProfileInfo) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{8} }
 
 func (m *CertProfileInfo) GetIssuer() string {
 	if m != nil {
@@ -216,836 +487,7105 @@ func (m *CertProfileInfo) GetExpiry() string {
 	return ""
 }
 
-// CertificateBundle provides certificate and its issuers
-type CertificateBundle struct {
-	// Certificate provides the certificate in PEM format
-	Certificate string `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
-	// Intermediates provides the intermediate CA certificates bundle in PEM format
-	Intermediates string `protobuf:"bytes,2,opt,name=intermediates,proto3" json:"intermediates,omitempty"`
-	// Root provides the Root CA certifica in PEM format
-	Root string `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
+func (m *CertProfileInfo) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
 }
 
-func (m *CertificateBundle) Reset()                    { *m = CertificateBundle{} }
-func (m *CertificateBundle) String() string            { return proto.CompactTextString(m) }
-func (*CertificateBundle) ProtoMessage()               {}
-func (*CertificateBundle) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{4} }
+func (m *CertProfileInfo) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
 
-func (m *CertificateBundle) GetCertificate() string {
+func (m *CertProfileInfo) GetBackdate() string {
 	if m != nil {
-		return m.Certificate
+		return m.Backdate
 	}
 	return ""
 }
 
-func (m *CertificateBundle) GetIntermediates() string {
+func (m *CertProfileInfo) GetCaConstraint() *CAConstraint {
 	if m != nil {
-		return m.Intermediates
+		return m.CaConstraint
+	}
+	return nil
+}
+
+func (m *CertProfileInfo) GetOcspNoCheck() bool {
+	if m != nil {
+		return m.OcspNoCheck
+	}
+	return false
+}
+
+func (m *CertProfileInfo) GetAllowedNames() string {
+	if m != nil {
+		return m.AllowedNames
 	}
 	return ""
 }
 
-func (m *CertificateBundle) GetRoot() string {
+func (m *CertProfileInfo) GetAllowedDns() string {
 	if m != nil {
-		return m.Root
+		return m.AllowedDns
 	}
 	return ""
 }
 
-// IssuerInfo provides Issuer information
-type IssuerInfo struct {
+func (m *CertProfileInfo) GetAllowedEmail() string {
+	if m != nil {
+		return m.AllowedEmail
+	}
+	return ""
+}
+
+func (m *CertProfileInfo) GetAllowedFields() *CSRAllowedFields {
+	if m != nil {
+		return m.AllowedFields
+	}
+	return nil
+}
+
+func (m *CertProfileInfo) GetAllowedExtensions() []string {
+	if m != nil {
+		return m.AllowedExtensions
+	}
+	return nil
+}
+
+func (m *CertProfileInfo) GetPolicies() []*CertificatePolicy {
+	if m != nil {
+		return m.Policies
+	}
+	return nil
+}
+
+func (m *CertProfileInfo) GetAllowedUri() string {
+	if m != nil {
+		return m.AllowedUri
+	}
+	return ""
+}
+
+func (m *CertProfileInfo) GetSpiffeTrustDomain() string {
+	if m != nil {
+		return m.SpiffeTrustDomain
+	}
+	return ""
+}
+
+func (m *CertProfileInfo) GetAllowedKeys() *KeyPolicy {
+	if m != nil {
+		return m.AllowedKeys
+	}
+	return nil
+}
+
+func (m *CertProfileInfo) GetRejectSharedKeys() bool {
+	if m != nil {
+		return m.RejectSharedKeys
+	}
+	return false
+}
+
+// CertificateBundle provides certificate and its issuers
+type CertificateBundle struct {
 	// Certificate provides the certificate in PEM format
 	Certificate string `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
 	// Intermediates provides the intermediate CA certificates bundle in PEM format
 	Intermediates string `protobuf:"bytes,2,opt,name=intermediates,proto3" json:"intermediates,omitempty"`
 	// Root provides the Root CA certifica in PEM format
 	Root string `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
-	// Label specifies the Issuer's label
-	Label string `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
+	// NotBefore specifies the effective time the certificate is valid from, in Unix format
+	NotBefore int64 `protobuf:"varint,4,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// NotAfter specifies the effective time the certificate expires, in Unix format
+	NotAfter int64 `protobuf:"varint,5,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	// Preview provides the fields of the certificate to be issued,
+	// returned for a dry-run request instead of the certificate
+	Preview *CertificatePreview `protobuf:"bytes,6,opt,name=preview" json:"preview,omitempty"`
 }
 
-func (m *IssuerInfo) Reset()                    { *m = IssuerInfo{} }
-func (m *IssuerInfo) String() string            { return proto.CompactTextString(m) }
-func (*IssuerInfo) ProtoMessage()               {}
-func (*IssuerInfo) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{5} }
+func (m *CertificateBundle) Reset()                    { *m = CertificateBundle{} }
+func (m *CertificateBundle) String() string            { return proto.CompactTextString(m) }
+func (*CertificateBundle) ProtoMessage()               {}
+func (*CertificateBundle) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{9} }
 
-func (m *IssuerInfo) GetCertificate() string {
+func (m *CertificateBundle) GetCertificate() string {
 	if m != nil {
 		return m.Certificate
 	}
 	return ""
 }
 
-func (m *IssuerInfo) GetIntermediates() string {
+func (m *CertificateBundle) GetIntermediates() string {
 	if m != nil {
 		return m.Intermediates
 	}
 	return ""
 }
 
-func (m *IssuerInfo) GetRoot() string {
+func (m *CertificateBundle) GetRoot() string {
 	if m != nil {
 		return m.Root
 	}
 	return ""
 }
 
-func (m *IssuerInfo) GetLabel() string {
+func (m *CertificateBundle) GetNotBefore() int64 {
 	if m != nil {
-		return m.Label
+		return m.NotBefore
 	}
-	return ""
+	return 0
 }
 
-// IssuersInfoResponse provides response for Issuers Info request
-type IssuersInfoResponse struct {
-	Issuers []*IssuerInfo `protobuf:"bytes,1,rep,name=issuers" json:"issuers,omitempty"`
+func (m *CertificateBundle) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
+	}
+	return 0
 }
 
-func (m *IssuersInfoResponse) Reset()                    { *m = IssuersInfoResponse{} }
-func (m *IssuersInfoResponse) String() string            { return proto.CompactTextString(m) }
-func (*IssuersInfoResponse) ProtoMessage()               {}
-func (*IssuersInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{6} }
-
-func (m *IssuersInfoResponse) GetIssuers() []*IssuerInfo {
+func (m *CertificateBundle) GetPreview() *CertificatePreview {
 	if m != nil {
-		return m.Issuers
+		return m.Preview
 	}
 	return nil
 }
 
-// CreateCertificateRequest specifies certificate request
-type CreateCertificateRequest struct {
-	// RequestFormat provides the certificate request format: CSR, CMS
-	RequestFormat EncodingFormat `protobuf:"varint,1,opt,name=request_format,json=requestFormat,proto3,enum=trustypb.EncodingFormat" json:"request_format,omitempty"`
-	// Request provides the certificate request
-	Request string `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
-	// Profile specifies the certificate profile: client, server, spiffe
-	Profile string `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
-	// IssuerLabel specifies which Issuer to be appointed to sign the request
-	IssuerLabel string `protobuf:"bytes,4,opt,name=issuer_label,json=issuerLabel,proto3" json:"issuer_label,omitempty"`
-	// WithBundle specifies whether to include an "optimal" certificate bundle along with the certificate
-	WithBundle bool `protobuf:"varint,5,opt,name=with_bundle,json=withBundle,proto3" json:"with_bundle,omitempty"`
-	// Token provides the authorization token for the request
-	Token string `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
+// Extension provides X509 extension
+type Extension struct {
+	// Id specifies OID of the extension
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Critical bool   `protobuf:"varint,2,opt,name=critical,proto3" json:"critical,omitempty"`
+	// Value provides hex encoded value of the extension
+	Value string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (m *CreateCertificateRequest) Reset()                    { *m = CreateCertificateRequest{} }
-func (m *CreateCertificateRequest) String() string            { return proto.CompactTextString(m) }
-func (*CreateCertificateRequest) ProtoMessage()               {}
-func (*CreateCertificateRequest) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{7} }
+func (m *Extension) Reset()                    { *m = Extension{} }
+func (m *Extension) String() string            { return proto.CompactTextString(m) }
+func (*Extension) ProtoMessage()               {}
+func (*Extension) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{10} }
 
-func (m *CreateCertificateRequest) GetRequestFormat() EncodingFormat {
+func (m *Extension) GetId() string {
 	if m != nil {
-		return m.RequestFormat
+		return m.Id
 	}
-	return EncodingFormat_PEM
+	return ""
 }
 
-func (m *CreateCertificateRequest) GetRequest() string {
+func (m *Extension) GetCritical() bool {
 	if m != nil {
-		return m.Request
+		return m.Critical
+	}
+	return false
+}
+
+func (m *Extension) GetValue() string {
+	if m != nil {
+		return m.Value
 	}
 	return ""
 }
 
-func (m *CreateCertificateRequest) GetProfile() string {
+// LintFinding provides the result of the failed certificate lint
+type LintFinding struct {
+	Lint string `protobuf:"bytes,1,opt,name=lint,proto3" json:"lint,omitempty"`
+	// Severity specifies the severity of the finding: warn, error.
+	// The certificate with error findings is not issued.
+	Severity string `protobuf:"bytes,2,opt,name=severity,proto3" json:"severity,omitempty"`
+	Message  string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *LintFinding) Reset()                    { *m = LintFinding{} }
+func (m *LintFinding) String() string            { return proto.CompactTextString(m) }
+func (*LintFinding) ProtoMessage()               {}
+func (*LintFinding) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{11} }
+
+func (m *LintFinding) GetLint() string {
 	if m != nil {
-		return m.Profile
+		return m.Lint
 	}
 	return ""
 }
 
-func (m *CreateCertificateRequest) GetIssuerLabel() string {
+func (m *LintFinding) GetSeverity() string {
 	if m != nil {
-		return m.IssuerLabel
+		return m.Severity
 	}
 	return ""
 }
 
-func (m *CreateCertificateRequest) GetWithBundle() bool {
+func (m *LintFinding) GetMessage() string {
 	if m != nil {
-		return m.WithBundle
+		return m.Message
 	}
-	return false
+	return ""
 }
 
-func (m *CreateCertificateRequest) GetToken() string {
+// CertificatePreview provides the fields of the certificate to be issued
+type CertificatePreview struct {
+	Subject        string   `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Issuer         string   `protobuf:"bytes,2,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	DnsNames       []string `protobuf:"bytes,3,rep,name=dns_names,json=dnsNames" json:"dns_names,omitempty"`
+	IpAddresses    []string `protobuf:"bytes,4,rep,name=ip_addresses,json=ipAddresses" json:"ip_addresses,omitempty"`
+	EmailAddresses []string `protobuf:"bytes,5,rep,name=email_addresses,json=emailAddresses" json:"email_addresses,omitempty"`
+	Uris           []string `protobuf:"bytes,6,rep,name=uris" json:"uris,omitempty"`
+	// NotBefore specifies the time the certificate is valid from, in Unix format
+	NotBefore int64 `protobuf:"varint,7,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// NotAfter specifies the time the certificate expires, in Unix format
+	NotAfter     int64         `protobuf:"varint,8,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	KeyUsage     []string      `protobuf:"bytes,9,rep,name=key_usage,json=keyUsage" json:"key_usage,omitempty"`
+	ExtKeyUsage  []string      `protobuf:"bytes,10,rep,name=ext_key_usage,json=extKeyUsage" json:"ext_key_usage,omitempty"`
+	CaConstraint *CAConstraint `protobuf:"bytes,11,opt,name=ca_constraint,json=caConstraint" json:"ca_constraint,omitempty"`
+	// Skid provides Subject Key Identifier
+	Skid string `protobuf:"bytes,12,opt,name=skid,proto3" json:"skid,omitempty"`
+	// Ikid provides Issuer Key Identifier
+	Ikid                   string   `protobuf:"bytes,13,opt,name=ikid,proto3" json:"ikid,omitempty"`
+	OcspServers            []string `protobuf:"bytes,14,rep,name=ocsp_servers,json=ocspServers" json:"ocsp_servers,omitempty"`
+	CrlDistributionPoints  []string `protobuf:"bytes,15,rep,name=crl_distribution_points,json=crlDistributionPoints" json:"crl_distribution_points,omitempty"`
+	IssuingCertificateUrls []string `protobuf:"bytes,16,rep,name=issuing_certificate_urls,json=issuingCertificateUrls" json:"issuing_certificate_urls,omitempty"`
+	// Extensions provides the extra extensions, such as policies and requested extensions
+	Extensions         []*Extension `protobuf:"bytes,17,rep,name=extensions" json:"extensions,omitempty"`
+	PublicKeyAlgorithm string       `protobuf:"bytes,18,opt,name=public_key_algorithm,json=publicKeyAlgorithm,proto3" json:"public_key_algorithm,omitempty"`
+	SignatureAlgorithm string       `protobuf:"bytes,19,opt,name=signature_algorithm,json=signatureAlgorithm,proto3" json:"signature_algorithm,omitempty"`
+	// LintFindings provides the result of the certificate lint
+	LintFindings []*LintFinding `protobuf:"bytes,20,rep,name=lint_findings,json=lintFindings" json:"lint_findings,omitempty"`
+}
+
+func (m *CertificatePreview) Reset()                    { *m = CertificatePreview{} }
+func (m *CertificatePreview) String() string            { return proto.CompactTextString(m) }
+func (*CertificatePreview) ProtoMessage()               {}
+func (*CertificatePreview) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{12} }
+
+func (m *CertificatePreview) GetSubject() string {
 	if m != nil {
-		return m.Token
+		return m.Subject
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*X509Name)(nil), "trustypb.X509Name")
-	proto.RegisterType((*X509Subject)(nil), "trustypb.X509Subject")
-	proto.RegisterType((*CertProfileInfoRequest)(nil), "trustypb.CertProfileInfoRequest")
-	proto.RegisterType((*CertProfileInfo)(nil), "trustypb.CertProfileInfo")
-	proto.RegisterType((*CertificateBundle)(nil), "trustypb.CertificateBundle")
-	proto.RegisterType((*IssuerInfo)(nil), "trustypb.IssuerInfo")
-	proto.RegisterType((*IssuersInfoResponse)(nil), "trustypb.IssuersInfoResponse")
-	proto.RegisterType((*CreateCertificateRequest)(nil), "trustypb.CreateCertificateRequest")
-	proto.RegisterEnum("trustypb.EncodingFormat", EncodingFormat_name, EncodingFormat_value)
+func (m *CertificatePreview) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for Authority service
-
-type AuthorityClient interface {
-	// ProfileInfo returns the certificate profile info
-	ProfileInfo(ctx context.Context, in *CertProfileInfoRequest, opts ...grpc.CallOption) (*CertProfileInfo, error)
-	// CreateCertificate returns the certificate
-	CreateCertificate(ctx context.Context, in *CreateCertificateRequest, opts ...grpc.CallOption) (*CertificateBundle, error)
-	// Issuers returns the issuing CAs
-	Issuers(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*IssuersInfoResponse, error)
+func (m *CertificatePreview) GetDnsNames() []string {
+	if m != nil {
+		return m.DnsNames
+	}
+	return nil
 }
 
-type authorityClient struct {
-	cc *grpc.ClientConn
+func (m *CertificatePreview) GetIpAddresses() []string {
+	if m != nil {
+		return m.IpAddresses
+	}
+	return nil
 }
 
-func NewAuthorityClient(cc *grpc.ClientConn) AuthorityClient {
-	return &authorityClient{cc}
+func (m *CertificatePreview) GetEmailAddresses() []string {
+	if m != nil {
+		return m.EmailAddresses
+	}
+	return nil
 }
 
-func (c *authorityClient) ProfileInfo(ctx context.Context, in *CertProfileInfoRequest, opts ...grpc.CallOption) (*CertProfileInfo, error) {
-	out := new(CertProfileInfo)
-	err := grpc.Invoke(ctx, "/trustypb.Authority/ProfileInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CertificatePreview) GetUris() []string {
+	if m != nil {
+		return m.Uris
 	}
-	return out, nil
+	return nil
 }
 
-func (c *authorityClient) CreateCertificate(ctx context.Context, in *CreateCertificateRequest, opts ...grpc.CallOption) (*CertificateBundle, error) {
-	out := new(CertificateBundle)
-	err := grpc.Invoke(ctx, "/trustypb.Authority/CreateCertificate", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CertificatePreview) GetNotBefore() int64 {
+	if m != nil {
+		return m.NotBefore
 	}
-	return out, nil
+	return 0
 }
 
-func (c *authorityClient) Issuers(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*IssuersInfoResponse, error) {
-	out := new(IssuersInfoResponse)
-	err := grpc.Invoke(ctx, "/trustypb.Authority/Issuers", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CertificatePreview) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
 	}
-	return out, nil
+	return 0
 }
 
-// Server API for Authority service
-
-type AuthorityServer interface {
-	// ProfileInfo returns the certificate profile info
-	ProfileInfo(context.Context, *CertProfileInfoRequest) (*CertProfileInfo, error)
-	// CreateCertificate returns the certificate
-	CreateCertificate(context.Context, *CreateCertificateRequest) (*CertificateBundle, error)
-	// Issuers returns the issuing CAs
-	Issuers(context.Context, *EmptyRequest) (*IssuersInfoResponse, error)
+func (m *CertificatePreview) GetKeyUsage() []string {
+	if m != nil {
+		return m.KeyUsage
+	}
+	return nil
 }
 
-func RegisterAuthorityServer(s *grpc.Server, srv AuthorityServer) {
-	s.RegisterService(&_Authority_serviceDesc, srv)
+func (m *CertificatePreview) GetExtKeyUsage() []string {
+	if m != nil {
+		return m.ExtKeyUsage
+	}
+	return nil
 }
 
-func _Authority_ProfileInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CertProfileInfoRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AuthorityServer).ProfileInfo(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/trustypb.Authority/ProfileInfo",
+func (m *CertificatePreview) GetCaConstraint() *CAConstraint {
+	if m != nil {
+		return m.CaConstraint
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthorityServer).ProfileInfo(ctx, req.(*CertProfileInfoRequest))
+	return nil
+}
+
+func (m *CertificatePreview) GetSkid() string {
+	if m != nil {
+		return m.Skid
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Authority_CreateCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateCertificateRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *CertificatePreview) GetIkid() string {
+	if m != nil {
+		return m.Ikid
 	}
-	if interceptor == nil {
-		return srv.(AuthorityServer).CreateCertificate(ctx, in)
+	return ""
+}
+
+func (m *CertificatePreview) GetOcspServers() []string {
+	if m != nil {
+		return m.OcspServers
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/trustypb.Authority/CreateCertificate",
+	return nil
+}
+
+func (m *CertificatePreview) GetCrlDistributionPoints() []string {
+	if m != nil {
+		return m.CrlDistributionPoints
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthorityServer).CreateCertificate(ctx, req.(*CreateCertificateRequest))
+	return nil
+}
+
+func (m *CertificatePreview) GetIssuingCertificateUrls() []string {
+	if m != nil {
+		return m.IssuingCertificateUrls
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Authority_Issuers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *CertificatePreview) GetExtensions() []*Extension {
+	if m != nil {
+		return m.Extensions
 	}
-	if interceptor == nil {
-		return srv.(AuthorityServer).Issuers(ctx, in)
+	return nil
+}
+
+func (m *CertificatePreview) GetPublicKeyAlgorithm() string {
+	if m != nil {
+		return m.PublicKeyAlgorithm
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/trustypb.Authority/Issuers",
+	return ""
+}
+
+func (m *CertificatePreview) GetSignatureAlgorithm() string {
+	if m != nil {
+		return m.SignatureAlgorithm
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthorityServer).Issuers(ctx, req.(*EmptyRequest))
+	return ""
+}
+
+func (m *CertificatePreview) GetLintFindings() []*LintFinding {
+	if m != nil {
+		return m.LintFindings
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-var _Authority_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "trustypb.Authority",
-	HandlerType: (*AuthorityServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ProfileInfo",
-			Handler:    _Authority_ProfileInfo_Handler,
-		},
-		{
-			MethodName: "CreateCertificate",
-			Handler:    _Authority_CreateCertificate_Handler,
-		},
-		{
-			MethodName: "Issuers",
-			Handler:    _Authority_Issuers_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "pkix.proto",
+// IssuerInfo provides Issuer information
+type IssuerInfo struct {
+	// Certificate provides the certificate in PEM format
+	Certificate string `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// Intermediates provides the intermediate CA certificates bundle in PEM format
+	Intermediates string `protobuf:"bytes,2,opt,name=intermediates,proto3" json:"intermediates,omitempty"`
+	// Root provides the Root CA certifica in PEM format
+	Root string `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
+	// Label specifies the Issuer's label
+	Label string `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
 }
 
-func (m *X509Name) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *IssuerInfo) Reset()                    { *m = IssuerInfo{} }
+func (m *IssuerInfo) String() string            { return proto.CompactTextString(m) }
+func (*IssuerInfo) ProtoMessage()               {}
+func (*IssuerInfo) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{13} }
+
+func (m *IssuerInfo) GetCertificate() string {
+	if m != nil {
+		return m.Certificate
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *X509Name) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Country) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Country)))
-		i += copy(dAtA[i:], m.Country)
-	}
-	if len(m.State) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.State)))
-		i += copy(dAtA[i:], m.State)
-	}
-	if len(m.Locality) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Locality)))
-		i += copy(dAtA[i:], m.Locality)
-	}
-	if len(m.Organisation) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Organisation)))
-		i += copy(dAtA[i:], m.Organisation)
+func (m *IssuerInfo) GetIntermediates() string {
+	if m != nil {
+		return m.Intermediates
 	}
-	if len(m.OrganisationalUnit) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.OrganisationalUnit)))
-		i += copy(dAtA[i:], m.OrganisationalUnit)
+	return ""
+}
+
+func (m *IssuerInfo) GetRoot() string {
+	if m != nil {
+		return m.Root
 	}
-	return i, nil
+	return ""
 }
 
-func (m *X509Subject) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *IssuerInfo) GetLabel() string {
+	if m != nil {
+		return m.Label
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *X509Subject) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Cn) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Cn)))
-		i += copy(dAtA[i:], m.Cn)
+// IssuersInfoResponse provides response for Issuers Info request
+type IssuersInfoResponse struct {
+	Issuers []*IssuerInfo `protobuf:"bytes,1,rep,name=issuers" json:"issuers,omitempty"`
+}
+
+func (m *IssuersInfoResponse) Reset()                    { *m = IssuersInfoResponse{} }
+func (m *IssuersInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*IssuersInfoResponse) ProtoMessage()               {}
+func (*IssuersInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{14} }
+
+func (m *IssuersInfoResponse) GetIssuers() []*IssuerInfo {
+	if m != nil {
+		return m.Issuers
 	}
-	if len(m.Names) > 0 {
-		for _, msg := range m.Names {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintPkix(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	return nil
+}
+
+// CreateCertificateRequest specifies certificate request
+type CreateCertificateRequest struct {
+	// RequestFormat provides the certificate request format: CSR, CMS
+	RequestFormat EncodingFormat `protobuf:"varint,1,opt,name=request_format,json=requestFormat,proto3,enum=trustypb.EncodingFormat" json:"request_format,omitempty"`
+	// Request provides the certificate request
+	Request string `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	// Profile specifies the certificate profile: client, server, spiffe
+	Profile string `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	// IssuerLabel specifies which Issuer to be appointed to sign the request
+	IssuerLabel string `protobuf:"bytes,4,opt,name=issuer_label,json=issuerLabel,proto3" json:"issuer_label,omitempty"`
+	// WithBundle specifies whether to include an "optimal" certificate bundle along with the certificate
+	WithBundle bool `protobuf:"varint,5,opt,name=with_bundle,json=withBundle,proto3" json:"with_bundle,omitempty"`
+	// Token provides the authorization token for the request
+	Token string `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`
+	// NotBefore specifies the requested time the certificate is valid from, in Unix format.
+	// If not provided, the time is derived from the profile's backdate.
+	NotBefore int64 `protobuf:"varint,7,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// NotAfter specifies the requested time the certificate expires, in Unix format.
+	// If not provided, the time is derived from the profile's expiry.
+	// The value is capped at the profile's expiry and the issuer's NotAfter.
+	NotAfter int64 `protobuf:"varint,8,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	// DryRun specifies to run the profile checks and the lint,
+	// and return the preview of the certificate, without signing and registering it
+	DryRun bool `protobuf:"varint,9,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (m *CreateCertificateRequest) Reset()                    { *m = CreateCertificateRequest{} }
+func (m *CreateCertificateRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateCertificateRequest) ProtoMessage()               {}
+func (*CreateCertificateRequest) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{15} }
+
+func (m *CreateCertificateRequest) GetRequestFormat() EncodingFormat {
+	if m != nil {
+		return m.RequestFormat
 	}
-	if len(m.SerialNumber) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.SerialNumber)))
-		i += copy(dAtA[i:], m.SerialNumber)
+	return EncodingFormat_PEM
+}
+
+func (m *CreateCertificateRequest) GetRequest() string {
+	if m != nil {
+		return m.Request
 	}
-	return i, nil
+	return ""
 }
 
-func (m *CertProfileInfoRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *CreateCertificateRequest) GetProfile() string {
+	if m != nil {
+		return m.Profile
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *CertProfileInfoRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Label) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Label)))
-		i += copy(dAtA[i:], m.Label)
+func (m *CreateCertificateRequest) GetIssuerLabel() string {
+	if m != nil {
+		return m.IssuerLabel
 	}
-	if len(m.Profile) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Profile)))
-		i += copy(dAtA[i:], m.Profile)
+	return ""
+}
+
+func (m *CreateCertificateRequest) GetWithBundle() bool {
+	if m != nil {
+		return m.WithBundle
 	}
-	return i, nil
+	return false
 }
 
-func (m *CertProfileInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *CreateCertificateRequest) GetToken() string {
+	if m != nil {
+		return m.Token
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *CertProfileInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Issuer) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Issuer)))
-		i += copy(dAtA[i:], m.Issuer)
+func (m *CreateCertificateRequest) GetNotBefore() int64 {
+	if m != nil {
+		return m.NotBefore
 	}
-	if len(m.Usage) > 0 {
-		for _, s := range m.Usage {
-			dAtA[i] = 0x12
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return 0
+}
+
+func (m *CreateCertificateRequest) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
 	}
-	if len(m.Expiry) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Expiry)))
-		i += copy(dAtA[i:], m.Expiry)
+	return 0
+}
+
+func (m *CreateCertificateRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
 	}
-	return i, nil
+	return false
 }
 
-func (m *CertificateBundle) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+// Certificate provides X509 Certificate information
+type Certificate struct {
+	// Id of the certificate
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// OwnerId of the certificate
+	OwnerId int64 `protobuf:"varint,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	// Skid provides Subject Key Identifier
+	Skid string `protobuf:"bytes,3,opt,name=skid,proto3" json:"skid,omitempty"`
+	// Ikid provides Issuer Key Identifier
+	Ikid string `protobuf:"bytes,4,opt,name=ikid,proto3" json:"ikid,omitempty"`
+	// SerialNumber provides Serial Number
+	SerialNumber string `protobuf:"bytes,5,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	// NotBefore is the time when the validity period starts, in Unix time
+	NotBefore int64 `protobuf:"varint,6,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// NotAfter is the time when the validity period ends, in Unix time
+	NotAfter int64 `protobuf:"varint,7,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	// Subject name
+	Subject string `protobuf:"bytes,8,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Profile of the certificate
+	Profile string `protobuf:"bytes,9,opt,name=profile,proto3" json:"profile,omitempty"`
+	// Pem encoded certificate
+	Pem string `protobuf:"bytes,10,opt,name=pem,proto3" json:"pem,omitempty"`
+}
+
+func (m *Certificate) Reset()                    { *m = Certificate{} }
+func (m *Certificate) String() string            { return proto.CompactTextString(m) }
+func (*Certificate) ProtoMessage()               {}
+func (*Certificate) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{16} }
+
+func (m *Certificate) GetId() int64 {
+	if m != nil {
+		return m.Id
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *CertificateBundle) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Certificate) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Certificate)))
-		i += copy(dAtA[i:], m.Certificate)
+func (m *Certificate) GetOwnerId() int64 {
+	if m != nil {
+		return m.OwnerId
 	}
-	if len(m.Intermediates) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Intermediates)))
-		i += copy(dAtA[i:], m.Intermediates)
+	return 0
+}
+
+func (m *Certificate) GetSkid() string {
+	if m != nil {
+		return m.Skid
 	}
-	if len(m.Root) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Root)))
-		i += copy(dAtA[i:], m.Root)
+	return ""
+}
+
+func (m *Certificate) GetIkid() string {
+	if m != nil {
+		return m.Ikid
 	}
-	return i, nil
+	return ""
 }
 
-func (m *IssuerInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Certificate) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *IssuerInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Certificate) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Certificate)))
-		i += copy(dAtA[i:], m.Certificate)
+func (m *Certificate) GetNotBefore() int64 {
+	if m != nil {
+		return m.NotBefore
 	}
-	if len(m.Intermediates) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Intermediates)))
-		i += copy(dAtA[i:], m.Intermediates)
+	return 0
+}
+
+func (m *Certificate) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
 	}
-	if len(m.Root) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Root)))
-		i += copy(dAtA[i:], m.Root)
+	return 0
+}
+
+func (m *Certificate) GetSubject() string {
+	if m != nil {
+		return m.Subject
 	}
-	if len(m.Label) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Label)))
-		i += copy(dAtA[i:], m.Label)
+	return ""
+}
+
+func (m *Certificate) GetProfile() string {
+	if m != nil {
+		return m.Profile
 	}
-	return i, nil
+	return ""
 }
 
-func (m *IssuersInfoResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Certificate) GetPem() string {
+	if m != nil {
+		return m.Pem
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *IssuersInfoResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Issuers) > 0 {
-		for _, msg := range m.Issuers {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintPkix(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+// RevokeCertificateRequest specifies revocation request
+type RevokeCertificateRequest struct {
+	// Skid provides Subject Key Identifier of the certificate,
+	// all active certificates with the key are revoked.
+	// If not provided, then Ikid and SerialNumber must be set
+	Skid string `protobuf:"bytes,1,opt,name=skid,proto3" json:"skid,omitempty"`
+	// Ikid provides Issuer Key Identifier
+	Ikid string `protobuf:"bytes,2,opt,name=ikid,proto3" json:"ikid,omitempty"`
+	// SerialNumber provides Serial Number
+	SerialNumber string `protobuf:"bytes,3,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	// Reason for revocation
+	Reason Reason `protobuf:"varint,4,opt,name=reason,proto3,enum=trustypb.Reason" json:"reason,omitempty"`
+}
+
+func (m *RevokeCertificateRequest) Reset()                    { *m = RevokeCertificateRequest{} }
+func (m *RevokeCertificateRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevokeCertificateRequest) ProtoMessage()               {}
+func (*RevokeCertificateRequest) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{17} }
+
+func (m *RevokeCertificateRequest) GetSkid() string {
+	if m != nil {
+		return m.Skid
 	}
-	return i, nil
+	return ""
 }
 
-func (m *CreateCertificateRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *RevokeCertificateRequest) GetIkid() string {
+	if m != nil {
+		return m.Ikid
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *CreateCertificateRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.RequestFormat != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(m.RequestFormat))
+func (m *RevokeCertificateRequest) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
 	}
-	if len(m.Request) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Request)))
-		i += copy(dAtA[i:], m.Request)
+	return ""
+}
+
+func (m *RevokeCertificateRequest) GetReason() Reason {
+	if m != nil {
+		return m.Reason
 	}
-	if len(m.Profile) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Profile)))
-		i += copy(dAtA[i:], m.Profile)
+	return Reason_UNSPECIFIED
+}
+
+// RevokedCertificate provides revoked certificate information
+type RevokedCertificate struct {
+	Certificate *Certificate `protobuf:"bytes,1,opt,name=certificate" json:"certificate,omitempty"`
+	// RevokedAt specifies the time of revocation, in Unix time
+	RevokedAt int64 `protobuf:"varint,2,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	// Reason for revocation
+	Reason Reason `protobuf:"varint,3,opt,name=reason,proto3,enum=trustypb.Reason" json:"reason,omitempty"`
+}
+
+func (m *RevokedCertificate) Reset()                    { *m = RevokedCertificate{} }
+func (m *RevokedCertificate) String() string            { return proto.CompactTextString(m) }
+func (*RevokedCertificate) ProtoMessage()               {}
+func (*RevokedCertificate) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{18} }
+
+func (m *RevokedCertificate) GetCertificate() *Certificate {
+	if m != nil {
+		return m.Certificate
 	}
-	if len(m.IssuerLabel) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.IssuerLabel)))
-		i += copy(dAtA[i:], m.IssuerLabel)
+	return nil
+}
+
+func (m *RevokedCertificate) GetRevokedAt() int64 {
+	if m != nil {
+		return m.RevokedAt
 	}
-	if m.WithBundle {
-		dAtA[i] = 0x28
-		i++
-		if m.WithBundle {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+	return 0
+}
+
+func (m *RevokedCertificate) GetReason() Reason {
+	if m != nil {
+		return m.Reason
 	}
-	if len(m.Token) > 0 {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintPkix(dAtA, i, uint64(len(m.Token)))
-		i += copy(dAtA[i:], m.Token)
+	return Reason_UNSPECIFIED
+}
+
+// BlockKeyRequest specifies the public key to be blocked
+type BlockKeyRequest struct {
+	// SpkiHash provides hex encoded SHA-256 hash of the SubjectPublicKeyInfo,
+	// if not provided, then Pem must be set
+	SpkiHash string `protobuf:"bytes,1,opt,name=spki_hash,json=spkiHash,proto3" json:"spki_hash,omitempty"`
+	// Pem provides the public key, the certificate, or the certificate request in PEM format
+	Pem string `protobuf:"bytes,2,opt,name=pem,proto3" json:"pem,omitempty"`
+	// Reason for blocking
+	Reason Reason `protobuf:"varint,3,opt,name=reason,proto3,enum=trustypb.Reason" json:"reason,omitempty"`
+}
+
+func (m *BlockKeyRequest) Reset()                    { *m = BlockKeyRequest{} }
+func (m *BlockKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*BlockKeyRequest) ProtoMessage()               {}
+func (*BlockKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{19} }
+
+func (m *BlockKeyRequest) GetSpkiHash() string {
+	if m != nil {
+		return m.SpkiHash
 	}
-	return i, nil
+	return ""
 }
 
-func encodeVarintPkix(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *BlockKeyRequest) GetPem() string {
+	if m != nil {
+		return m.Pem
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return ""
 }
-func (m *X509Name) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Country)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+
+func (m *BlockKeyRequest) GetReason() Reason {
+	if m != nil {
+		return m.Reason
 	}
-	l = len(m.State)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+	return Reason_UNSPECIFIED
+}
+
+// BlockedKey provides blocked key information
+type BlockedKey struct {
+	// SpkiHash provides hex encoded SHA-256 hash of the SubjectPublicKeyInfo
+	SpkiHash string `protobuf:"bytes,1,opt,name=spki_hash,json=spkiHash,proto3" json:"spki_hash,omitempty"`
+	// Reason for blocking
+	Reason Reason `protobuf:"varint,2,opt,name=reason,proto3,enum=trustypb.Reason" json:"reason,omitempty"`
+	// Requestor specifies the name of the requestor
+	Requestor string `protobuf:"bytes,3,opt,name=requestor,proto3" json:"requestor,omitempty"`
+	// CreatedAt specifies the time when the key was blocked, in Unix time
+	CreatedAt int64 `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (m *BlockedKey) Reset()                    { *m = BlockedKey{} }
+func (m *BlockedKey) String() string            { return proto.CompactTextString(m) }
+func (*BlockedKey) ProtoMessage()               {}
+func (*BlockedKey) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{20} }
+
+func (m *BlockedKey) GetSpkiHash() string {
+	if m != nil {
+		return m.SpkiHash
 	}
-	l = len(m.Locality)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+	return ""
+}
+
+func (m *BlockedKey) GetReason() Reason {
+	if m != nil {
+		return m.Reason
 	}
-	l = len(m.Organisation)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+	return Reason_UNSPECIFIED
+}
+
+func (m *BlockedKey) GetRequestor() string {
+	if m != nil {
+		return m.Requestor
 	}
-	l = len(m.OrganisationalUnit)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+	return ""
+}
+
+func (m *BlockedKey) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
 	}
-	return n
+	return 0
 }
 
-func (m *X509Subject) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Cn)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+// CreateEnrollmentTokenRequest specifies the constraints of the enrollment token
+type CreateEnrollmentTokenRequest struct {
+	// Profile specifies the certificate profile, that the token is bound to
+	Profile string `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	// IssuerLabel specifies the issuer, that the token is bound to,
+	// if not provided, then the issuer is found by the profile
+	IssuerLabel string `protobuf:"bytes,2,opt,name=issuer_label,json=issuerLabel,proto3" json:"issuer_label,omitempty"`
+	// NamePattern specifies RegExp, that must match the entire Common Name
+	// and all Subject Alternative Names in the certificate request
+	NamePattern string `protobuf:"bytes,3,opt,name=name_pattern,json=namePattern,proto3" json:"name_pattern,omitempty"`
+	// Expiry specifies the token lifetime, in Go duration format, for example 24h.
+	// If not provided, the token expires in 24 hours
+	Expiry string `protobuf:"bytes,4,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// AllowAnyName specifies to create the token without name_pattern,
+	// that is valid for any name
+	AllowAnyName bool `protobuf:"varint,5,opt,name=allow_any_name,json=allowAnyName,proto3" json:"allow_any_name,omitempty"`
+}
+
+func (m *CreateEnrollmentTokenRequest) Reset()         { *m = CreateEnrollmentTokenRequest{} }
+func (m *CreateEnrollmentTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateEnrollmentTokenRequest) ProtoMessage()    {}
+func (*CreateEnrollmentTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorPkix, []int{21}
+}
+
+func (m *CreateEnrollmentTokenRequest) GetProfile() string {
+	if m != nil {
+		return m.Profile
 	}
-	if len(m.Names) > 0 {
-		for _, e := range m.Names {
-			l = e.Size()
-			n += 1 + l + sovPkix(uint64(l))
-		}
+	return ""
+}
+
+func (m *CreateEnrollmentTokenRequest) GetIssuerLabel() string {
+	if m != nil {
+		return m.IssuerLabel
 	}
-	l = len(m.SerialNumber)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+	return ""
+}
+
+func (m *CreateEnrollmentTokenRequest) GetNamePattern() string {
+	if m != nil {
+		return m.NamePattern
 	}
-	return n
+	return ""
 }
 
-func (m *CertProfileInfoRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Label)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+func (m *CreateEnrollmentTokenRequest) GetExpiry() string {
+	if m != nil {
+		return m.Expiry
 	}
-	l = len(m.Profile)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+	return ""
+}
+
+func (m *CreateEnrollmentTokenRequest) GetAllowAnyName() bool {
+	if m != nil {
+		return m.AllowAnyName
 	}
-	return n
+	return false
 }
 
-func (m *CertProfileInfo) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Issuer)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+// EnrollmentToken provides enrollment token information
+type EnrollmentToken struct {
+	// Id of the token
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Token provides the token value, it is returned only when the token is created
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// Profile specifies the certificate profile, that the token is bound to
+	Profile string `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	// IssuerLabel specifies the issuer, that the token is bound to
+	IssuerLabel string `protobuf:"bytes,4,opt,name=issuer_label,json=issuerLabel,proto3" json:"issuer_label,omitempty"`
+	// NamePattern specifies RegExp, that must match the Common Name
+	// and all Subject Alternative Names in the certificate request
+	NamePattern string `protobuf:"bytes,5,opt,name=name_pattern,json=namePattern,proto3" json:"name_pattern,omitempty"`
+	// CreatedBy specifies the name of the requestor
+	CreatedBy string `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// CreatedAt specifies the time when the token was created, in Unix time
+	CreatedAt int64 `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// ExpiresAt specifies the time when the token expires, in Unix time
+	ExpiresAt int64 `protobuf:"varint,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// UsedAt specifies the time when the token was used, in Unix time,
+	// or 0 if the token is not used
+	UsedAt int64 `protobuf:"varint,9,opt,name=used_at,json=usedAt,proto3" json:"used_at,omitempty"`
+}
+
+func (m *EnrollmentToken) Reset()                    { *m = EnrollmentToken{} }
+func (m *EnrollmentToken) String() string            { return proto.CompactTextString(m) }
+func (*EnrollmentToken) ProtoMessage()               {}
+func (*EnrollmentToken) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{22} }
+
+func (m *EnrollmentToken) GetId() int64 {
+	if m != nil {
+		return m.Id
 	}
-	if len(m.Usage) > 0 {
-		for _, s := range m.Usage {
-			l = len(s)
-			n += 1 + l + sovPkix(uint64(l))
-		}
+	return 0
+}
+
+func (m *EnrollmentToken) GetToken() string {
+	if m != nil {
+		return m.Token
 	}
-	l = len(m.Expiry)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+	return ""
+}
+
+func (m *EnrollmentToken) GetProfile() string {
+	if m != nil {
+		return m.Profile
 	}
-	return n
+	return ""
 }
 
-func (m *CertificateBundle) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Certificate)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+func (m *EnrollmentToken) GetIssuerLabel() string {
+	if m != nil {
+		return m.IssuerLabel
 	}
-	l = len(m.Intermediates)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+	return ""
+}
+
+func (m *EnrollmentToken) GetNamePattern() string {
+	if m != nil {
+		return m.NamePattern
 	}
-	l = len(m.Root)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+	return ""
+}
+
+func (m *EnrollmentToken) GetCreatedBy() string {
+	if m != nil {
+		return m.CreatedBy
 	}
-	return n
+	return ""
 }
 
-func (m *IssuerInfo) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Certificate)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+func (m *EnrollmentToken) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
 	}
-	l = len(m.Intermediates)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+	return 0
+}
+
+func (m *EnrollmentToken) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
 	}
-	l = len(m.Root)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+	return 0
+}
+
+func (m *EnrollmentToken) GetUsedAt() int64 {
+	if m != nil {
+		return m.UsedAt
 	}
-	l = len(m.Label)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
+	return 0
+}
+
+// EnrollmentTokensResponse provides the list of enrollment tokens
+type EnrollmentTokensResponse struct {
+	Tokens []*EnrollmentToken `protobuf:"bytes,1,rep,name=tokens" json:"tokens,omitempty"`
+}
+
+func (m *EnrollmentTokensResponse) Reset()                    { *m = EnrollmentTokensResponse{} }
+func (m *EnrollmentTokensResponse) String() string            { return proto.CompactTextString(m) }
+func (*EnrollmentTokensResponse) ProtoMessage()               {}
+func (*EnrollmentTokensResponse) Descriptor() ([]byte, []int) { return fileDescriptorPkix, []int{23} }
+
+func (m *EnrollmentTokensResponse) GetTokens() []*EnrollmentToken {
+	if m != nil {
+		return m.Tokens
 	}
-	return n
+	return nil
 }
 
-func (m *IssuersInfoResponse) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.Issuers) > 0 {
-		for _, e := range m.Issuers {
-			l = e.Size()
-			n += 1 + l + sovPkix(uint64(l))
+// RevokeEnrollmentTokenRequest specifies the enrollment token to be removed
+type RevokeEnrollmentTokenRequest struct {
+	// Id of the token
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *RevokeEnrollmentTokenRequest) Reset()         { *m = RevokeEnrollmentTokenRequest{} }
+func (m *RevokeEnrollmentTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeEnrollmentTokenRequest) ProtoMessage()    {}
+func (*RevokeEnrollmentTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorPkix, []int{24}
+}
+
+func (m *RevokeEnrollmentTokenRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*X509Name)(nil), "trustypb.X509Name")
+	proto.RegisterType((*X509Subject)(nil), "trustypb.X509Subject")
+	proto.RegisterType((*CertProfileInfoRequest)(nil), "trustypb.CertProfileInfoRequest")
+	proto.RegisterType((*CAConstraint)(nil), "trustypb.CAConstraint")
+	proto.RegisterType((*CSRAllowedFields)(nil), "trustypb.CSRAllowedFields")
+	proto.RegisterType((*KeyPolicy)(nil), "trustypb.KeyPolicy")
+	proto.RegisterType((*CertificatePolicyQualifier)(nil), "trustypb.CertificatePolicyQualifier")
+	proto.RegisterType((*CertificatePolicy)(nil), "trustypb.CertificatePolicy")
+	proto.RegisterType((*CertProfileInfo)(nil), "trustypb.CertProfileInfo")
+	proto.RegisterType((*CertificateBundle)(nil), "trustypb.CertificateBundle")
+	proto.RegisterType((*Extension)(nil), "trustypb.Extension")
+	proto.RegisterType((*LintFinding)(nil), "trustypb.LintFinding")
+	proto.RegisterType((*CertificatePreview)(nil), "trustypb.CertificatePreview")
+	proto.RegisterType((*IssuerInfo)(nil), "trustypb.IssuerInfo")
+	proto.RegisterType((*IssuersInfoResponse)(nil), "trustypb.IssuersInfoResponse")
+	proto.RegisterType((*CreateCertificateRequest)(nil), "trustypb.CreateCertificateRequest")
+	proto.RegisterType((*Certificate)(nil), "trustypb.Certificate")
+	proto.RegisterType((*RevokeCertificateRequest)(nil), "trustypb.RevokeCertificateRequest")
+	proto.RegisterType((*RevokedCertificate)(nil), "trustypb.RevokedCertificate")
+	proto.RegisterType((*BlockKeyRequest)(nil), "trustypb.BlockKeyRequest")
+	proto.RegisterType((*BlockedKey)(nil), "trustypb.BlockedKey")
+	proto.RegisterType((*CreateEnrollmentTokenRequest)(nil), "trustypb.CreateEnrollmentTokenRequest")
+	proto.RegisterType((*EnrollmentToken)(nil), "trustypb.EnrollmentToken")
+	proto.RegisterType((*EnrollmentTokensResponse)(nil), "trustypb.EnrollmentTokensResponse")
+	proto.RegisterType((*RevokeEnrollmentTokenRequest)(nil), "trustypb.RevokeEnrollmentTokenRequest")
+	proto.RegisterEnum("trustypb.EncodingFormat", EncodingFormat_name, EncodingFormat_value)
+	proto.RegisterEnum("trustypb.Reason", Reason_name, Reason_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Authority service
+
+type AuthorityClient interface {
+	// ProfileInfo returns the certificate profile info
+	ProfileInfo(ctx context.Context, in *CertProfileInfoRequest, opts ...grpc.CallOption) (*CertProfileInfo, error)
+	// CreateCertificate returns the certificate
+	CreateCertificate(ctx context.Context, in *CreateCertificateRequest, opts ...grpc.CallOption) (*CertificateBundle, error)
+	// EnrollCertificate returns the certificate, authorized by the enrollment token,
+	// for the callers without identity
+	EnrollCertificate(ctx context.Context, in *CreateCertificateRequest, opts ...grpc.CallOption) (*CertificateBundle, error)
+	// Issuers returns the issuing CAs
+	Issuers(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*IssuersInfoResponse, error)
+	// RevokeCertificate returns the revoked certificate
+	RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*RevokedCertificate, error)
+	// BlockKey adds the public key to the list of keys, that are not allowed to be certified
+	BlockKey(ctx context.Context, in *BlockKeyRequest, opts ...grpc.CallOption) (*BlockedKey, error)
+	// CreateEnrollmentToken returns one-time token, that authorizes CreateCertificate request
+	CreateEnrollmentToken(ctx context.Context, in *CreateEnrollmentTokenRequest, opts ...grpc.CallOption) (*EnrollmentToken, error)
+	// ListEnrollmentTokens returns the enrollment tokens, that are not expired
+	ListEnrollmentTokens(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EnrollmentTokensResponse, error)
+	// RevokeEnrollmentToken removes the enrollment token
+	RevokeEnrollmentToken(ctx context.Context, in *RevokeEnrollmentTokenRequest, opts ...grpc.CallOption) (*EnrollmentToken, error)
+}
+
+type authorityClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAuthorityClient(cc *grpc.ClientConn) AuthorityClient {
+	return &authorityClient{cc}
+}
+
+func (c *authorityClient) ProfileInfo(ctx context.Context, in *CertProfileInfoRequest, opts ...grpc.CallOption) (*CertProfileInfo, error) {
+	out := new(CertProfileInfo)
+	err := grpc.Invoke(ctx, "/trustypb.Authority/ProfileInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorityClient) CreateCertificate(ctx context.Context, in *CreateCertificateRequest, opts ...grpc.CallOption) (*CertificateBundle, error) {
+	out := new(CertificateBundle)
+	err := grpc.Invoke(ctx, "/trustypb.Authority/CreateCertificate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorityClient) EnrollCertificate(ctx context.Context, in *CreateCertificateRequest, opts ...grpc.CallOption) (*CertificateBundle, error) {
+	out := new(CertificateBundle)
+	err := grpc.Invoke(ctx, "/trustypb.Authority/EnrollCertificate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorityClient) Issuers(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*IssuersInfoResponse, error) {
+	out := new(IssuersInfoResponse)
+	err := grpc.Invoke(ctx, "/trustypb.Authority/Issuers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorityClient) RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*RevokedCertificate, error) {
+	out := new(RevokedCertificate)
+	err := grpc.Invoke(ctx, "/trustypb.Authority/RevokeCertificate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorityClient) BlockKey(ctx context.Context, in *BlockKeyRequest, opts ...grpc.CallOption) (*BlockedKey, error) {
+	out := new(BlockedKey)
+	err := grpc.Invoke(ctx, "/trustypb.Authority/BlockKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorityClient) CreateEnrollmentToken(ctx context.Context, in *CreateEnrollmentTokenRequest, opts ...grpc.CallOption) (*EnrollmentToken, error) {
+	out := new(EnrollmentToken)
+	err := grpc.Invoke(ctx, "/trustypb.Authority/CreateEnrollmentToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorityClient) ListEnrollmentTokens(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EnrollmentTokensResponse, error) {
+	out := new(EnrollmentTokensResponse)
+	err := grpc.Invoke(ctx, "/trustypb.Authority/ListEnrollmentTokens", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorityClient) RevokeEnrollmentToken(ctx context.Context, in *RevokeEnrollmentTokenRequest, opts ...grpc.CallOption) (*EnrollmentToken, error) {
+	out := new(EnrollmentToken)
+	err := grpc.Invoke(ctx, "/trustypb.Authority/RevokeEnrollmentToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Authority service
+
+type AuthorityServer interface {
+	// ProfileInfo returns the certificate profile info
+	ProfileInfo(context.Context, *CertProfileInfoRequest) (*CertProfileInfo, error)
+	// CreateCertificate returns the certificate
+	CreateCertificate(context.Context, *CreateCertificateRequest) (*CertificateBundle, error)
+	// EnrollCertificate returns the certificate, authorized by the enrollment token,
+	// for the callers without identity
+	EnrollCertificate(context.Context, *CreateCertificateRequest) (*CertificateBundle, error)
+	// Issuers returns the issuing CAs
+	Issuers(context.Context, *EmptyRequest) (*IssuersInfoResponse, error)
+	// RevokeCertificate returns the revoked certificate
+	RevokeCertificate(context.Context, *RevokeCertificateRequest) (*RevokedCertificate, error)
+	// BlockKey adds the public key to the list of keys, that are not allowed to be certified
+	BlockKey(context.Context, *BlockKeyRequest) (*BlockedKey, error)
+	// CreateEnrollmentToken returns one-time token, that authorizes CreateCertificate request
+	CreateEnrollmentToken(context.Context, *CreateEnrollmentTokenRequest) (*EnrollmentToken, error)
+	// ListEnrollmentTokens returns the enrollment tokens, that are not expired
+	ListEnrollmentTokens(context.Context, *EmptyRequest) (*EnrollmentTokensResponse, error)
+	// RevokeEnrollmentToken removes the enrollment token
+	RevokeEnrollmentToken(context.Context, *RevokeEnrollmentTokenRequest) (*EnrollmentToken, error)
+}
+
+func RegisterAuthorityServer(s *grpc.Server, srv AuthorityServer) {
+	s.RegisterService(&_Authority_serviceDesc, srv)
+}
+
+func _Authority_ProfileInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CertProfileInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorityServer).ProfileInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trustypb.Authority/ProfileInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorityServer).ProfileInfo(ctx, req.(*CertProfileInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authority_CreateCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorityServer).CreateCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trustypb.Authority/CreateCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorityServer).CreateCertificate(ctx, req.(*CreateCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authority_EnrollCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorityServer).EnrollCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trustypb.Authority/EnrollCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorityServer).EnrollCertificate(ctx, req.(*CreateCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authority_Issuers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorityServer).Issuers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trustypb.Authority/Issuers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorityServer).Issuers(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authority_RevokeCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorityServer).RevokeCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trustypb.Authority/RevokeCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorityServer).RevokeCertificate(ctx, req.(*RevokeCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authority_BlockKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorityServer).BlockKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trustypb.Authority/BlockKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorityServer).BlockKey(ctx, req.(*BlockKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authority_CreateEnrollmentToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEnrollmentTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorityServer).CreateEnrollmentToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trustypb.Authority/CreateEnrollmentToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorityServer).CreateEnrollmentToken(ctx, req.(*CreateEnrollmentTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authority_ListEnrollmentTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorityServer).ListEnrollmentTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trustypb.Authority/ListEnrollmentTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorityServer).ListEnrollmentTokens(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Authority_RevokeEnrollmentToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeEnrollmentTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorityServer).RevokeEnrollmentToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trustypb.Authority/RevokeEnrollmentToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorityServer).RevokeEnrollmentToken(ctx, req.(*RevokeEnrollmentTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Authority_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "trustypb.Authority",
+	HandlerType: (*AuthorityServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProfileInfo",
+			Handler:    _Authority_ProfileInfo_Handler,
+		},
+		{
+			MethodName: "CreateCertificate",
+			Handler:    _Authority_CreateCertificate_Handler,
+		},
+		{
+			MethodName: "EnrollCertificate",
+			Handler:    _Authority_EnrollCertificate_Handler,
+		},
+		{
+			MethodName: "Issuers",
+			Handler:    _Authority_Issuers_Handler,
+		},
+		{
+			MethodName: "RevokeCertificate",
+			Handler:    _Authority_RevokeCertificate_Handler,
+		},
+		{
+			MethodName: "BlockKey",
+			Handler:    _Authority_BlockKey_Handler,
+		},
+		{
+			MethodName: "CreateEnrollmentToken",
+			Handler:    _Authority_CreateEnrollmentToken_Handler,
+		},
+		{
+			MethodName: "ListEnrollmentTokens",
+			Handler:    _Authority_ListEnrollmentTokens_Handler,
+		},
+		{
+			MethodName: "RevokeEnrollmentToken",
+			Handler:    _Authority_RevokeEnrollmentToken_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkix.proto",
+}
+
+func (m *X509Name) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *X509Name) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Country) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Country)))
+		i += copy(dAtA[i:], m.Country)
+	}
+	if len(m.State) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.State)))
+		i += copy(dAtA[i:], m.State)
+	}
+	if len(m.Locality) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Locality)))
+		i += copy(dAtA[i:], m.Locality)
+	}
+	if len(m.Organisation) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Organisation)))
+		i += copy(dAtA[i:], m.Organisation)
+	}
+	if len(m.OrganisationalUnit) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.OrganisationalUnit)))
+		i += copy(dAtA[i:], m.OrganisationalUnit)
+	}
+	return i, nil
+}
+
+func (m *X509Subject) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *X509Subject) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Cn) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Cn)))
+		i += copy(dAtA[i:], m.Cn)
+	}
+	if len(m.Names) > 0 {
+		for _, msg := range m.Names {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPkix(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.SerialNumber) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.SerialNumber)))
+		i += copy(dAtA[i:], m.SerialNumber)
+	}
+	return i, nil
+}
+
+func (m *CertProfileInfoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CertProfileInfoRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Label) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Label)))
+		i += copy(dAtA[i:], m.Label)
+	}
+	if len(m.Profile) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Profile)))
+		i += copy(dAtA[i:], m.Profile)
+	}
+	return i, nil
+}
+
+func (m *CAConstraint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CAConstraint) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.IsCa {
+		dAtA[i] = 0x8
+		i++
+		if m.IsCa {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.MaxPathLen != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.MaxPathLen))
+	}
+	if m.MaxPathLenZero {
+		dAtA[i] = 0x18
+		i++
+		if m.MaxPathLenZero {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *CSRAllowedFields) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CSRAllowedFields) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Subject {
+		dAtA[i] = 0x8
+		i++
+		if m.Subject {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Dns {
+		dAtA[i] = 0x10
+		i++
+		if m.Dns {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Ip {
+		dAtA[i] = 0x18
+		i++
+		if m.Ip {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Email {
+		dAtA[i] = 0x20
+		i++
+		if m.Email {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Uris {
+		dAtA[i] = 0x28
+		i++
+		if m.Uris {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *KeyPolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeyPolicy) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Algorithms) > 0 {
+		for _, s := range m.Algorithms {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.MinRsaSize != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.MinRsaSize))
+	}
+	if len(m.Curves) > 0 {
+		for _, s := range m.Curves {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.MatchKeyUsage {
+		dAtA[i] = 0x20
+		i++
+		if m.MatchKeyUsage {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *CertificatePolicyQualifier) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CertificatePolicyQualifier) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Type) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Type)))
+		i += copy(dAtA[i:], m.Type)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	return i, nil
+}
+
+func (m *CertificatePolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CertificatePolicy) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Qualifiers) > 0 {
+		for _, msg := range m.Qualifiers {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintPkix(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *CertProfileInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CertProfileInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Issuer) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Issuer)))
+		i += copy(dAtA[i:], m.Issuer)
+	}
+	if len(m.Usage) > 0 {
+		for _, s := range m.Usage {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Expiry) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Expiry)))
+		i += copy(dAtA[i:], m.Expiry)
+	}
+	if len(m.Profile) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Profile)))
+		i += copy(dAtA[i:], m.Profile)
+	}
+	if len(m.Description) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Description)))
+		i += copy(dAtA[i:], m.Description)
+	}
+	if len(m.Backdate) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Backdate)))
+		i += copy(dAtA[i:], m.Backdate)
+	}
+	if m.CaConstraint != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.CaConstraint.Size()))
+		n1, err := m.CaConstraint.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if m.OcspNoCheck {
+		dAtA[i] = 0x40
+		i++
+		if m.OcspNoCheck {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.AllowedNames) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.AllowedNames)))
+		i += copy(dAtA[i:], m.AllowedNames)
+	}
+	if len(m.AllowedDns) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.AllowedDns)))
+		i += copy(dAtA[i:], m.AllowedDns)
+	}
+	if len(m.AllowedEmail) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.AllowedEmail)))
+		i += copy(dAtA[i:], m.AllowedEmail)
+	}
+	if m.AllowedFields != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.AllowedFields.Size()))
+		n2, err := m.AllowedFields.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if len(m.AllowedExtensions) > 0 {
+		for _, s := range m.AllowedExtensions {
+			dAtA[i] = 0x6a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Policies) > 0 {
+		for _, msg := range m.Policies {
+			dAtA[i] = 0x72
+			i++
+			i = encodeVarintPkix(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.AllowedUri) > 0 {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.AllowedUri)))
+		i += copy(dAtA[i:], m.AllowedUri)
+	}
+	if len(m.SpiffeTrustDomain) > 0 {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.SpiffeTrustDomain)))
+		i += copy(dAtA[i:], m.SpiffeTrustDomain)
+	}
+	if m.AllowedKeys != nil {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.AllowedKeys.Size()))
+		n3, err := m.AllowedKeys.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.RejectSharedKeys {
+		dAtA[i] = 0x90
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.RejectSharedKeys {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *CertificateBundle) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CertificateBundle) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Certificate) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Certificate)))
+		i += copy(dAtA[i:], m.Certificate)
+	}
+	if len(m.Intermediates) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Intermediates)))
+		i += copy(dAtA[i:], m.Intermediates)
+	}
+	if len(m.Root) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Root)))
+		i += copy(dAtA[i:], m.Root)
+	}
+	if m.NotBefore != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.NotBefore))
+	}
+	if m.NotAfter != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.NotAfter))
+	}
+	if m.Preview != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.Preview.Size()))
+		n4, err := m.Preview.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	return i, nil
+}
+
+func (m *Extension) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Extension) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if m.Critical {
+		dAtA[i] = 0x10
+		i++
+		if m.Critical {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	return i, nil
+}
+
+func (m *LintFinding) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LintFinding) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Lint) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Lint)))
+		i += copy(dAtA[i:], m.Lint)
+	}
+	if len(m.Severity) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Severity)))
+		i += copy(dAtA[i:], m.Severity)
+	}
+	if len(m.Message) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
+	}
+	return i, nil
+}
+
+func (m *CertificatePreview) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CertificatePreview) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	if len(m.Issuer) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Issuer)))
+		i += copy(dAtA[i:], m.Issuer)
+	}
+	if len(m.DnsNames) > 0 {
+		for _, s := range m.DnsNames {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.IpAddresses) > 0 {
+		for _, s := range m.IpAddresses {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.EmailAddresses) > 0 {
+		for _, s := range m.EmailAddresses {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Uris) > 0 {
+		for _, s := range m.Uris {
+			dAtA[i] = 0x32
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.NotBefore != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.NotBefore))
+	}
+	if m.NotAfter != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.NotAfter))
+	}
+	if len(m.KeyUsage) > 0 {
+		for _, s := range m.KeyUsage {
+			dAtA[i] = 0x4a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.ExtKeyUsage) > 0 {
+		for _, s := range m.ExtKeyUsage {
+			dAtA[i] = 0x52
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.CaConstraint != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.CaConstraint.Size()))
+		n5, err := m.CaConstraint.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if len(m.Skid) > 0 {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Skid)))
+		i += copy(dAtA[i:], m.Skid)
+	}
+	if len(m.Ikid) > 0 {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Ikid)))
+		i += copy(dAtA[i:], m.Ikid)
+	}
+	if len(m.OcspServers) > 0 {
+		for _, s := range m.OcspServers {
+			dAtA[i] = 0x72
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.CrlDistributionPoints) > 0 {
+		for _, s := range m.CrlDistributionPoints {
+			dAtA[i] = 0x7a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.IssuingCertificateUrls) > 0 {
+		for _, s := range m.IssuingCertificateUrls {
+			dAtA[i] = 0x82
+			i++
+			dAtA[i] = 0x1
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Extensions) > 0 {
+		for _, msg := range m.Extensions {
+			dAtA[i] = 0x8a
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintPkix(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.PublicKeyAlgorithm) > 0 {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.PublicKeyAlgorithm)))
+		i += copy(dAtA[i:], m.PublicKeyAlgorithm)
+	}
+	if len(m.SignatureAlgorithm) > 0 {
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.SignatureAlgorithm)))
+		i += copy(dAtA[i:], m.SignatureAlgorithm)
+	}
+	if len(m.LintFindings) > 0 {
+		for _, msg := range m.LintFindings {
+			dAtA[i] = 0xa2
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintPkix(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *IssuerInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IssuerInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Certificate) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Certificate)))
+		i += copy(dAtA[i:], m.Certificate)
+	}
+	if len(m.Intermediates) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Intermediates)))
+		i += copy(dAtA[i:], m.Intermediates)
+	}
+	if len(m.Root) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Root)))
+		i += copy(dAtA[i:], m.Root)
+	}
+	if len(m.Label) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Label)))
+		i += copy(dAtA[i:], m.Label)
+	}
+	return i, nil
+}
+
+func (m *IssuersInfoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IssuersInfoResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Issuers) > 0 {
+		for _, msg := range m.Issuers {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPkix(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *CreateCertificateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateCertificateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.RequestFormat != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.RequestFormat))
+	}
+	if len(m.Request) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Request)))
+		i += copy(dAtA[i:], m.Request)
+	}
+	if len(m.Profile) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Profile)))
+		i += copy(dAtA[i:], m.Profile)
+	}
+	if len(m.IssuerLabel) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.IssuerLabel)))
+		i += copy(dAtA[i:], m.IssuerLabel)
+	}
+	if m.WithBundle {
+		dAtA[i] = 0x28
+		i++
+		if m.WithBundle {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Token) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Token)))
+		i += copy(dAtA[i:], m.Token)
+	}
+	if m.NotBefore != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.NotBefore))
+	}
+	if m.NotAfter != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.NotAfter))
+	}
+	if m.DryRun {
+		dAtA[i] = 0x48
+		i++
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *Certificate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Certificate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Id != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.Id))
+	}
+	if m.OwnerId != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.OwnerId))
+	}
+	if len(m.Skid) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Skid)))
+		i += copy(dAtA[i:], m.Skid)
+	}
+	if len(m.Ikid) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Ikid)))
+		i += copy(dAtA[i:], m.Ikid)
+	}
+	if len(m.SerialNumber) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.SerialNumber)))
+		i += copy(dAtA[i:], m.SerialNumber)
+	}
+	if m.NotBefore != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.NotBefore))
+	}
+	if m.NotAfter != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.NotAfter))
+	}
+	if len(m.Subject) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Subject)))
+		i += copy(dAtA[i:], m.Subject)
+	}
+	if len(m.Profile) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Profile)))
+		i += copy(dAtA[i:], m.Profile)
+	}
+	if len(m.Pem) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Pem)))
+		i += copy(dAtA[i:], m.Pem)
+	}
+	return i, nil
+}
+
+func (m *RevokeCertificateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokeCertificateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Skid) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Skid)))
+		i += copy(dAtA[i:], m.Skid)
+	}
+	if len(m.Ikid) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Ikid)))
+		i += copy(dAtA[i:], m.Ikid)
+	}
+	if len(m.SerialNumber) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.SerialNumber)))
+		i += copy(dAtA[i:], m.SerialNumber)
+	}
+	if m.Reason != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.Reason))
+	}
+	return i, nil
+}
+
+func (m *RevokedCertificate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokedCertificate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Certificate != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.Certificate.Size()))
+		n6, err := m.Certificate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.RevokedAt != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.RevokedAt))
+	}
+	if m.Reason != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.Reason))
+	}
+	return i, nil
+}
+
+func (m *BlockKeyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockKeyRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.SpkiHash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.SpkiHash)))
+		i += copy(dAtA[i:], m.SpkiHash)
+	}
+	if len(m.Pem) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Pem)))
+		i += copy(dAtA[i:], m.Pem)
+	}
+	if m.Reason != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.Reason))
+	}
+	return i, nil
+}
+
+func (m *BlockedKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockedKey) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.SpkiHash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.SpkiHash)))
+		i += copy(dAtA[i:], m.SpkiHash)
+	}
+	if m.Reason != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.Reason))
+	}
+	if len(m.Requestor) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Requestor)))
+		i += copy(dAtA[i:], m.Requestor)
+	}
+	if m.CreatedAt != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.CreatedAt))
+	}
+	return i, nil
+}
+
+func (m *CreateEnrollmentTokenRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateEnrollmentTokenRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Profile) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Profile)))
+		i += copy(dAtA[i:], m.Profile)
+	}
+	if len(m.IssuerLabel) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.IssuerLabel)))
+		i += copy(dAtA[i:], m.IssuerLabel)
+	}
+	if len(m.NamePattern) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.NamePattern)))
+		i += copy(dAtA[i:], m.NamePattern)
+	}
+	if len(m.Expiry) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Expiry)))
+		i += copy(dAtA[i:], m.Expiry)
+	}
+	if m.AllowAnyName {
+		dAtA[i] = 0x28
+		i++
+		if m.AllowAnyName {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *EnrollmentToken) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EnrollmentToken) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Id != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.Id))
+	}
+	if len(m.Token) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Token)))
+		i += copy(dAtA[i:], m.Token)
+	}
+	if len(m.Profile) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.Profile)))
+		i += copy(dAtA[i:], m.Profile)
+	}
+	if len(m.IssuerLabel) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.IssuerLabel)))
+		i += copy(dAtA[i:], m.IssuerLabel)
+	}
+	if len(m.NamePattern) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.NamePattern)))
+		i += copy(dAtA[i:], m.NamePattern)
+	}
+	if len(m.CreatedBy) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(len(m.CreatedBy)))
+		i += copy(dAtA[i:], m.CreatedBy)
+	}
+	if m.CreatedAt != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.CreatedAt))
+	}
+	if m.ExpiresAt != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.ExpiresAt))
+	}
+	if m.UsedAt != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.UsedAt))
+	}
+	return i, nil
+}
+
+func (m *EnrollmentTokensResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EnrollmentTokensResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Tokens) > 0 {
+		for _, msg := range m.Tokens {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintPkix(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *RevokeEnrollmentTokenRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevokeEnrollmentTokenRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Id != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPkix(dAtA, i, uint64(m.Id))
+	}
+	return i, nil
+}
+
+func encodeVarintPkix(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *X509Name) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Country)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Locality)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Organisation)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.OrganisationalUnit)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	return n
+}
+
+func (m *X509Subject) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Cn)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if len(m.Names) > 0 {
+		for _, e := range m.Names {
+			l = e.Size()
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	l = len(m.SerialNumber)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	return n
+}
+
+func (m *CertProfileInfoRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Label)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Profile)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	return n
+}
+
+func (m *CAConstraint) Size() (n int) {
+	var l int
+	_ = l
+	if m.IsCa {
+		n += 2
+	}
+	if m.MaxPathLen != 0 {
+		n += 1 + sovPkix(uint64(m.MaxPathLen))
+	}
+	if m.MaxPathLenZero {
+		n += 2
+	}
+	return n
+}
+
+func (m *CSRAllowedFields) Size() (n int) {
+	var l int
+	_ = l
+	if m.Subject {
+		n += 2
+	}
+	if m.Dns {
+		n += 2
+	}
+	if m.Ip {
+		n += 2
+	}
+	if m.Email {
+		n += 2
+	}
+	if m.Uris {
+		n += 2
+	}
+	return n
+}
+
+func (m *KeyPolicy) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Algorithms) > 0 {
+		for _, s := range m.Algorithms {
+			l = len(s)
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	if m.MinRsaSize != 0 {
+		n += 1 + sovPkix(uint64(m.MinRsaSize))
+	}
+	if len(m.Curves) > 0 {
+		for _, s := range m.Curves {
+			l = len(s)
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	if m.MatchKeyUsage {
+		n += 2
+	}
+	return n
+}
+
+func (m *CertificatePolicyQualifier) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	return n
+}
+
+func (m *CertificatePolicy) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if len(m.Qualifiers) > 0 {
+		for _, e := range m.Qualifiers {
+			l = e.Size()
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CertProfileInfo) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Issuer)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if len(m.Usage) > 0 {
+		for _, s := range m.Usage {
+			l = len(s)
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	l = len(m.Expiry)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Profile)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Backdate)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.CaConstraint != nil {
+		l = m.CaConstraint.Size()
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.OcspNoCheck {
+		n += 2
+	}
+	l = len(m.AllowedNames)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.AllowedDns)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.AllowedEmail)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.AllowedFields != nil {
+		l = m.AllowedFields.Size()
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if len(m.AllowedExtensions) > 0 {
+		for _, s := range m.AllowedExtensions {
+			l = len(s)
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	if len(m.Policies) > 0 {
+		for _, e := range m.Policies {
+			l = e.Size()
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	l = len(m.AllowedUri)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.SpiffeTrustDomain)
+	if l > 0 {
+		n += 2 + l + sovPkix(uint64(l))
+	}
+	if m.AllowedKeys != nil {
+		l = m.AllowedKeys.Size()
+		n += 2 + l + sovPkix(uint64(l))
+	}
+	if m.RejectSharedKeys {
+		n += 3
+	}
+	return n
+}
+
+func (m *CertificateBundle) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Certificate)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Intermediates)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Root)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.NotBefore != 0 {
+		n += 1 + sovPkix(uint64(m.NotBefore))
+	}
+	if m.NotAfter != 0 {
+		n += 1 + sovPkix(uint64(m.NotAfter))
+	}
+	if m.Preview != nil {
+		l = m.Preview.Size()
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	return n
+}
+
+func (m *Extension) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.Critical {
+		n += 2
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	return n
+}
+
+func (m *LintFinding) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Lint)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Severity)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	return n
+}
+
+func (m *CertificatePreview) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Issuer)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if len(m.DnsNames) > 0 {
+		for _, s := range m.DnsNames {
+			l = len(s)
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	if len(m.IpAddresses) > 0 {
+		for _, s := range m.IpAddresses {
+			l = len(s)
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	if len(m.EmailAddresses) > 0 {
+		for _, s := range m.EmailAddresses {
+			l = len(s)
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	if len(m.Uris) > 0 {
+		for _, s := range m.Uris {
+			l = len(s)
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	if m.NotBefore != 0 {
+		n += 1 + sovPkix(uint64(m.NotBefore))
+	}
+	if m.NotAfter != 0 {
+		n += 1 + sovPkix(uint64(m.NotAfter))
+	}
+	if len(m.KeyUsage) > 0 {
+		for _, s := range m.KeyUsage {
+			l = len(s)
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	if len(m.ExtKeyUsage) > 0 {
+		for _, s := range m.ExtKeyUsage {
+			l = len(s)
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	if m.CaConstraint != nil {
+		l = m.CaConstraint.Size()
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Skid)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Ikid)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if len(m.OcspServers) > 0 {
+		for _, s := range m.OcspServers {
+			l = len(s)
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	if len(m.CrlDistributionPoints) > 0 {
+		for _, s := range m.CrlDistributionPoints {
+			l = len(s)
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	if len(m.IssuingCertificateUrls) > 0 {
+		for _, s := range m.IssuingCertificateUrls {
+			l = len(s)
+			n += 2 + l + sovPkix(uint64(l))
+		}
+	}
+	if len(m.Extensions) > 0 {
+		for _, e := range m.Extensions {
+			l = e.Size()
+			n += 2 + l + sovPkix(uint64(l))
+		}
+	}
+	l = len(m.PublicKeyAlgorithm)
+	if l > 0 {
+		n += 2 + l + sovPkix(uint64(l))
+	}
+	l = len(m.SignatureAlgorithm)
+	if l > 0 {
+		n += 2 + l + sovPkix(uint64(l))
+	}
+	if len(m.LintFindings) > 0 {
+		for _, e := range m.LintFindings {
+			l = e.Size()
+			n += 2 + l + sovPkix(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *IssuerInfo) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Certificate)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Intermediates)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Root)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Label)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	return n
+}
+
+func (m *IssuersInfoResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Issuers) > 0 {
+		for _, e := range m.Issuers {
+			l = e.Size()
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *CreateCertificateRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.RequestFormat != 0 {
+		n += 1 + sovPkix(uint64(m.RequestFormat))
+	}
+	l = len(m.Request)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Profile)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.IssuerLabel)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.WithBundle {
+		n += 2
+	}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.NotBefore != 0 {
+		n += 1 + sovPkix(uint64(m.NotBefore))
+	}
+	if m.NotAfter != 0 {
+		n += 1 + sovPkix(uint64(m.NotAfter))
+	}
+	if m.DryRun {
+		n += 2
+	}
+	return n
+}
+
+func (m *Certificate) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovPkix(uint64(m.Id))
+	}
+	if m.OwnerId != 0 {
+		n += 1 + sovPkix(uint64(m.OwnerId))
+	}
+	l = len(m.Skid)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Ikid)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.SerialNumber)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.NotBefore != 0 {
+		n += 1 + sovPkix(uint64(m.NotBefore))
+	}
+	if m.NotAfter != 0 {
+		n += 1 + sovPkix(uint64(m.NotAfter))
+	}
+	l = len(m.Subject)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Profile)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Pem)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	return n
+}
+
+func (m *RevokeCertificateRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Skid)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Ikid)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.SerialNumber)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.Reason != 0 {
+		n += 1 + sovPkix(uint64(m.Reason))
+	}
+	return n
+}
+
+func (m *RevokedCertificate) Size() (n int) {
+	var l int
+	_ = l
+	if m.Certificate != nil {
+		l = m.Certificate.Size()
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.RevokedAt != 0 {
+		n += 1 + sovPkix(uint64(m.RevokedAt))
+	}
+	if m.Reason != 0 {
+		n += 1 + sovPkix(uint64(m.Reason))
+	}
+	return n
+}
+
+func (m *BlockKeyRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.SpkiHash)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Pem)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.Reason != 0 {
+		n += 1 + sovPkix(uint64(m.Reason))
+	}
+	return n
+}
+
+func (m *BlockedKey) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.SpkiHash)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.Reason != 0 {
+		n += 1 + sovPkix(uint64(m.Reason))
+	}
+	l = len(m.Requestor)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovPkix(uint64(m.CreatedAt))
+	}
+	return n
+}
+
+func (m *CreateEnrollmentTokenRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Profile)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.IssuerLabel)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.NamePattern)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Expiry)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.AllowAnyName {
+		n += 2
+	}
+	return n
+}
+
+func (m *EnrollmentToken) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovPkix(uint64(m.Id))
+	}
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.Profile)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.IssuerLabel)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.NamePattern)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	l = len(m.CreatedBy)
+	if l > 0 {
+		n += 1 + l + sovPkix(uint64(l))
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovPkix(uint64(m.CreatedAt))
+	}
+	if m.ExpiresAt != 0 {
+		n += 1 + sovPkix(uint64(m.ExpiresAt))
+	}
+	if m.UsedAt != 0 {
+		n += 1 + sovPkix(uint64(m.UsedAt))
+	}
+	return n
+}
+
+func (m *EnrollmentTokensResponse) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Tokens) > 0 {
+		for _, e := range m.Tokens {
+			l = e.Size()
+			n += 1 + l + sovPkix(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RevokeEnrollmentTokenRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovPkix(uint64(m.Id))
+	}
+	return n
+}
+
+func sovPkix(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozPkix(x uint64) (n int) {
+	return sovPkix(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *X509Name) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: X509Name: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: X509Name: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Country", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Country = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locality", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Locality = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Organisation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Organisation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrganisationalUnit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrganisationalUnit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *X509Subject) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: X509Subject: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: X509Subject: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cn", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cn = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Names", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Names = append(m.Names, &X509Name{})
+			if err := m.Names[len(m.Names)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SerialNumber", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SerialNumber = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertProfileInfoRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CertProfileInfoRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CertProfileInfoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Label = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Profile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CAConstraint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CAConstraint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CAConstraint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsCa", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsCa = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPathLen", wireType)
+			}
+			m.MaxPathLen = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPathLen |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPathLenZero", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MaxPathLenZero = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CSRAllowedFields) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CSRAllowedFields: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CSRAllowedFields: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Subject = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dns", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Dns = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ip", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ip = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Email = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uris", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Uris = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KeyPolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeyPolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeyPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Algorithms", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Algorithms = append(m.Algorithms, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinRsaSize", wireType)
+			}
+			m.MinRsaSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinRsaSize |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Curves", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Curves = append(m.Curves, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MatchKeyUsage", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.MatchKeyUsage = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertificatePolicyQualifier) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CertificatePolicyQualifier: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CertificatePolicyQualifier: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertificatePolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CertificatePolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CertificatePolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Qualifiers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Qualifiers = append(m.Qualifiers, &CertificatePolicyQualifier{})
+			if err := m.Qualifiers[len(m.Qualifiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertProfileInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CertProfileInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CertProfileInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Issuer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Issuer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Usage = append(m.Usage, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expiry", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Expiry = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Profile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Backdate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Backdate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CaConstraint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CaConstraint == nil {
+				m.CaConstraint = &CAConstraint{}
+			}
+			if err := m.CaConstraint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OcspNoCheck", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.OcspNoCheck = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedNames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedNames = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedDns", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedDns = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedEmail", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedEmail = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedFields", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AllowedFields == nil {
+				m.AllowedFields = &CSRAllowedFields{}
+			}
+			if err := m.AllowedFields.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedExtensions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedExtensions = append(m.AllowedExtensions, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Policies", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Policies = append(m.Policies, &CertificatePolicy{})
+			if err := m.Policies[len(m.Policies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedUri", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedUri = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpiffeTrustDomain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SpiffeTrustDomain = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedKeys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AllowedKeys == nil {
+				m.AllowedKeys = &KeyPolicy{}
+			}
+			if err := m.AllowedKeys.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectSharedKeys", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RejectSharedKeys = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertificateBundle) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CertificateBundle: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CertificateBundle: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Certificate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Intermediates", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Intermediates = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Root = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotBefore", wireType)
+			}
+			m.NotBefore = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotBefore |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotAfter", wireType)
+			}
+			m.NotAfter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotAfter |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Preview", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Preview == nil {
+				m.Preview = &CertificatePreview{}
+			}
+			if err := m.Preview.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Extension) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Extension: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Extension: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Critical", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Critical = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LintFinding) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LintFinding: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LintFinding: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Lint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Severity", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Severity = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CertificatePreview) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CertificatePreview: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CertificatePreview: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Issuer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Issuer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DnsNames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DnsNames = append(m.DnsNames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpAddresses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IpAddresses = append(m.IpAddresses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmailAddresses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EmailAddresses = append(m.EmailAddresses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uris", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uris = append(m.Uris, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotBefore", wireType)
+			}
+			m.NotBefore = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotBefore |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotAfter", wireType)
+			}
+			m.NotAfter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotAfter |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyUsage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyUsage = append(m.KeyUsage, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtKeyUsage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExtKeyUsage = append(m.ExtKeyUsage, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CaConstraint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CaConstraint == nil {
+				m.CaConstraint = &CAConstraint{}
+			}
+			if err := m.CaConstraint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Skid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Skid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ikid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ikid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OcspServers", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OcspServers = append(m.OcspServers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CrlDistributionPoints", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CrlDistributionPoints = append(m.CrlDistributionPoints, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IssuingCertificateUrls", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IssuingCertificateUrls = append(m.IssuingCertificateUrls, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extensions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Extensions = append(m.Extensions, &Extension{})
+			if err := m.Extensions[len(m.Extensions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKeyAlgorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKeyAlgorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureAlgorithm", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignatureAlgorithm = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LintFindings", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LintFindings = append(m.LintFindings, &LintFinding{})
+			if err := m.LintFindings[len(m.LintFindings)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IssuerInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IssuerInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IssuerInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Certificate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Intermediates", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Intermediates = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Root = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Label = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IssuersInfoResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IssuersInfoResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IssuersInfoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Issuers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Issuers = append(m.Issuers, &IssuerInfo{})
+			if err := m.Issuers[len(m.Issuers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateCertificateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateCertificateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateCertificateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestFormat", wireType)
+			}
+			m.RequestFormat = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequestFormat |= (EncodingFormat(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Request = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Profile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IssuerLabel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IssuerLabel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WithBundle", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.WithBundle = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotBefore", wireType)
+			}
+			m.NotBefore = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotBefore |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotAfter", wireType)
+			}
+			m.NotAfter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotAfter |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Certificate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Certificate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Certificate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OwnerId", wireType)
+			}
+			m.OwnerId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OwnerId |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Skid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Skid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ikid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ikid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SerialNumber", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SerialNumber = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotBefore", wireType)
+			}
+			m.NotBefore = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotBefore |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotAfter", wireType)
+			}
+			m.NotAfter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NotAfter |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Profile = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pem", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pem = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPkix
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
-
-func (m *CreateCertificateRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.RequestFormat != 0 {
-		n += 1 + sovPkix(uint64(m.RequestFormat))
-	}
-	l = len(m.Request)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
-	}
-	l = len(m.Profile)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
-	}
-	l = len(m.IssuerLabel)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
-	}
-	if m.WithBundle {
-		n += 2
-	}
-	l = len(m.Token)
-	if l > 0 {
-		n += 1 + l + sovPkix(uint64(l))
-	}
-	return n
-}
 
-func sovPkix(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozPkix(x uint64) (n int) {
-	return sovPkix(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *X509Name) Unmarshal(dAtA []byte) error {
+func (m *RevokeCertificateRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1068,15 +7608,15 @@ func (m *X509Name) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: X509Name: wiretype end group for non-group")
+			return fmt.Errorf("proto: RevokeCertificateRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: X509Name: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RevokeCertificateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Country", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Skid", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1101,13 +7641,71 @@ func (m *X509Name) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Country = string(dAtA[iNdEx:postIndex])
+			m.Skid = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ikid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ikid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SerialNumber", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SerialNumber = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
-			var stringLen uint64
+			m.Reason = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPkix
@@ -1117,26 +7715,66 @@ func (m *X509Name) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Reason |= (Reason(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPkix(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthPkix
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.State = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RevokedCertificate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPkix
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RevokedCertificate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RevokedCertificate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locality", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPkix
@@ -1146,26 +7784,30 @@ func (m *X509Name) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPkix
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Locality = string(dAtA[iNdEx:postIndex])
+			if m.Certificate == nil {
+				m.Certificate = &Certificate{}
+			}
+			if err := m.Certificate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Organisation", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevokedAt", wireType)
 			}
-			var stringLen uint64
+			m.RevokedAt = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPkix
@@ -1175,26 +7817,16 @@ func (m *X509Name) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.RevokedAt |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPkix
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Organisation = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OrganisationalUnit", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
-			var stringLen uint64
+			m.Reason = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPkix
@@ -1204,21 +7836,11 @@ func (m *X509Name) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Reason |= (Reason(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPkix
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.OrganisationalUnit = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPkix(dAtA[iNdEx:])
@@ -1240,7 +7862,7 @@ func (m *X509Name) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *X509Subject) Unmarshal(dAtA []byte) error {
+func (m *BlockKeyRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1263,15 +7885,15 @@ func (m *X509Subject) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: X509Subject: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockKeyRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: X509Subject: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockKeyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cn", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SpkiHash", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1296,13 +7918,13 @@ func (m *X509Subject) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Cn = string(dAtA[iNdEx:postIndex])
+			m.SpkiHash = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Names", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pem", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPkix
@@ -1312,28 +7934,26 @@ func (m *X509Subject) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPkix
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Names = append(m.Names, &X509Name{})
-			if err := m.Names[len(m.Names)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Pem = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SerialNumber", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
-			var stringLen uint64
+			m.Reason = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPkix
@@ -1343,21 +7963,11 @@ func (m *X509Subject) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Reason |= (Reason(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPkix
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.SerialNumber = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPkix(dAtA[iNdEx:])
@@ -1379,7 +7989,7 @@ func (m *X509Subject) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CertProfileInfoRequest) Unmarshal(dAtA []byte) error {
+func (m *BlockedKey) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1402,15 +8012,15 @@ func (m *CertProfileInfoRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CertProfileInfoRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockedKey: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CertProfileInfoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockedKey: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SpkiHash", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1435,11 +8045,30 @@ func (m *CertProfileInfoRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Label = string(dAtA[iNdEx:postIndex])
+			m.SpkiHash = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			m.Reason = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Reason |= (Reason(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Requestor", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1464,8 +8093,27 @@ func (m *CertProfileInfoRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Profile = string(dAtA[iNdEx:postIndex])
+			m.Requestor = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPkix(dAtA[iNdEx:])
@@ -1487,7 +8135,7 @@ func (m *CertProfileInfoRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CertProfileInfo) Unmarshal(dAtA []byte) error {
+func (m *CreateEnrollmentTokenRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1510,15 +8158,15 @@ func (m *CertProfileInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CertProfileInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateEnrollmentTokenRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CertProfileInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateEnrollmentTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Issuer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1543,11 +8191,11 @@ func (m *CertProfileInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Issuer = string(dAtA[iNdEx:postIndex])
+			m.Profile = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Usage", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IssuerLabel", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1572,9 +8220,38 @@ func (m *CertProfileInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Usage = append(m.Usage, string(dAtA[iNdEx:postIndex]))
+			m.IssuerLabel = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamePattern", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPkix
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamePattern = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Expiry", wireType)
 			}
@@ -1603,6 +8280,26 @@ func (m *CertProfileInfo) Unmarshal(dAtA []byte) error {
 			}
 			m.Expiry = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowAnyName", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllowAnyName = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPkix(dAtA[iNdEx:])
@@ -1624,7 +8321,7 @@ func (m *CertProfileInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CertificateBundle) Unmarshal(dAtA []byte) error {
+func (m *EnrollmentToken) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1647,17 +8344,17 @@ func (m *CertificateBundle) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CertificateBundle: wiretype end group for non-group")
+			return fmt.Errorf("proto: EnrollmentToken: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CertificateBundle: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EnrollmentToken: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var stringLen uint64
+			m.Id = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPkix
@@ -1667,24 +8364,14 @@ func (m *CertificateBundle) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Id |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPkix
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Certificate = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Intermediates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1709,11 +8396,11 @@ func (m *CertificateBundle) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Intermediates = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1738,61 +8425,11 @@ func (m *CertificateBundle) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Root = string(dAtA[iNdEx:postIndex])
+			m.Profile = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPkix(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPkix
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *IssuerInfo) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPkix
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: IssuerInfo: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IssuerInfo: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Certificate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IssuerLabel", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1817,11 +8454,11 @@ func (m *IssuerInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Certificate = string(dAtA[iNdEx:postIndex])
+			m.IssuerLabel = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Intermediates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NamePattern", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1846,11 +8483,11 @@ func (m *IssuerInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Intermediates = string(dAtA[iNdEx:postIndex])
+			m.NamePattern = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Root", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedBy", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1875,13 +8512,13 @@ func (m *IssuerInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Root = string(dAtA[iNdEx:postIndex])
+			m.CreatedBy = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
 			}
-			var stringLen uint64
+			m.CreatedAt = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPkix
@@ -1891,21 +8528,49 @@ func (m *IssuerInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.CreatedAt |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPkix
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresAt", wireType)
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.ExpiresAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpiresAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UsedAt", wireType)
+			}
+			m.UsedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPkix
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UsedAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Label = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPkix(dAtA[iNdEx:])
@@ -1927,7 +8592,7 @@ func (m *IssuerInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IssuersInfoResponse) Unmarshal(dAtA []byte) error {
+func (m *EnrollmentTokensResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1950,15 +8615,15 @@ func (m *IssuersInfoResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IssuersInfoResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: EnrollmentTokensResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IssuersInfoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EnrollmentTokensResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Issuers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tokens", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1982,8 +8647,8 @@ func (m *IssuersInfoResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Issuers = append(m.Issuers, &IssuerInfo{})
-			if err := m.Issuers[len(m.Issuers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Tokens = append(m.Tokens, &EnrollmentToken{})
+			if err := m.Tokens[len(m.Tokens)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2008,7 +8673,7 @@ func (m *IssuersInfoResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateCertificateRequest) Unmarshal(dAtA []byte) error {
+func (m *RevokeEnrollmentTokenRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2031,143 +8696,17 @@ func (m *CreateCertificateRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateCertificateRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RevokeEnrollmentTokenRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateCertificateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RevokeEnrollmentTokenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestFormat", wireType)
-			}
-			m.RequestFormat = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPkix
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.RequestFormat |= (EncodingFormat(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPkix
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPkix
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Request = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPkix
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPkix
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Profile = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IssuerLabel", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPkix
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPkix
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.IssuerLabel = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WithBundle", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPkix
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.WithBundle = bool(v != 0)
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
-			}
-			var stringLen uint64
+			m.Id = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPkix
@@ -2177,21 +8716,11 @@ func (m *CreateCertificateRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Id |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPkix
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Token = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPkix(dAtA[iNdEx:])
@@ -2321,50 +8850,155 @@ var (
 func init() { proto.RegisterFile("pkix.proto", fileDescriptorPkix) }
 
 var fileDescriptorPkix = []byte{
-	// 716 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0xc1, 0x6e, 0xd3, 0x48,
-	0x18, 0xae, 0x93, 0xa6, 0x49, 0xfe, 0xb4, 0xd9, 0x74, 0x1a, 0x65, 0xdd, 0xec, 0x6e, 0x9a, 0xf5,
-	0xee, 0xa1, 0x5a, 0x69, 0x63, 0x28, 0x42, 0x88, 0x13, 0xa2, 0x25, 0x88, 0x0a, 0xa8, 0x2a, 0x57,
-	0x88, 0xde, 0xa2, 0x89, 0x3b, 0x49, 0x87, 0x3a, 0x33, 0x66, 0x66, 0x0c, 0xcd, 0x85, 0x03, 0xaf,
-	0xc0, 0x85, 0x77, 0xe0, 0x31, 0xb8, 0x70, 0x44, 0xe2, 0x05, 0x50, 0xe1, 0xc2, 0x5b, 0x20, 0xcf,
-	0x8c, 0x89, 0x5d, 0xd1, 0x23, 0xb7, 0xf9, 0xbe, 0xf9, 0xf5, 0xfd, 0xff, 0x7c, 0xff, 0x67, 0x03,
-	0xc4, 0x67, 0xf4, 0x7c, 0x10, 0x0b, 0xae, 0x38, 0xaa, 0x29, 0x91, 0x48, 0x35, 0x8f, 0xc7, 0xdd,
-	0xba, 0x88, 0x43, 0x43, 0x76, 0xdb, 0x53, 0x3e, 0xe5, 0xfa, 0xe8, 0xa7, 0x27, 0xcb, 0xfe, 0x39,
-	0xe5, 0x7c, 0x1a, 0x11, 0x1f, 0xc7, 0xd4, 0xc7, 0x8c, 0x71, 0x85, 0x15, 0xe5, 0x4c, 0x9a, 0x5b,
-	0xef, 0x9d, 0x03, 0xb5, 0xe3, 0x9b, 0xd7, 0x6e, 0x1f, 0xe0, 0x19, 0x41, 0x2e, 0x54, 0x43, 0x9e,
-	0x30, 0x25, 0xe6, 0xae, 0xd3, 0x77, 0xb6, 0xeb, 0x41, 0x06, 0x51, 0x1b, 0x2a, 0x52, 0x61, 0x45,
-	0xdc, 0x92, 0xe6, 0x0d, 0x40, 0x5d, 0xa8, 0x45, 0x3c, 0xc4, 0x11, 0x55, 0x73, 0xb7, 0xac, 0x2f,
-	0x7e, 0x60, 0xe4, 0xc1, 0x2a, 0x17, 0x53, 0xcc, 0xa8, 0xd4, 0xfd, 0xdc, 0x65, 0x7d, 0x5f, 0xe0,
-	0x90, 0x0f, 0x1b, 0x79, 0x8c, 0xa3, 0x51, 0xc2, 0xa8, 0x72, 0x2b, 0xba, 0x14, 0x15, 0xaf, 0x9e,
-	0x30, 0xaa, 0xbc, 0x08, 0x1a, 0xe9, 0xb0, 0x47, 0xc9, 0xf8, 0x19, 0x09, 0x15, 0x6a, 0x42, 0x29,
-	0x64, 0x76, 0xd4, 0x52, 0xc8, 0xd0, 0x36, 0x54, 0x18, 0x9e, 0x11, 0xe9, 0x96, 0xfa, 0xe5, 0xed,
-	0xc6, 0x0e, 0x1a, 0x64, 0x2e, 0x0d, 0xb2, 0x27, 0x06, 0xa6, 0x00, 0xfd, 0x03, 0x6b, 0x92, 0x08,
-	0x8a, 0xa3, 0x11, 0x4b, 0x66, 0x63, 0x22, 0xec, 0xf8, 0xab, 0x86, 0x3c, 0xd0, 0x9c, 0xf7, 0x00,
-	0x3a, 0x7b, 0x44, 0xa8, 0x43, 0xc1, 0x27, 0x34, 0x22, 0xfb, 0x6c, 0xc2, 0x03, 0xf2, 0x3c, 0x21,
-	0x52, 0xa5, 0x76, 0x44, 0x78, 0x4c, 0x22, 0xdb, 0xdb, 0x80, 0xd4, 0xbe, 0xd8, 0xd4, 0x5a, 0x9b,
-	0x32, 0xe8, 0x3d, 0x85, 0xdf, 0x2e, 0x29, 0xa1, 0x0e, 0xac, 0x50, 0x29, 0x13, 0x22, 0xac, 0x86,
-	0x45, 0xa9, 0x74, 0x22, 0xf1, 0x94, 0xe8, 0x37, 0xd4, 0x03, 0x03, 0xd2, 0x6a, 0x72, 0x1e, 0x53,
-	0x91, 0xf9, 0x6c, 0x91, 0xc7, 0x61, 0x3d, 0x15, 0xa6, 0x13, 0x1a, 0x62, 0x45, 0x76, 0x13, 0x76,
-	0x12, 0x11, 0xd4, 0x87, 0x46, 0xb8, 0x20, 0xad, 0x7e, 0x9e, 0x42, 0xff, 0xc2, 0x1a, 0x65, 0x8a,
-	0x88, 0x19, 0x39, 0xa1, 0x58, 0x69, 0xc3, 0xd2, 0x9a, 0x22, 0x89, 0x10, 0x2c, 0x0b, 0xce, 0x95,
-	0x6d, 0xa9, 0xcf, 0xde, 0x2b, 0x80, 0x7d, 0x3d, 0xa8, 0x7e, 0xc4, 0x2f, 0xec, 0xb4, 0xf0, 0x78,
-	0x39, 0xe7, 0xb1, 0x37, 0x84, 0x0d, 0xd3, 0x5f, 0x9a, 0x7d, 0xc8, 0x98, 0x33, 0x49, 0xd0, 0x00,
-	0xaa, 0xc6, 0x3f, 0xe9, 0x3a, 0x7a, 0xf7, 0xed, 0xc5, 0xee, 0x17, 0xf3, 0x06, 0x59, 0x91, 0xf7,
-	0xcd, 0x01, 0x77, 0x4f, 0x10, 0xac, 0x48, 0xce, 0xbe, 0x6c, 0xbb, 0x77, 0xa0, 0x29, 0xcc, 0x71,
-	0x34, 0xe1, 0x62, 0x86, 0x95, 0x7e, 0x58, 0x73, 0xc7, 0x5d, 0x68, 0x0e, 0x59, 0xc8, 0x4f, 0x28,
-	0x9b, 0xde, 0xd7, 0xf7, 0xc1, 0x9a, 0xad, 0x37, 0x30, 0x0d, 0x82, 0x25, 0xb2, 0x20, 0x58, 0x98,
-	0x8f, 0x48, 0xb9, 0x10, 0x11, 0xf4, 0x37, 0xac, 0x9a, 0xe1, 0x46, 0xf9, 0x57, 0x37, 0x0c, 0xf7,
-	0x48, 0xe7, 0x6b, 0x0b, 0x1a, 0x2f, 0xa9, 0x3a, 0x1d, 0x8d, 0xf5, 0x9a, 0xf5, 0x67, 0x52, 0x0b,
-	0x20, 0xa5, 0xec, 0xe2, 0xdb, 0x50, 0x51, 0xfc, 0x8c, 0x30, 0x77, 0xc5, 0x58, 0xa6, 0xc1, 0x7f,
-	0xff, 0x43, 0xb3, 0x38, 0x2e, 0xaa, 0x42, 0xf9, 0x70, 0xf8, 0xb8, 0xb5, 0x94, 0x1e, 0xee, 0x0d,
-	0x83, 0x96, 0x83, 0xea, 0x50, 0x39, 0x7c, 0xb8, 0x77, 0x74, 0xab, 0x55, 0xda, 0x79, 0x5f, 0x82,
-	0xfa, 0xdd, 0x44, 0x9d, 0x72, 0x91, 0x7e, 0xc6, 0x67, 0xd0, 0xc8, 0xa7, 0xb6, 0xbf, 0xb0, 0xe0,
-	0xe7, 0x9f, 0x46, 0x77, 0xf3, 0xca, 0x0a, 0x6f, 0xeb, 0xf5, 0xa7, 0xaf, 0x6f, 0x4a, 0x9b, 0xde,
-	0xef, 0xfe, 0x8b, 0xeb, 0x7e, 0x88, 0xfd, 0x50, 0x0a, 0xdf, 0x3e, 0x7f, 0x44, 0x53, 0xf5, 0x34,
-	0xcd, 0x97, 0x97, 0x82, 0xbc, 0x9c, 0xe0, 0x15, 0x1b, 0xeb, 0xfe, 0x51, 0x6c, 0x5a, 0xf8, 0x1c,
-	0xbc, 0x4d, 0xdd, 0x76, 0xc3, 0x5b, 0xcf, 0xb5, 0x0d, 0xb5, 0x12, 0x3a, 0x86, 0xaa, 0x4d, 0x13,
-	0xea, 0xe4, 0x96, 0x3b, 0x8b, 0xd5, 0x3c, 0x93, 0xfe, 0xeb, 0x72, 0x90, 0x0a, 0xc1, 0xf3, 0x3a,
-	0x5a, 0xbc, 0x85, 0x9a, 0x56, 0xdc, 0x06, 0x6c, 0xb7, 0xf5, 0xe1, 0xa2, 0xe7, 0x7c, 0xbc, 0xe8,
-	0x39, 0x9f, 0x2f, 0x7a, 0xce, 0xdb, 0x2f, 0xbd, 0xa5, 0xf1, 0x8a, 0xfe, 0xe1, 0xde, 0xf8, 0x1e,
-	0x00, 0x00, 0xff, 0xff, 0xa2, 0xf3, 0x80, 0x1e, 0xc7, 0x05, 0x00, 0x00,
+	// 2394 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x49, 0x6f, 0x23, 0xc7,
+	0xf5, 0x37, 0x49, 0x91, 0x22, 0x1f, 0x45, 0x8a, 0x2a, 0x2d, 0xd3, 0x43, 0xc9, 0x63, 0xb9, 0xff,
+	0x86, 0xff, 0x8a, 0x11, 0x4b, 0xb6, 0x8c, 0xd8, 0x59, 0x0e, 0x01, 0x87, 0xa2, 0x3c, 0x84, 0x36,
+	0xa6, 0x29, 0xd9, 0x4e, 0x2e, 0x8d, 0x62, 0xb3, 0x24, 0x95, 0xd5, 0xec, 0x6e, 0x57, 0x15, 0x35,
+	0xa2, 0x0f, 0x39, 0xe4, 0x92, 0x93, 0x83, 0x04, 0x01, 0x82, 0x7c, 0x87, 0x7c, 0x85, 0x20, 0xe7,
+	0x1c, 0x03, 0xe4, 0x92, 0x43, 0x02, 0x04, 0xe3, 0x7c, 0x90, 0xa0, 0x96, 0x26, 0x8b, 0xa4, 0xa4,
+	0xc0, 0x30, 0x7c, 0xab, 0xb7, 0xb0, 0x5e, 0xbd, 0xed, 0xf7, 0x5e, 0x13, 0x20, 0xb9, 0xa1, 0x77,
+	0xbb, 0x09, 0x8b, 0x45, 0x8c, 0x8a, 0x82, 0x0d, 0xb9, 0x18, 0x25, 0xbd, 0x7a, 0x89, 0x25, 0x81,
+	0x66, 0xd6, 0xd7, 0xae, 0xe2, 0xab, 0x58, 0x1d, 0xf7, 0xe4, 0xc9, 0x70, 0xb7, 0xae, 0xe2, 0xf8,
+	0x2a, 0x24, 0x7b, 0x38, 0xa1, 0x7b, 0x38, 0x8a, 0x62, 0x81, 0x05, 0x8d, 0x23, 0xae, 0xa5, 0xee,
+	0x9f, 0x32, 0x50, 0xfc, 0xec, 0x07, 0xef, 0xfd, 0xe8, 0x14, 0x0f, 0x08, 0x72, 0x60, 0x31, 0x88,
+	0x87, 0x91, 0x60, 0x23, 0x27, 0xb3, 0x9d, 0xd9, 0x29, 0x79, 0x29, 0x89, 0xd6, 0x20, 0xcf, 0x05,
+	0x16, 0xc4, 0xc9, 0x2a, 0xbe, 0x26, 0x50, 0x1d, 0x8a, 0x61, 0x1c, 0xe0, 0x90, 0x8a, 0x91, 0x93,
+	0x53, 0x82, 0x31, 0x8d, 0x5c, 0x58, 0x8a, 0xd9, 0x15, 0x8e, 0x28, 0x57, 0xf6, 0x9c, 0x05, 0x25,
+	0x9f, 0xe2, 0xa1, 0x3d, 0x58, 0xb5, 0x69, 0x1c, 0xfa, 0xc3, 0x88, 0x0a, 0x27, 0xaf, 0x54, 0xd1,
+	0xb4, 0xe8, 0x22, 0xa2, 0xc2, 0x0d, 0xa1, 0x2c, 0x1f, 0xdb, 0x1d, 0xf6, 0x3e, 0x27, 0x81, 0x40,
+	0x55, 0xc8, 0x06, 0x91, 0x79, 0x6a, 0x36, 0x88, 0xd0, 0x0e, 0xe4, 0x23, 0x3c, 0x20, 0xdc, 0xc9,
+	0x6e, 0xe7, 0x76, 0xca, 0xfb, 0x68, 0x37, 0x8d, 0xd2, 0x6e, 0xea, 0xa2, 0xa7, 0x15, 0xd0, 0xff,
+	0x41, 0x85, 0x13, 0x46, 0x71, 0xe8, 0x47, 0xc3, 0x41, 0x8f, 0x30, 0xf3, 0xfc, 0x25, 0xcd, 0x3c,
+	0x55, 0x3c, 0xf7, 0x05, 0x6c, 0x34, 0x09, 0x13, 0x1d, 0x16, 0x5f, 0xd2, 0x90, 0xb4, 0xa3, 0xcb,
+	0xd8, 0x23, 0x5f, 0x0c, 0x09, 0x17, 0x32, 0x1c, 0x21, 0xee, 0x91, 0xd0, 0xd8, 0xd6, 0x84, 0x0c,
+	0x5f, 0xa2, 0x75, 0x4d, 0x98, 0x52, 0xd2, 0x4d, 0x60, 0xa9, 0xd9, 0x68, 0xc6, 0x11, 0x17, 0x0c,
+	0xd3, 0x48, 0xa0, 0x55, 0xc8, 0x53, 0xee, 0x07, 0x58, 0xfd, 0xbe, 0xe8, 0x2d, 0x50, 0xde, 0xc4,
+	0x68, 0x1b, 0x96, 0x06, 0xf8, 0xce, 0x4f, 0xb0, 0xb8, 0xf6, 0x43, 0x12, 0xa9, 0x3b, 0xf2, 0x1e,
+	0x0c, 0xf0, 0x5d, 0x07, 0x8b, 0xeb, 0x63, 0x12, 0xa1, 0xef, 0xc1, 0x8a, 0xad, 0xe1, 0x7f, 0x49,
+	0x58, 0xac, 0x5e, 0x5e, 0xf4, 0xaa, 0x13, 0xb5, 0x5f, 0x10, 0x16, 0xbb, 0x77, 0x50, 0x6b, 0x76,
+	0xbd, 0x46, 0x18, 0xc6, 0x2f, 0x49, 0xff, 0x90, 0x92, 0xb0, 0xcf, 0xe5, 0xfb, 0xb8, 0x8e, 0x9c,
+	0xb1, 0x9b, 0x92, 0xa8, 0x06, 0xb9, 0x7e, 0xc4, 0x95, 0xc5, 0xa2, 0x27, 0x8f, 0x32, 0xb4, 0x34,
+	0x31, 0x77, 0x67, 0x69, 0x22, 0x3d, 0x26, 0x03, 0x4c, 0x43, 0x95, 0xc7, 0xa2, 0xa7, 0x09, 0x84,
+	0x60, 0x61, 0xc8, 0x28, 0x57, 0x19, 0x2b, 0x7a, 0xea, 0xec, 0x7e, 0x95, 0x81, 0xd2, 0x11, 0x19,
+	0x75, 0xe2, 0x90, 0x06, 0x23, 0xf4, 0x0c, 0x00, 0x87, 0x57, 0x31, 0xa3, 0xe2, 0x7a, 0xc0, 0x9d,
+	0xcc, 0x76, 0x6e, 0xa7, 0xe4, 0x59, 0x1c, 0xe5, 0x34, 0x8d, 0x7c, 0xc6, 0xb1, 0xcf, 0xe9, 0x97,
+	0x64, 0xec, 0x34, 0x8d, 0x3c, 0x8e, 0xbb, 0xf4, 0x4b, 0x82, 0x36, 0xa0, 0x10, 0x0c, 0xd9, 0x2d,
+	0xe1, 0x4e, 0x4e, 0xfd, 0xda, 0x50, 0xe8, 0x6d, 0x58, 0x1e, 0x60, 0x11, 0x5c, 0xfb, 0x37, 0x64,
+	0xe4, 0x0f, 0x39, 0xbe, 0x22, 0xe6, 0x6d, 0x15, 0xc5, 0x3e, 0x22, 0xa3, 0x0b, 0xc9, 0x74, 0x0f,
+	0xa1, 0x2e, 0xb3, 0x48, 0x2f, 0x69, 0x80, 0x05, 0xd1, 0xcf, 0xfa, 0xd9, 0x10, 0x87, 0xf4, 0x92,
+	0x12, 0x26, 0x3d, 0x10, 0xa3, 0x84, 0x98, 0x44, 0xaa, 0xb3, 0xf4, 0xf5, 0x16, 0x87, 0xc3, 0x71,
+	0xb1, 0x2b, 0xc2, 0xa5, 0xb0, 0x32, 0x77, 0x8f, 0x0a, 0x53, 0x3f, 0xad, 0x40, 0xda, 0x47, 0x07,
+	0x00, 0x5f, 0xa4, 0x77, 0xa7, 0x65, 0xf8, 0xd6, 0xa4, 0x0c, 0x1f, 0x7e, 0x88, 0x67, 0xfd, 0xce,
+	0xfd, 0x57, 0x1e, 0x96, 0x67, 0x2a, 0x4f, 0x86, 0x81, 0x72, 0x3e, 0x24, 0xcc, 0x58, 0x33, 0x94,
+	0x7c, 0xac, 0x76, 0x3e, 0xab, 0xa2, 0xa3, 0x09, 0xa9, 0x4d, 0xee, 0x12, 0xca, 0xd2, 0xbe, 0x34,
+	0x94, 0x5d, 0xa2, 0x0b, 0x53, 0x25, 0x8a, 0xb6, 0xa1, 0xdc, 0x27, 0x3c, 0x60, 0x34, 0x51, 0xed,
+	0xaa, 0x7b, 0xd0, 0x66, 0xc9, 0x6e, 0xef, 0xe1, 0xe0, 0xa6, 0x2f, 0x61, 0xa0, 0xa0, 0xbb, 0x3d,
+	0xa5, 0xd1, 0x4f, 0xa0, 0x12, 0x60, 0x3f, 0x18, 0x57, 0xb8, 0xb3, 0xb8, 0x9d, 0xd9, 0x29, 0xef,
+	0x6f, 0x58, 0xae, 0x5b, 0xf5, 0xef, 0x2d, 0x05, 0x78, 0x42, 0x21, 0x17, 0x2a, 0x71, 0xc0, 0x13,
+	0x3f, 0x8a, 0xfd, 0xe0, 0x9a, 0x04, 0x37, 0x4e, 0x51, 0xe5, 0xb1, 0x2c, 0x99, 0xa7, 0x71, 0x53,
+	0xb2, 0x64, 0xc3, 0x62, 0x5d, 0xcc, 0xbe, 0x6e, 0xf1, 0x92, 0x6e, 0x58, 0xc3, 0x94, 0xbd, 0xcd,
+	0xd1, 0x1b, 0x50, 0x4e, 0x95, 0x64, 0x39, 0x83, 0x52, 0x01, 0xc3, 0x3a, 0x88, 0xb8, 0x7d, 0x8b,
+	0xae, 0xe6, 0xf2, 0xd4, 0x2d, 0x2d, 0xc9, 0x43, 0x0d, 0xa8, 0xa6, 0x4a, 0x97, 0xaa, 0x71, 0x9c,
+	0x25, 0xe5, 0x4c, 0xdd, 0x72, 0x66, 0xa6, 0xb5, 0xbc, 0x0a, 0xb6, 0x49, 0xf4, 0x2e, 0xa0, 0xb1,
+	0x9d, 0x3b, 0x41, 0x22, 0x2e, 0x11, 0xd7, 0xa9, 0xa8, 0x0c, 0xad, 0xa4, 0xc6, 0xc6, 0x02, 0xf4,
+	0x11, 0x14, 0x13, 0x59, 0x0e, 0x94, 0x70, 0xa7, 0xaa, 0x6a, 0x66, 0xf3, 0x91, 0x9a, 0xf1, 0xc6,
+	0xca, 0xb6, 0xc3, 0x43, 0x46, 0x9d, 0xe5, 0x29, 0x87, 0x2f, 0x18, 0x45, 0xbb, 0xb0, 0xca, 0x13,
+	0x7a, 0x79, 0x49, 0x7c, 0x75, 0x9f, 0xdf, 0x8f, 0x07, 0x98, 0x46, 0x4e, 0x4d, 0x29, 0xae, 0x68,
+	0xd1, 0xb9, 0x94, 0x1c, 0x28, 0x01, 0xfa, 0x10, 0xd2, 0x58, 0xc8, 0xb6, 0xe2, 0xce, 0x8a, 0xf2,
+	0x7c, 0x75, 0xf2, 0x9a, 0x71, 0x67, 0x7b, 0xa9, 0xe5, 0x23, 0x32, 0xe2, 0xe8, 0xfb, 0x80, 0x18,
+	0x91, 0x50, 0xe2, 0xf3, 0x6b, 0xcc, 0xd2, 0x5f, 0x23, 0x95, 0xc7, 0x9a, 0x96, 0x74, 0x95, 0x40,
+	0x6a, 0xbb, 0x5f, 0x67, 0xa6, 0x7a, 0xe9, 0xf9, 0x30, 0xea, 0xeb, 0x0a, 0x0c, 0x26, 0x4c, 0x53,
+	0xe6, 0x36, 0x0b, 0xbd, 0x05, 0x15, 0x1a, 0x09, 0xc2, 0x06, 0xa4, 0x4f, 0xb1, 0x20, 0xdc, 0x34,
+	0xe8, 0x34, 0x53, 0xb6, 0x34, 0x8b, 0x63, 0x61, 0x2a, 0x5f, 0x9d, 0xd1, 0xeb, 0x00, 0x51, 0x2c,
+	0xfc, 0x1e, 0xb9, 0x8c, 0x99, 0x2e, 0xfd, 0x9c, 0x57, 0x8a, 0x62, 0xf1, 0x5c, 0x31, 0xd0, 0x26,
+	0x48, 0xc2, 0xc7, 0x97, 0x82, 0x30, 0x55, 0xfa, 0x39, 0xaf, 0x18, 0xc5, 0xa2, 0x21, 0x69, 0xf4,
+	0xa1, 0xec, 0x19, 0x72, 0x4b, 0xc9, 0x4b, 0x55, 0xf6, 0xe5, 0xfd, 0xad, 0xfb, 0x93, 0xa3, 0x75,
+	0xbc, 0x54, 0xd9, 0x3d, 0x81, 0xd2, 0x38, 0xc7, 0x73, 0x40, 0x51, 0x87, 0x62, 0xc0, 0xa8, 0xa0,
+	0x01, 0x0e, 0x0d, 0xec, 0x8e, 0xe9, 0x09, 0xfe, 0xe4, 0x6c, 0xfc, 0xf9, 0x14, 0xca, 0xc7, 0x34,
+	0x12, 0x87, 0x34, 0xea, 0xd3, 0xe8, 0x4a, 0x7a, 0x19, 0xca, 0x46, 0x33, 0xc0, 0x25, 0xcf, 0xf2,
+	0x52, 0x4e, 0x6e, 0x09, 0x93, 0xf3, 0x58, 0x87, 0x66, 0x4c, 0xcb, 0xce, 0x1f, 0x10, 0xae, 0x90,
+	0x42, 0x5f, 0x9b, 0x92, 0xee, 0x6f, 0x0a, 0x80, 0xe6, 0xfd, 0x98, 0x9d, 0x16, 0xa5, 0xc9, 0xb4,
+	0x98, 0x40, 0x51, 0x76, 0x0a, 0x8a, 0x36, 0xa1, 0xd4, 0x8f, 0xb8, 0xe9, 0x4f, 0x0d, 0xd6, 0xc5,
+	0x7e, 0xc4, 0x75, 0x6f, 0xbe, 0x09, 0x4b, 0x34, 0xf1, 0x71, 0xbf, 0xcf, 0x08, 0xe7, 0x84, 0x3b,
+	0x0b, 0x4a, 0x5e, 0xa6, 0x49, 0x23, 0x65, 0xa1, 0xff, 0x87, 0x65, 0xd5, 0x95, 0x96, 0x56, 0x5e,
+	0x69, 0x55, 0x15, 0x7b, 0xa2, 0x98, 0x8e, 0x9d, 0x82, 0x92, 0xaa, 0xf3, 0x4c, 0x86, 0x17, 0x1f,
+	0xcd, 0x70, 0x71, 0x26, 0xc3, 0x9b, 0x50, 0x9a, 0x0c, 0x91, 0x92, 0x7e, 0xf8, 0x8d, 0x99, 0x1f,
+	0x12, 0x9d, 0xc8, 0x9d, 0xb0, 0xa6, 0x0c, 0xe8, 0x97, 0x93, 0x3b, 0x91, 0xce, 0x98, 0x79, 0xf8,
+	0x2b, 0x7f, 0x03, 0xf8, 0x43, 0xb0, 0xc0, 0x6f, 0x68, 0x5f, 0xa1, 0x4c, 0xc9, 0x53, 0x67, 0xc9,
+	0xa3, 0x92, 0x57, 0xd1, 0x3c, 0x79, 0x96, 0x11, 0x54, 0x30, 0xc9, 0x09, 0xbb, 0x25, 0x4c, 0x23,
+	0x45, 0x49, 0xa3, 0x64, 0x57, 0xb3, 0xd0, 0x87, 0xf0, 0x24, 0x60, 0xa1, 0xdf, 0xa7, 0x5c, 0x30,
+	0xda, 0x1b, 0x4a, 0xd8, 0xf6, 0x93, 0x98, 0x46, 0x82, 0x3b, 0xcb, 0x4a, 0x7b, 0x3d, 0x60, 0xe1,
+	0x81, 0x25, 0xed, 0x28, 0x21, 0xfa, 0x21, 0x38, 0x32, 0x87, 0x34, 0xba, 0xf2, 0xad, 0x7e, 0xf3,
+	0x87, 0x2c, 0xe4, 0x4e, 0x4d, 0xfd, 0x70, 0xc3, 0xc8, 0xad, 0x42, 0xb9, 0x60, 0x21, 0x47, 0x1f,
+	0x00, 0x58, 0x08, 0xb7, 0xb2, 0x9d, 0x9b, 0x86, 0x8b, 0x71, 0x03, 0x78, 0x96, 0x1a, 0x7a, 0x0f,
+	0xd6, 0x92, 0x61, 0x2f, 0xa4, 0x81, 0x8a, 0xea, 0x78, 0x1b, 0x50, 0x78, 0x51, 0xf2, 0x90, 0x96,
+	0x1d, 0x91, 0x51, 0x23, 0x95, 0xc8, 0x4d, 0x91, 0xd3, 0xab, 0x08, 0x8b, 0x21, 0x23, 0xd6, 0x0f,
+	0x56, 0xf5, 0x0f, 0xc6, 0xa2, 0xc9, 0x0f, 0x7e, 0x0c, 0x15, 0xd9, 0x12, 0xfe, 0xa5, 0x6e, 0x17,
+	0xee, 0xac, 0xa9, 0xa7, 0xad, 0x4f, 0x9e, 0x66, 0x35, 0x93, 0xb7, 0x14, 0x4e, 0x08, 0xee, 0xfe,
+	0x12, 0xa0, 0xad, 0x2a, 0x5a, 0x0d, 0xde, 0xef, 0x12, 0x96, 0xc6, 0x7b, 0xe4, 0x82, 0xb5, 0x47,
+	0xba, 0x2d, 0x58, 0xd5, 0xf6, 0xb9, 0xde, 0x39, 0x79, 0x12, 0x47, 0x9c, 0xa0, 0x5d, 0x58, 0xd4,
+	0x8d, 0xa6, 0xf7, 0xa8, 0xf2, 0xfe, 0xda, 0xc4, 0x99, 0xc9, 0x7b, 0xbd, 0x54, 0xc9, 0xfd, 0x4b,
+	0x16, 0x9c, 0x26, 0x23, 0x58, 0x10, 0x2b, 0x69, 0xe9, 0x06, 0xfb, 0x53, 0xa8, 0x32, 0x7d, 0xf4,
+	0x2f, 0x63, 0x36, 0xc0, 0xba, 0xc9, 0xab, 0xfb, 0x8e, 0x95, 0xbb, 0x28, 0x88, 0x65, 0x40, 0x0e,
+	0x95, 0xdc, 0xab, 0x18, 0x7d, 0x4d, 0x4a, 0x78, 0x30, 0x8c, 0x74, 0xd9, 0x35, 0xa4, 0xbd, 0x63,
+	0xe4, 0xa6, 0x77, 0x0c, 0x89, 0x01, 0xea, 0x71, 0xbe, 0xed, 0x75, 0x59, 0xf3, 0x8e, 0x25, 0x4b,
+	0x4e, 0xb4, 0x97, 0x54, 0x5c, 0xfb, 0x3d, 0x35, 0x13, 0xcc, 0x62, 0x09, 0x92, 0x65, 0xa6, 0xc4,
+	0x1a, 0xe4, 0x45, 0x7c, 0x43, 0x22, 0xb3, 0x82, 0x68, 0xe2, 0x5b, 0x75, 0xff, 0x13, 0x58, 0xec,
+	0xb3, 0x91, 0xcf, 0x86, 0x91, 0x5a, 0x2a, 0x8a, 0x5e, 0xa1, 0xcf, 0x46, 0xde, 0x30, 0x72, 0x7f,
+	0x9d, 0x85, 0xb2, 0x15, 0x3a, 0x0b, 0xc3, 0x73, 0x0a, 0xc3, 0x9f, 0x42, 0x31, 0x7e, 0x19, 0x11,
+	0xe6, 0xd3, 0xbe, 0x8a, 0x41, 0xce, 0x5b, 0x54, 0x74, 0xbb, 0x3f, 0xee, 0xe9, 0xdc, 0x3d, 0x3d,
+	0xbd, 0x60, 0xf5, 0xf4, 0xdc, 0x77, 0x48, 0x7e, 0xfe, 0x3b, 0x64, 0xc6, 0xb9, 0xc2, 0xa3, 0xce,
+	0x2d, 0xce, 0x38, 0x67, 0xa1, 0x78, 0x71, 0x1a, 0xc5, 0xad, 0x34, 0x95, 0xa6, 0xd3, 0x54, 0x83,
+	0x5c, 0x42, 0x06, 0x66, 0x7d, 0x92, 0x47, 0xf7, 0x77, 0x19, 0x70, 0x3c, 0x72, 0x1b, 0xdf, 0xdc,
+	0x57, 0x4a, 0xa9, 0xaf, 0x99, 0x7b, 0x7c, 0xcd, 0x3e, 0xe6, 0xeb, 0x3d, 0xdf, 0x5c, 0x68, 0x07,
+	0x0a, 0x8c, 0x60, 0x6e, 0x3e, 0x18, 0xab, 0xfb, 0xb5, 0x49, 0x3d, 0x7a, 0x8a, 0xef, 0x19, 0xb9,
+	0xfb, 0x87, 0x0c, 0x20, 0xfd, 0xa6, 0xbe, 0x9d, 0xa4, 0x8f, 0xe6, 0xdb, 0x75, 0xaa, 0xed, 0x6d,
+	0x07, 0xa6, 0xba, 0xf8, 0x75, 0x00, 0xa6, 0xaf, 0xf3, 0xb1, 0x30, 0xf9, 0x2c, 0x19, 0x4e, 0x43,
+	0x58, 0x0f, 0xcb, 0xfd, 0x8f, 0x87, 0x7d, 0x0e, 0xcb, 0xcf, 0xc3, 0x38, 0xb8, 0x39, 0x22, 0xa3,
+	0x34, 0x44, 0x9b, 0x50, 0xe2, 0xc9, 0x0d, 0xf5, 0xaf, 0x31, 0xbf, 0x36, 0x71, 0x2a, 0x4a, 0xc6,
+	0x0b, 0xcc, 0xaf, 0xd3, 0x70, 0x67, 0xc7, 0xe1, 0xfe, 0x06, 0xb6, 0x7e, 0x9b, 0x01, 0x50, 0xc6,
+	0xd4, 0x66, 0xf5, 0xb8, 0x9d, 0xc9, 0xad, 0xd9, 0xc7, 0x6f, 0x45, 0x5b, 0x50, 0x32, 0xcd, 0x1c,
+	0xa7, 0x59, 0x9a, 0x30, 0x64, 0xa0, 0x02, 0x05, 0x2b, 0x2a, 0x50, 0x66, 0x97, 0x32, 0x9c, 0x86,
+	0x70, 0xff, 0x9c, 0x81, 0x2d, 0x0d, 0x3b, 0xad, 0x88, 0xc5, 0x61, 0x38, 0x20, 0x91, 0x38, 0x97,
+	0x4d, 0xea, 0xcd, 0xe3, 0x43, 0xe6, 0x71, 0x7c, 0xc8, 0xce, 0xe3, 0xc3, 0x9b, 0xb0, 0x24, 0xf7,
+	0x0b, 0xf9, 0x0d, 0x2c, 0x08, 0x8b, 0xcc, 0xeb, 0xca, 0x92, 0xd7, 0xd1, 0x2c, 0xeb, 0xdb, 0x67,
+	0x61, 0xea, 0xdb, 0xe7, 0x2d, 0xb3, 0xd7, 0xfb, 0x38, 0x1a, 0xa9, 0x25, 0xc5, 0xa0, 0x8b, 0xde,
+	0x78, 0x1b, 0xd1, 0x48, 0x2e, 0x2a, 0xee, 0x57, 0x59, 0x58, 0x9e, 0x79, 0xf8, 0x5c, 0xe3, 0x8f,
+	0x31, 0x28, 0x6b, 0x63, 0xd0, 0xb7, 0xc2, 0xbd, 0x59, 0xbf, 0xf2, 0xf3, 0x7e, 0x59, 0x71, 0xef,
+	0x8d, 0x0c, 0xfc, 0xa5, 0x71, 0x7f, 0x3e, 0x9a, 0x49, 0xcb, 0xe2, 0x4c, 0x5a, 0xa4, 0x58, 0xc5,
+	0x81, 0x70, 0x1f, 0x6b, 0x2c, 0xc8, 0x79, 0x25, 0xc3, 0x69, 0x08, 0x09, 0x82, 0x43, 0xae, 0x7f,
+	0x5a, 0x52, 0xb2, 0x82, 0x24, 0x1b, 0xc2, 0x3d, 0x01, 0x67, 0x26, 0x1c, 0x7c, 0x3c, 0x91, 0xde,
+	0x87, 0x82, 0x72, 0x3d, 0x1d, 0x48, 0x4f, 0xed, 0xe1, 0x31, 0x9d, 0x7b, 0xa3, 0xe8, 0xee, 0xc2,
+	0x96, 0x6e, 0xda, 0x07, 0x8a, 0x63, 0x26, 0xd4, 0xef, 0xbc, 0x0b, 0xd5, 0xe9, 0x39, 0x84, 0x16,
+	0x21, 0xd7, 0x69, 0x9d, 0xd4, 0x5e, 0x93, 0x87, 0x83, 0x96, 0x57, 0xcb, 0xa0, 0x12, 0xe4, 0x3b,
+	0x47, 0xcd, 0xee, 0x47, 0xb5, 0xec, 0x3b, 0xff, 0xcc, 0x40, 0x41, 0x17, 0x33, 0x5a, 0x86, 0xf2,
+	0xc5, 0x69, 0xb7, 0xd3, 0x6a, 0xb6, 0x0f, 0xdb, 0xad, 0x83, 0xda, 0x6b, 0x08, 0x41, 0xf5, 0xa8,
+	0xf5, 0x73, 0xbf, 0x79, 0x76, 0xd2, 0xf1, 0xce, 0x4e, 0xda, 0xdd, 0x56, 0x2d, 0x83, 0x56, 0xa0,
+	0xd2, 0x6c, 0xd8, 0xac, 0x2c, 0x7a, 0x02, 0xab, 0x8d, 0xc3, 0xc3, 0xf6, 0x71, 0xbb, 0x71, 0xde,
+	0x3e, 0x3b, 0xf5, 0x9b, 0x2f, 0x1a, 0xa7, 0x1f, 0xb7, 0x0e, 0x6a, 0x39, 0x54, 0x05, 0xe8, 0x5e,
+	0x74, 0x5a, 0x5e, 0xb7, 0x75, 0xd0, 0x3a, 0xa8, 0x2d, 0xa0, 0x3a, 0x6c, 0x34, 0x5b, 0xdd, 0xae,
+	0x56, 0x3b, 0x3b, 0xf4, 0xcf, 0x3a, 0x2d, 0x4f, 0x11, 0xb5, 0x3c, 0x5a, 0x83, 0x5a, 0xb3, 0xe5,
+	0x9d, 0xb7, 0x0f, 0xdb, 0xcd, 0xc6, 0x79, 0xcb, 0x7f, 0x71, 0x76, 0x7c, 0x50, 0x2b, 0xa0, 0x55,
+	0x58, 0xf6, 0x5a, 0x27, 0x67, 0x9f, 0xb4, 0xfc, 0x43, 0xef, 0xec, 0xc4, 0x6f, 0x7a, 0xc7, 0xb5,
+	0xa2, 0xb4, 0xd7, 0xf1, 0xda, 0x9f, 0xb4, 0x8f, 0x5b, 0x1f, 0xb7, 0xfc, 0x4f, 0xdb, 0xe7, 0x2f,
+	0x0e, 0xbc, 0xc6, 0xa7, 0xa7, 0xb5, 0x92, 0x7c, 0x5b, 0x63, 0xea, 0x6d, 0xb0, 0xff, 0x8f, 0x45,
+	0x28, 0x35, 0x86, 0xe2, 0x3a, 0x56, 0x2b, 0xfd, 0x0d, 0x94, 0xed, 0x7f, 0x08, 0xb6, 0xa7, 0x41,
+	0x6e, 0xfe, 0x6f, 0xab, 0xfa, 0xd3, 0x07, 0x35, 0xdc, 0x37, 0x7e, 0xf5, 0xf7, 0xff, 0xfc, 0x3e,
+	0xfb, 0xd4, 0x7d, 0xb2, 0x77, 0xfb, 0xfe, 0x5e, 0x80, 0xf7, 0x02, 0xce, 0xf6, 0x4c, 0xf9, 0xfa,
+	0x54, 0xde, 0x1e, 0xc3, 0xca, 0xdc, 0x32, 0x81, 0x5c, 0xeb, 0xc2, 0x07, 0x36, 0x8d, 0xfa, 0xfd,
+	0x9f, 0xb2, 0x7a, 0x9a, 0xbb, 0x4f, 0x95, 0xd9, 0x55, 0x77, 0xc5, 0x32, 0xab, 0x6b, 0x56, 0x1a,
+	0xd4, 0x35, 0xf2, 0x9d, 0x1b, 0x24, 0xca, 0x0c, 0xfa, 0x0c, 0x16, 0xcd, 0xda, 0x85, 0xac, 0xc5,
+	0xbd, 0x35, 0x48, 0x44, 0x8a, 0xe3, 0xf5, 0xd7, 0x67, 0x37, 0xae, 0xa9, 0x0d, 0xcd, 0xdd, 0x50,
+	0x97, 0xd7, 0x50, 0xd5, 0x5c, 0x6e, 0x36, 0x31, 0xc4, 0x60, 0x65, 0x6e, 0x7a, 0xda, 0xae, 0x3c,
+	0x34, 0x5a, 0xeb, 0x5b, 0xb3, 0x3a, 0xf6, 0xa8, 0x73, 0x37, 0x95, 0xb9, 0x75, 0x77, 0x35, 0xf5,
+	0x85, 0x30, 0xc1, 0xf7, 0xf4, 0xc8, 0x42, 0x9f, 0x40, 0x31, 0x9d, 0x42, 0xc8, 0xca, 0xfb, 0xcc,
+	0x64, 0xaa, 0xaf, 0xcd, 0x88, 0xd4, 0x1c, 0x99, 0x8b, 0x92, 0xfc, 0x9a, 0xdf, 0xeb, 0x49, 0x39,
+	0xfa, 0x02, 0xd6, 0xef, 0x45, 0x77, 0xf4, 0xf6, 0x6c, 0x6a, 0xee, 0xef, 0xf0, 0xfa, 0xc3, 0x20,
+	0xe1, 0xae, 0x2b, 0xb3, 0xcb, 0x6e, 0xc5, 0x98, 0xd5, 0x98, 0x81, 0x28, 0xac, 0x1d, 0x53, 0x2e,
+	0x66, 0xb4, 0x1f, 0xce, 0x92, 0xfb, 0xa0, 0x85, 0x31, 0x74, 0xa5, 0xa6, 0xd0, 0x8c, 0xa9, 0x3b,
+	0x58, 0xbf, 0x17, 0x9e, 0x6c, 0xef, 0x1e, 0xc3, 0xaf, 0xc7, 0xbc, 0xdb, 0x52, 0x26, 0x37, 0xdc,
+	0xb5, 0x29, 0x93, 0x26, 0x5f, 0xcf, 0x6b, 0x7f, 0x7d, 0xf5, 0x2c, 0xf3, 0xb7, 0x57, 0xcf, 0x32,
+	0xff, 0x7e, 0xf5, 0x2c, 0xf3, 0xc7, 0xaf, 0x9f, 0xbd, 0xd6, 0x2b, 0xa8, 0x7f, 0xe8, 0x3f, 0xf8,
+	0xef, 0x00, 0xaf, 0xbe, 0xbf, 0x65, 0xf8, 0x17, 0x00, 0x00,
 }
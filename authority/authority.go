@@ -1,6 +1,10 @@
 package authority
 
 import (
+	"bytes"
+	"crypto"
+	"encoding/hex"
+
 	"github.com/go-phorce/dolly/xlog"
 	"github.com/go-phorce/dolly/xpki/cryptoprov"
 	"github.com/go-phorce/trusty/config"
@@ -58,7 +62,18 @@ func NewAuthority(cfg *config.Authority, crypto *cryptoprov.Crypto) (*Authority,
 			issuer.ocspExpiry = ocspNextUpdate
 		}
 		ca.issuers[isscfg.Label] = issuer
-		// TODO: profiles => issuerByProfile
+
+		for _, profile := range isscfg.Profiles {
+			if cacfg.Profiles[profile] == nil {
+				return nil, errors.Errorf("profile %q is not defined in ca-config, issuer: %q", profile, isscfg.Label)
+			}
+			if other := ca.issuersByProfile[profile]; other != nil {
+				return nil, errors.Errorf("profile %q is already served by %q issuer, duplicate issuer: %q",
+					profile, other.Label(), isscfg.Label)
+			}
+			ca.issuersByProfile[profile] = issuer
+			logger.Infof("src=NewAuthority, issuer=%s, profile=%s", isscfg.Label, profile)
+		}
 	}
 
 	return ca, nil
@@ -73,6 +88,49 @@ func (s *Authority) GetIssuerByLabel(label string) (*Issuer, error) {
 	return nil, errors.Errorf("issuer not found: %s", label)
 }
 
+// GetIssuerByProfile returns the issuer that serves the profile
+func (s *Authority) GetIssuerByProfile(profile string) (*Issuer, error) {
+	if profile == "" {
+		profile = "default"
+	}
+	issuer, ok := s.issuersByProfile[profile]
+	if ok {
+		return issuer, nil
+	}
+	return nil, errors.Errorf("issuer not found for profile: %s", profile)
+}
+
+// GetIssuerByKeyID returns the issuer by Subject Key ID
+func (s *Authority) GetIssuerByKeyID(skid string) (*Issuer, error) {
+	for _, issuer := range s.issuers {
+		if issuer.SubjectKID() == skid {
+			return issuer, nil
+		}
+	}
+	return nil, errors.Errorf("issuer not found: %s", skid)
+}
+
+// GetIssuerByKeyHash returns the issuer by the hash of its public key
+func (s *Authority) GetIssuerByKeyHash(alg crypto.Hash, val []byte) (*Issuer, error) {
+	if len(val) == 0 {
+		return nil, errors.NotValidf("empty key hash")
+	}
+	for _, issuer := range s.issuers {
+		// KeyHash returns nil for unsupported algorithm
+		if h := issuer.KeyHash(alg); h != nil && bytes.Equal(h, val) {
+			return issuer, nil
+		}
+	}
+	return nil, errors.Errorf("issuer not found: %s", hex.EncodeToString(val))
+}
+
+// SetKeyBlocklist sets the list of blocked keys for all issuers
+func (s *Authority) SetKeyBlocklist(blocklist KeyBlocklist) {
+	for _, issuer := range s.issuers {
+		issuer.SetKeyBlocklist(blocklist)
+	}
+}
+
 // Issuers returns a list of issuers
 func (s *Authority) Issuers() []*Issuer {
 	list := make([]*Issuer, 0, len(s.issuers))
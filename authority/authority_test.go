@@ -1,6 +1,7 @@
 package authority_test
 
 import (
+	"crypto"
 	"testing"
 
 	"github.com/go-phorce/dolly/algorithms/guid"
@@ -117,10 +118,71 @@ func (s *testSuite) TestNewAuthority() {
 		i, err := a.GetIssuerByLabel(issuer.Label())
 		s.NoError(err)
 		s.NotNil(i)
+
+		i, err = a.GetIssuerByKeyHash(crypto.SHA256, issuer.KeyHash(crypto.SHA256))
+		s.NoError(err)
+		s.Equal(issuer.Label(), i.Label())
 	}
+	_, err = a.GetIssuerByKeyHash(crypto.SHA256, nil)
+	s.Error(err)
+	_, err = a.GetIssuerByKeyHash(crypto.MD5, []byte{})
+	s.Error(err)
+	_, err = a.GetIssuerByKeyHash(crypto.MD5, []byte{1, 2, 3})
+	s.Error(err)
 	_, err = a.GetIssuerByLabel("wrong")
 	s.Error(err)
 	s.Equal("issuer not found: wrong", err.Error())
+
+	for _, isscfg := range cfg4.Issuers {
+		for _, profile := range isscfg.Profiles {
+			i, err := a.GetIssuerByProfile(profile)
+			s.NoError(err)
+			s.Equal(isscfg.Label, i.Label())
+		}
+	}
+	_, err = a.GetIssuerByProfile("wrong")
+	s.Error(err)
+	s.Equal("issuer not found for profile: wrong", err.Error())
+
+	//
+	// test profiles routing
+	//
+	s.Require().NotEmpty(s.cfg.Authority.Issuers)
+	iss := s.cfg.Authority.Issuers[0]
+
+	cfg5 := s.cfg.Authority
+	iss1 := iss
+	iss1.Label = "issuer1"
+	iss1.Profiles = []string{"server"}
+	iss2 := iss
+	iss2.Label = "issuer2"
+	iss2.Profiles = []string{"client", "server"}
+	cfg5.Issuers = []config.Issuer{iss1, iss2}
+
+	_, err = authority.NewAuthority(&cfg5, s.crypto)
+	s.Require().Error(err)
+	s.Equal("profile \"server\" is already served by \"issuer1\" issuer, duplicate issuer: \"issuer2\"", err.Error())
+
+	iss2.Profiles = []string{"client", "unknown"}
+	cfg5.Issuers = []config.Issuer{iss1, iss2}
+
+	_, err = authority.NewAuthority(&cfg5, s.crypto)
+	s.Require().Error(err)
+	s.Equal("profile \"unknown\" is not defined in ca-config, issuer: \"issuer2\"", err.Error())
+
+	iss2.Profiles = []string{"client"}
+	cfg5.Issuers = []config.Issuer{iss1, iss2}
+
+	a, err = authority.NewAuthority(&cfg5, s.crypto)
+	s.Require().NoError(err)
+
+	i, err := a.GetIssuerByProfile("server")
+	s.Require().NoError(err)
+	s.Equal("issuer1", i.Label())
+
+	i, err = a.GetIssuerByProfile("client")
+	s.Require().NoError(err)
+	s.Equal("issuer2", i.Label())
 }
 
 func loadConfig() (*config.Configuration, error) {
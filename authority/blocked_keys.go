@@ -0,0 +1,65 @@
+package authority
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+
+	"github.com/juju/errors"
+)
+
+// KeyBlocklist provides the list of public keys, that are not allowed to be certified,
+// for example compromised keys
+type KeyBlocklist interface {
+	// IsKeyBlocked returns true if the key with SPKI hash is blocked
+	IsKeyBlocked(ctx context.Context, spkiHash string) (bool, error)
+}
+
+// SetKeyBlocklist sets the list of blocked keys,
+// that is checked before signing
+func (ca *Issuer) SetKeyBlocklist(blocklist KeyBlocklist) {
+	ca.keyBlocklist = blocklist
+}
+
+// SPKIHash returns hex encoded SHA-256 hash of the SubjectPublicKeyInfo of the key
+func SPKIHash(pub crypto.PublicKey) (string, error) {
+	spki, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	hash := sha256.Sum256(spki)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// SubjectKeyID returns hex encoded Subject Key Identifier of the key,
+// as it is set in the issued certificates
+func SubjectKeyID(pub crypto.PublicKey) (string, error) {
+	ski, err := computeSKI(&x509.Certificate{PublicKey: pub})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return hex.EncodeToString(ski), nil
+}
+
+// checkKeyBlocked returns an error if the key is blocked
+func (ca *Issuer) checkKeyBlocked(ctx context.Context, pub crypto.PublicKey) error {
+	if ca.keyBlocklist == nil {
+		return nil
+	}
+
+	hash, err := SPKIHash(pub)
+	if err != nil {
+		return errors.NewBadRequest(err, "invalid public key")
+	}
+
+	blocked, err := ca.keyBlocklist.IsKeyBlocked(ctx, hash)
+	if err != nil {
+		return errors.Annotate(err, "unable to check blocked keys")
+	}
+	if blocked {
+		return errors.Forbiddenf("the key is blocked: %s", hash)
+	}
+	return nil
+}
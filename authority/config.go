@@ -4,6 +4,7 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"io/ioutil"
+	"net"
 	"regexp"
 	"strings"
 	"time"
@@ -21,6 +22,47 @@ type CAConstraint struct {
 	MaxPathLenZero bool `json:"max_path_len_zero"`
 }
 
+// NameConstraints specifies RFC 5280 Name Constraints
+// for the issued CA certificate.
+// The DNS, email and URI constraints started with "." allow only sub-domains.
+// The extension is always marked critical, as required by RFC 5280 4.2.1.10.
+type NameConstraints struct {
+	PermittedDNSDomains []string `json:"permitted_dns_domains"`
+	ExcludedDNSDomains  []string `json:"excluded_dns_domains"`
+
+	// PermittedIPRanges and ExcludedIPRanges specify list of CIDR
+	PermittedIPRanges []string `json:"permitted_ip_ranges"`
+	ExcludedIPRanges  []string `json:"excluded_ip_ranges"`
+
+	PermittedEmailAddresses []string `json:"permitted_email_addresses"`
+	ExcludedEmailAddresses  []string `json:"excluded_email_addresses"`
+
+	PermittedURIDomains []string `json:"permitted_uri_domains"`
+	ExcludedURIDomains  []string `json:"excluded_uri_domains"`
+
+	permittedIPNets []*net.IPNet
+	excludedIPNets  []*net.IPNet
+}
+
+// KeyPolicy specifies allowed public keys
+type KeyPolicy struct {
+	// Algorithms specifies a list of allowed key algorithms: RSA, ECDSA, Ed25519.
+	// If not provided, then all algorithms are allowed
+	Algorithms []string `json:"algorithms"`
+
+	// MinRSASize specifies the minimum size of RSA key in bits
+	MinRSASize int `json:"min_rsa_size"`
+
+	// Curves specifies a list of allowed ECDSA curves: P-256, P-384, P-521.
+	// If not provided, then all curves are allowed
+	Curves []string `json:"curves"`
+
+	// MatchKeyUsage specifies to reject the profile's key usages,
+	// that are not applicable for the key type,
+	// for example key encipherment for ECDSA key
+	MatchKeyUsage bool `json:"match_key_usage"`
+}
+
 // CertProfile provides certificate profile
 type CertProfile struct {
 	Description string `json:"description"`
@@ -31,11 +73,37 @@ type CertProfile struct {
 	CAConstraint CAConstraint `json:"ca_constraint"`
 	OCSPNoCheck  bool         `json:"ocsp_no_check"`
 
+	// AllowedKeys specifies the policy for public keys.
+	// If not provided, then all keys are allowed
+	AllowedKeys *KeyPolicy `json:"allowed_keys"`
+
+	// RejectSharedKeys specifies to reject keys,
+	// that are already bound to another active certificate owned by a different user
+	RejectSharedKeys bool `json:"reject_shared_keys"`
+
+	// NameConstraints specifies the constraints for the issued CA certificate
+	NameConstraints *NameConstraints `json:"name_constraints"`
+
 	Expiry   csr.Duration `json:"expiry"`
 	Backdate csr.Duration `json:"backdate"`
 
+	// StrictValidity specifies to reject requests with NotAfter exceeding
+	// the profile's Expiry or the issuer's validity.
+	// If not set, then NotAfter is capped at the allowed value.
+	StrictValidity bool `json:"strict_validity"`
+
 	AllowedExtensions []csr.OID `json:"allowed_extensions"`
 
+	// Lints specifies the severity of the lints run before signing:
+	// ignore, warn or error.
+	// If a lint is not specified, then its findings are logged as warnings.
+	Lints map[string]LintSeverity `json:"lints"`
+
+	// CTLogServers specifies a list of Certificate Transparency logs.
+	// If provided, then a precertificate is submitted to the logs,
+	// and the returned SCT list is included in the certificate.
+	CTLogServers []string `json:"ct_log_servers"`
+
 	// AllowedCommonNames specifies a RegExp to check for allowed names.
 	// If not provided, then all names are allowed
 	AllowedCommonNames string `json:"allowed_names"`
@@ -48,6 +116,15 @@ type CertProfile struct {
 	// If not provided, then all names are allowed
 	AllowedEmail string `json:"allowed_email"`
 
+	// AllowedURI specifies a RegExp to check for allowed URI.
+	// If not provided, then all names are allowed
+	AllowedURI string `json:"allowed_uri"`
+
+	// SPIFFETrustDomain specifies the trust domain for SPIFFE X.509-SVID profile.
+	// If provided, then the certificate must have exactly one URI SAN,
+	// which is a valid SPIFFE ID in the trust domain.
+	SPIFFETrustDomain string `json:"spiffe_trust_domain"`
+
 	// AllowedFields provides booleans for fields in the CSR.
 	// If a AllowedFields is not present in a CertProfile,
 	// all of these fields may be copied from the CSR into the signed certificate.
@@ -66,6 +143,7 @@ type CertProfile struct {
 	AllowedNamesRegex *regexp.Regexp `json:"-"`
 	AllowedDNSRegex   *regexp.Regexp `json:"-"`
 	AllowedEmailRegex *regexp.Regexp `json:"-"`
+	AllowedURIRegex   *regexp.Regexp `json:"-"`
 }
 
 // Config provides configuration for Certification Authority
@@ -164,6 +242,32 @@ func (p *CertProfile) Validate() error {
 		}
 	}
 
+	if p.AllowedKeys != nil {
+		err := p.AllowedKeys.Validate()
+		if err != nil {
+			return errors.Annotate(err, "invalid allowed_keys")
+		}
+	}
+
+	for name, severity := range p.Lints {
+		if !IsSupportedLint(name) {
+			return errors.Errorf("unsupported lint: %s", name)
+		}
+		if severity != LintIgnore && severity != LintWarn && severity != LintError {
+			return errors.Errorf("unsupported lint severity: %s", severity)
+		}
+	}
+
+	if p.NameConstraints != nil {
+		if !p.CAConstraint.IsCA {
+			return errors.New("name constraints are allowed only for CA profile")
+		}
+		err := p.NameConstraints.parse()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	if p.AllowedCommonNames != "" && p.AllowedNamesRegex == nil {
 		rule, err := regexp.Compile(p.AllowedCommonNames)
 		if err != nil {
@@ -185,6 +289,24 @@ func (p *CertProfile) Validate() error {
 		}
 		p.AllowedEmailRegex = rule
 	}
+	if p.AllowedURI != "" && p.AllowedURIRegex == nil {
+		rule, err := regexp.Compile(p.AllowedURI)
+		if err != nil {
+			return errors.Annotate(err, "failed to compile AllowedURI")
+		}
+		p.AllowedURIRegex = rule
+	}
+	if p.SPIFFETrustDomain != "" {
+		if !isValidTrustDomain(p.SPIFFETrustDomain) {
+			return errors.Errorf("invalid SPIFFE trust domain: %s", p.SPIFFETrustDomain)
+		}
+		// leaf SVID must have digitalSignature, and must not have keyCertSign or cRLSign
+		ku, _, _ := p.Usages()
+		if !p.CAConstraint.IsCA &&
+			(ku&x509.KeyUsageDigitalSignature == 0 || ku&(x509.KeyUsageCertSign|x509.KeyUsageCRLSign) != 0) {
+			return errors.New("SPIFFE profile must have digital signature usage, and must not have cert sign or crl sign usage")
+		}
+	}
 	return nil
 }
 
@@ -226,3 +348,28 @@ func (p *CertProfile) Usages() (ku x509.KeyUsage, eku []x509.ExtKeyUsage, unk []
 	}
 	return
 }
+
+func (c *NameConstraints) parse() error {
+	var err error
+	c.permittedIPNets, err = parseCIDRs(c.PermittedIPRanges)
+	if err != nil {
+		return errors.Annotate(err, "invalid permitted IP range")
+	}
+	c.excludedIPNets, err = parseCIDRs(c.ExcludedIPRanges)
+	if err != nil {
+		return errors.Annotate(err, "invalid excluded IP range")
+	}
+	return nil
+}
+
+func parseCIDRs(list []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, cidr := range list {
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		nets = append(nets, ipnet)
+	}
+	return nets, nil
+}
@@ -48,7 +48,13 @@ func TestLoadInvalidConfigFile(t *testing.T) {
 		{"testdata/invalid_allowedname.json", "invalid configuration: invalid withregex profile: failed to compile AllowedCommonNames: error parsing regexp: missing closing ]: `[}`"},
 		{"testdata/invalid_dns.json", "invalid configuration: invalid withregex profile: failed to compile AllowedDNS: error parsing regexp: missing closing ]: `[}`"},
 		{"testdata/invalid_email.json", "invalid configuration: invalid withregex profile: failed to compile AllowedEmail: error parsing regexp: missing closing ]: `[}`"},
+		{"testdata/invalid_uri.json", "invalid configuration: invalid withregex profile: failed to compile AllowedURI: error parsing regexp: missing closing ]: `[}`"},
+		{"testdata/invalid_spiffe.json", "invalid configuration: invalid spiffe profile: invalid SPIFFE trust domain: Trusty.com:8080"},
+		{"testdata/invalid_spiffe_usage.json", "invalid configuration: invalid spiffe profile: SPIFFE profile must have digital signature usage, and must not have cert sign or crl sign usage"},
+		{"testdata/invalid_keys.json", "invalid configuration: invalid server profile: invalid allowed_keys: unsupported key algorithm: DSA"},
 		{"testdata/invalid_qualifier.json", "invalid configuration: invalid with-qt profile: invalid policy qualifier type: qt-type"},
+		{"testdata/invalid_nameconstraints_notca.json", "invalid configuration: invalid server profile: name constraints are allowed only for CA profile"},
+		{"testdata/invalid_nameconstraints_ip.json", "invalid configuration: invalid L2_CA profile: invalid permitted IP range: invalid CIDR address: 10.0.0.0"},
 	}
 	for _, tc := range tcases {
 		t.Run(tc.file, func(t *testing.T) {
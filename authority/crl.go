@@ -0,0 +1,86 @@
+package authority
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// OIDExtensionReasonCode is the CRL entry extension for revocation reason, RFC 5280 5.3.1
+var OIDExtensionReasonCode = asn1.ObjectIdentifier{2, 5, 29, 21}
+
+// OIDExtensionCRLNumber is the CRL extension for CRL number, RFC 5280 5.2.3
+var OIDExtensionCRLNumber = asn1.ObjectIdentifier{2, 5, 29, 20}
+
+// CRLEntry returns CRL entry for the revoked certificate
+func CRLEntry(serial *big.Int, revokedAt time.Time, reason int) (pkix.RevokedCertificate, error) {
+	entry := pkix.RevokedCertificate{
+		SerialNumber:   serial,
+		RevocationTime: revokedAt.UTC(),
+	}
+	// reasonCode with unspecified value SHOULD be absent
+	if reason > 0 {
+		val, err := asn1.Marshal(asn1.Enumerated(reason))
+		if err != nil {
+			return entry, errors.Trace(err)
+		}
+		entry.Extensions = []pkix.Extension{
+			{
+				Id:    OIDExtensionReasonCode,
+				Value: val,
+			},
+		}
+	}
+	return entry, nil
+}
+
+// CreateCRL returns DER encoded CRL signed by the issuer,
+// the CRL number is derived from thisUpdate to be monotonically increasing
+func (ca *Issuer) CreateCRL(revoked []pkix.RevokedCertificate, thisUpdate, nextUpdate time.Time) ([]byte, error) {
+	return ca.CreateCRLWithNumber(revoked, big.NewInt(thisUpdate.Unix()), thisUpdate, nextUpdate)
+}
+
+// CreateCRLWithNumber returns DER encoded CRL signed by the issuer,
+// with the provided CRL number
+func (ca *Issuer) CreateCRLWithNumber(revoked []pkix.RevokedCertificate, number *big.Int, thisUpdate, nextUpdate time.Time) ([]byte, error) {
+	if !nextUpdate.After(thisUpdate) {
+		return nil, errors.NotValidf("nextUpdate")
+	}
+	if number == nil || number.Sign() < 0 {
+		return nil, errors.NotValidf("CRL number")
+	}
+
+	template := &x509.RevocationList{
+		SignatureAlgorithm:  ca.sigAlgo,
+		RevokedCertificates: revoked,
+		Number:              number,
+		ThisUpdate:          thisUpdate.UTC(),
+		NextUpdate:          nextUpdate.UTC(),
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, template, ca.bundle.Cert, ca.signer)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to create CRL")
+	}
+	return der, nil
+}
+
+// CRLNumber returns the CRL number, or nil if the CRL does not have the extension
+func CRLNumber(crl *pkix.CertificateList) (*big.Int, error) {
+	for _, ext := range crl.TBSCertList.Extensions {
+		if ext.Id.Equal(OIDExtensionCRLNumber) {
+			number := new(big.Int)
+			_, err := asn1.Unmarshal(ext.Value, &number)
+			if err != nil {
+				return nil, errors.Annotate(err, "invalid CRL number")
+			}
+			return number, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,125 @@
+package authority_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/dolly/algorithms/guid"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRLEntry(t *testing.T) {
+	now := time.Now()
+
+	e, err := authority.CRLEntry(big.NewInt(1234), now, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), e.SerialNumber.Int64())
+	assert.Equal(t, now.UTC(), e.RevocationTime)
+	assert.Empty(t, e.Extensions)
+
+	e, err = authority.CRLEntry(big.NewInt(1234), now, 1)
+	require.NoError(t, err)
+	require.Len(t, e.Extensions, 1)
+	assert.Equal(t, authority.OIDExtensionReasonCode, e.Extensions[0].Id)
+
+	var reason asn1.Enumerated
+	_, err = asn1.Unmarshal(e.Extensions[0].Value, &reason)
+	require.NoError(t, err)
+	assert.Equal(t, asn1.Enumerated(1), reason)
+}
+
+func (s *testSuite) TestCreateCRL() {
+	crypto := s.crypto.Default()
+	kr := csr.NewKeyRequest(crypto, "TestCreateCRL"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey)
+	rootReq := csr.CertificateRequest{
+		CN:         "[TEST] Trusty Root CA",
+		KeyRequest: kr,
+	}
+	rootPEM, _, rootKey, err := authority.NewRoot("ROOT", rootCfg, crypto, &rootReq)
+	s.Require().NoError(err)
+
+	rootSigner, err := authority.NewSignerFromPEM(s.crypto, rootKey)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		AiaURL:  "https://localhost/v1/certs/${ISSUER_ID}.crt",
+		OcspURL: "https://localhost/v1/ocsp",
+		CrlURL:  "https://localhost/v1/crl/${ISSUER_ID}.crl",
+		Profiles: map[string]*authority.CertProfile{
+			"default": {
+				Usage:  []string{"server auth", "signing", "key encipherment"},
+				Expiry: 1 * csr.OneYear,
+			},
+		},
+	}
+
+	rootCA, err := authority.CreateIssuer("TrustyRoot", caCfg, rootPEM, nil, nil, rootSigner)
+	s.Require().NoError(err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	_, err = rootCA.CreateCRL(nil, now, now)
+	s.Require().Error(err)
+	s.Equal("nextUpdate not valid", err.Error())
+
+	e1, err := authority.CRLEntry(big.NewInt(1), now, 0)
+	s.Require().NoError(err)
+	e2, err := authority.CRLEntry(big.NewInt(2), now, 1)
+	s.Require().NoError(err)
+
+	der, err := rootCA.CreateCRL([]pkix.RevokedCertificate{e1, e2}, now, now.Add(time.Hour))
+	s.Require().NoError(err)
+
+	crl, err := x509.ParseCRL(der)
+	s.Require().NoError(err)
+	s.NoError(rootCA.Bundle().Cert.CheckCRLSignature(crl))
+	s.Equal(now, crl.TBSCertList.ThisUpdate)
+	s.Equal(now.Add(time.Hour), crl.TBSCertList.NextUpdate)
+	s.Len(crl.TBSCertList.RevokedCertificates, 2)
+}
+
+func (s *testSuite) TestCreateCRLWithNumber() {
+	crypto := s.crypto.Default()
+	kr := csr.NewKeyRequest(crypto, "TestCreateCRLWithNumber"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey)
+	rootReq := csr.CertificateRequest{
+		CN:         "[TEST] Trusty Root CA",
+		KeyRequest: kr,
+	}
+	rootPEM, _, rootKey, err := authority.NewRoot("ROOT", rootCfg, crypto, &rootReq)
+	s.Require().NoError(err)
+
+	rootSigner, err := authority.NewSignerFromPEM(s.crypto, rootKey)
+	s.Require().NoError(err)
+
+	rootCA, err := authority.CreateIssuer("TrustyRoot", &authority.Config{}, rootPEM, nil, nil, rootSigner)
+	s.Require().NoError(err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	_, err = rootCA.CreateCRLWithNumber(nil, big.NewInt(-1), now, now.Add(time.Hour))
+	s.Require().Error(err)
+	s.Equal("CRL number not valid", err.Error())
+
+	der, err := rootCA.CreateCRLWithNumber(nil, big.NewInt(12), now, now.Add(time.Hour))
+	s.Require().NoError(err)
+
+	crl, err := x509.ParseCRL(der)
+	s.Require().NoError(err)
+	s.NoError(rootCA.Bundle().Cert.CheckCRLSignature(crl))
+
+	number, err := authority.CRLNumber(crl)
+	s.Require().NoError(err)
+	s.Require().NotNil(number)
+	s.Equal(int64(12), number.Int64())
+
+	number, err = authority.CRLNumber(&pkix.CertificateList{})
+	s.Require().NoError(err)
+	s.Nil(number)
+}
@@ -0,0 +1,126 @@
+package authority
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/client"
+	"github.com/google/certificate-transparency-go/jsonclient"
+	cttls "github.com/google/certificate-transparency-go/tls"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+	"github.com/juju/errors"
+)
+
+// ctSubmitTimeout specifies the timeout to submit the precertificate to a log
+const ctSubmitTimeout = 10 * time.Second
+
+// CTSubmitter submits precertificates to Certificate Transparency logs
+type CTSubmitter interface {
+	// SubmitPrecert submits the precertificate chain to RFC 6962 log at logURL,
+	// and returns Signed Certificate Timestamp
+	SubmitPrecert(ctx context.Context, logURL string, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error)
+}
+
+// ctClient submits precertificates to the logs over HTTP
+type ctClient struct{}
+
+// SubmitPrecert submits the precertificate chain to RFC 6962 log at logURL
+func (c ctClient) SubmitPrecert(ctx context.Context, logURL string, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	lc, err := client.New(logURL, nil, jsonclient.Options{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sct, err := lc.AddPreChain(ctx, chain)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return sct, nil
+}
+
+// SetCTSubmitter sets the submitter for Certificate Transparency logs.
+// By default, the precertificates are submitted to the logs over HTTP.
+func (ca *Issuer) SetCTSubmitter(submitter CTSubmitter) {
+	ca.ctSubmitter = submitter
+}
+
+// submitPrecert signs a poisoned precertificate for the template,
+// submits it to the logs, and returns SCT list extension
+// to be included in the final certificate
+func (ca *Issuer) submitPrecert(ctx context.Context, template *x509.Certificate, logs []string) (*pkix.Extension, error) {
+	if ca.bundle == nil {
+		return nil, errors.NotSupportedf("Certificate Transparency for self-signed certificate")
+	}
+
+	precert := *template
+	precert.ExtraExtensions = append([]pkix.Extension{}, template.ExtraExtensions...)
+	precert.ExtraExtensions = append(precert.ExtraExtensions, pkix.Extension{
+		Id:       CTPoisonOID,
+		Critical: true,
+		Value:    asn1.NullBytes,
+	})
+
+	precertPEM, err := ca.sign(&precert)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to sign precertificate")
+	}
+	block, _ := pem.Decode(precertPEM)
+
+	// the logs require the chain up to the accepted root
+	chain := []ct.ASN1Cert{{Data: block.Bytes}}
+	for _, crt := range ca.bundle.Chain {
+		chain = append(chain, ct.ASN1Cert{Data: crt.Raw})
+	}
+	if len(ca.bundle.Chain) == 0 {
+		chain = append(chain, ct.ASN1Cert{Data: ca.bundle.Cert.Raw})
+	}
+	if root := ca.bundle.RootCert; root != nil &&
+		!bytes.Equal(root.Raw, chain[len(chain)-1].Data) {
+		chain = append(chain, ct.ASN1Cert{Data: root.Raw})
+	}
+
+	submitter := ca.ctSubmitter
+	if submitter == nil {
+		submitter = ctClient{}
+	}
+
+	list := ctx509.SignedCertificateTimestampList{}
+	for _, logURL := range logs {
+		logger.Infof("src=submitPrecert, serial=%d, log=%s", template.SerialNumber, logURL)
+
+		logCtx, cancel := context.WithTimeout(ctx, ctSubmitTimeout)
+		sct, err := submitter.SubmitPrecert(logCtx, logURL, chain)
+		cancel()
+		if err != nil {
+			return nil, errors.Annotatef(err, "failed to submit precertificate to %s", logURL)
+		}
+
+		sctBytes, err := cttls.Marshal(*sct)
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to serialize SCT")
+		}
+		list.SCTList = append(list.SCTList, ctx509.SerializedSCT{Val: sctBytes})
+	}
+
+	serialized, err := cttls.Marshal(list)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to serialize SCT list")
+	}
+
+	// the list is embedded as an octet string
+	value, err := asn1.Marshal(serialized)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &pkix.Extension{
+		Id:       SCTListOID,
+		Critical: false,
+		Value:    value,
+	}, nil
+}
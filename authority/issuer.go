@@ -1,6 +1,7 @@
 package authority
 
 import (
+	"context"
 	"crypto"
 	"crypto/rand"
 	"crypto/x509"
@@ -46,6 +47,17 @@ type Issuer struct {
 
 	keyHash  map[crypto.Hash][]byte
 	nameHash map[crypto.Hash][]byte
+
+	// ocspCert and ocspSigner are set for delegated OCSP responder,
+	// otherwise OCSP responses are signed by the Issuer
+	ocspCert   *x509.Certificate
+	ocspSigner crypto.Signer
+
+	// ctSubmitter submits precertificates to Certificate Transparency logs
+	ctSubmitter CTSubmitter
+
+	// keyBlocklist provides the keys, that are not allowed to be certified
+	keyBlocklist KeyBlocklist
 }
 
 // Bundle returns certificates bundle
@@ -93,6 +105,11 @@ func (ca *Issuer) KeyHash(h crypto.Hash) []byte {
 	return ca.keyHash[h]
 }
 
+// NameHash returns name hash
+func (ca *Issuer) NameHash(h crypto.Hash) []byte {
+	return ca.nameHash[h]
+}
+
 // CrlRenewal is duration for CRL renewal interval
 func (ca *Issuer) CrlRenewal() time.Duration {
 	return ca.crlRenewal
@@ -108,6 +125,14 @@ func (ca *Issuer) OcspExpiry() time.Duration {
 	return ca.ocspExpiry
 }
 
+// Profile returns the certificate profile, or nil if the issuer does not support it
+func (ca *Issuer) Profile(name string) *CertProfile {
+	if name == "" {
+		name = "default"
+	}
+	return ca.cfg.Profiles[name]
+}
+
 // NewIssuer creates Issuer from provided configuration
 func NewIssuer(cfg *config.Issuer, caCfg *Config, prov *cryptoprov.Crypto) (*Issuer, error) {
 	// ensure that signer can be created before the key is generated
@@ -153,6 +178,21 @@ func NewIssuer(cfg *config.Issuer, caCfg *Config, prov *cryptoprov.Crypto) (*Iss
 		issuer.ocspExpiry = cfg.OCSPExpiry.TimeDuration()
 	}
 
+	if cfg.OCSPCertFile != "" {
+		ocspCert, err := certutil.LoadFromPEM(cfg.OCSPCertFile)
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to load OCSP cert")
+		}
+		ocspSigner, err := NewSignerFromFromFile(prov, cfg.OCSPKeyFile)
+		if err != nil {
+			return nil, errors.Annotate(err, "unable to create OCSP signer")
+		}
+		err = issuer.SetOCSPSigner(ocspCert, ocspSigner)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	return issuer, nil
 }
 
@@ -231,20 +271,95 @@ func CreateIssuer(label string, caCfg *Config, certBytes, intCAbytes, rootBytes
 // Sign signs a new certificate based on the PEM-encoded
 // certificate request with the specified profile.
 func (ca *Issuer) Sign(req csr.SignRequest) (*x509.Certificate, []byte, error) {
+	return ca.SignContext(context.Background(), req)
+}
+
+// SignContext signs a new certificate based on the PEM-encoded
+// certificate request with the specified profile,
+// the context is used for the blocked keys lookup and CT logs submission.
+func (ca *Issuer) SignContext(ctx context.Context, req csr.SignRequest) (*x509.Certificate, []byte, error) {
+	safeTemplate, profile, err := ca.prepareTemplate(ctx, req)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	err = ca.lint(safeTemplate, profile)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	var certTBS = *safeTemplate
+
+	if len(profile.CTLogServers) > 0 {
+		sctExt, err := ca.submitPrecert(ctx, safeTemplate, profile.CTLogServers)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		certTBS.ExtraExtensions = append([]pkix.Extension{}, safeTemplate.ExtraExtensions...)
+		certTBS.ExtraExtensions = append(certTBS.ExtraExtensions, *sctExt)
+	}
+
+	signedCertPEM, err := ca.sign(&certTBS)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	crt, err := certutil.ParseFromPEM(signedCertPEM)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	return crt, signedCertPEM, nil
+}
+
+// Preview runs the profile checks and the lint for the certificate request,
+// and returns the certificate template to be signed, with the lint findings.
+// The certificate is not signed, and CT logs are not called.
+func (ca *Issuer) Preview(req csr.SignRequest) (*x509.Certificate, []*LintFinding, error) {
+	return ca.PreviewContext(context.Background(), req)
+}
+
+// PreviewContext is Preview, that uses the context for the blocked keys lookup
+func (ca *Issuer) PreviewContext(ctx context.Context, req csr.SignRequest) (*x509.Certificate, []*LintFinding, error) {
+	safeTemplate, profile, err := ca.prepareTemplate(ctx, req)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	tbs := ca.tbsTemplate(safeTemplate)
+	return tbs, Lint(tbs, profile.Lints), nil
+}
+
+// prepareTemplate returns the certificate template for the request,
+// after the profile checks
+func (ca *Issuer) prepareTemplate(ctx context.Context, req csr.SignRequest) (*x509.Certificate, *CertProfile, error) {
 	profileName := req.Profile
 	if profileName == "" {
 		profileName = "default"
 	}
 	profile := ca.cfg.Profiles[profileName]
 	if profile == nil {
-		return nil, nil, errors.New("unsupported profile: " + profileName)
+		return nil, nil, errors.BadRequestf("unsupported profile: %s", profileName)
 	}
 
 	csrTemplate, err := csr.ParsePEM([]byte(req.Request))
+	if err != nil {
+		return nil, nil, errors.NewBadRequest(err, "failed to parse CSR")
+	}
+
+	err = ca.checkKeyBlocked(ctx, csrTemplate.PublicKey)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
 
+	if profile.AllowedKeys != nil {
+		ku, _, _ := profile.Usages()
+		err = profile.AllowedKeys.Check(csrTemplate.PublicKey, ku)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
+
 	csrTemplate.SignatureAlgorithm = ca.sigAlgo
 
 	// Copy out only the fields from the CSR authorized by policy.
@@ -266,31 +381,17 @@ func (ca *Issuer) Sign(req csr.SignRequest) (*x509.Certificate, []byte, error) {
 		if profile.AllowedCSRFields.EmailAddresses {
 			safeTemplate.EmailAddresses = csrTemplate.EmailAddresses
 		}
+		if profile.AllowedCSRFields.URIs {
+			safeTemplate.URIs = csrTemplate.URIs
+		}
 		safeTemplate.PublicKeyAlgorithm = csrTemplate.PublicKeyAlgorithm
 		safeTemplate.PublicKey = csrTemplate.PublicKey
 		safeTemplate.SignatureAlgorithm = csrTemplate.SignatureAlgorithm
 	}
 
-	/*
-		isSelfSign := ca.bundle == nil
-			if safeTemplate.IsCA {
-				if !profile.CAConstraint.IsCA {
-					return nil, nil, errors.New("the policy disallows issuing CA certificate")
-				}
-
-				if !isSelfSign {
-					caCert := ca.bundle.Cert
-					if caCert.MaxPathLen > 0 {
-						if safeTemplate.MaxPathLen >= caCert.MaxPathLen {
-							return nil, nil, errors.New("the issuer disallows CA MaxPathLen extending")
-						}
-					} else if caCert.MaxPathLen == 0 && caCert.MaxPathLenZero {
-						// signer has pathlen of 0, do not sign more intermediate CAs
-						return nil, nil, errors.New("the issuer disallows issuing CA certificate")
-					}
-				}
-			}
-	*/
+	if csrTemplate.IsCA && !profile.CAConstraint.IsCA {
+		return nil, nil, errors.Forbiddenf("the policy disallows issuing CA certificate")
+	}
 
 	csr.SetSAN(&safeTemplate, req.SAN)
 	safeTemplate.Subject = csr.PopulateName(req.Subject, safeTemplate.Subject)
@@ -298,23 +399,36 @@ func (ca *Issuer) Sign(req csr.SignRequest) (*x509.Certificate, []byte, error) {
 	// If there is a whitelist, ensure that both the Common Name, SAN DNSNames and Emails match
 	if profile.AllowedNamesRegex != nil && safeTemplate.Subject.CommonName != "" {
 		if !profile.AllowedNamesRegex.Match([]byte(safeTemplate.Subject.CommonName)) {
-			return nil, nil, errors.New("CN does not match allowed list: " + safeTemplate.Subject.CommonName)
+			return nil, nil, errors.Forbiddenf("CN does not match allowed list: %s", safeTemplate.Subject.CommonName)
 		}
 	}
 	if profile.AllowedDNSRegex != nil {
 		for _, name := range safeTemplate.DNSNames {
 			if !profile.AllowedDNSRegex.Match([]byte(name)) {
-				return nil, nil, errors.New("DNS Name does not match allowed list: " + name)
+				return nil, nil, errors.Forbiddenf("DNS Name does not match allowed list: %s", name)
 			}
 		}
 	}
 	if profile.AllowedEmailRegex != nil {
 		for _, name := range safeTemplate.EmailAddresses {
 			if !profile.AllowedEmailRegex.Match([]byte(name)) {
-				return nil, nil, errors.New("Email does not match allowed list: " + name)
+				return nil, nil, errors.Forbiddenf("Email does not match allowed list: %s", name)
 			}
 		}
 	}
+	if profile.AllowedURIRegex != nil {
+		for _, uri := range safeTemplate.URIs {
+			if !profile.AllowedURIRegex.Match([]byte(uri.String())) {
+				return nil, nil, errors.Forbiddenf("URI does not match allowed list: %s", uri.String())
+			}
+		}
+	}
+	if profile.SPIFFETrustDomain != "" {
+		err = validateSPIFFE(&safeTemplate, profile.SPIFFETrustDomain)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+	}
 
 	{
 		// RFC 5280 4.1.2.2:
@@ -338,12 +452,12 @@ func (ca *Issuer) Sign(req csr.SignRequest) (*x509.Certificate, []byte, error) {
 	if len(req.Extensions) > 0 {
 		for _, ext := range req.Extensions {
 			if !profile.IsAllowedExtention(ext.ID) {
-				return nil, nil, errors.New("extension not allowed: " + ext.ID.String())
+				return nil, nil, errors.Forbiddenf("extension not allowed: %s", ext.ID.String())
 			}
 
 			rawValue, err := hex.DecodeString(ext.Value)
 			if err != nil {
-				return nil, nil, errors.Annotatef(err, "failed to decode extension")
+				return nil, nil, errors.NewBadRequest(err, "failed to decode extension")
 			}
 
 			safeTemplate.ExtraExtensions = append(safeTemplate.ExtraExtensions, pkix.Extension{
@@ -359,19 +473,40 @@ func (ca *Issuer) Sign(req csr.SignRequest) (*x509.Certificate, []byte, error) {
 		return nil, nil, errors.Annotatef(err, "failed to populate template")
 	}
 
-	var certTBS = safeTemplate
-
-	signedCertPEM, err := ca.sign(&certTBS)
+	err = ca.checkPathLen(&safeTemplate)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
 
-	crt, err := certutil.ParseFromPEM(signedCertPEM)
+	err = ca.checkNameConstraints(&safeTemplate)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
 
-	return crt, signedCertPEM, nil
+	return &safeTemplate, profile, nil
+}
+
+// checkPathLen ensures that a CA template does not extend
+// the path length constraint of the issuer
+func (ca *Issuer) checkPathLen(template *x509.Certificate) error {
+	if !template.IsCA || ca.bundle == nil {
+		return nil
+	}
+
+	caCert := ca.bundle.Cert
+	if caCert.MaxPathLen == 0 && caCert.MaxPathLenZero {
+		// signer has pathlen of 0, do not sign more intermediate CAs
+		return errors.Forbiddenf("the issuer disallows issuing CA certificate")
+	}
+
+	if caCert.MaxPathLen > 0 {
+		unlimited := template.MaxPathLen < 0 ||
+			(template.MaxPathLen == 0 && !template.MaxPathLenZero)
+		if unlimited || template.MaxPathLen >= caCert.MaxPathLen {
+			return errors.Forbiddenf("the issuer disallows CA MaxPathLen extending")
+		}
+	}
+	return nil
 }
 
 func (ca *Issuer) sign(template *x509.Certificate) ([]byte, error) {
@@ -433,23 +568,51 @@ func (ca *Issuer) fillTemplate(template *x509.Certificate, profile *CertProfile,
 		return errors.Errorf("invalid profile: no key usages")
 	}
 
+	requestedNotAfter := notAfter
+	backdate := profile.Backdate.TimeDuration()
+	if backdate == 0 {
+		backdate = 5 * time.Minute
+	}
 	if notBefore.IsZero() {
-		backdate := -1 * profile.Backdate.TimeDuration()
-		if backdate == 0 {
-			backdate = -5 * time.Minute
+		notBefore = time.Now().Round(time.Minute).Add(-backdate)
+	} else {
+		// allow a minute for the clock skew
+		if notBefore.Before(time.Now().Add(-backdate - time.Minute)) {
+			return errors.BadRequestf("requested NotBefore exceeds the profile backdate: %s",
+				notBefore.UTC().Format(time.RFC3339))
+		}
+		if ca.bundle != nil && !notBefore.Before(ca.bundle.Cert.NotAfter) {
+			return errors.BadRequestf("requested NotBefore exceeds the issuer's NotAfter: %s",
+				notBefore.UTC().Format(time.RFC3339))
 		}
-		notBefore = time.Now().Round(time.Minute).Add(backdate)
 	}
 	if notAfter.IsZero() {
 		notAfter = notBefore.Add(expiry)
+	} else if expiry > 0 && notAfter.After(notBefore.Add(expiry)) {
+		if profile.StrictValidity {
+			return errors.Forbiddenf("requested NotAfter exceeds the profile expiry: %s",
+				notAfter.UTC().Format(time.RFC3339))
+		}
+		notAfter = notBefore.Add(expiry)
+	}
+	// the certificate must not outlive its issuer
+	if ca.bundle != nil && notAfter.After(ca.bundle.Cert.NotAfter) {
+		if profile.StrictValidity && !requestedNotAfter.IsZero() {
+			return errors.Forbiddenf("requested NotAfter exceeds the issuer's NotAfter: %s",
+				ca.bundle.Cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+		notAfter = ca.bundle.Cert.NotAfter
 	}
-	// TODO: ensure that time from CSR does no exceed allowed in profile
 	if template.NotBefore.IsZero() || template.NotBefore.Before(notBefore) {
 		template.NotBefore = notBefore.UTC()
 	}
 	if template.NotAfter.IsZero() || notAfter.Before(template.NotAfter) {
 		template.NotAfter = notAfter.UTC()
 	}
+	if !template.NotBefore.Before(template.NotAfter) {
+		return errors.BadRequestf("invalid validity period: NotBefore %s is not before NotAfter %s",
+			template.NotBefore.Format(time.RFC3339), template.NotAfter.Format(time.RFC3339))
+	}
 	template.KeyUsage = ku
 	template.ExtKeyUsage = eku
 	template.BasicConstraintsValid = true
@@ -463,6 +626,19 @@ func (ca *Issuer) fillTemplate(template *x509.Certificate, profile *CertProfile,
 		template.IPAddresses = nil
 		template.EmailAddresses = nil
 		template.URIs = nil
+
+		if nc := profile.NameConstraints; nc != nil {
+			// RFC 5280 4.2.1.10: conforming CAs MUST mark this extension as critical
+			template.PermittedDNSDomainsCritical = true
+			template.PermittedDNSDomains = nc.PermittedDNSDomains
+			template.ExcludedDNSDomains = nc.ExcludedDNSDomains
+			template.PermittedIPRanges = nc.permittedIPNets
+			template.ExcludedIPRanges = nc.excludedIPNets
+			template.PermittedEmailAddresses = nc.PermittedEmailAddresses
+			template.ExcludedEmailAddresses = nc.ExcludedEmailAddresses
+			template.PermittedURIDomains = nc.PermittedURIDomains
+			template.ExcludedURIDomains = nc.ExcludedURIDomains
+		}
 	}
 	template.SubjectKeyId = ski
 
@@ -1,11 +1,27 @@
 package authority_test
 
 import (
+	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
 	"fmt"
+	"time"
 
+	"github.com/go-phorce/dolly/algorithms/guid"
 	"github.com/go-phorce/trusty/authority"
 	"github.com/go-phorce/trusty/config"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/go-phorce/trusty/pkg/ctlog"
+	ct "github.com/google/certificate-transparency-go"
+	cttls "github.com/google/certificate-transparency-go/tls"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+	"github.com/juju/errors"
 )
 
 func (s *testSuite) TestNewIssuer() {
@@ -78,3 +94,904 @@ func (s *testSuite) TestNewIssuerErrors() {
 	s.Require().Error(err)
 	s.Equal("failed to load ca-bundle: open not_found: no such file or directory", err.Error())
 }
+
+func (s *testSuite) TestIssuerSignPathLen() {
+	defprov := s.crypto.Default()
+	prov := csr.NewProvider(defprov)
+
+	caCfg := &authority.Config{
+		Profiles: map[string]*authority.CertProfile{
+			"L1": {
+				Usage:  []string{"cert sign", "crl sign"},
+				Expiry: 1 * csr.OneYear,
+				CAConstraint: authority.CAConstraint{
+					IsCA:       true,
+					MaxPathLen: 1,
+				},
+			},
+			"L2": {
+				Usage:  []string{"cert sign", "crl sign"},
+				Expiry: 1 * csr.OneYear,
+				CAConstraint: authority.CAConstraint{
+					IsCA:           true,
+					MaxPathLen:     0,
+					MaxPathLenZero: true,
+				},
+			},
+			"Unlimited": {
+				Usage:  []string{"cert sign", "crl sign"},
+				Expiry: 1 * csr.OneYear,
+				CAConstraint: authority.CAConstraint{
+					IsCA:       true,
+					MaxPathLen: -1,
+				},
+			},
+			"default": {
+				Usage:  []string{"server auth", "signing", "key encipherment"},
+				Expiry: 1 * csr.OneYear,
+			},
+		},
+	}
+
+	// newCSR returns CSR and the signer for its key
+	newCSR := func(cn string) (string, crypto.Signer) {
+		kr := csr.NewKeyRequest(defprov, "TestIssuerSignPathLen"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey)
+		csrPEM, key, _, _, err := prov.CreateRequestAndExportKey(&csr.CertificateRequest{
+			CN:         cn,
+			KeyRequest: kr,
+		})
+		s.Require().NoError(err)
+
+		signer, err := authority.NewSignerFromPEM(s.crypto, key)
+		s.Require().NoError(err)
+		return string(csrPEM), signer
+	}
+
+	rootReq := csr.CertificateRequest{
+		CN:         "[TEST] Trusty Root CA",
+		KeyRequest: csr.NewKeyRequest(defprov, "TestIssuerSignPathLen"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey),
+	}
+	rootPEM, _, rootKey, err := authority.NewRoot("ROOT", rootCfg, defprov, &rootReq)
+	s.Require().NoError(err)
+
+	rootSigner, err := authority.NewSignerFromPEM(s.crypto, rootKey)
+	s.Require().NoError(err)
+
+	rootCA, err := authority.CreateIssuer("TrustyRoot", caCfg, rootPEM, nil, nil, rootSigner)
+	s.Require().NoError(err)
+
+	l1CSR, l1Signer := newCSR("[TEST] Trusty Level 1 CA")
+	l1Crt, l1PEM, err := rootCA.Sign(csr.SignRequest{Request: l1CSR, Profile: "L1"})
+	s.Require().NoError(err)
+	s.Equal(1, l1Crt.MaxPathLen)
+
+	l1CA, err := authority.CreateIssuer("L1", caCfg, l1PEM, nil, rootPEM, l1Signer)
+	s.Require().NoError(err)
+
+	s.Run("L1 extending", func() {
+		req, _ := newCSR("[TEST] Trusty Level 2 CA")
+		for _, profile := range []string{"L1", "Unlimited"} {
+			_, _, err := l1CA.Sign(csr.SignRequest{Request: req, Profile: profile})
+			s.Require().Error(err)
+			s.True(errors.IsForbidden(err))
+			s.Equal("the issuer disallows CA MaxPathLen extending", err.Error())
+		}
+	})
+
+	l2CSR, l2Signer := newCSR("[TEST] Trusty Level 2 CA")
+	l2Crt, l2PEM, err := l1CA.Sign(csr.SignRequest{Request: l2CSR, Profile: "L2"})
+	s.Require().NoError(err)
+	s.True(l2Crt.IsCA)
+	s.Equal(0, l2Crt.MaxPathLen)
+	s.True(l2Crt.MaxPathLenZero)
+
+	l2CA, err := authority.CreateIssuer("L2", caCfg, l2PEM, l1PEM, rootPEM, l2Signer)
+	s.Require().NoError(err)
+
+	s.Run("L2 issuing CA", func() {
+		req, _ := newCSR("[TEST] Trusty Level 3 CA")
+		for _, profile := range []string{"L1", "L2", "Unlimited"} {
+			_, _, err := l2CA.Sign(csr.SignRequest{Request: req, Profile: profile})
+			s.Require().Error(err)
+			s.True(errors.IsForbidden(err))
+			s.Equal("the issuer disallows issuing CA certificate", err.Error())
+		}
+	})
+
+	s.Run("L2 issuing leaf", func() {
+		req, _ := newCSR("trusty.com")
+		crt, _, err := l2CA.Sign(csr.SignRequest{Request: req})
+		s.Require().NoError(err)
+		s.False(crt.IsCA)
+		s.Equal(l2Crt.Subject.CommonName, crt.Issuer.CommonName)
+	})
+
+	s.Run("CSR requests CA", func() {
+		bc, err := asn1.Marshal(csr.BasicConstraints{IsCA: true, MaxPathLen: -1})
+		s.Require().NoError(err)
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		s.Require().NoError(err)
+		der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+			Subject: pkix.Name{CommonName: "trusty.com"},
+			ExtraExtensions: []pkix.Extension{
+				{Id: csr.BasicConstraintsOID, Critical: true, Value: bc},
+			},
+		}, key)
+		s.Require().NoError(err)
+
+		req := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+		_, _, err = l1CA.Sign(csr.SignRequest{Request: string(req)})
+		s.Require().Error(err)
+		s.True(errors.IsForbidden(err))
+		s.Equal("the policy disallows issuing CA certificate", err.Error())
+	})
+}
+
+func (s *testSuite) TestIssuerSignValidity() {
+	defprov := s.crypto.Default()
+	kr := csr.NewKeyRequest(defprov, "TestIssuerSignValidity"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey)
+	rootReq := csr.CertificateRequest{
+		CN:         "[TEST] Trusty Root CA",
+		KeyRequest: kr,
+	}
+	rootPEM, _, rootKey, err := authority.NewRoot("ROOT", rootCfg, defprov, &rootReq)
+	s.Require().NoError(err)
+
+	rootSigner, err := authority.NewSignerFromPEM(s.crypto, rootKey)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		Profiles: map[string]*authority.CertProfile{
+			"default": {
+				Usage:  []string{"server auth", "signing", "key encipherment"},
+				Expiry: 1 * csr.OneYear,
+			},
+			"strict": {
+				Usage:          []string{"server auth", "signing", "key encipherment"},
+				Expiry:         1 * csr.OneYear,
+				StrictValidity: true,
+			},
+			"long": {
+				Usage:  []string{"server auth", "signing", "key encipherment"},
+				Expiry: 10 * csr.OneYear,
+			},
+			"long_strict": {
+				Usage:          []string{"server auth", "signing", "key encipherment"},
+				Expiry:         10 * csr.OneYear,
+				StrictValidity: true,
+			},
+		},
+	}
+
+	rootCA, err := authority.CreateIssuer("TrustyRoot", caCfg, rootPEM, nil, nil, rootSigner)
+	s.Require().NoError(err)
+	issuerNotAfter := rootCA.Bundle().Cert.NotAfter
+
+	csrPEM, _, _, _, err := csr.NewProvider(defprov).CreateRequestAndExportKey(&csr.CertificateRequest{
+		CN:         "trusty.com",
+		KeyRequest: kr,
+	})
+	s.Require().NoError(err)
+
+	notBefore := time.Now().Add(-2 * time.Minute).Truncate(time.Second).UTC()
+	oneYear := csr.OneYear.TimeDuration()
+
+	s.Run("profile expiry", func() {
+		crt, _, err := rootCA.Sign(csr.SignRequest{
+			Request:   string(csrPEM),
+			NotBefore: notBefore,
+			NotAfter:  notBefore.Add(2 * oneYear),
+		})
+		s.Require().NoError(err)
+		s.Equal(notBefore, crt.NotBefore)
+		s.Equal(notBefore.Add(oneYear), crt.NotAfter)
+
+		_, _, err = rootCA.Sign(csr.SignRequest{
+			Request:   string(csrPEM),
+			Profile:   "strict",
+			NotBefore: notBefore,
+			NotAfter:  notBefore.Add(2 * oneYear),
+		})
+		s.Require().Error(err)
+		s.True(errors.IsForbidden(err))
+		s.Contains(err.Error(), "requested NotAfter exceeds the profile expiry")
+
+		crt, _, err = rootCA.Sign(csr.SignRequest{
+			Request:   string(csrPEM),
+			Profile:   "strict",
+			NotBefore: notBefore,
+			NotAfter:  notBefore.Add(24 * time.Hour),
+		})
+		s.Require().NoError(err)
+		s.Equal(notBefore.Add(24*time.Hour), crt.NotAfter)
+	})
+
+	s.Run("issuer expiry", func() {
+		crt, _, err := rootCA.Sign(csr.SignRequest{
+			Request: string(csrPEM),
+			Profile: "long",
+		})
+		s.Require().NoError(err)
+		s.Equal(issuerNotAfter, crt.NotAfter)
+
+		crt, _, err = rootCA.Sign(csr.SignRequest{
+			Request: string(csrPEM),
+			Profile: "long_strict",
+		})
+		s.Require().NoError(err)
+		s.Equal(issuerNotAfter, crt.NotAfter)
+
+		_, _, err = rootCA.Sign(csr.SignRequest{
+			Request:  string(csrPEM),
+			Profile:  "long_strict",
+			NotAfter: issuerNotAfter.Add(time.Hour),
+		})
+		s.Require().Error(err)
+		s.True(errors.IsForbidden(err))
+		s.Contains(err.Error(), "requested NotAfter exceeds the issuer's NotAfter")
+	})
+
+	s.Run("invalid NotBefore", func() {
+		_, _, err := rootCA.Sign(csr.SignRequest{
+			Request:   string(csrPEM),
+			NotBefore: time.Now().Add(-time.Hour),
+		})
+		s.Require().Error(err)
+		s.True(errors.IsBadRequest(err))
+		s.Contains(err.Error(), "requested NotBefore exceeds the profile backdate")
+
+		_, _, err = rootCA.Sign(csr.SignRequest{
+			Request:   string(csrPEM),
+			NotBefore: issuerNotAfter,
+		})
+		s.Require().Error(err)
+		s.True(errors.IsBadRequest(err))
+		s.Contains(err.Error(), "requested NotBefore exceeds the issuer's NotAfter")
+
+		_, _, err = rootCA.Sign(csr.SignRequest{
+			Request:   string(csrPEM),
+			NotBefore: notBefore,
+			NotAfter:  notBefore.Add(-time.Hour),
+		})
+		s.Require().Error(err)
+		s.True(errors.IsBadRequest(err))
+		s.Contains(err.Error(), "invalid validity period")
+	})
+}
+
+type failingCTSubmitter struct{}
+
+func (s failingCTSubmitter) SubmitPrecert(context.Context, string, []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return nil, errors.New("log is not available")
+}
+
+// recordingCTSubmitter records the submitted chains
+type recordingCTSubmitter struct {
+	*ctlog.FakeLog
+	chains      [][]ct.ASN1Cert
+	hasDeadline bool
+}
+
+func (s *recordingCTSubmitter) SubmitPrecert(ctx context.Context, logURL string, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	_, s.hasDeadline = ctx.Deadline()
+	s.chains = append(s.chains, chain)
+	return s.FakeLog.SubmitPrecert(ctx, logURL, chain)
+}
+
+func (s *testSuite) TestIssuerSignCT() {
+	defprov := s.crypto.Default()
+	kr := csr.NewKeyRequest(defprov, "TestIssuerSignCT"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey)
+	rootReq := csr.CertificateRequest{
+		CN:         "[TEST] Trusty Root CA",
+		KeyRequest: kr,
+	}
+	rootPEM, _, rootKey, err := authority.NewRoot("ROOT", rootCfg, defprov, &rootReq)
+	s.Require().NoError(err)
+
+	rootSigner, err := authority.NewSignerFromPEM(s.crypto, rootKey)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		Profiles: map[string]*authority.CertProfile{
+			"default": {
+				Usage:  []string{"server auth", "signing", "key encipherment"},
+				Expiry: 1 * csr.OneYear,
+			},
+			"ct": {
+				Usage:        []string{"server auth", "signing", "key encipherment"},
+				Expiry:       1 * csr.OneYear,
+				CTLogServers: []string{"https://ct1.trusty.com", "https://ct2.trusty.com"},
+			},
+		},
+	}
+
+	rootCA, err := authority.CreateIssuer("TrustyRoot", caCfg, rootPEM, nil, nil, rootSigner)
+	s.Require().NoError(err)
+
+	fake, err := ctlog.NewFakeLog()
+	s.Require().NoError(err)
+	rootCA.SetCTSubmitter(fake)
+
+	csrPEM, _, _, _, err := csr.NewProvider(defprov).CreateRequestAndExportKey(&csr.CertificateRequest{
+		CN:         "trusty.com",
+		SAN:        []string{"trusty.com"},
+		KeyRequest: kr,
+	})
+	s.Require().NoError(err)
+
+	crt, _, err := rootCA.Sign(csr.SignRequest{Request: string(csrPEM)})
+	s.Require().NoError(err)
+	s.Empty(fake.Entries())
+	for _, ext := range crt.Extensions {
+		s.False(ext.Id.Equal(authority.SCTListOID))
+	}
+
+	crt, _, err = rootCA.Sign(csr.SignRequest{Request: string(csrPEM), Profile: "ct"})
+	s.Require().NoError(err)
+	s.Len(fake.Entries(), 2)
+	for _, ext := range crt.Extensions {
+		s.False(ext.Id.Equal(authority.CTPoisonOID))
+	}
+
+	// verify the embedded SCTs
+	ctCrt, err := ctx509.ParseCertificate(crt.Raw)
+	s.Require().NoError(err)
+	ctIssuer, err := ctx509.ParseCertificate(rootCA.Bundle().Cert.Raw)
+	s.Require().NoError(err)
+	s.Require().Len(ctCrt.SCTList.SCTList, 2)
+
+	verifier, err := ct.NewSignatureVerifier(fake.PublicKey())
+	s.Require().NoError(err)
+
+	for _, serialized := range ctCrt.SCTList.SCTList {
+		var sct ct.SignedCertificateTimestamp
+		_, err = cttls.Unmarshal(serialized.Val, &sct)
+		s.Require().NoError(err)
+
+		leaf, err := ct.MerkleTreeLeafForEmbeddedSCT([]*ctx509.Certificate{ctCrt, ctIssuer}, sct.Timestamp)
+		s.Require().NoError(err)
+		s.NoError(verifier.VerifySCTSignature(sct, ct.LogEntry{Leaf: *leaf}))
+	}
+
+	// the chain includes the root, and the submission has a deadline
+	recorder := &recordingCTSubmitter{FakeLog: fake}
+	rootCA.SetCTSubmitter(recorder)
+	_, _, err = rootCA.Sign(csr.SignRequest{Request: string(csrPEM), Profile: "ct"})
+	s.Require().NoError(err)
+	s.True(recorder.hasDeadline)
+	s.Require().Len(recorder.chains, 2)
+	chain := recorder.chains[0]
+	s.Require().Len(chain, 2)
+	s.Equal(rootCA.Bundle().RootCert.Raw, chain[len(chain)-1].Data)
+
+	rootCA.SetCTSubmitter(failingCTSubmitter{})
+	_, _, err = rootCA.Sign(csr.SignRequest{Request: string(csrPEM), Profile: "ct"})
+	s.Require().Error(err)
+	s.Equal("failed to submit precertificate to https://ct1.trusty.com: log is not available", err.Error())
+}
+
+func (s *testSuite) TestIssuerSignNameConstraints() {
+	defprov := s.crypto.Default()
+	prov := csr.NewProvider(defprov)
+
+	newCSR := func(cn string, san ...string) (string, crypto.Signer) {
+		kr := csr.NewKeyRequest(defprov, "TestIssuerSignNameConstraints"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey)
+		csrPEM, key, _, _, err := prov.CreateRequestAndExportKey(&csr.CertificateRequest{
+			CN:         cn,
+			SAN:        san,
+			KeyRequest: kr,
+		})
+		s.Require().NoError(err)
+
+		signer, err := authority.NewSignerFromPEM(s.crypto, key)
+		s.Require().NoError(err)
+		return string(csrPEM), signer
+	}
+
+	rootReq := csr.CertificateRequest{
+		CN:         "[TEST] Trusty Root CA",
+		KeyRequest: csr.NewKeyRequest(defprov, "TestIssuerSignNameConstraints"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey),
+	}
+	rootPEM, _, rootKey, err := authority.NewRoot("ROOT", rootCfg, defprov, &rootReq)
+	s.Require().NoError(err)
+
+	rootSigner, err := authority.NewSignerFromPEM(s.crypto, rootKey)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		Profiles: map[string]*authority.CertProfile{
+			"constrained_ca": {
+				Usage:  []string{"cert sign", "crl sign"},
+				Expiry: 1 * csr.OneYear,
+				CAConstraint: authority.CAConstraint{
+					IsCA:           true,
+					MaxPathLen:     0,
+					MaxPathLenZero: true,
+				},
+				NameConstraints: &authority.NameConstraints{
+					PermittedDNSDomains:     []string{"trusty.com"},
+					ExcludedDNSDomains:      []string{"internal.trusty.com"},
+					PermittedIPRanges:       []string{"10.0.0.0/8"},
+					PermittedEmailAddresses: []string{".trusty.com", "ca@trusty.com"},
+				},
+			},
+			"constrained_parent": {
+				Usage:  []string{"cert sign", "crl sign"},
+				Expiry: 1 * csr.OneYear,
+				CAConstraint: authority.CAConstraint{
+					IsCA:       true,
+					MaxPathLen: 1,
+				},
+				NameConstraints: &authority.NameConstraints{
+					PermittedDNSDomains: []string{"trusty.com"},
+				},
+			},
+			"sub_ca": {
+				Usage:  []string{"cert sign", "crl sign"},
+				Expiry: 1 * csr.OneYear,
+				CAConstraint: authority.CAConstraint{
+					IsCA:           true,
+					MaxPathLen:     0,
+					MaxPathLenZero: true,
+				},
+			},
+			"default": {
+				Usage:  []string{"server auth", "client auth", "signing", "key encipherment"},
+				Expiry: 1 * csr.OneYear,
+			},
+		},
+	}
+
+	rootCA, err := authority.CreateIssuer("TrustyRoot", caCfg, rootPEM, nil, nil, rootSigner)
+	s.Require().NoError(err)
+
+	caCSR, caSigner := newCSR("[TEST] Trusty Constrained CA")
+	caCrt, caPEM, err := rootCA.Sign(csr.SignRequest{Request: caCSR, Profile: "constrained_ca"})
+	s.Require().NoError(err)
+	s.True(caCrt.PermittedDNSDomainsCritical)
+	s.Equal([]string{"trusty.com"}, caCrt.PermittedDNSDomains)
+	s.Equal([]string{"internal.trusty.com"}, caCrt.ExcludedDNSDomains)
+	s.Require().Len(caCrt.PermittedIPRanges, 1)
+	s.Equal("10.0.0.0/8", caCrt.PermittedIPRanges[0].String())
+	s.Equal([]string{".trusty.com", "ca@trusty.com"}, caCrt.PermittedEmailAddresses)
+
+	// the root is not constrained
+	req, _ := newCSR("example.com", "example.com")
+	_, _, err = rootCA.Sign(csr.SignRequest{Request: req})
+	s.Require().NoError(err)
+
+	constrainedCA, err := authority.CreateIssuer("Constrained", caCfg, caPEM, nil, rootPEM, caSigner)
+	s.Require().NoError(err)
+
+	s.Run("permitted", func() {
+		req, _ := newCSR("trusty.com", "trusty.com", "www.trusty.com", "10.1.1.1", "ca@trusty.com", "admin@mail.trusty.com")
+		crt, _, err := constrainedCA.Sign(csr.SignRequest{Request: req})
+		s.Require().NoError(err)
+		s.Len(crt.DNSNames, 2)
+	})
+
+	tcases := []struct {
+		san string
+		err string
+	}{
+		{"example.com", "DNS name is not permitted by the issuer's name constraints: example.com"},
+		{"nottrusty.com", "DNS name is not permitted by the issuer's name constraints: nottrusty.com"},
+		{"api.internal.trusty.com", "DNS name is not permitted by the issuer's name constraints: api.internal.trusty.com"},
+		{"192.168.1.1", "IP address is not permitted by the issuer's name constraints: 192.168.1.1"},
+		{"admin@trusty.com", "email is not permitted by the issuer's name constraints: admin@trusty.com"},
+		{"ca@example.com", "email is not permitted by the issuer's name constraints: ca@example.com"},
+	}
+	for _, tc := range tcases {
+		s.Run(tc.san, func() {
+			req, _ := newCSR("trusty.com", tc.san)
+			_, _, err := constrainedCA.Sign(csr.SignRequest{Request: req})
+			s.Require().Error(err)
+			s.True(errors.IsForbidden(err))
+			s.Equal(tc.err, err.Error())
+		})
+	}
+
+	s.Run("common name", func() {
+		req, _ := newCSR("example.com", "trusty.com")
+		_, _, err := constrainedCA.Sign(csr.SignRequest{Request: req})
+		s.Require().Error(err)
+		s.True(errors.IsForbidden(err))
+		s.Equal("common name is not permitted by the issuer's name constraints: example.com", err.Error())
+
+		// not a hostname
+		req, _ = newCSR("Trusty Service", "trusty.com")
+		_, _, err = constrainedCA.Sign(csr.SignRequest{Request: req})
+		s.Require().NoError(err)
+	})
+
+	s.Run("constrained parent", func() {
+		parentCSR, parentSigner := newCSR("[TEST] Trusty Constrained Parent CA")
+		_, parentPEM, err := rootCA.Sign(csr.SignRequest{Request: parentCSR, Profile: "constrained_parent"})
+		s.Require().NoError(err)
+
+		parentCA, err := authority.CreateIssuer("ConstrainedParent", caCfg, parentPEM, nil, rootPEM, parentSigner)
+		s.Require().NoError(err)
+
+		subCSR, subSigner := newCSR("[TEST] Trusty Sub CA")
+		subCrt, subPEM, err := parentCA.Sign(csr.SignRequest{Request: subCSR, Profile: "sub_ca"})
+		s.Require().NoError(err)
+		s.Empty(subCrt.PermittedDNSDomains)
+
+		subCA, err := authority.CreateIssuer("SubCA", caCfg, subPEM, parentPEM, rootPEM, subSigner)
+		s.Require().NoError(err)
+
+		req, _ := newCSR("www.trusty.com", "www.trusty.com")
+		_, _, err = subCA.Sign(csr.SignRequest{Request: req})
+		s.Require().NoError(err)
+
+		req, _ = newCSR("www.trusty.com", "example.com")
+		_, _, err = subCA.Sign(csr.SignRequest{Request: req})
+		s.Require().Error(err)
+		s.True(errors.IsForbidden(err))
+		s.Equal("DNS name is not permitted by the issuer's name constraints: example.com", err.Error())
+	})
+}
+
+func (s *testSuite) TestIssuerSignURI() {
+	defprov := s.crypto.Default()
+	kr := csr.NewKeyRequest(defprov, "TestIssuerSignURI"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey)
+	rootReq := csr.CertificateRequest{
+		CN:         "[TEST] Trusty Root CA",
+		KeyRequest: kr,
+	}
+	rootPEM, _, rootKey, err := authority.NewRoot("ROOT", rootCfg, defprov, &rootReq)
+	s.Require().NoError(err)
+
+	rootSigner, err := authority.NewSignerFromPEM(s.crypto, rootKey)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		Profiles: map[string]*authority.CertProfile{
+			"default": {
+				Usage:  []string{"server auth", "signing", "key encipherment"},
+				Expiry: 1 * csr.OneYear,
+				AllowedCSRFields: &csr.AllowedFields{
+					Subject:  true,
+					DNSNames: true,
+				},
+			},
+			"uri": {
+				Usage:      []string{"server auth", "signing", "key encipherment"},
+				Expiry:     1 * csr.OneYear,
+				AllowedURI: "^https://trusty\\.com/.*$",
+				AllowedCSRFields: &csr.AllowedFields{
+					Subject: true,
+					URIs:    true,
+				},
+			},
+			"spiffe": {
+				Usage:             []string{"client auth", "server auth", "digital signature", "key encipherment"},
+				Expiry:            1 * csr.OneYear,
+				SPIFFETrustDomain: "trusty.com",
+				AllowedCSRFields: &csr.AllowedFields{
+					Subject:  true,
+					DNSNames: true,
+					URIs:     true,
+				},
+			},
+		},
+	}
+
+	rootCA, err := authority.CreateIssuer("TrustyRoot", caCfg, rootPEM, nil, nil, rootSigner)
+	s.Require().NoError(err)
+
+	newCSR := func(san ...string) string {
+		csrPEM, _, _, _, err := csr.NewProvider(defprov).CreateRequestAndExportKey(&csr.CertificateRequest{
+			CN:         "trusty",
+			SAN:        san,
+			KeyRequest: kr,
+		})
+		s.Require().NoError(err)
+		return string(csrPEM)
+	}
+
+	s.Run("not allowed field", func() {
+		crt, _, err := rootCA.Sign(csr.SignRequest{Request: newCSR("https://trusty.com/users/1")})
+		s.Require().NoError(err)
+		s.Empty(crt.URIs)
+	})
+
+	s.Run("allowed URI", func() {
+		crt, _, err := rootCA.Sign(csr.SignRequest{
+			Request: newCSR("https://trusty.com/users/1"),
+			Profile: "uri",
+		})
+		s.Require().NoError(err)
+		s.Require().Len(crt.URIs, 1)
+		s.Equal("https://trusty.com/users/1", crt.URIs[0].String())
+
+		_, _, err = rootCA.Sign(csr.SignRequest{
+			Request: newCSR("https://example.com/users/1"),
+			Profile: "uri",
+		})
+		s.Require().Error(err)
+		s.Equal("URI does not match allowed list: https://example.com/users/1", err.Error())
+	})
+
+	s.Run("SPIFFE", func() {
+		crt, _, err := rootCA.Sign(csr.SignRequest{
+			Request: newCSR("spiffe://trusty.com/ns/default/sa/trusty", "trusty.svc"),
+			Profile: "spiffe",
+		})
+		s.Require().NoError(err)
+		s.Require().Len(crt.URIs, 1)
+		s.Equal("spiffe://trusty.com/ns/default/sa/trusty", crt.URIs[0].String())
+		s.Equal([]string{"trusty.svc"}, crt.DNSNames)
+
+		// SAN from the request
+		crt, _, err = rootCA.Sign(csr.SignRequest{
+			Request: newCSR(),
+			Profile: "spiffe",
+			SAN:     []string{"spiffe://trusty.com/workload"},
+		})
+		s.Require().NoError(err)
+		s.Require().Len(crt.URIs, 1)
+		s.Equal("spiffe://trusty.com/workload", crt.URIs[0].String())
+
+		tcases := []struct {
+			san []string
+			err string
+		}{
+			{[]string{"trusty.svc"}, "SPIFFE SVID must have exactly one URI SAN, found 0"},
+			{[]string{"spiffe://trusty.com/a", "spiffe://trusty.com/b"}, "SPIFFE SVID must have exactly one URI SAN, found 2"},
+			{[]string{"https://trusty.com/a"}, "invalid SPIFFE ID scheme: https://trusty.com/a"},
+			{[]string{"spiffe://example.com/a"}, "SPIFFE ID is not in \"trusty.com\" trust domain: spiffe://example.com/a"},
+			{[]string{"spiffe://Trusty.com/a"}, "invalid SPIFFE ID trust domain: spiffe://Trusty.com/a"},
+			{[]string{"spiffe://trusty.com:8080/a"}, "invalid SPIFFE ID trust domain: spiffe://trusty.com:8080/a"},
+			{[]string{"spiffe://user@trusty.com/a"}, "invalid SPIFFE ID trust domain: spiffe://user@trusty.com/a"},
+			{[]string{"spiffe://trusty.com/"}, "invalid SPIFFE ID path: spiffe://trusty.com/"},
+			{[]string{"spiffe://trusty.com/a//b"}, "invalid SPIFFE ID path: spiffe://trusty.com/a//b"},
+			{[]string{"spiffe://trusty.com/a/../b"}, "invalid SPIFFE ID path: spiffe://trusty.com/a/../b"},
+			{[]string{"spiffe://trusty.com/a?b=c"}, "invalid SPIFFE ID path: spiffe://trusty.com/a?b=c"},
+			{[]string{"spiffe://trusty.com/a#b"}, "invalid SPIFFE ID path: spiffe://trusty.com/a#b"},
+		}
+		for _, tc := range tcases {
+			_, _, err = rootCA.Sign(csr.SignRequest{
+				Request: newCSR(),
+				Profile: "spiffe",
+				SAN:     tc.san,
+			})
+			s.Require().Error(err, tc.san)
+			s.Equal(tc.err, err.Error())
+		}
+	})
+}
+
+func (s *testSuite) TestIssuerSignKeyPolicy() {
+	defprov := s.crypto.Default()
+	rootReq := csr.CertificateRequest{
+		CN:         "[TEST] Trusty Root CA",
+		KeyRequest: csr.NewKeyRequest(defprov, "TestIssuerSignKeyPolicy"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey),
+	}
+	rootPEM, _, rootKey, err := authority.NewRoot("ROOT", rootCfg, defprov, &rootReq)
+	s.Require().NoError(err)
+
+	rootSigner, err := authority.NewSignerFromPEM(s.crypto, rootKey)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		Profiles: map[string]*authority.CertProfile{
+			"default": {
+				Usage:  []string{"server auth", "signing", "key encipherment"},
+				Expiry: 1 * csr.OneYear,
+			},
+			"rsa": {
+				Usage:  []string{"server auth", "signing", "key encipherment"},
+				Expiry: 1 * csr.OneYear,
+				AllowedKeys: &authority.KeyPolicy{
+					Algorithms: []string{"RSA"},
+					MinRSASize: 3072,
+				},
+			},
+			"ecdsa": {
+				Usage:  []string{"server auth", "signing", "key encipherment"},
+				Expiry: 1 * csr.OneYear,
+				AllowedKeys: &authority.KeyPolicy{
+					Algorithms:    []string{"ecdsa"},
+					Curves:        []string{"P-384"},
+					MatchKeyUsage: true,
+				},
+			},
+			"ecdsa_signing": {
+				Usage:  []string{"server auth", "signing"},
+				Expiry: 1 * csr.OneYear,
+				AllowedKeys: &authority.KeyPolicy{
+					Curves:        []string{"P-256", "P-384"},
+					MatchKeyUsage: true,
+				},
+			},
+		},
+	}
+
+	rootCA, err := authority.CreateIssuer("TrustyRoot", caCfg, rootPEM, nil, nil, rootSigner)
+	s.Require().NoError(err)
+
+	newCSR := func(algo string, size int) string {
+		csrPEM, _, _, _, err := csr.NewProvider(defprov).CreateRequestAndExportKey(&csr.CertificateRequest{
+			CN:         "trusty.com",
+			KeyRequest: csr.NewKeyRequest(defprov, "TestIssuerSignKeyPolicy"+guid.MustCreate(), algo, size, csr.SigningKey),
+		})
+		s.Require().NoError(err)
+		return string(csrPEM)
+	}
+
+	rsa2048 := newCSR("RSA", 2048)
+	rsa3072 := newCSR("RSA", 3072)
+	p256 := newCSR("ECDSA", 256)
+	p384 := newCSR("ECDSA", 384)
+
+	tcases := []struct {
+		profile string
+		request string
+		err     string
+	}{
+		{"default", rsa2048, ""},
+		{"default", p256, ""},
+		{"rsa", rsa3072, ""},
+		{"rsa", rsa2048, "RSA key size 2048 is less than allowed minimum 3072"},
+		{"rsa", p384, "ECDSA key is not allowed, supported: RSA"},
+		{"ecdsa", p256, "ECDSA curve is not allowed: P-256"},
+		{"ecdsa", rsa3072, "RSA key is not allowed, supported: ecdsa"},
+		{"ecdsa", p384, "key usage is not applicable for ECDSA key: key encipherment"},
+		{"ecdsa_signing", p256, ""},
+		{"ecdsa_signing", rsa2048, ""},
+	}
+	for _, tc := range tcases {
+		_, _, err = rootCA.Sign(csr.SignRequest{
+			Request: tc.request,
+			Profile: tc.profile,
+		})
+		if tc.err == "" {
+			s.NoError(err, tc.profile)
+		} else {
+			s.Require().Error(err, tc.profile)
+			s.True(errors.IsForbidden(err))
+			s.Equal(tc.err, err.Error())
+		}
+	}
+}
+
+type blocklist map[string]bool
+
+func (b blocklist) IsKeyBlocked(ctx context.Context, spkiHash string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	return b[spkiHash], nil
+}
+
+func (s *testSuite) TestIssuerSignBlockedKey() {
+	defprov := s.crypto.Default()
+	rootReq := csr.CertificateRequest{
+		CN:         "[TEST] Trusty Root CA",
+		KeyRequest: csr.NewKeyRequest(defprov, "TestIssuerSignBlockedKey"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey),
+	}
+	rootPEM, _, rootKey, err := authority.NewRoot("ROOT", rootCfg, defprov, &rootReq)
+	s.Require().NoError(err)
+
+	rootSigner, err := authority.NewSignerFromPEM(s.crypto, rootKey)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		Profiles: map[string]*authority.CertProfile{
+			"default": {
+				Usage:  []string{"server auth", "signing"},
+				Expiry: 1 * csr.OneYear,
+			},
+		},
+	}
+
+	rootCA, err := authority.CreateIssuer("TrustyRoot", caCfg, rootPEM, nil, nil, rootSigner)
+	s.Require().NoError(err)
+
+	csrPEM, _, _, _, err := csr.NewProvider(defprov).CreateRequestAndExportKey(&csr.CertificateRequest{
+		CN:         "trusty.com",
+		KeyRequest: csr.NewKeyRequest(defprov, "TestIssuerSignBlockedKey"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey),
+	})
+	s.Require().NoError(err)
+
+	crt, _, err := rootCA.Sign(csr.SignRequest{Request: string(csrPEM)})
+	s.Require().NoError(err)
+
+	skid, err := authority.SubjectKeyID(crt.PublicKey)
+	s.Require().NoError(err)
+	s.Equal(fmt.Sprintf("%x", crt.SubjectKeyId), skid)
+
+	hash, err := authority.SPKIHash(crt.PublicKey)
+	s.Require().NoError(err)
+	s.Len(hash, 64)
+
+	blocked := blocklist{}
+	rootCA.SetKeyBlocklist(blocked)
+
+	_, _, err = rootCA.Sign(csr.SignRequest{Request: string(csrPEM)})
+	s.Require().NoError(err)
+
+	// the lookup uses the request context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = rootCA.SignContext(ctx, csr.SignRequest{Request: string(csrPEM)})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "unable to check blocked keys")
+	_, _, err = rootCA.PreviewContext(ctx, csr.SignRequest{Request: string(csrPEM)})
+	s.Require().Error(err)
+
+	blocked[hash] = true
+	_, _, err = rootCA.Sign(csr.SignRequest{Request: string(csrPEM)})
+	s.Require().Error(err)
+	s.True(errors.IsForbidden(err))
+	s.Equal("the key is blocked: "+hash, err.Error())
+}
+
+func (s *testSuite) TestIssuerPreview() {
+	defprov := s.crypto.Default()
+	rootReq := csr.CertificateRequest{
+		CN:         "[TEST] Trusty Root CA",
+		KeyRequest: csr.NewKeyRequest(defprov, "TestIssuerPreview"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey),
+	}
+	rootPEM, _, rootKey, err := authority.NewRoot("ROOT", rootCfg, defprov, &rootReq)
+	s.Require().NoError(err)
+
+	rootSigner, err := authority.NewSignerFromPEM(s.crypto, rootKey)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		Profiles: map[string]*authority.CertProfile{
+			"default": {
+				Usage:        []string{"server auth", "signing"},
+				Expiry:       1 * csr.OneYear,
+				CTLogServers: []string{"https://ct.trusty.com/log"},
+				Lints: map[string]authority.LintSeverity{
+					authority.LintCommonNameInSAN: authority.LintError,
+				},
+			},
+		},
+	}
+
+	rootCA, err := authority.CreateIssuer("TrustyRoot", caCfg, rootPEM, nil, nil, rootSigner)
+	s.Require().NoError(err)
+	// CT logs must not be called for preview
+	rootCA.SetCTSubmitter(failingCTSubmitter{})
+
+	csrPEM, _, _, _, err := csr.NewProvider(defprov).CreateRequestAndExportKey(&csr.CertificateRequest{
+		CN:         "trusty.com",
+		KeyRequest: csr.NewKeyRequest(defprov, "TestIssuerPreview"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey),
+	})
+	s.Require().NoError(err)
+
+	tbs, findings, err := rootCA.Preview(csr.SignRequest{
+		Request: string(csrPEM),
+		SAN:     []string{"www.trusty.com"},
+	})
+	s.Require().NoError(err)
+	s.Equal("trusty.com", tbs.Subject.CommonName)
+	s.Equal("[TEST] Trusty Root CA", tbs.Issuer.CommonName)
+	s.Equal([]string{"www.trusty.com"}, tbs.DNSNames)
+	s.Equal(rootCA.Bundle().Cert.SubjectKeyId, tbs.AuthorityKeyId)
+	s.NotEmpty(tbs.SubjectKeyId)
+	s.Equal([]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, tbs.ExtKeyUsage)
+	s.Require().Len(findings, 1)
+	s.Equal(authority.LintCommonNameInSAN, findings[0].Lint)
+	s.Equal(authority.LintError, findings[0].Severity)
+
+	// the same request is rejected by Sign
+	_, _, err = rootCA.Sign(csr.SignRequest{
+		Request: string(csrPEM),
+		SAN:     []string{"www.trusty.com"},
+	})
+	s.Require().Error(err)
+	s.True(errors.IsForbidden(err))
+
+	_, _, err = rootCA.Preview(csr.SignRequest{
+		Request: string(csrPEM),
+		Profile: "unknown",
+	})
+	s.Require().Error(err)
+	s.Equal("unsupported profile: unknown", err.Error())
+}
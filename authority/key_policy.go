@@ -0,0 +1,107 @@
+package authority
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Supported key algorithms
+const (
+	KeyAlgorithmRSA     = "RSA"
+	KeyAlgorithmECDSA   = "ECDSA"
+	KeyAlgorithmEd25519 = "Ed25519"
+)
+
+var supportedCurves = []string{"P-256", "P-384", "P-521"}
+
+// Validate returns an error if the policy is invalid
+func (p *KeyPolicy) Validate() error {
+	for _, alg := range p.Algorithms {
+		if !containsFold([]string{KeyAlgorithmRSA, KeyAlgorithmECDSA, KeyAlgorithmEd25519}, alg) {
+			return errors.Errorf("unsupported key algorithm: %s", alg)
+		}
+	}
+	for _, curve := range p.Curves {
+		if !containsFold(supportedCurves, curve) {
+			return errors.Errorf("unsupported curve: %s", curve)
+		}
+	}
+	if p.MinRSASize < 0 {
+		return errors.Errorf("invalid min_rsa_size: %d", p.MinRSASize)
+	}
+	return nil
+}
+
+// Check returns an error if the public key is not allowed by the policy,
+// or the key usage is not applicable for the key type
+func (p *KeyPolicy) Check(pub crypto.PublicKey, ku x509.KeyUsage) error {
+	var alg string
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		alg = KeyAlgorithmRSA
+		if size := key.N.BitLen(); size < p.MinRSASize {
+			return errors.Forbiddenf("RSA key size %d is less than allowed minimum %d", size, p.MinRSASize)
+		}
+	case *ecdsa.PublicKey:
+		alg = KeyAlgorithmECDSA
+		curve := key.Curve.Params().Name
+		if len(p.Curves) > 0 && !containsFold(p.Curves, curve) {
+			return errors.Forbiddenf("ECDSA curve is not allowed: %s", curve)
+		}
+	case ed25519.PublicKey:
+		alg = KeyAlgorithmEd25519
+	default:
+		return errors.Forbiddenf("unsupported key type: %T", pub)
+	}
+
+	if len(p.Algorithms) > 0 && !containsFold(p.Algorithms, alg) {
+		return errors.Forbiddenf("%s key is not allowed, supported: %s", alg, strings.Join(p.Algorithms, ","))
+	}
+
+	if p.MatchKeyUsage {
+		var notApplicable x509.KeyUsage
+		switch alg {
+		case KeyAlgorithmRSA:
+			notApplicable = x509.KeyUsageKeyAgreement
+		case KeyAlgorithmECDSA:
+			notApplicable = x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment
+		case KeyAlgorithmEd25519:
+			notApplicable = x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment | x509.KeyUsageKeyAgreement
+		}
+		if ku&notApplicable != 0 {
+			return errors.Forbiddenf("key usage is not applicable for %s key: %s",
+				alg, strings.Join(keyUsageNames(ku&notApplicable), ","))
+		}
+	}
+	return nil
+}
+
+// keyUsageNames returns the names of key usages
+func keyUsageNames(ku x509.KeyUsage) []string {
+	var names []string
+	if ku&x509.KeyUsageKeyEncipherment != 0 {
+		names = append(names, "key encipherment")
+	}
+	if ku&x509.KeyUsageDataEncipherment != 0 {
+		names = append(names, "data encipherment")
+	}
+	if ku&x509.KeyUsageKeyAgreement != 0 {
+		names = append(names, "key agreement")
+	}
+	return names
+}
+
+func containsFold(list []string, val string) bool {
+	for _, s := range list {
+		if strings.EqualFold(s, val) {
+			return true
+		}
+	}
+	return false
+}
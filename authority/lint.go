@@ -0,0 +1,275 @@
+package authority
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// LintSeverity specifies the severity of the lint finding
+type LintSeverity string
+
+// Supported lint severities
+const (
+	// LintIgnore specifies to not run the lint
+	LintIgnore LintSeverity = "ignore"
+	// LintWarn specifies to log the finding
+	LintWarn LintSeverity = "warn"
+	// LintError specifies to reject the certificate
+	LintError LintSeverity = "error"
+)
+
+// Supported lints
+const (
+	// LintSerialLength checks that the serial number is positive,
+	// has at least 64 bits of entropy and no longer than 20 octets
+	LintSerialLength = "serial_length"
+	// LintServerAuthSAN checks that TLS server certificate has DNS or IP SAN
+	LintServerAuthSAN = "server_auth_san"
+	// LintCommonNameInSAN checks that the Common Name is included in SAN
+	LintCommonNameInSAN = "cn_in_san"
+	// LintValidityPeriod checks the validity period of the certificate
+	LintValidityPeriod = "validity_period"
+	// LintMissingSKID checks that the certificate has Subject Key Identifier
+	LintMissingSKID = "missing_skid"
+	// LintMissingAKID checks that not self-signed certificate has Authority Key Identifier
+	LintMissingAKID = "missing_akid"
+	// LintEmptySubject checks that the certificate with empty subject has SAN
+	LintEmptySubject = "empty_subject"
+)
+
+// MaxServerAuthValidity is the maximum validity period
+// of TLS server certificates, as per CA/Browser Forum Baseline Requirements
+const MaxServerAuthValidity = 398 * 24 * time.Hour
+
+// LintFinding provides the result of the failed lint
+type LintFinding struct {
+	Lint     string       `json:"lint"`
+	Severity LintSeverity `json:"severity"`
+	Message  string       `json:"message"`
+}
+
+// String returns the finding as a string
+func (f *LintFinding) String() string {
+	return fmt.Sprintf("[%s] %s: %s", f.Severity, f.Lint, f.Message)
+}
+
+// lintFunc returns a message if the certificate does not pass the lint
+type lintFunc func(crt *x509.Certificate) string
+
+var lints = []struct {
+	name  string
+	check lintFunc
+}{
+	{LintSerialLength, lintSerialLength},
+	{LintServerAuthSAN, lintServerAuthSAN},
+	{LintCommonNameInSAN, lintCommonNameInSAN},
+	{LintValidityPeriod, lintValidityPeriod},
+	{LintMissingSKID, lintMissingSKID},
+	{LintMissingAKID, lintMissingAKID},
+	{LintEmptySubject, lintEmptySubject},
+}
+
+// IsSupportedLint returns true if the lint is supported
+func IsSupportedLint(name string) bool {
+	for _, l := range lints {
+		if l.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint runs the lints over the certificate, and returns the findings.
+// The severities specify the severity per lint, the default is LintWarn.
+func Lint(crt *x509.Certificate, severities map[string]LintSeverity) []*LintFinding {
+	var findings []*LintFinding
+	for _, l := range lints {
+		severity := severities[l.name]
+		if severity == "" {
+			severity = LintWarn
+		}
+		if severity == LintIgnore {
+			continue
+		}
+		if msg := l.check(crt); msg != "" {
+			findings = append(findings, &LintFinding{
+				Lint:     l.name,
+				Severity: severity,
+				Message:  msg,
+			})
+		}
+	}
+	return findings
+}
+
+// LintErrors returns an error if the findings contain errors
+func LintErrors(findings []*LintFinding) error {
+	var errs []string
+	for _, f := range findings {
+		if f.Severity == LintError {
+			errs = append(errs, fmt.Sprintf("%s: %s", f.Lint, f.Message))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("certificate lint failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// lint runs the lints over the TBS certificate template,
+// and returns an error if any of the lints with error severity failed
+func (ca *Issuer) lint(template *x509.Certificate, profile *CertProfile) error {
+	findings := Lint(ca.tbsTemplate(template), profile.Lints)
+	for _, f := range findings {
+		if f.Severity == LintWarn {
+			logger.Warningf("src=lint, serial=%d, CN=%q, lint=%s, reason=%q",
+				template.SerialNumber, template.Subject.CommonName, f.Lint, f.Message)
+		}
+	}
+
+	if err := LintErrors(findings); err != nil {
+		return errors.Forbiddenf("%s", err.Error())
+	}
+	return nil
+}
+
+// tbsTemplate returns a copy of the template with Issuer and AKID,
+// as x509.CreateCertificate populates them from the parent
+func (ca *Issuer) tbsTemplate(template *x509.Certificate) *x509.Certificate {
+	tbs := *template
+	if ca.bundle == nil {
+		tbs.Issuer = tbs.Subject
+	} else {
+		tbs.Issuer = ca.bundle.Cert.Subject
+		if len(ca.bundle.Cert.SubjectKeyId) > 0 {
+			tbs.AuthorityKeyId = ca.bundle.Cert.SubjectKeyId
+		}
+	}
+	return &tbs
+}
+
+// RFC 5280 4.1.2.2, CABF BR 7.1
+func lintSerialLength(crt *x509.Certificate) string {
+	if crt.SerialNumber == nil || crt.SerialNumber.Sign() <= 0 {
+		return "serial number must be a positive integer"
+	}
+	// DER encoding may add a leading zero byte
+	size := len(crt.SerialNumber.Bytes())
+	if crt.SerialNumber.Bytes()[0]&0x80 != 0 {
+		size++
+	}
+	if size > 20 {
+		return fmt.Sprintf("serial number is longer than 20 octets: %d", size)
+	}
+	if crt.SerialNumber.BitLen() < 64 {
+		return fmt.Sprintf("serial number has less than 64 bits: %d", crt.SerialNumber.BitLen())
+	}
+	return ""
+}
+
+// CABF BR 7.1.4.2.1
+func lintServerAuthSAN(crt *x509.Certificate) string {
+	if !crt.IsCA && hasExtKeyUsage(crt, x509.ExtKeyUsageServerAuth) &&
+		len(crt.DNSNames) == 0 && len(crt.IPAddresses) == 0 {
+		return "TLS server certificate must have DNS or IP SAN"
+	}
+	return ""
+}
+
+// CABF BR 7.1.4.2.2
+func lintCommonNameInSAN(crt *x509.Certificate) string {
+	cn := crt.Subject.CommonName
+	if crt.IsCA || cn == "" || !hasSAN(crt) {
+		return ""
+	}
+	for _, name := range crt.DNSNames {
+		if strings.EqualFold(name, cn) {
+			return ""
+		}
+	}
+	for _, email := range crt.EmailAddresses {
+		if strings.EqualFold(email, cn) {
+			return ""
+		}
+	}
+	if ip := net.ParseIP(cn); ip != nil {
+		for _, n := range crt.IPAddresses {
+			if n.Equal(ip) {
+				return ""
+			}
+		}
+	}
+	for _, uri := range crt.URIs {
+		if uri.String() == cn {
+			return ""
+		}
+	}
+	return fmt.Sprintf("Common Name is not included in SAN: %s", cn)
+}
+
+// RFC 5280 4.1.2.5, CABF BR 6.3.2
+func lintValidityPeriod(crt *x509.Certificate) string {
+	if !crt.NotAfter.After(crt.NotBefore) {
+		return "NotAfter must be after NotBefore"
+	}
+	if !crt.IsCA && hasExtKeyUsage(crt, x509.ExtKeyUsageServerAuth) {
+		if validity := crt.NotAfter.Sub(crt.NotBefore); validity > MaxServerAuthValidity {
+			return fmt.Sprintf("TLS server certificate validity exceeds %d days: %d days",
+				MaxServerAuthValidity/(24*time.Hour), validity/(24*time.Hour))
+		}
+	}
+	return ""
+}
+
+// RFC 5280 4.2.1.2
+func lintMissingSKID(crt *x509.Certificate) string {
+	if len(crt.SubjectKeyId) == 0 {
+		return "Subject Key Identifier is missing"
+	}
+	return ""
+}
+
+// RFC 5280 4.2.1.1
+func lintMissingAKID(crt *x509.Certificate) string {
+	if len(crt.AuthorityKeyId) == 0 && !isSelfIssued(crt) {
+		return "Authority Key Identifier is missing"
+	}
+	return ""
+}
+
+// RFC 5280 4.1.2.6
+func lintEmptySubject(crt *x509.Certificate) string {
+	if len(crt.Subject.ToRDNSequence()) == 0 && !hasSAN(crt) {
+		return "certificate with empty Subject must have SAN"
+	}
+	return ""
+}
+
+func hasSAN(crt *x509.Certificate) bool {
+	return len(crt.DNSNames) > 0 || len(crt.IPAddresses) > 0 ||
+		len(crt.EmailAddresses) > 0 || len(crt.URIs) > 0
+}
+
+func hasExtKeyUsage(crt *x509.Certificate, usage x509.ExtKeyUsage) bool {
+	for _, eku := range crt.ExtKeyUsage {
+		if eku == usage {
+			return true
+		}
+	}
+	return false
+}
+
+// isSelfIssued returns true if the subject and issuer are the same,
+// the raw values are available only for parsed certificates
+func isSelfIssued(crt *x509.Certificate) bool {
+	if len(crt.RawSubject) > 0 && len(crt.RawIssuer) > 0 {
+		return bytes.Equal(crt.RawSubject, crt.RawIssuer)
+	}
+	return crt.Subject.String() == crt.Issuer.String()
+}
@@ -0,0 +1,184 @@
+package authority_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/dolly/algorithms/guid"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	now := time.Now()
+	serial, _ := new(big.Int).SetString("1234567890123456789012345", 10)
+
+	valid := func() *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber:   serial,
+			Subject:        pkix.Name{CommonName: "trusty.com"},
+			Issuer:         pkix.Name{CommonName: "[TEST] Trusty Root CA"},
+			NotBefore:      now,
+			NotAfter:       now.Add(90 * 24 * time.Hour),
+			DNSNames:       []string{"trusty.com", "www.trusty.com"},
+			ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			SubjectKeyId:   []byte{1, 2, 3},
+			AuthorityKeyId: []byte{4, 5, 6},
+		}
+	}
+
+	assert.Empty(t, authority.Lint(valid(), nil))
+
+	tcases := []struct {
+		lint   string
+		modify func(crt *x509.Certificate)
+		msg    string
+	}{
+		{authority.LintSerialLength, func(crt *x509.Certificate) { crt.SerialNumber = big.NewInt(-1) }, "serial number must be a positive integer"},
+		{authority.LintSerialLength, func(crt *x509.Certificate) { crt.SerialNumber = big.NewInt(12345) }, "serial number has less than 64 bits: 14"},
+		{authority.LintSerialLength, func(crt *x509.Certificate) { crt.SerialNumber = new(big.Int).Lsh(big.NewInt(1), 159) }, "serial number is longer than 20 octets: 21"},
+		{authority.LintServerAuthSAN, func(crt *x509.Certificate) { crt.DNSNames = nil; crt.URIs = []*url.URL{{Path: "trusty.com"}} }, "TLS server certificate must have DNS or IP SAN"},
+		{authority.LintCommonNameInSAN, func(crt *x509.Certificate) { crt.DNSNames = []string{"www.trusty.com"} }, "Common Name is not included in SAN: trusty.com"},
+		{authority.LintValidityPeriod, func(crt *x509.Certificate) { crt.NotAfter = crt.NotBefore }, "NotAfter must be after NotBefore"},
+		{authority.LintValidityPeriod, func(crt *x509.Certificate) { crt.NotAfter = crt.NotBefore.Add(400 * 24 * time.Hour) }, "TLS server certificate validity exceeds 398 days: 400 days"},
+		{authority.LintMissingSKID, func(crt *x509.Certificate) { crt.SubjectKeyId = nil }, "Subject Key Identifier is missing"},
+		{authority.LintMissingAKID, func(crt *x509.Certificate) { crt.AuthorityKeyId = nil }, "Authority Key Identifier is missing"},
+		{authority.LintEmptySubject, func(crt *x509.Certificate) { crt.Subject = pkix.Name{}; crt.DNSNames = nil; crt.ExtKeyUsage = nil }, "certificate with empty Subject must have SAN"},
+	}
+
+	for _, tc := range tcases {
+		crt := valid()
+		tc.modify(crt)
+
+		findings := authority.Lint(crt, nil)
+		require.Len(t, findings, 1, tc.msg)
+		assert.Equal(t, tc.lint, findings[0].Lint)
+		assert.Equal(t, authority.LintWarn, findings[0].Severity)
+		assert.Equal(t, tc.msg, findings[0].Message)
+		assert.NoError(t, authority.LintErrors(findings))
+
+		findings = authority.Lint(crt, map[string]authority.LintSeverity{tc.lint: authority.LintError})
+		require.Len(t, findings, 1, tc.msg)
+		err := authority.LintErrors(findings)
+		require.Error(t, err)
+		assert.Equal(t, "certificate lint failed: "+tc.lint+": "+tc.msg, err.Error())
+		assert.Equal(t, "[error] "+tc.lint+": "+tc.msg, findings[0].String())
+
+		findings = authority.Lint(crt, map[string]authority.LintSeverity{tc.lint: authority.LintIgnore})
+		assert.Empty(t, findings, tc.msg)
+	}
+
+	// self-signed certificate does not require AKID
+	crt := valid()
+	crt.AuthorityKeyId = nil
+	crt.Issuer = crt.Subject
+	assert.Empty(t, authority.Lint(crt, nil))
+
+	// CN as IP SAN
+	crt = valid()
+	crt.Subject.CommonName = "10.0.0.1"
+	crt.IPAddresses = []net.IP{net.ParseIP("10.0.0.1")}
+	assert.Empty(t, authority.Lint(crt, nil))
+
+	assert.True(t, authority.IsSupportedLint(authority.LintCommonNameInSAN))
+	assert.False(t, authority.IsSupportedLint("unknown"))
+}
+
+func TestCertProfileLints(t *testing.T) {
+	p := &authority.CertProfile{
+		Usage:  []string{"server auth"},
+		Expiry: csr.OneYear,
+		Lints:  map[string]authority.LintSeverity{"unknown": authority.LintError},
+	}
+	assert.EqualError(t, p.Validate(), "unsupported lint: unknown")
+
+	p.Lints = map[string]authority.LintSeverity{authority.LintCommonNameInSAN: "fatal"}
+	assert.EqualError(t, p.Validate(), "unsupported lint severity: fatal")
+
+	p.Lints = map[string]authority.LintSeverity{authority.LintCommonNameInSAN: authority.LintError}
+	assert.NoError(t, p.Validate())
+}
+
+func (s *testSuite) TestIssuerSignLint() {
+	defprov := s.crypto.Default()
+	rootReq := csr.CertificateRequest{
+		CN:         "[TEST] Trusty Root CA",
+		KeyRequest: csr.NewKeyRequest(defprov, "TestIssuerSignLint"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey),
+	}
+	rootPEM, _, rootKey, err := authority.NewRoot("ROOT", rootCfg, defprov, &rootReq)
+	s.Require().NoError(err)
+
+	rootSigner, err := authority.NewSignerFromPEM(s.crypto, rootKey)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		Profiles: map[string]*authority.CertProfile{
+			"default": {
+				Usage:  []string{"server auth", "signing"},
+				Expiry: 1 * csr.OneYear,
+			},
+			"strict": {
+				Usage:  []string{"server auth", "signing"},
+				Expiry: 1 * csr.OneYear,
+				Lints: map[string]authority.LintSeverity{
+					authority.LintServerAuthSAN:   authority.LintError,
+					authority.LintCommonNameInSAN: authority.LintError,
+					authority.LintMissingAKID:     authority.LintError,
+					authority.LintMissingSKID:     authority.LintError,
+					authority.LintSerialLength:    authority.LintError,
+				},
+			},
+			"long": {
+				Usage:  []string{"server auth", "signing"},
+				Expiry: 2 * csr.OneYear,
+				Lints: map[string]authority.LintSeverity{
+					authority.LintValidityPeriod: authority.LintError,
+				},
+			},
+		},
+	}
+
+	rootCA, err := authority.CreateIssuer("TrustyRoot", caCfg, rootPEM, nil, nil, rootSigner)
+	s.Require().NoError(err)
+
+	csrPEM, _, _, _, err := csr.NewProvider(defprov).CreateRequestAndExportKey(&csr.CertificateRequest{
+		CN:         "trusty.com",
+		KeyRequest: csr.NewKeyRequest(defprov, "TestIssuerSignLint"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey),
+	})
+	s.Require().NoError(err)
+
+	tcases := []struct {
+		profile string
+		san     []string
+		err     string
+	}{
+		{"default", nil, ""},
+		{"strict", []string{"trusty.com", "www.trusty.com"}, ""},
+		{"strict", nil, "certificate lint failed: server_auth_san: TLS server certificate must have DNS or IP SAN"},
+		{"strict", []string{"www.trusty.com"}, "certificate lint failed: cn_in_san: Common Name is not included in SAN: trusty.com"},
+		{"long", []string{"trusty.com"}, "certificate lint failed: validity_period: TLS server certificate validity exceeds 398 days: 730 days"},
+	}
+	for _, tc := range tcases {
+		crt, _, err := rootCA.Sign(csr.SignRequest{
+			Request: string(csrPEM),
+			Profile: tc.profile,
+			SAN:     tc.san,
+		})
+		if tc.err == "" {
+			s.Require().NoError(err, tc.profile)
+			s.Empty(authority.LintErrors(authority.Lint(crt, caCfg.Profiles[tc.profile].Lints)))
+		} else {
+			s.Require().Error(err, tc.profile)
+			s.True(errors.IsForbidden(err))
+			s.Equal(tc.err, err.Error())
+		}
+	}
+}
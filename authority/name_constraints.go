@@ -0,0 +1,177 @@
+package authority
+
+import (
+	"bytes"
+	"crypto/x509"
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// checkNameConstraints ensures that the names in the template
+// are permitted by the Name Constraints of the issuer and its parents
+func (ca *Issuer) checkNameConstraints(template *x509.Certificate) error {
+	if ca.bundle == nil {
+		return nil
+	}
+
+	for _, caCert := range ca.chainCerts() {
+		err := checkNameConstraints(caCert, template)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// chainCerts returns the issuer certificate, its intermediates and the root
+func (ca *Issuer) chainCerts() []*x509.Certificate {
+	certs := []*x509.Certificate{ca.bundle.Cert}
+	add := func(crt *x509.Certificate) {
+		if crt == nil {
+			return
+		}
+		for _, c := range certs {
+			if bytes.Equal(c.Raw, crt.Raw) {
+				return
+			}
+		}
+		certs = append(certs, crt)
+	}
+	for _, crt := range ca.bundle.Chain {
+		add(crt)
+	}
+	add(ca.bundle.RootCert)
+	return certs
+}
+
+// checkNameConstraints ensures that the names in the template
+// are permitted by the Name Constraints of the CA certificate.
+// The DNS constraints are applied to the Common Name as well,
+// if it is a hostname
+func checkNameConstraints(caCert, template *x509.Certificate) error {
+	if cn := template.Subject.CommonName; isHostname(cn) &&
+		!isPermitted(cn, caCert.PermittedDNSDomains, caCert.ExcludedDNSDomains, matchDomain) {
+		return errors.Forbiddenf("common name is not permitted by the issuer's name constraints: %s", cn)
+	}
+	for _, name := range template.DNSNames {
+		if !isPermitted(name, caCert.PermittedDNSDomains, caCert.ExcludedDNSDomains, matchDomain) {
+			return errors.Forbiddenf("DNS name is not permitted by the issuer's name constraints: %s", name)
+		}
+	}
+	for _, email := range template.EmailAddresses {
+		if !isPermitted(email, caCert.PermittedEmailAddresses, caCert.ExcludedEmailAddresses, matchEmail) {
+			return errors.Forbiddenf("email is not permitted by the issuer's name constraints: %s", email)
+		}
+	}
+	for _, uri := range template.URIs {
+		if !isPermitted(uri.Hostname(), caCert.PermittedURIDomains, caCert.ExcludedURIDomains, matchHost) {
+			return errors.Forbiddenf("URI is not permitted by the issuer's name constraints: %s", uri.String())
+		}
+	}
+	for _, ip := range template.IPAddresses {
+		if !isPermittedIP(ip, caCert.PermittedIPRanges, caCert.ExcludedIPRanges) {
+			return errors.Forbiddenf("IP address is not permitted by the issuer's name constraints: %s", ip.String())
+		}
+	}
+	return nil
+}
+
+// isHostname returns true if the name looks like a DNS name
+// with at least two labels, that may start with a wildcard
+func isHostname(name string) bool {
+	name = strings.TrimPrefix(name, "*.")
+	if len(name) == 0 || len(name) > 253 || !strings.Contains(name, ".") ||
+		net.ParseIP(name) != nil {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isPermitted returns true if the name does not match any of excluded constraints,
+// and matches any of permitted, if the permitted list is not empty
+func isPermitted(name string, permitted, excluded []string, match func(name, constraint string) bool) bool {
+	for _, constraint := range excluded {
+		if match(name, constraint) {
+			return false
+		}
+	}
+	if len(permitted) == 0 {
+		return true
+	}
+	for _, constraint := range permitted {
+		if match(name, constraint) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPermittedIP(ip net.IP, permitted, excluded []*net.IPNet) bool {
+	for _, ipnet := range excluded {
+		if ipnet.Contains(ip) {
+			return false
+		}
+	}
+	if len(permitted) == 0 {
+		return true
+	}
+	for _, ipnet := range permitted {
+		if ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDomain returns true if the DNS name is equal to the constraint,
+// or is a sub-domain of it.
+// The constraint started with "." matches only sub-domains.
+func matchDomain(name, constraint string) bool {
+	name = strings.ToLower(name)
+	constraint = strings.ToLower(constraint)
+	if constraint == "" {
+		return true
+	}
+	if strings.HasPrefix(constraint, ".") {
+		return strings.HasSuffix(name, constraint)
+	}
+	return name == constraint || strings.HasSuffix(name, "."+constraint)
+}
+
+// matchHost returns true if the host is equal to the constraint.
+// The constraint started with "." matches only sub-domains.
+func matchHost(host, constraint string) bool {
+	host = strings.ToLower(host)
+	constraint = strings.ToLower(constraint)
+	if strings.HasPrefix(constraint, ".") {
+		return strings.HasSuffix(host, constraint)
+	}
+	return host == constraint
+}
+
+// matchEmail returns true if the email matches the constraint, that can be
+// a particular mailbox, all addresses on a host, or all addresses in a domain
+// if started with "."
+func matchEmail(email, constraint string) bool {
+	if strings.Contains(constraint, "@") {
+		return strings.EqualFold(email, constraint)
+	}
+
+	idx := strings.LastIndex(email, "@")
+	if idx < 0 {
+		return false
+	}
+	return matchHost(email[idx+1:], constraint)
+}
@@ -0,0 +1,71 @@
+package authority
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"time"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/ocsp"
+)
+
+// SetOCSPSigner sets the delegated OCSP signer,
+// the certificate must be issued by the Issuer with OCSP Signing extended key usage
+func (ca *Issuer) SetOCSPSigner(crt *x509.Certificate, signer crypto.Signer) error {
+	if err := crt.CheckSignatureFrom(ca.bundle.Cert); err != nil {
+		return errors.Annotate(err, "OCSP cert is not issued by the issuer")
+	}
+
+	ocspSigning := false
+	for _, eku := range crt.ExtKeyUsage {
+		if eku == x509.ExtKeyUsageOCSPSigning {
+			ocspSigning = true
+			break
+		}
+	}
+	if !ocspSigning {
+		return errors.New("OCSP cert does not have OCSP Signing extended key usage")
+	}
+
+	pub, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !bytes.Equal(pub, crt.RawSubjectPublicKeyInfo) {
+		return errors.New("OCSP key does not match the cert")
+	}
+
+	ca.ocspCert = crt
+	ca.ocspSigner = signer
+	return nil
+}
+
+// OCSPResponder returns the certificate of the OCSP responder
+func (ca *Issuer) OCSPResponder() *x509.Certificate {
+	if ca.ocspCert != nil {
+		return ca.ocspCert
+	}
+	return ca.bundle.Cert
+}
+
+// SignOCSP returns DER encoded OCSP response signed by the issuer,
+// or by the delegated OCSP signer.
+// ThisUpdate and NextUpdate are set from the current time and OcspExpiry.
+func (ca *Issuer) SignOCSP(template ocsp.Response) ([]byte, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	template.ThisUpdate = now
+	template.NextUpdate = now.Add(ca.ocspExpiry)
+
+	signer := ca.signer
+	if ca.ocspSigner != nil {
+		signer = ca.ocspSigner
+		template.Certificate = ca.ocspCert
+	}
+
+	der, err := ocsp.CreateResponse(ca.bundle.Cert, ca.OCSPResponder(), template, signer)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to create OCSP response")
+	}
+	return der, nil
+}
@@ -0,0 +1,105 @@
+package authority_test
+
+import (
+	"crypto"
+	"math/big"
+	"time"
+
+	"github.com/go-phorce/dolly/algorithms/guid"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"golang.org/x/crypto/ocsp"
+)
+
+func (s *testSuite) TestSignOCSP() {
+	crypto11 := s.crypto.Default()
+	kr := csr.NewKeyRequest(crypto11, "TestSignOCSP"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey)
+	rootReq := csr.CertificateRequest{
+		CN:         "[TEST] Trusty Root CA",
+		KeyRequest: kr,
+	}
+	rootPEM, _, rootKey, err := authority.NewRoot("ROOT", rootCfg, crypto11, &rootReq)
+	s.Require().NoError(err)
+
+	rootSigner, err := authority.NewSignerFromPEM(s.crypto, rootKey)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		AiaURL:  "https://localhost/v1/certs/${ISSUER_ID}.crt",
+		OcspURL: "https://localhost/v1/ocsp",
+		CrlURL:  "https://localhost/v1/crl/${ISSUER_ID}.crl",
+		Profiles: map[string]*authority.CertProfile{
+			"ocsp": {
+				Usage:       []string{"signing", "ocsp signing"},
+				Expiry:      1 * csr.OneYear,
+				OCSPNoCheck: true,
+			},
+			"default": {
+				Usage:  []string{"server auth", "signing", "key encipherment"},
+				Expiry: 1 * csr.OneYear,
+			},
+		},
+	}
+
+	rootCA, err := authority.CreateIssuer("TrustyRoot", caCfg, rootPEM, nil, nil, rootSigner)
+	s.Require().NoError(err)
+	s.NotEmpty(rootCA.NameHash(crypto.SHA1))
+	s.Equal(rootCA.Bundle().Cert, rootCA.OCSPResponder())
+
+	template := ocsp.Response{
+		SerialNumber: big.NewInt(1234),
+		Status:       ocsp.Good,
+	}
+
+	der, err := rootCA.SignOCSP(template)
+	s.Require().NoError(err)
+
+	res, err := ocsp.ParseResponse(der, rootCA.Bundle().Cert)
+	s.Require().NoError(err)
+	s.Equal(ocsp.Good, res.Status)
+	s.Equal(int64(1234), res.SerialNumber.Int64())
+	s.Nil(res.Certificate)
+	s.False(res.ThisUpdate.After(time.Now()))
+
+	setSigner := func(profile string) error {
+		req := csr.CertificateRequest{
+			CN:         "[TEST] Trusty OCSP",
+			KeyRequest: csr.NewKeyRequest(crypto11, "TestSignOCSP"+guid.MustCreate(), "ECDSA", 256, csr.SigningKey),
+		}
+		csrPEM, key, _, _, err := csr.NewProvider(crypto11).CreateRequestAndExportKey(&req)
+		s.Require().NoError(err)
+
+		crt, _, err := rootCA.Sign(csr.SignRequest{
+			Request: string(csrPEM),
+			Profile: profile,
+		})
+		s.Require().NoError(err)
+
+		signer, err := authority.NewSignerFromPEM(s.crypto, key)
+		s.Require().NoError(err)
+
+		return rootCA.SetOCSPSigner(crt, signer)
+	}
+
+	err = setSigner("default")
+	s.Require().Error(err)
+	s.Equal("OCSP cert does not have OCSP Signing extended key usage", err.Error())
+
+	err = setSigner("ocsp")
+	s.Require().NoError(err)
+	s.NotEqual(rootCA.Bundle().Cert, rootCA.OCSPResponder())
+
+	template.Status = ocsp.Revoked
+	template.RevokedAt = time.Now().UTC().Truncate(time.Second)
+	template.RevocationReason = ocsp.KeyCompromise
+
+	der, err = rootCA.SignOCSP(template)
+	s.Require().NoError(err)
+
+	res, err = ocsp.ParseResponse(der, rootCA.Bundle().Cert)
+	s.Require().NoError(err)
+	s.Equal(ocsp.Revoked, res.Status)
+	s.Equal(ocsp.KeyCompromise, res.RevocationReason)
+	s.Require().NotNil(res.Certificate)
+	s.Equal(rootCA.OCSPResponder().Raw, res.Certificate.Raw)
+}
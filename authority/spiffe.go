@@ -0,0 +1,91 @@
+package authority
+
+import (
+	"crypto/x509"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// SPIFFE ID format is defined in
+// https://github.com/spiffe/spiffe/blob/master/standards/SPIFFE-ID.md
+const (
+	spiffeScheme      = "spiffe://"
+	maxSPIFFEIDLength = 2048
+	maxTrustDomainLen = 255
+)
+
+// validateSPIFFE ensures that the template conforms to
+// SPIFFE X.509-SVID: it must contain exactly one URI SAN,
+// which is SPIFFE ID in the trust domain
+func validateSPIFFE(template *x509.Certificate, trustDomain string) error {
+	if len(template.URIs) != 1 {
+		return errors.BadRequestf("SPIFFE SVID must have exactly one URI SAN, found %d", len(template.URIs))
+	}
+
+	id := template.URIs[0].String()
+	td, err := parseSPIFFEID(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if td != trustDomain {
+		return errors.Forbiddenf("SPIFFE ID is not in %q trust domain: %s", trustDomain, id)
+	}
+	return nil
+}
+
+// parseSPIFFEID validates SPIFFE ID, and returns its trust domain
+func parseSPIFFEID(id string) (string, error) {
+	if len(id) > maxSPIFFEIDLength {
+		return "", errors.BadRequestf("SPIFFE ID is too long: %d", len(id))
+	}
+	if !strings.HasPrefix(id, spiffeScheme) {
+		return "", errors.BadRequestf("invalid SPIFFE ID scheme: %s", id)
+	}
+
+	td := id[len(spiffeScheme):]
+	path := ""
+	if idx := strings.Index(td, "/"); idx >= 0 {
+		td, path = td[:idx], td[idx:]
+	}
+
+	if !isValidTrustDomain(td) {
+		return "", errors.BadRequestf("invalid SPIFFE ID trust domain: %s", id)
+	}
+	if path != "" && !isValidSPIFFEPath(path) {
+		return "", errors.BadRequestf("invalid SPIFFE ID path: %s", id)
+	}
+	return td, nil
+}
+
+// isValidTrustDomain returns true if the trust domain contains
+// only lowercase letters, numbers, dots, dashes, and underscores
+func isValidTrustDomain(td string) bool {
+	if td == "" || len(td) > maxTrustDomainLen {
+		return false
+	}
+	for _, c := range td {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '.' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidSPIFFEPath returns true if the path consists of non-empty segments,
+// that are not relative, and contain only letters, numbers, dots, dashes, and underscores
+func isValidSPIFFEPath(path string) bool {
+	segments := strings.Split(path, "/")
+	// the path starts with "/"
+	for _, segment := range segments[1:] {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+		for _, c := range segment {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '-' || c == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,365 @@
+package acme
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xhttp/header"
+	v1 "github.com/go-phorce/trusty/api/v1"
+	"github.com/juju/errors"
+)
+
+// maxRequestSize specifies the max size of JWS request
+const maxRequestSize = 64 * 1024
+
+type directoryResponse struct {
+	NewNonce   string `json:"newNonce"`
+	NewAccount string `json:"newAccount"`
+	NewOrder   string `json:"newOrder"`
+}
+
+type accountRequest struct {
+	Contact              []string `json:"contact"`
+	TermsOfServiceAgreed bool     `json:"termsOfServiceAgreed"`
+	OnlyReturnExisting   bool     `json:"onlyReturnExisting"`
+	Status               string   `json:"status"`
+}
+
+type accountResponse struct {
+	Status  string   `json:"status"`
+	Contact []string `json:"contact,omitempty"`
+	Orders  string   `json:"orders"`
+}
+
+type ordersResponse struct {
+	Orders []string `json:"orders"`
+}
+
+// jwsRequest is the verified ACME request
+type jwsRequest struct {
+	header  *jwsHeader
+	payload []byte
+	key     crypto.PublicKey
+	// account is set for requests signed with kid
+	account *Account
+}
+
+// isPostAsGet returns true for POST-as-GET request, RFC 8555 6.3
+func (req *jwsRequest) isPostAsGet() bool {
+	return len(req.payload) == 0
+}
+
+// handle adds the headers required in all ACME responses
+func (s *Service) handle(h rest.Handle) rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p rest.Params) {
+		w.Header().Set(header.ReplayNonce, s.nonces.New())
+		w.Header().Set(header.CacheControl, "no-store")
+		w.Header().Add(header.Link, link(s.baseURL(r)+v1.PathForACMEDirectory, "index"))
+		h(w, r, p)
+	}
+}
+
+func (s *Service) directory() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ rest.Params) {
+		base := s.baseURL(r)
+		writeJSON(w, http.StatusOK, &directoryResponse{
+			NewNonce:   base + v1.PathForACMENewNonce,
+			NewAccount: base + v1.PathForACMENewAccount,
+			NewOrder:   base + v1.PathForACMENewOrder,
+		})
+	}
+}
+
+func (s *Service) newNonce(status int) rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ rest.Params) {
+		w.WriteHeader(status)
+	}
+}
+
+func (s *Service) newAccount() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ rest.Params) {
+		req, p := s.parseJWS(r, false)
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		var areq accountRequest
+		if err := json.Unmarshal(req.payload, &areq); err != nil {
+			writeProblem(w, malformed("invalid request: %s", err.Error()))
+			return
+		}
+
+		thumbprint, err := jwkThumbprint(req.key)
+		if err != nil {
+			writeProblem(w, malformed("invalid JWK: %s", err.Error()))
+			return
+		}
+
+		acct, err := s.store.GetAccountByThumbprint(r.Context(), thumbprint)
+		if err == nil {
+			w.Header().Set(header.Location, s.resourceURL(r, v1.PathForACMEAccount, acct.ID))
+			writeJSON(w, http.StatusOK, s.accountResponse(r, acct))
+			return
+		} else if !errors.IsNotFound(err) {
+			logger.Errorf("src=newAccount, err=[%v]", errors.ErrorStack(err))
+			writeProblem(w, serverInternal("unable to find account"))
+			return
+		}
+
+		if areq.OnlyReturnExisting {
+			writeProblem(w, newProblem(http.StatusBadRequest, errAccountDoesNotExist, "account does not exist"))
+			return
+		}
+		if p := validateContact(areq.Contact); p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		acct = &Account{
+			ID:         newID(),
+			Thumbprint: thumbprint,
+			KeyJWK:     req.header.JWK,
+			Status:     StatusValid,
+			Contact:    areq.Contact,
+			CreatedAt:  time.Now().UTC(),
+		}
+		if err = s.store.PutAccount(r.Context(), acct); err != nil {
+			logger.Errorf("src=newAccount, err=[%v]", errors.ErrorStack(err))
+			writeProblem(w, serverInternal("unable to create account"))
+			return
+		}
+
+		logger.Infof("src=newAccount, account=%s, contact=%v", acct.ID, acct.Contact)
+
+		w.Header().Set(header.Location, s.resourceURL(r, v1.PathForACMEAccount, acct.ID))
+		writeJSON(w, http.StatusCreated, s.accountResponse(r, acct))
+	}
+}
+
+func (s *Service) account() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params rest.Params) {
+		req, p := s.parseJWS(r, true)
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		acct := req.account
+		if acct.ID != params.ByName("id") {
+			writeProblem(w, unauthorized("account does not match the key"))
+			return
+		}
+
+		if !req.isPostAsGet() {
+			var areq accountRequest
+			if err := json.Unmarshal(req.payload, &areq); err != nil {
+				writeProblem(w, malformed("invalid request: %s", err.Error()))
+				return
+			}
+
+			switch areq.Status {
+			case "":
+			case StatusDeactivated:
+				acct.Status = StatusDeactivated
+			default:
+				writeProblem(w, malformed("invalid account status: %s", areq.Status))
+				return
+			}
+			if areq.Contact != nil {
+				if p := validateContact(areq.Contact); p != nil {
+					writeProblem(w, p)
+					return
+				}
+				acct.Contact = areq.Contact
+			}
+
+			if err := s.store.PutAccount(r.Context(), acct); err != nil {
+				logger.Errorf("src=account, account=%s, err=[%v]", acct.ID, errors.ErrorStack(err))
+				writeProblem(w, serverInternal("unable to update account"))
+				return
+			}
+			logger.Infof("src=account, account=%s, status=%s, contact=%v", acct.ID, acct.Status, acct.Contact)
+		}
+
+		writeJSON(w, http.StatusOK, s.accountResponse(r, acct))
+	}
+}
+
+func (s *Service) accountOrders() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params rest.Params) {
+		req, p := s.parseJWS(r, true)
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		if req.account.ID != params.ByName("id") {
+			writeProblem(w, unauthorized("account does not match the key"))
+			return
+		}
+
+		orders, err := s.store.ListOrders(r.Context(), req.account.ID)
+		if err != nil {
+			logger.Errorf("src=accountOrders, account=%s, err=[%v]", req.account.ID, errors.ErrorStack(err))
+			writeProblem(w, serverInternal("unable to list orders"))
+			return
+		}
+
+		res := &ordersResponse{Orders: []string{}}
+		for _, order := range orders {
+			res.Orders = append(res.Orders, s.resourceURL(r, v1.PathForACMEOrder, order.ID))
+		}
+		writeJSON(w, http.StatusOK, res)
+	}
+}
+
+func (s *Service) accountResponse(r *http.Request, acct *Account) *accountResponse {
+	return &accountResponse{
+		Status:  acct.Status,
+		Contact: acct.Contact,
+		Orders:  s.resourceURL(r, v1.PathForACMEAccountOrders, acct.ID),
+	}
+}
+
+// parseJWS verifies the request, RFC 8555 6.2.
+// If withKID is true, then the request must be signed by the existing account,
+// otherwise it must contain JWK
+func (s *Service) parseJWS(r *http.Request, withKID bool) (*jwsRequest, *Problem) {
+	if ct := r.Header.Get(header.ContentType); ct != header.ApplicationJoseJSON {
+		return nil, newProblem(http.StatusUnsupportedMediaType, errMalformed, "unsupported content type: %s", ct)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		return nil, malformed("unable to read request")
+	}
+
+	var msg jwsMessage
+	if err = json.Unmarshal(body, &msg); err != nil {
+		return nil, malformed("invalid JWS: %s", err.Error())
+	}
+
+	protected, err := base64.RawURLEncoding.DecodeString(msg.Protected)
+	if err != nil {
+		return nil, malformed("invalid JWS protected header")
+	}
+	var h jwsHeader
+	if err = json.Unmarshal(protected, &h); err != nil {
+		return nil, malformed("invalid JWS protected header: %s", err.Error())
+	}
+
+	if h.Alg == "" || h.Alg == "none" || strings.HasPrefix(h.Alg, "HS") {
+		return nil, newProblem(http.StatusBadRequest, errBadSignatureAlgorithm, "unsupported algorithm: %q", h.Alg)
+	}
+	if !s.nonces.Use(h.Nonce) {
+		return nil, newProblem(http.StatusBadRequest, errBadNonce, "invalid nonce: %q", h.Nonce)
+	}
+	if expected := s.baseURL(r) + r.URL.Path; h.URL != expected {
+		return nil, unauthorized("URL in JWS header does not match the request: %q", h.URL)
+	}
+
+	req := &jwsRequest{
+		header: &h,
+	}
+
+	if withKID {
+		if h.KID == "" || len(h.JWK) > 0 {
+			return nil, malformed("JWS must contain kid")
+		}
+		prefix := s.resourceURL(r, v1.PathForACMEAccount, "")
+		if !strings.HasPrefix(h.KID, prefix) {
+			return nil, newProblem(http.StatusBadRequest, errAccountDoesNotExist, "account does not exist: %q", h.KID)
+		}
+		req.account, err = s.store.GetAccount(r.Context(), strings.TrimPrefix(h.KID, prefix))
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, newProblem(http.StatusBadRequest, errAccountDoesNotExist, "account does not exist: %q", h.KID)
+			}
+			logger.Errorf("src=parseJWS, kid=%s, err=[%v]", h.KID, errors.ErrorStack(err))
+			return nil, serverInternal("unable to find account")
+		}
+		if req.account.Status != StatusValid {
+			return nil, unauthorized("account is %s", req.account.Status)
+		}
+		req.key, err = parseJWK(req.account.KeyJWK)
+		if err != nil {
+			logger.Errorf("src=parseJWS, kid=%s, err=[%v]", h.KID, errors.ErrorStack(err))
+			return nil, serverInternal("invalid account key")
+		}
+	} else {
+		if h.KID != "" || len(h.JWK) == 0 {
+			return nil, malformed("JWS must contain jwk")
+		}
+		req.key, err = parseJWK(h.JWK)
+		if err != nil {
+			if errors.IsNotSupported(err) {
+				return nil, newProblem(http.StatusBadRequest, errBadSignatureAlgorithm, "unsupported JWK: %s", err.Error())
+			}
+			return nil, malformed("invalid JWK: %s", err.Error())
+		}
+	}
+
+	req.payload, err = base64.RawURLEncoding.DecodeString(msg.Payload)
+	if err != nil {
+		return nil, malformed("invalid JWS payload")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(msg.Signature)
+	if err != nil {
+		return nil, malformed("invalid JWS signature")
+	}
+
+	err = verifyJWS(req.key, h.Alg, []byte(msg.Protected+"."+msg.Payload), signature)
+	if err != nil {
+		if errors.IsNotSupported(err) {
+			return nil, newProblem(http.StatusBadRequest, errBadSignatureAlgorithm, "%s", err.Error())
+		}
+		return nil, malformed("JWS verification failed")
+	}
+
+	return req, nil
+}
+
+// baseURL returns the public URL of the server
+func (s *Service) baseURL(r *http.Request) string {
+	if u := s.cfg.GetBaseURL(); u != "" {
+		return strings.TrimSuffix(u, "/")
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// resourceURL returns URL of the resource with ID
+func (s *Service) resourceURL(r *http.Request, path, id string) string {
+	return s.baseURL(r) + strings.Replace(path, ":id", id, 1)
+}
+
+func validateContact(contact []string) *Problem {
+	for _, c := range contact {
+		if !strings.HasPrefix(c, "mailto:") || !strings.Contains(c, "@") {
+			return newProblem(http.StatusBadRequest, errInvalidContact, "invalid contact: %q", c)
+		}
+	}
+	return nil
+}
+
+func link(url, rel string) string {
+	return fmt.Sprintf("<%s>;rel=%q", url, rel)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set(header.ContentType, header.ApplicationJSON)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
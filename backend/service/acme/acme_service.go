@@ -0,0 +1,136 @@
+package acme
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xlog"
+	v1 "github.com/go-phorce/trusty/api/v1"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/config"
+	"github.com/go-phorce/trusty/internal/db"
+	"github.com/juju/errors"
+)
+
+// ServiceName provides the Service Name for this package
+const ServiceName = "acme"
+
+var logger = xlog.NewPackageLogger("github.com/go-phorce/trusty/backend/service", "acme")
+
+const (
+	// defaultOrderExpiry specifies the default expiry
+	// of pending orders and authorizations
+	defaultOrderExpiry = 24 * time.Hour
+	// defaultProfile specifies the default certificate profile
+	defaultProfile = "server"
+)
+
+// IssuerProvider provides the issuer for certificate profile,
+// authority.Authority implements the interface
+type IssuerProvider interface {
+	GetIssuerByProfile(profile string) (*authority.Issuer, error)
+}
+
+// Service defines the ACME service, RFC 8555
+type Service struct {
+	server     *trustyserver.TrustyServer
+	cfg        *config.ACME
+	ca         IssuerProvider
+	db         db.Provider
+	store      Store
+	validators map[string]Validator
+	nonces     *nonces
+}
+
+// Factory returns a factory of the service
+func Factory(server *trustyserver.TrustyServer) interface{} {
+	if server == nil {
+		logger.Panic("acme.Factory: invalid parameter")
+	}
+
+	return func(cfg *config.Configuration, ca *authority.Authority, db db.Provider) error {
+		allowed, err := ParseNetworks(cfg.ACME.GetAllowedNetworks())
+		if err != nil {
+			return errors.Annotate(err, "unable to parse ACME allowed networks")
+		}
+
+		var resolver TXTResolver
+		if cfg.ACME.DNSResolver != "" {
+			resolver = NewResolver(cfg.ACME.DNSResolver)
+		}
+
+		validators := map[string]Validator{
+			ChallengeHTTP01: &HTTP01Validator{AllowedNetworks: allowed},
+			ChallengeDNS01:  &DNS01Validator{Resolver: resolver},
+		}
+
+		svc := newService(server, &cfg.ACME, ca, db, NewMemoryStore(), validators)
+		server.AddService(svc)
+		return nil
+	}
+}
+
+func newService(
+	server *trustyserver.TrustyServer,
+	cfg *config.ACME,
+	ca IssuerProvider,
+	db db.Provider,
+	store Store,
+	validators map[string]Validator,
+) *Service {
+	return &Service{
+		server:     server,
+		cfg:        cfg,
+		ca:         ca,
+		db:         db,
+		store:      store,
+		validators: validators,
+		nonces:     newNonces(),
+	}
+}
+
+// Name returns the service name
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// IsReady indicates that the service is ready to serve its end-points
+func (s *Service) IsReady() bool {
+	return true
+}
+
+// Close the subservices and it's resources
+func (s *Service) Close() {
+}
+
+// RegisterRoute adds the ACME API endpoints to the overall URL router
+func (s *Service) RegisterRoute(r rest.Router) {
+	r.GET(v1.PathForACMEDirectory, s.handle(s.directory()))
+	r.HEAD(v1.PathForACMENewNonce, s.handle(s.newNonce(http.StatusOK)))
+	r.GET(v1.PathForACMENewNonce, s.handle(s.newNonce(http.StatusNoContent)))
+	r.POST(v1.PathForACMENewAccount, s.handle(s.newAccount()))
+	r.POST(v1.PathForACMEAccount, s.handle(s.account()))
+	r.POST(v1.PathForACMEAccountOrders, s.handle(s.accountOrders()))
+	r.POST(v1.PathForACMENewOrder, s.handle(s.newOrder()))
+	r.POST(v1.PathForACMEOrder, s.handle(s.order()))
+	r.POST(v1.PathForACMEOrderFinalize, s.handle(s.finalize()))
+	r.POST(v1.PathForACMEAuthz, s.handle(s.authz()))
+	r.POST(v1.PathForACMEChallenge, s.handle(s.challenge()))
+	r.POST(v1.PathForACMECertificate, s.handle(s.certificate()))
+}
+
+func (s *Service) orderExpiry() time.Duration {
+	if d := s.cfg.GetOrderExpiry(); d > 0 {
+		return d
+	}
+	return defaultOrderExpiry
+}
+
+func (s *Service) profile() string {
+	if p := s.cfg.GetProfile(); p != "" {
+		return p
+	}
+	return defaultProfile
+}
@@ -0,0 +1,765 @@
+package acme
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/dolly/audit"
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xhttp/header"
+	"github.com/go-phorce/dolly/xpki/cryptoprov"
+	v1 "github.com/go-phorce/trusty/api/v1"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/config"
+	"github.com/go-phorce/trusty/internal/db"
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/go-phorce/trusty/tests/testutils"
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/dig"
+)
+
+type testSuite struct {
+	suite.Suite
+
+	server   *trustyserver.TrustyServer
+	baseURL  string
+	rootCA   *x509.Certificate
+	db       *fakeDB
+	resolver *fakeResolver
+	// challenges is stand-in HTTP server for http-01
+	challenges *httptest.Server
+	responses  sync.Map
+}
+
+func TestACME(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) SetupSuite() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "[TEST] Trusty ACME Root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	s.Require().NoError(err)
+	s.rootCA, err = x509.ParseCertificate(der)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		Profiles: map[string]*authority.CertProfile{
+			"server": {
+				Usage:  []string{"signing", "server auth"},
+				Expiry: csr.Duration(90 * 24 * time.Hour),
+			},
+		},
+	}
+	rootPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	issuer, err := authority.CreateIssuer("TrustyACME", caCfg, rootPEM, nil, nil, key)
+	s.Require().NoError(err)
+
+	s.challenges = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.URL.Path, "/.well-known/acme-challenge/")
+		if ka, ok := s.responses.Load(r.Host + "/" + token); ok {
+			w.Write([]byte(ka.(string)))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	// all http-01 requests are sent to the stand-in server
+	addr := s.challenges.Listener.Addr().String()
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, addr)
+			},
+		},
+	}
+
+	s.db = &fakeDB{}
+	s.resolver = &fakeResolver{records: map[string][]string{}}
+	validators := map[string]Validator{
+		ChallengeHTTP01: &HTTP01Validator{Client: client},
+		ChallengeDNS01:  &DNS01Validator{Resolver: s.resolver},
+	}
+
+	factories := map[string]trustyserver.ServiceFactory{
+		ServiceName: func(server *trustyserver.TrustyServer) interface{} {
+			return func() {
+				issuers := fakeIssuers{"server": issuer}
+				svc := newService(server, &config.ACME{}, issuers, s.db, NewMemoryStore(), validators)
+				server.AddService(svc)
+			}
+		},
+	}
+
+	c := dig.New()
+	c.Provide(func() (rest.Authz, audit.Auditor, *cryptoprov.Crypto, db.Provider) {
+		return nil, nil, nil, nil
+	})
+
+	s.baseURL = testutils.CreateURLs("http", "localhost")
+	s.server, err = trustyserver.StartTrusty(&config.HTTPServer{
+		Name:       "ACME",
+		ListenURLs: []string{s.baseURL},
+		Services:   []string{ServiceName},
+	}, c, factories)
+	s.Require().NoError(err)
+
+	// wait for the server to start
+	for i := 0; i < 10; i++ {
+		if s.server.IsReady() {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func (s *testSuite) TearDownSuite() {
+	if s.server != nil {
+		s.server.Close()
+	}
+	if s.challenges != nil {
+		s.challenges.Close()
+	}
+}
+
+func (s *testSuite) TestDirectory() {
+	res, err := http.Get(s.baseURL + v1.PathForACMEDirectory)
+	s.Require().NoError(err)
+	defer res.Body.Close()
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+
+	var dir directoryResponse
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&dir))
+	s.Equal(s.baseURL+v1.PathForACMENewNonce, dir.NewNonce)
+	s.Equal(s.baseURL+v1.PathForACMENewAccount, dir.NewAccount)
+	s.Equal(s.baseURL+v1.PathForACMENewOrder, dir.NewOrder)
+	s.NotEmpty(res.Header.Get(header.ReplayNonce))
+
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequest(method, s.baseURL+v1.PathForACMENewNonce, nil)
+		s.Require().NoError(err)
+		res, err := http.DefaultClient.Do(req)
+		s.Require().NoError(err)
+		res.Body.Close()
+		s.NotEmpty(res.Header.Get(header.ReplayNonce))
+		s.Equal("no-store", res.Header.Get(header.CacheControl))
+	}
+}
+
+func (s *testSuite) TestIssue() {
+	c := s.newAccount()
+
+	order := c.newOrder("www.trusty.com", "*.trusty.com")
+	s.Equal(StatusPending, order.Status)
+	s.Require().Len(order.Authorizations, 2)
+	s.Equal([]Identifier{{"dns", "*.trusty.com"}, {"dns", "www.trusty.com"}}, order.Identifiers)
+
+	orderURL := c.location
+
+	// not ready
+	csrDER := s.createCSR("www.trusty.com", "www.trusty.com", "*.trusty.com")
+	c.expectProblem(order.Finalize, &finalizeRequest{CSR: base64.RawURLEncoding.EncodeToString(csrDER)},
+		http.StatusForbidden, errOrderNotReady)
+
+	for _, authzURL := range order.Authorizations {
+		var authz authzResponse
+		c.postJSON(authzURL, nil, http.StatusOK, &authz)
+		s.Equal(StatusPending, authz.Status)
+
+		if authz.Wildcard {
+			s.Equal("trusty.com", authz.Identifier.Value)
+			s.Require().Len(authz.Challenges, 1)
+			ch := authz.Challenges[0]
+			s.Equal(ChallengeDNS01, ch.Type)
+
+			digest := sha256.Sum256([]byte(keyAuthorization(ch.Token, c.thumbprint())))
+			s.resolver.set("_acme-challenge.trusty.com", base64.RawURLEncoding.EncodeToString(digest[:]))
+			s.respond(c, ch, StatusValid)
+		} else {
+			s.Equal("www.trusty.com", authz.Identifier.Value)
+			s.Require().Len(authz.Challenges, 2)
+			ch := authz.Challenges[0]
+			s.Equal(ChallengeHTTP01, ch.Type)
+
+			s.responses.Store("www.trusty.com/"+ch.Token, keyAuthorization(ch.Token, c.thumbprint()))
+			s.respond(c, ch, StatusValid)
+		}
+
+		c.postJSON(authzURL, nil, http.StatusOK, &authz)
+		s.Equal(StatusValid, authz.Status)
+	}
+
+	c.postJSON(orderURL, nil, http.StatusOK, &order)
+	s.Equal(StatusReady, order.Status)
+
+	// CSR with a name not in the order
+	c.expectProblem(order.Finalize, &finalizeRequest{
+		CSR: base64.RawURLEncoding.EncodeToString(s.createCSR("", "www.trusty.com", "api.trusty.com")),
+	}, http.StatusBadRequest, errBadCSR)
+	// CSR without all names in the order
+	c.expectProblem(order.Finalize, &finalizeRequest{
+		CSR: base64.RawURLEncoding.EncodeToString(s.createCSR("", "www.trusty.com")),
+	}, http.StatusBadRequest, errBadCSR)
+
+	count := s.db.count()
+	c.postJSON(order.Finalize, &finalizeRequest{CSR: base64.RawURLEncoding.EncodeToString(csrDER)}, http.StatusOK, &order)
+	s.Equal(StatusValid, order.Status)
+	s.Require().NotEmpty(order.Certificate)
+	s.Equal(count+1, s.db.count())
+
+	// finalized
+	c.expectProblem(order.Finalize, &finalizeRequest{CSR: base64.RawURLEncoding.EncodeToString(csrDER)},
+		http.StatusForbidden, errOrderNotReady)
+
+	res, body := c.post(order.Certificate, nil)
+	s.Require().Equal(http.StatusOK, res.StatusCode, string(body))
+	s.Equal(contentTypePEMChain, res.Header.Get(header.ContentType))
+
+	var chain []*x509.Certificate
+	for block, rest := pem.Decode(body); block != nil; block, rest = pem.Decode(rest) {
+		crt, err := x509.ParseCertificate(block.Bytes)
+		s.Require().NoError(err)
+		chain = append(chain, crt)
+	}
+	s.Require().Len(chain, 2)
+	s.ElementsMatch([]string{"www.trusty.com", "*.trusty.com"}, chain[0].DNSNames)
+	s.Equal(s.rootCA.Raw, chain[1].Raw)
+	s.NoError(chain[0].CheckSignatureFrom(s.rootCA))
+
+	// orders of the account
+	var orders ordersResponse
+	c.postJSON(c.kid+"/orders", nil, http.StatusOK, &orders)
+	s.Equal([]string{orderURL}, orders.Orders)
+
+	// other account can not access the order and the certificate
+	other := s.newAccount()
+	other.expectProblem(orderURL, nil, http.StatusNotFound, errMalformed)
+	other.expectProblem(order.Certificate, nil, http.StatusNotFound, errMalformed)
+	other.expectProblem(order.Authorizations[0], nil, http.StatusNotFound, errMalformed)
+	other.expectProblem(c.kid, nil, http.StatusForbidden, errUnauthorized)
+}
+
+func (s *testSuite) TestIssueNotRegistered() {
+	c := s.newAccount()
+
+	order := c.newOrder("unregistered.trusty.com")
+	orderURL := c.location
+
+	var authz authzResponse
+	c.postJSON(order.Authorizations[0], nil, http.StatusOK, &authz)
+	ch := authz.Challenges[0]
+	s.Require().Equal(ChallengeHTTP01, ch.Type)
+	s.responses.Store("unregistered.trusty.com/"+ch.Token, keyAuthorization(ch.Token, c.thumbprint()))
+	s.respond(c, ch, StatusValid)
+
+	c.postJSON(orderURL, nil, http.StatusOK, &order)
+	s.Require().Equal(StatusReady, order.Status)
+
+	s.db.setError(errors.New("db is down"))
+	defer s.db.setError(nil)
+
+	// the certificate is not returned, if it is not registered
+	count := s.db.count()
+	c.expectProblem(order.Finalize, &finalizeRequest{
+		CSR: base64.RawURLEncoding.EncodeToString(s.createCSR("unregistered.trusty.com", "unregistered.trusty.com")),
+	}, http.StatusInternalServerError, errServerInternal)
+	s.Equal(count, s.db.count())
+
+	c.postJSON(orderURL, nil, http.StatusOK, &order)
+	s.Equal(StatusInvalid, order.Status)
+	s.Empty(order.Certificate)
+}
+
+func (s *testSuite) TestChallengeFailed() {
+	c := s.newAccount()
+
+	s.Run("http-01", func() {
+		order := c.newOrder("bad-http.trusty.com")
+		orderURL := c.location
+
+		var authz authzResponse
+		c.postJSON(order.Authorizations[0], nil, http.StatusOK, &authz)
+		ch := authz.Challenges[0]
+		s.Require().Equal(ChallengeHTTP01, ch.Type)
+
+		s.responses.Store("bad-http.trusty.com/"+ch.Token, "invalid")
+		res := s.respond(c, ch, StatusInvalid)
+		s.Require().NotNil(res.Error)
+		s.Equal(errIncorrectResponse, res.Error.Type)
+
+		// validated only once
+		s.responses.Store("bad-http.trusty.com/"+ch.Token, keyAuthorization(ch.Token, c.thumbprint()))
+		s.respond(c, ch, StatusInvalid)
+
+		c.postJSON(order.Authorizations[0], nil, http.StatusOK, &authz)
+		s.Equal(StatusInvalid, authz.Status)
+		c.postJSON(orderURL, nil, http.StatusOK, &order)
+		s.Equal(StatusInvalid, order.Status)
+	})
+
+	s.Run("http-01 not found", func() {
+		order := c.newOrder("missing.trusty.com")
+
+		var authz authzResponse
+		c.postJSON(order.Authorizations[0], nil, http.StatusOK, &authz)
+		res := s.respond(c, authz.Challenges[0], StatusInvalid)
+		s.Require().NotNil(res.Error)
+		s.Equal(errConnection, res.Error.Type)
+	})
+
+	s.Run("dns-01", func() {
+		order := c.newOrder("bad-dns.trusty.com")
+
+		var authz authzResponse
+		c.postJSON(order.Authorizations[0], nil, http.StatusOK, &authz)
+		ch := authz.Challenges[1]
+		s.Require().Equal(ChallengeDNS01, ch.Type)
+
+		res := s.respond(c, ch, StatusInvalid)
+		s.Require().NotNil(res.Error)
+		s.Equal(errDNS, res.Error.Type)
+	})
+
+	s.Run("deactivate", func() {
+		order := c.newOrder("deactivated.trusty.com")
+
+		var authz authzResponse
+		c.postJSON(order.Authorizations[0], &authzRequest{Status: StatusDeactivated}, http.StatusOK, &authz)
+		s.Equal(StatusDeactivated, authz.Status)
+		c.expectProblem(order.Authorizations[0], &authzRequest{Status: StatusValid}, http.StatusBadRequest, errMalformed)
+	})
+}
+
+func (s *testSuite) TestNewOrderErrors() {
+	c := s.newAccount()
+
+	tcases := []struct {
+		ids []Identifier
+		typ string
+	}{
+		{nil, errMalformed},
+		{[]Identifier{{"ip", "10.0.0.1"}}, errUnsupportedIdentifier},
+		{[]Identifier{{"dns", "localhost"}}, errRejectedIdentifier},
+		{[]Identifier{{"dns", "bad_name.trusty.com"}}, errRejectedIdentifier},
+		{[]Identifier{{"dns", "*.*.trusty.com"}}, errRejectedIdentifier},
+	}
+	for _, tc := range tcases {
+		c.expectProblem(s.baseURL+v1.PathForACMENewOrder, &orderRequest{Identifiers: tc.ids}, http.StatusBadRequest, tc.typ)
+	}
+
+	c.expectProblem(s.baseURL+v1.PathForACMENewOrder, &orderRequest{
+		Identifiers: []Identifier{{"dns", "trusty.com"}},
+		NotBefore:   "yesterday",
+	}, http.StatusBadRequest, errMalformed)
+}
+
+func (s *testSuite) TestAccount() {
+	c := s.newAccount()
+	kid := c.kid
+
+	// existing account for the same key
+	c.kid = ""
+	var acct accountResponse
+	c.postJSON(s.baseURL+v1.PathForACMENewAccount, &accountRequest{}, http.StatusOK, &acct)
+	s.Equal(kid, c.location)
+	s.Equal(StatusValid, acct.Status)
+	c.kid = kid
+
+	c.postJSON(kid, &accountRequest{Contact: []string{"mailto:ops@trusty.com"}}, http.StatusOK, &acct)
+	s.Equal([]string{"mailto:ops@trusty.com"}, acct.Contact)
+	c.expectProblem(kid, &accountRequest{Contact: []string{"tel:12345"}}, http.StatusBadRequest, errInvalidContact)
+
+	// new key, only existing
+	other := s.newClient()
+	other.expectProblem(s.baseURL+v1.PathForACMENewAccount,
+		&accountRequest{OnlyReturnExisting: true}, http.StatusBadRequest, errAccountDoesNotExist)
+	other.expectProblem(s.baseURL+v1.PathForACMENewAccount,
+		&accountRequest{Contact: []string{"ops@trusty.com"}}, http.StatusBadRequest, errInvalidContact)
+
+	// deactivate
+	c.postJSON(kid, &accountRequest{Status: StatusDeactivated}, http.StatusOK, &acct)
+	s.Equal(StatusDeactivated, acct.Status)
+	c.expectProblem(kid, nil, http.StatusForbidden, errUnauthorized)
+	c.expectProblem(s.baseURL+v1.PathForACMENewOrder, &orderRequest{
+		Identifiers: []Identifier{{"dns", "trusty.com"}},
+	}, http.StatusForbidden, errUnauthorized)
+}
+
+func (s *testSuite) TestJWSErrors() {
+	c := s.newAccount()
+	newOrder := s.baseURL + v1.PathForACMENewOrder
+	payload := &orderRequest{Identifiers: []Identifier{{"dns", "trusty.com"}}}
+
+	s.Run("bad nonce", func() {
+		c.nonce = "invalid"
+		c.expectProblem(newOrder, payload, http.StatusBadRequest, errBadNonce)
+	})
+	s.Run("URL mismatch", func() {
+		body := c.sign(s.baseURL+v1.PathForACMENewAccount, payload)
+		res, resBody := c.do(newOrder, header.ApplicationJoseJSON, body)
+		s.Equal(http.StatusForbidden, res.StatusCode)
+		s.Contains(string(resBody), errUnauthorized)
+	})
+	s.Run("content type", func() {
+		body := c.sign(newOrder, payload)
+		res, _ := c.do(newOrder, header.ApplicationJSON, body)
+		s.Equal(http.StatusUnsupportedMediaType, res.StatusCode)
+	})
+	s.Run("bad signature", func() {
+		body := c.sign(newOrder, payload)
+		var msg jwsMessage
+		s.Require().NoError(json.Unmarshal(body, &msg))
+		msg.Payload = base64.RawURLEncoding.EncodeToString([]byte(`{"identifiers":[{"type":"dns","value":"evil.com"}]}`))
+		body, _ = json.Marshal(&msg)
+		res, resBody := c.do(newOrder, header.ApplicationJoseJSON, body)
+		s.Equal(http.StatusBadRequest, res.StatusCode)
+		s.Contains(string(resBody), errMalformed)
+	})
+	s.Run("unknown account", func() {
+		kid := c.kid
+		c.kid = s.baseURL + "/v1/acme/account/unknown"
+		c.expectProblem(newOrder, payload, http.StatusBadRequest, errAccountDoesNotExist)
+		c.kid = kid
+	})
+}
+
+func TestJWS(t *testing.T) {
+	ec, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	jwk, err := newJWK(ec.Public())
+	require.NoError(t, err)
+	raw, err := json.Marshal(jwk)
+	require.NoError(t, err)
+
+	pub, err := parseJWK(raw)
+	require.NoError(t, err)
+	assert.True(t, ec.PublicKey.Equal(pub))
+
+	t1, err := jwkThumbprint(ec.Public())
+	require.NoError(t, err)
+	t2, err := jwkThumbprint(pub)
+	require.NoError(t, err)
+	assert.Equal(t, t1, t2)
+
+	data := []byte("signing input")
+	sig := signES(t, ec, crypto.SHA384, data)
+	assert.NoError(t, verifyJWS(pub, "ES384", data, sig))
+	assert.Error(t, verifyJWS(pub, "ES384", []byte("other"), sig))
+
+	err = verifyJWS(pub, "ES256", data, sig)
+	require.Error(t, err)
+	assert.True(t, errors.IsNotSupported(err))
+
+	for _, jwk := range []string{
+		`{"kty":"oct","k":"secret"}`,
+		`{"kty":"EC","crv":"P-192","x":"AA","y":"AA"}`,
+		`{"kty":"EC","crv":"P-256","x":"AA","y":"AA"}`,
+		`{"kty":"RSA","n":"AQAB","e":"AQAB"}`,
+		`{"kty":"OKP","crv":"X25519","x":"AA"}`,
+	} {
+		_, err = parseJWK([]byte(jwk))
+		assert.Error(t, err, jwk)
+	}
+}
+
+// testClient is ACME client
+type testClient struct {
+	s        *testSuite
+	key      *ecdsa.PrivateKey
+	kid      string
+	nonce    string
+	location string
+}
+
+func (s *testSuite) newClient() *testClient {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	return &testClient{s: s, key: key}
+}
+
+func (s *testSuite) newAccount() *testClient {
+	c := s.newClient()
+	var acct accountResponse
+	c.postJSON(s.baseURL+v1.PathForACMENewAccount, &accountRequest{
+		Contact:              []string{"mailto:admin@trusty.com"},
+		TermsOfServiceAgreed: true,
+	}, http.StatusCreated, &acct)
+	s.Equal(StatusValid, acct.Status)
+	s.Require().True(strings.HasPrefix(c.location, s.baseURL+"/v1/acme/account/"))
+	c.kid = c.location
+	return c
+}
+
+func (c *testClient) thumbprint() string {
+	tp, err := jwkThumbprint(c.key.Public())
+	c.s.Require().NoError(err)
+	return tp
+}
+
+func (c *testClient) newOrder(names ...string) *orderResponse {
+	req := &orderRequest{}
+	for _, name := range names {
+		req.Identifiers = append(req.Identifiers, Identifier{Type: "dns", Value: name})
+	}
+	var order orderResponse
+	c.postJSON(c.s.baseURL+v1.PathForACMENewOrder, req, http.StatusCreated, &order)
+	return &order
+}
+
+// sign returns JWS with the payload, nil payload is for POST-as-GET
+func (c *testClient) sign(url string, payload interface{}) []byte {
+	if c.nonce == "" {
+		res, err := http.Head(c.s.baseURL + v1.PathForACMENewNonce)
+		c.s.Require().NoError(err)
+		res.Body.Close()
+		c.nonce = res.Header.Get(header.ReplayNonce)
+	}
+
+	h := &jwsHeader{
+		Alg:   "ES256",
+		Nonce: c.nonce,
+		URL:   url,
+		KID:   c.kid,
+	}
+	c.nonce = ""
+	if c.kid == "" {
+		jwk, err := newJWK(c.key.Public())
+		c.s.Require().NoError(err)
+		h.JWK, err = json.Marshal(jwk)
+		c.s.Require().NoError(err)
+	}
+
+	var data []byte
+	if payload != nil {
+		var err error
+		data, err = json.Marshal(payload)
+		c.s.Require().NoError(err)
+	}
+
+	protected, err := json.Marshal(h)
+	c.s.Require().NoError(err)
+
+	msg := &jwsMessage{
+		Protected: base64.RawURLEncoding.EncodeToString(protected),
+		Payload:   base64.RawURLEncoding.EncodeToString(data),
+	}
+	sig := signES(c.s.T(), c.key, crypto.SHA256, []byte(msg.Protected+"."+msg.Payload))
+	msg.Signature = base64.RawURLEncoding.EncodeToString(sig)
+
+	body, err := json.Marshal(msg)
+	c.s.Require().NoError(err)
+	return body
+}
+
+func (c *testClient) do(url, contentType string, body []byte) (*http.Response, []byte) {
+	res, err := http.Post(url, contentType, bytes.NewReader(body))
+	c.s.Require().NoError(err)
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	c.s.Require().NoError(err)
+
+	c.nonce = res.Header.Get(header.ReplayNonce)
+	c.location = res.Header.Get(header.Location)
+	return res, resBody
+}
+
+func (c *testClient) post(url string, payload interface{}) (*http.Response, []byte) {
+	return c.do(url, header.ApplicationJoseJSON, c.sign(url, payload))
+}
+
+func (c *testClient) postJSON(url string, payload interface{}, status int, v interface{}) {
+	res, body := c.post(url, payload)
+	c.s.Require().Equal(status, res.StatusCode, string(body))
+	c.s.Require().NoError(json.Unmarshal(body, v))
+}
+
+func (c *testClient) expectProblem(url string, payload interface{}, status int, typ string) {
+	res, body := c.post(url, payload)
+	c.s.Require().Equal(status, res.StatusCode, string(body))
+	c.s.Equal(contentTypeProblem, res.Header.Get(header.ContentType))
+
+	var p Problem
+	c.s.Require().NoError(json.Unmarshal(body, &p))
+	c.s.Equal(typ, p.Type, p.Detail)
+}
+
+// respond responds to the challenge, and returns the validated challenge
+func (s *testSuite) respond(c *testClient, ch *challengeResponse, status string) *challengeResponse {
+	var res challengeResponse
+	c.postJSON(ch.URL, struct{}{}, http.StatusOK, &res)
+	s.Equal(status, res.Status)
+	return &res
+}
+
+func (s *testSuite) createCSR(cn string, names ...string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: cn},
+		DNSNames: names,
+	}, key)
+	s.Require().NoError(err)
+	return der
+}
+
+func signES(t *testing.T, key *ecdsa.PrivateKey, h crypto.Hash, data []byte) []byte {
+	r, sig, err := ecdsa.Sign(rand.Reader, key, digest(h, data))
+	require.NoError(t, err)
+
+	size := (key.Curve.Params().BitSize + 7) / 8
+	return append(padBytes(r.Bytes(), size), padBytes(sig.Bytes(), size)...)
+}
+
+type fakeIssuers map[string]*authority.Issuer
+
+func (f fakeIssuers) GetIssuerByProfile(profile string) (*authority.Issuer, error) {
+	if issuer, ok := f[profile]; ok {
+		return issuer, nil
+	}
+	return nil, errors.NotFoundf("issuer for profile %q", profile)
+}
+
+type fakeResolver struct {
+	lock    sync.Mutex
+	records map[string][]string
+}
+
+func (r *fakeResolver) set(name, value string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.records[name] = append(r.records[name], value)
+}
+
+func (r *fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if records, ok := r.records[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+// fakeDB implements only db.CertificatesDb.CreateCertificate
+type fakeDB struct {
+	db.Provider
+
+	lock  sync.Mutex
+	certs []*model.Certificate
+	err   error
+}
+
+func (f *fakeDB) CreateCertificate(_ context.Context, crt *model.Certificate) (*model.Certificate, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	c := *crt
+	c.ID = int64(len(f.certs) + 1)
+	f.certs = append(f.certs, &c)
+	return &c, nil
+}
+
+func (f *fakeDB) setError(err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.err = err
+}
+
+func (f *fakeDB) count() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.certs)
+}
+
+func TestNonces(t *testing.T) {
+	n := newNonces()
+	first := n.New()
+	for i := 1; i < maxNonces; i++ {
+		n.New()
+	}
+	assert.Len(t, n.issued, maxNonces)
+
+	last := n.New()
+	assert.Len(t, n.issued, maxNonces)
+	assert.Equal(t, maxNonces, n.order.Len())
+	assert.False(t, n.Use(first), "the oldest nonce must be evicted")
+	assert.True(t, n.Use(last))
+	assert.False(t, n.Use(last), "the nonce can be used only once")
+	assert.Equal(t, maxNonces-1, n.order.Len())
+}
+
+func TestHTTP01ValidatorNonPublic(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("token.thumbprint"))
+	}))
+	defer srv.Close()
+
+	v := &HTTP01Validator{}
+	err := v.Validate(context.Background(), strings.TrimPrefix(srv.URL, "http://"), "token", "token.thumbprint")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "non-public address")
+
+	allowed, err := ParseNetworks([]string{"10.0.0.0/8", "192.168.0.0/16"})
+	require.NoError(t, err)
+	v = &HTTP01Validator{AllowedNetworks: allowed}
+	err = v.Validate(context.Background(), strings.TrimPrefix(srv.URL, "http://"), "token", "token.thumbprint")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "non-public address")
+
+	allowed, err = ParseNetworks([]string{"127.0.0.0/8", "::1/128"})
+	require.NoError(t, err)
+	v = &HTTP01Validator{AllowedNetworks: allowed}
+	err = v.Validate(context.Background(), strings.TrimPrefix(srv.URL, "http://"), "token", "token.thumbprint")
+	assert.NoError(t, err)
+
+	_, err = ParseNetworks([]string{"127.0.0.1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid network "127.0.0.1"`)
+
+	for _, ip := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "::1", "fe80::1", "fd00::1", "224.0.0.1"} {
+		assert.False(t, isPublicIP(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{"8.8.8.8", "2001:4860:4860::8888"} {
+		assert.True(t, isPublicIP(net.ParseIP(ip)), ip)
+	}
+}
@@ -0,0 +1,245 @@
+package acme
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xhttp/header"
+	v1 "github.com/go-phorce/trusty/api/v1"
+	"github.com/juju/errors"
+)
+
+// validationTimeout specifies the timeout of the challenge validation
+const validationTimeout = 30 * time.Second
+
+type authzRequest struct {
+	Status string `json:"status"`
+}
+
+type authzResponse struct {
+	Identifier Identifier           `json:"identifier"`
+	Status     string               `json:"status"`
+	Expires    string               `json:"expires,omitempty"`
+	Challenges []*challengeResponse `json:"challenges"`
+	Wildcard   bool                 `json:"wildcard,omitempty"`
+}
+
+type challengeResponse struct {
+	Type      string   `json:"type"`
+	URL       string   `json:"url"`
+	Status    string   `json:"status"`
+	Token     string   `json:"token"`
+	Validated string   `json:"validated,omitempty"`
+	Error     *Problem `json:"error,omitempty"`
+}
+
+// newAuthorization returns pending authorization for the identifier,
+// with the challenges supported by the configured validators
+func (s *Service) newAuthorization(accountID string, id Identifier, expires time.Time) *Authorization {
+	authz := &Authorization{
+		ID:         newID(),
+		AccountID:  accountID,
+		Identifier: id,
+		Status:     StatusPending,
+		Expires:    expires,
+	}
+	if strings.HasPrefix(id.Value, "*.") {
+		authz.Identifier.Value = strings.TrimPrefix(id.Value, "*.")
+		authz.Wildcard = true
+	}
+
+	for _, typ := range []string{ChallengeHTTP01, ChallengeDNS01} {
+		// wildcard domain can be validated only with dns-01, RFC 8555 7.1.3
+		if s.validators[typ] == nil || (authz.Wildcard && typ != ChallengeDNS01) {
+			continue
+		}
+		authz.Challenges = append(authz.Challenges, &Challenge{
+			Type:   typ,
+			Token:  newToken(),
+			Status: StatusPending,
+		})
+	}
+	return authz
+}
+
+func (s *Service) authz() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params rest.Params) {
+		req, p := s.parseJWS(r, true)
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		authz, p := s.getAuthorization(r.Context(), req.account, params.ByName("id"))
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		if !req.isPostAsGet() {
+			var areq authzRequest
+			if err := json.Unmarshal(req.payload, &areq); err != nil {
+				writeProblem(w, malformed("invalid request: %s", err.Error()))
+				return
+			}
+			if areq.Status != StatusDeactivated {
+				writeProblem(w, malformed("invalid authorization status: %s", areq.Status))
+				return
+			}
+			if authz.Status != StatusPending && authz.Status != StatusValid {
+				writeProblem(w, malformed("authorization is %s", authz.Status))
+				return
+			}
+
+			authz.Status = StatusDeactivated
+			if err := s.store.PutAuthorization(r.Context(), authz); err != nil {
+				logger.Errorf("src=authz, authz=%s, err=[%v]", authz.ID, errors.ErrorStack(err))
+				writeProblem(w, serverInternal("unable to update authorization"))
+				return
+			}
+			logger.Infof("src=authz, account=%s, authz=%s, status=%s", req.account.ID, authz.ID, authz.Status)
+		}
+
+		writeJSON(w, http.StatusOK, s.authzResponse(r, authz))
+	}
+}
+
+func (s *Service) challenge() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params rest.Params) {
+		req, p := s.parseJWS(r, true)
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		// challenge ID is {authz_id}-{type}
+		parts := strings.SplitN(params.ByName("id"), "-", 2)
+		if len(parts) != 2 {
+			writeProblem(w, notFound("challenge not found"))
+			return
+		}
+
+		authz, p := s.getAuthorization(r.Context(), req.account, parts[0])
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		var ch *Challenge
+		for _, c := range authz.Challenges {
+			if c.Type == parts[1] {
+				ch = c
+				break
+			}
+		}
+		if ch == nil {
+			writeProblem(w, notFound("challenge not found"))
+			return
+		}
+
+		// the client responds to the challenge with empty JSON object,
+		// the validation is performed only once
+		if !req.isPostAsGet() && ch.Status == StatusPending && authz.Status == StatusPending {
+			s.validate(r.Context(), req.account, authz, ch)
+
+			if err := s.store.PutAuthorization(r.Context(), authz); err != nil {
+				logger.Errorf("src=challenge, authz=%s, err=[%v]", authz.ID, errors.ErrorStack(err))
+				writeProblem(w, serverInternal("unable to update authorization"))
+				return
+			}
+		}
+
+		w.Header().Add(header.Link, link(s.resourceURL(r, v1.PathForACMEAuthz, authz.ID), "up"))
+		writeJSON(w, http.StatusOK, s.challengeResponse(r, authz, ch))
+	}
+}
+
+// validate validates the challenge, and updates the status
+// of the challenge and authorization
+func (s *Service) validate(ctx context.Context, acct *Account, authz *Authorization, ch *Challenge) {
+	ctx, cancel := context.WithTimeout(ctx, validationTimeout)
+	defer cancel()
+
+	domain := authz.Identifier.Value
+	err := s.validators[ch.Type].Validate(ctx, domain, ch.Token, keyAuthorization(ch.Token, acct.Thumbprint))
+	if err != nil {
+		logger.Warningf("src=validate, account=%s, authz=%s, type=%s, domain=%s, err=[%v]",
+			acct.ID, authz.ID, ch.Type, domain, err.Error())
+
+		typ := errConnection
+		if errors.IsNotValid(err) {
+			typ = errIncorrectResponse
+		} else if ch.Type == ChallengeDNS01 {
+			typ = errDNS
+		}
+
+		ch.Status = StatusInvalid
+		ch.Error = newProblem(http.StatusForbidden, typ, "%s", err.Error())
+		authz.Status = StatusInvalid
+		return
+	}
+
+	logger.Infof("src=validate, account=%s, authz=%s, type=%s, domain=%s, status=valid",
+		acct.ID, authz.ID, ch.Type, domain)
+
+	ch.Status = StatusValid
+	ch.Validated = time.Now().UTC()
+	authz.Status = StatusValid
+}
+
+// getAuthorization returns the authorization of the account, with updated status
+func (s *Service) getAuthorization(ctx context.Context, acct *Account, id string) (*Authorization, *Problem) {
+	authz, err := s.store.GetAuthorization(ctx, id)
+	if err != nil || authz.AccountID != acct.ID {
+		return nil, notFound("authorization not found")
+	}
+
+	if authz.Status == StatusPending && time.Now().After(authz.Expires) {
+		authz.Status = StatusExpired
+		if err = s.store.PutAuthorization(ctx, authz); err != nil {
+			logger.Errorf("src=getAuthorization, authz=%s, err=[%v]", authz.ID, errors.ErrorStack(err))
+			return nil, serverInternal("unable to update authorization")
+		}
+	}
+	return authz, nil
+}
+
+func (s *Service) authzResponse(r *http.Request, authz *Authorization) *authzResponse {
+	res := &authzResponse{
+		Identifier: authz.Identifier,
+		Status:     authz.Status,
+		Expires:    authz.Expires.Format(time.RFC3339),
+		Wildcard:   authz.Wildcard,
+	}
+	for _, ch := range authz.Challenges {
+		res.Challenges = append(res.Challenges, s.challengeResponse(r, authz, ch))
+	}
+	return res
+}
+
+func (s *Service) challengeResponse(r *http.Request, authz *Authorization, ch *Challenge) *challengeResponse {
+	res := &challengeResponse{
+		Type:   ch.Type,
+		URL:    s.resourceURL(r, v1.PathForACMEChallenge, authz.ID+"-"+ch.Type),
+		Status: ch.Status,
+		Token:  ch.Token,
+		Error:  ch.Error,
+	}
+	if !ch.Validated.IsZero() {
+		res.Validated = ch.Validated.Format(time.RFC3339)
+	}
+	return res
+}
+
+// newToken returns the challenge token with 128 bits of entropy, RFC 8555 8.1
+func newToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,237 @@
+package acme
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/juju/errors"
+)
+
+// jwsMessage is JWS in Flattened JSON Serialization, RFC 7515 7.2.2
+type jwsMessage struct {
+	Protected string `json:"protected"`
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// jwsHeader is the protected header of ACME request, RFC 8555 6.2
+type jwsHeader struct {
+	Alg   string          `json:"alg"`
+	Nonce string          `json:"nonce"`
+	URL   string          `json:"url"`
+	KID   string          `json:"kid,omitempty"`
+	JWK   json.RawMessage `json:"jwk,omitempty"`
+}
+
+// jsonWebKey is the public JWK, RFC 7517
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+// newJWK returns JWK for the public key
+func newJWK(pub crypto.PublicKey) (*jsonWebKey, error) {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return &jsonWebKey{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		return &jsonWebKey{
+			Kty: "EC",
+			Crv: key.Curve.Params().Name,
+			X:   base64.RawURLEncoding.EncodeToString(padBytes(key.X.Bytes(), size)),
+			Y:   base64.RawURLEncoding.EncodeToString(padBytes(key.Y.Bytes(), size)),
+		}, nil
+	case ed25519.PublicKey:
+		return &jsonWebKey{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}, nil
+	default:
+		return nil, errors.NotSupportedf("key type %T", pub)
+	}
+}
+
+// parseJWK returns the public key from JWK
+func parseJWK(raw []byte) (crypto.PublicKey, error) {
+	var jwk jsonWebKey
+	if err := json.Unmarshal(raw, &jwk); err != nil {
+		return nil, errors.NotValidf("JWK: %s", err.Error())
+	}
+
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil || len(n) == 0 {
+			return nil, errors.NotValidf("RSA modulus")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.NotValidf("RSA exponent")
+		}
+		key := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+		if key.N.BitLen() < 2048 {
+			return nil, errors.NotValidf("RSA key size %d", key.N.BitLen())
+		}
+		return key, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.NotSupportedf("curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, errors.NotValidf("EC point")
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, errors.NotValidf("EC point")
+		}
+		key := &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.NotValidf("EC point")
+		}
+		return key, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, errors.NotSupportedf("curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.NotValidf("Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, errors.NotSupportedf("key type %q", jwk.Kty)
+	}
+}
+
+// jwkThumbprint returns base64url encoded SHA-256 thumbprint of the key, RFC 7638
+func jwkThumbprint(pub crypto.PublicKey) (string, error) {
+	jwk, err := newJWK(pub)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// the members must be in lexicographic order, without whitespaces
+	var canonical string
+	switch jwk.Kty {
+	case "RSA":
+		canonical = fmt.Sprintf(`{"e":%q,"kty":%q,"n":%q}`, jwk.E, jwk.Kty, jwk.N)
+	case "EC":
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q,"y":%q}`, jwk.Crv, jwk.Kty, jwk.X, jwk.Y)
+	default:
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, jwk.Crv, jwk.Kty, jwk.X)
+	}
+
+	digest := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(digest[:]), nil
+}
+
+// verifyJWS verifies the signature of the signing input,
+// the algorithm must match the key type
+func verifyJWS(pub crypto.PublicKey, alg string, signingInput, signature []byte) error {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		var h crypto.Hash
+		switch alg {
+		case "RS256":
+			h = crypto.SHA256
+		case "RS384":
+			h = crypto.SHA384
+		case "RS512":
+			h = crypto.SHA512
+		default:
+			return errors.NotSupportedf("algorithm %q for RSA key", alg)
+		}
+		return errors.Trace(rsa.VerifyPKCS1v15(key, h, digest(h, signingInput), signature))
+	case *ecdsa.PublicKey:
+		var h crypto.Hash
+		switch {
+		case alg == "ES256" && key.Curve == elliptic.P256():
+			h = crypto.SHA256
+		case alg == "ES384" && key.Curve == elliptic.P384():
+			h = crypto.SHA384
+		case alg == "ES512" && key.Curve == elliptic.P521():
+			h = crypto.SHA512
+		default:
+			return errors.NotSupportedf("algorithm %q for %s key", alg, key.Curve.Params().Name)
+		}
+		// the signature is R || S, each of the curve size
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.NotValidf("signature size %d", len(signature))
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest(h, signingInput), r, s) {
+			return errors.NotValidf("signature")
+		}
+		return nil
+	case ed25519.PublicKey:
+		if alg != "EdDSA" {
+			return errors.NotSupportedf("algorithm %q for Ed25519 key", alg)
+		}
+		if !ed25519.Verify(key, signingInput, signature) {
+			return errors.NotValidf("signature")
+		}
+		return nil
+	default:
+		return errors.NotSupportedf("key type %T", pub)
+	}
+}
+
+func digest(h crypto.Hash, data []byte) []byte {
+	switch h {
+	case crypto.SHA384:
+		d := sha512.Sum384(data)
+		return d[:]
+	case crypto.SHA512:
+		d := sha512.Sum512(data)
+		return d[:]
+	default:
+		d := sha256.Sum256(data)
+		return d[:]
+	}
+}
+
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
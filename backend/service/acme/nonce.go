@@ -0,0 +1,82 @@
+package acme
+
+import (
+	"container/list"
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+const (
+	// nonceExpiry specifies how long the issued nonce is valid
+	nonceExpiry = time.Hour
+	// maxNonces specifies the max number of outstanding nonces,
+	// when reached then the oldest nonce is removed
+	maxNonces = 10000
+)
+
+// nonces provides the anti-replay nonces, RFC 8555 6.5
+type nonces struct {
+	lock   sync.Mutex
+	issued map[string]*list.Element
+	// order holds the issued nonces, the oldest in front
+	order *list.List
+}
+
+type issuedNonce struct {
+	nonce   string
+	expires time.Time
+}
+
+func newNonces() *nonces {
+	return &nonces{
+		issued: make(map[string]*list.Element),
+		order:  list.New(),
+	}
+}
+
+// New returns a new nonce
+func (n *nonces) New() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	nonce := base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now()
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	// remove the expired nonces, and the oldest ones above the limit
+	for e := n.order.Front(); e != nil; e = n.order.Front() {
+		if len(n.issued) < maxNonces && now.Before(e.Value.(*issuedNonce).expires) {
+			break
+		}
+		n.remove(e)
+	}
+
+	n.issued[nonce] = n.order.PushBack(&issuedNonce{
+		nonce:   nonce,
+		expires: now.Add(nonceExpiry),
+	})
+	return nonce
+}
+
+// Use returns true if the nonce was issued and not used yet,
+// the nonce can be used only once
+func (n *nonces) Use(nonce string) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	e, ok := n.issued[nonce]
+	if !ok {
+		return false
+	}
+	n.remove(e)
+	return time.Now().Before(e.Value.(*issuedNonce).expires)
+}
+
+func (n *nonces) remove(e *list.Element) {
+	delete(n.issued, e.Value.(*issuedNonce).nonce)
+	n.order.Remove(e)
+}
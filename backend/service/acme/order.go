@@ -0,0 +1,497 @@
+package acme
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xhttp/header"
+	"github.com/go-phorce/dolly/xhttp/identity"
+	"github.com/go-phorce/dolly/xpki/certutil"
+	v1 "github.com/go-phorce/trusty/api/v1"
+	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/juju/errors"
+)
+
+const contentTypePEMChain = "application/pem-certificate-chain"
+
+type orderRequest struct {
+	Identifiers []Identifier `json:"identifiers"`
+	NotBefore   string       `json:"notBefore"`
+	NotAfter    string       `json:"notAfter"`
+}
+
+type orderResponse struct {
+	Status         string       `json:"status"`
+	Expires        string       `json:"expires,omitempty"`
+	Identifiers    []Identifier `json:"identifiers"`
+	NotBefore      string       `json:"notBefore,omitempty"`
+	NotAfter       string       `json:"notAfter,omitempty"`
+	Error          *Problem     `json:"error,omitempty"`
+	Authorizations []string     `json:"authorizations"`
+	Finalize       string       `json:"finalize"`
+	Certificate    string       `json:"certificate,omitempty"`
+}
+
+type finalizeRequest struct {
+	CSR string `json:"csr"`
+}
+
+func (s *Service) newOrder() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ rest.Params) {
+		req, p := s.parseJWS(r, true)
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		var oreq orderRequest
+		if err := json.Unmarshal(req.payload, &oreq); err != nil {
+			writeProblem(w, malformed("invalid request: %s", err.Error()))
+			return
+		}
+
+		identifiers, p := normalizeIdentifiers(oreq.Identifiers)
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		order := &Order{
+			ID:          newID(),
+			AccountID:   req.account.ID,
+			Status:      StatusPending,
+			Expires:     time.Now().Add(s.orderExpiry()).UTC(),
+			Identifiers: identifiers,
+		}
+
+		var err error
+		if oreq.NotBefore != "" {
+			if order.NotBefore, err = time.Parse(time.RFC3339, oreq.NotBefore); err != nil {
+				writeProblem(w, malformed("invalid notBefore: %s", oreq.NotBefore))
+				return
+			}
+		}
+		if oreq.NotAfter != "" {
+			if order.NotAfter, err = time.Parse(time.RFC3339, oreq.NotAfter); err != nil {
+				writeProblem(w, malformed("invalid notAfter: %s", oreq.NotAfter))
+				return
+			}
+		}
+
+		for _, id := range identifiers {
+			authz := s.newAuthorization(req.account.ID, id, order.Expires)
+			if len(authz.Challenges) == 0 {
+				writeProblem(w, newProblem(http.StatusBadRequest, errRejectedIdentifier,
+					"no supported challenges for identifier: %s", id.Value))
+				return
+			}
+			if err = s.store.PutAuthorization(r.Context(), authz); err != nil {
+				logger.Errorf("src=newOrder, account=%s, err=[%v]", req.account.ID, errors.ErrorStack(err))
+				writeProblem(w, serverInternal("unable to create authorization"))
+				return
+			}
+			order.AuthzIDs = append(order.AuthzIDs, authz.ID)
+		}
+
+		if err = s.store.PutOrder(r.Context(), order); err != nil {
+			logger.Errorf("src=newOrder, account=%s, err=[%v]", req.account.ID, errors.ErrorStack(err))
+			writeProblem(w, serverInternal("unable to create order"))
+			return
+		}
+
+		logger.Infof("src=newOrder, account=%s, order=%s, identifiers=%v", req.account.ID, order.ID, identifiers)
+
+		w.Header().Set(header.Location, s.resourceURL(r, v1.PathForACMEOrder, order.ID))
+		writeJSON(w, http.StatusCreated, s.orderResponse(r, order))
+	}
+}
+
+func (s *Service) order() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params rest.Params) {
+		req, p := s.parseJWS(r, true)
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		order, p := s.getOrder(r.Context(), req.account, params.ByName("id"))
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, s.orderResponse(r, order))
+	}
+}
+
+func (s *Service) finalize() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params rest.Params) {
+		req, p := s.parseJWS(r, true)
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		order, p := s.getOrder(r.Context(), req.account, params.ByName("id"))
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+		if order.Status != StatusReady {
+			writeProblem(w, newProblem(http.StatusForbidden, errOrderNotReady, "order is %s", order.Status))
+			return
+		}
+
+		var freq finalizeRequest
+		if err := json.Unmarshal(req.payload, &freq); err != nil {
+			writeProblem(w, malformed("invalid request: %s", err.Error()))
+			return
+		}
+
+		der, err := base64.RawURLEncoding.DecodeString(freq.CSR)
+		if err != nil {
+			writeProblem(w, newProblem(http.StatusBadRequest, errBadCSR, "invalid CSR encoding"))
+			return
+		}
+		certReq, err := x509.ParseCertificateRequest(der)
+		if err != nil {
+			writeProblem(w, newProblem(http.StatusBadRequest, errBadCSR, "unable to parse CSR: %s", err.Error()))
+			return
+		}
+		if err = certReq.CheckSignature(); err != nil {
+			writeProblem(w, newProblem(http.StatusBadRequest, errBadCSR, "invalid CSR signature"))
+			return
+		}
+		names, p := csrNames(certReq, order.Identifiers)
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		order, p = s.issue(r, req.account, order, certReq, names)
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		w.Header().Set(header.Location, s.resourceURL(r, v1.PathForACMEOrder, order.ID))
+		writeJSON(w, http.StatusOK, s.orderResponse(r, order))
+	}
+}
+
+func (s *Service) certificate() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params rest.Params) {
+		req, p := s.parseJWS(r, true)
+		if p != nil {
+			writeProblem(w, p)
+			return
+		}
+
+		crt, err := s.store.GetCertificate(r.Context(), params.ByName("id"))
+		if err != nil || crt.AccountID != req.account.ID {
+			writeProblem(w, notFound("certificate not found"))
+			return
+		}
+
+		w.Header().Set(header.ContentType, contentTypePEMChain)
+		w.Write([]byte(crt.PEM))
+	}
+}
+
+// issue signs the certificate, and updates the order
+func (s *Service) issue(r *http.Request, acct *Account, order *Order, certReq *x509.CertificateRequest, names []string) (*Order, *Problem) {
+	ctx := r.Context()
+	profile := s.profile()
+
+	issuer, err := s.ca.GetIssuerByProfile(profile)
+	if err != nil {
+		logger.Errorf("src=issue, order=%s, profile=%s, err=[%v]", order.ID, profile, errors.ErrorStack(err))
+		return nil, serverInternal("issuer not found for profile: %s", profile)
+	}
+
+	// the attributes are validated before signing,
+	// so the signed certificate can be registered
+	mcert := &model.Certificate{
+		Subject: certReq.Subject.String(),
+		Profile: profile,
+		Role:    ServiceName,
+	}
+	if err = mcert.ValidateAttributes(); err != nil {
+		return nil, newProblem(http.StatusBadRequest, errBadCSR, "%s", err.Error())
+	}
+
+	order.Status = StatusProcessing
+	if err = s.store.PutOrder(ctx, order); err != nil {
+		logger.Errorf("src=issue, order=%s, err=[%v]", order.ID, errors.ErrorStack(err))
+		return nil, serverInternal("unable to update order")
+	}
+
+	sreq := csr.SignRequest{
+		Request:   string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: certReq.Raw})),
+		Profile:   profile,
+		SAN:       names,
+		NotBefore: order.NotBefore,
+		NotAfter:  order.NotAfter,
+	}
+
+	cert, certPEM, err := issuer.SignContext(ctx, sreq)
+	if err != nil {
+		logger.Errorf("src=issue, order=%s, issuer=%s, profile=%s, err=[%v]",
+			order.ID, issuer.Label(), profile, errors.ErrorStack(err))
+
+		var p *Problem
+		if errors.IsBadRequest(err) || errors.IsForbidden(err) || errors.IsNotValid(err) {
+			p = newProblem(http.StatusBadRequest, errBadCSR, "%s", err.Error())
+		} else {
+			p = serverInternal("unable to sign certificate")
+		}
+
+		order.Status = StatusInvalid
+		order.Error = p
+		if err = s.store.PutOrder(ctx, order); err != nil {
+			logger.Errorf("src=issue, order=%s, err=[%v]", order.ID, errors.ErrorStack(err))
+		}
+		return nil, p
+	}
+
+	mcert.SKID = certutil.GetSubjectKeyID(cert)
+	mcert.IKID = certutil.GetAuthorityKeyID(cert)
+	mcert.SerialNumber = cert.SerialNumber.String()
+	mcert.NotBefore = cert.NotBefore.UTC()
+	mcert.NotAfter = cert.NotAfter.UTC()
+	mcert.Subject = cert.Subject.String()
+	mcert.Pem = string(certPEM)
+
+	registered, err := s.db.CreateCertificate(ctx, mcert)
+	if err != nil {
+		// the signed certificate is valid, and must not be lost
+		logger.Errorf("src=issue, reason=db, order=%s, serial=%s, pem=%q, err=[%v]",
+			order.ID, mcert.SerialNumber, mcert.Pem, errors.ErrorStack(err))
+		s.server.Audit(
+			trustyserver.EvtSourceCA,
+			trustyserver.EvtCertificateNotRegistered,
+			"acme:"+acct.ID,
+			identity.ForRequest(r).CorrelationID(),
+			0,
+			fmt.Sprintf("issuer=%s, profile=%s, serial=%s, skid=%s, ikid=%s, subject=%q, order=%s, err=%q",
+				issuer.Label(),
+				profile,
+				mcert.SerialNumber,
+				mcert.SKID,
+				mcert.IKID,
+				mcert.Subject,
+				order.ID,
+				err.Error(),
+			),
+		)
+
+		p := serverInternal("failed to register certificate")
+		order.Status = StatusInvalid
+		order.Error = p
+		if err = s.store.PutOrder(ctx, order); err != nil {
+			logger.Errorf("src=issue, order=%s, err=[%v]", order.ID, errors.ErrorStack(err))
+		}
+		return nil, p
+	}
+	mcert = registered
+
+	s.server.Audit(
+		trustyserver.EvtSourceCA,
+		trustyserver.EvtCertificateIssued,
+		"acme:"+acct.ID,
+		identity.ForRequest(r).CorrelationID(),
+		0,
+		fmt.Sprintf("id=%d, issuer=%s, profile=%s, serial=%s, skid=%s, ikid=%s, subject=%q, order=%s, notBefore=%s, notAfter=%s",
+			mcert.ID,
+			issuer.Label(),
+			profile,
+			mcert.SerialNumber,
+			mcert.SKID,
+			mcert.IKID,
+			mcert.Subject,
+			order.ID,
+			mcert.NotBefore.Format(time.RFC3339),
+			mcert.NotAfter.Format(time.RFC3339),
+		),
+	)
+
+	crt := &Certificate{
+		ID:        newID(),
+		AccountID: acct.ID,
+		OrderID:   order.ID,
+		PEM:       strings.TrimSpace(string(certPEM)) + "\n" + issuer.PEM() + "\n",
+	}
+	if err = s.store.PutCertificate(ctx, crt); err != nil {
+		logger.Errorf("src=issue, order=%s, err=[%v]", order.ID, errors.ErrorStack(err))
+		return nil, serverInternal("unable to store certificate")
+	}
+
+	order.Status = StatusValid
+	order.CertificateID = crt.ID
+	if err = s.store.PutOrder(ctx, order); err != nil {
+		logger.Errorf("src=issue, order=%s, err=[%v]", order.ID, errors.ErrorStack(err))
+		return nil, serverInternal("unable to update order")
+	}
+	return order, nil
+}
+
+// getOrder returns the order of the account, with updated status
+func (s *Service) getOrder(ctx context.Context, acct *Account, id string) (*Order, *Problem) {
+	order, err := s.store.GetOrder(ctx, id)
+	if err != nil || order.AccountID != acct.ID {
+		return nil, notFound("order not found")
+	}
+
+	if order.Status != StatusPending && order.Status != StatusReady {
+		return order, nil
+	}
+
+	status := StatusReady
+	if time.Now().After(order.Expires) {
+		status = StatusInvalid
+	} else {
+		for _, authzID := range order.AuthzIDs {
+			authz, p := s.getAuthorization(ctx, acct, authzID)
+			if p != nil {
+				return nil, p
+			}
+			if authz.Status == StatusPending {
+				status = StatusPending
+			} else if authz.Status != StatusValid {
+				status = StatusInvalid
+				break
+			}
+		}
+	}
+
+	if status != order.Status {
+		order.Status = status
+		if err = s.store.PutOrder(ctx, order); err != nil {
+			logger.Errorf("src=getOrder, order=%s, err=[%v]", order.ID, errors.ErrorStack(err))
+			return nil, serverInternal("unable to update order")
+		}
+	}
+	return order, nil
+}
+
+func (s *Service) orderResponse(r *http.Request, order *Order) *orderResponse {
+	res := &orderResponse{
+		Status:         order.Status,
+		Identifiers:    order.Identifiers,
+		Error:          order.Error,
+		Authorizations: []string{},
+		Finalize:       s.resourceURL(r, v1.PathForACMEOrderFinalize, order.ID),
+	}
+	if !order.Expires.IsZero() {
+		res.Expires = order.Expires.Format(time.RFC3339)
+	}
+	if !order.NotBefore.IsZero() {
+		res.NotBefore = order.NotBefore.Format(time.RFC3339)
+	}
+	if !order.NotAfter.IsZero() {
+		res.NotAfter = order.NotAfter.Format(time.RFC3339)
+	}
+	for _, id := range order.AuthzIDs {
+		res.Authorizations = append(res.Authorizations, s.resourceURL(r, v1.PathForACMEAuthz, id))
+	}
+	if order.CertificateID != "" {
+		res.Certificate = s.resourceURL(r, v1.PathForACMECertificate, order.CertificateID)
+	}
+	return res
+}
+
+// normalizeIdentifiers validates DNS identifiers,
+// and returns sorted list of unique identifiers in lower case
+func normalizeIdentifiers(list []Identifier) ([]Identifier, *Problem) {
+	if len(list) == 0 {
+		return nil, malformed("identifiers are required")
+	}
+
+	unique := map[string]bool{}
+	var identifiers []Identifier
+	for _, id := range list {
+		if id.Type != "dns" {
+			return nil, newProblem(http.StatusBadRequest, errUnsupportedIdentifier, "unsupported identifier type: %s", id.Type)
+		}
+		value := strings.TrimSuffix(strings.ToLower(id.Value), ".")
+		if !isValidDomain(strings.TrimPrefix(value, "*.")) {
+			return nil, newProblem(http.StatusBadRequest, errRejectedIdentifier, "invalid DNS identifier: %q", id.Value)
+		}
+		if !unique[value] {
+			unique[value] = true
+			identifiers = append(identifiers, Identifier{Type: "dns", Value: value})
+		}
+	}
+
+	sort.Slice(identifiers, func(i, j int) bool {
+		return identifiers[i].Value < identifiers[j].Value
+	})
+	return identifiers, nil
+}
+
+// csrNames returns DNS names from CSR, that must match the order identifiers,
+// the Common Name, if present, must be one of the identifiers
+func csrNames(certReq *x509.CertificateRequest, identifiers []Identifier) ([]string, *Problem) {
+	if len(certReq.IPAddresses) > 0 || len(certReq.EmailAddresses) > 0 || len(certReq.URIs) > 0 {
+		return nil, newProblem(http.StatusBadRequest, errBadCSR, "CSR may contain only DNS names")
+	}
+
+	expected := map[string]bool{}
+	for _, id := range identifiers {
+		expected[id.Value] = true
+	}
+
+	requested := map[string]bool{}
+	var names []string
+	for _, name := range certReq.DNSNames {
+		name = strings.ToLower(name)
+		if !expected[name] {
+			return nil, newProblem(http.StatusBadRequest, errBadCSR, "CSR contains name not in the order: %s", name)
+		}
+		if !requested[name] {
+			requested[name] = true
+			names = append(names, name)
+		}
+	}
+	if cn := strings.ToLower(certReq.Subject.CommonName); cn != "" && !expected[cn] {
+		return nil, newProblem(http.StatusBadRequest, errBadCSR, "CSR Common Name is not in the order: %s", cn)
+	}
+	if len(requested) != len(expected) {
+		return nil, newProblem(http.StatusBadRequest, errBadCSR, "CSR names do not match the order identifiers")
+	}
+	return names, nil
+}
+
+// isValidDomain returns true if the name is valid DNS name
+// with at least two labels
+func isValidDomain(name string) bool {
+	if len(name) == 0 || len(name) > 253 {
+		return false
+	}
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
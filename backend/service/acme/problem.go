@@ -0,0 +1,71 @@
+package acme
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-phorce/dolly/xhttp/header"
+)
+
+// ACME error types, RFC 8555 6.7
+const (
+	errAccountDoesNotExist   = "urn:ietf:params:acme:error:accountDoesNotExist"
+	errBadCSR                = "urn:ietf:params:acme:error:badCSR"
+	errBadNonce              = "urn:ietf:params:acme:error:badNonce"
+	errBadSignatureAlgorithm = "urn:ietf:params:acme:error:badSignatureAlgorithm"
+	errConnection            = "urn:ietf:params:acme:error:connection"
+	errDNS                   = "urn:ietf:params:acme:error:dns"
+	errIncorrectResponse     = "urn:ietf:params:acme:error:incorrectResponse"
+	errInvalidContact        = "urn:ietf:params:acme:error:invalidContact"
+	errMalformed             = "urn:ietf:params:acme:error:malformed"
+	errOrderNotReady         = "urn:ietf:params:acme:error:orderNotReady"
+	errRejectedIdentifier    = "urn:ietf:params:acme:error:rejectedIdentifier"
+	errServerInternal        = "urn:ietf:params:acme:error:serverInternal"
+	errUnauthorized          = "urn:ietf:params:acme:error:unauthorized"
+	errUnsupportedIdentifier = "urn:ietf:params:acme:error:unsupportedIdentifier"
+)
+
+const contentTypeProblem = "application/problem+json"
+
+// Problem is ACME error document, RFC 7807
+type Problem struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail,omitempty"`
+	Status int    `json:"status,omitempty"`
+}
+
+// Error implements error interface
+func (p *Problem) Error() string {
+	return fmt.Sprintf("%s: %s", p.Type, p.Detail)
+}
+
+func newProblem(status int, typ string, format string, args ...interface{}) *Problem {
+	return &Problem{
+		Type:   typ,
+		Detail: fmt.Sprintf(format, args...),
+		Status: status,
+	}
+}
+
+func malformed(format string, args ...interface{}) *Problem {
+	return newProblem(http.StatusBadRequest, errMalformed, format, args...)
+}
+
+func unauthorized(format string, args ...interface{}) *Problem {
+	return newProblem(http.StatusForbidden, errUnauthorized, format, args...)
+}
+
+func notFound(format string, args ...interface{}) *Problem {
+	return newProblem(http.StatusNotFound, errMalformed, format, args...)
+}
+
+func serverInternal(format string, args ...interface{}) *Problem {
+	return newProblem(http.StatusInternalServerError, errServerInternal, format, args...)
+}
+
+func writeProblem(w http.ResponseWriter, p *Problem) {
+	w.Header().Set(header.ContentType, contentTypeProblem)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
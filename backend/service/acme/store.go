@@ -0,0 +1,239 @@
+package acme
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// ACME object statuses, RFC 8555 7.1.6
+const (
+	StatusPending     = "pending"
+	StatusProcessing  = "processing"
+	StatusReady       = "ready"
+	StatusValid       = "valid"
+	StatusInvalid     = "invalid"
+	StatusExpired     = "expired"
+	StatusDeactivated = "deactivated"
+)
+
+// Account is ACME account
+type Account struct {
+	ID         string
+	Thumbprint string
+	KeyJWK     []byte
+	Status     string
+	Contact    []string
+	CreatedAt  time.Time
+}
+
+// Identifier is ACME identifier
+type Identifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Order is ACME order
+type Order struct {
+	ID            string
+	AccountID     string
+	Status        string
+	Expires       time.Time
+	Identifiers   []Identifier
+	NotBefore     time.Time
+	NotAfter      time.Time
+	AuthzIDs      []string
+	CertificateID string
+	Error         *Problem
+}
+
+// Authorization is ACME authorization
+type Authorization struct {
+	ID         string
+	AccountID  string
+	Identifier Identifier
+	Status     string
+	Expires    time.Time
+	Wildcard   bool
+	Challenges []*Challenge
+}
+
+// Challenge is ACME challenge
+type Challenge struct {
+	Type      string
+	Token     string
+	Status    string
+	Validated time.Time
+	Error     *Problem
+}
+
+// Certificate is the issued certificate chain
+type Certificate struct {
+	ID        string
+	AccountID string
+	OrderID   string
+	PEM       string
+}
+
+// Store provides persistence of ACME objects
+type Store interface {
+	// PutAccount creates or updates the account
+	PutAccount(ctx context.Context, acct *Account) error
+	// GetAccount returns the account by ID
+	GetAccount(ctx context.Context, id string) (*Account, error)
+	// GetAccountByThumbprint returns the account by the key thumbprint
+	GetAccountByThumbprint(ctx context.Context, thumbprint string) (*Account, error)
+	// PutOrder creates or updates the order
+	PutOrder(ctx context.Context, order *Order) error
+	// GetOrder returns the order by ID
+	GetOrder(ctx context.Context, id string) (*Order, error)
+	// ListOrders returns the orders of the account
+	ListOrders(ctx context.Context, accountID string) ([]*Order, error)
+	// PutAuthorization creates or updates the authorization
+	PutAuthorization(ctx context.Context, authz *Authorization) error
+	// GetAuthorization returns the authorization by ID
+	GetAuthorization(ctx context.Context, id string) (*Authorization, error)
+	// PutCertificate stores the issued certificate
+	PutCertificate(ctx context.Context, crt *Certificate) error
+	// GetCertificate returns the issued certificate by ID
+	GetCertificate(ctx context.Context, id string) (*Certificate, error)
+}
+
+// NewMemoryStore returns in-memory Store,
+// the objects are lost on restart
+func NewMemoryStore() Store {
+	return &memStore{
+		accounts: make(map[string]*Account),
+		orders:   make(map[string]*Order),
+		authzs:   make(map[string]*Authorization),
+		certs:    make(map[string]*Certificate),
+	}
+}
+
+type memStore struct {
+	lock     sync.RWMutex
+	accounts map[string]*Account
+	orders   map[string]*Order
+	authzs   map[string]*Authorization
+	certs    map[string]*Certificate
+}
+
+func (m *memStore) PutAccount(_ context.Context, acct *Account) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	c := *acct
+	m.accounts[acct.ID] = &c
+	return nil
+}
+
+func (m *memStore) GetAccount(_ context.Context, id string) (*Account, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	acct, ok := m.accounts[id]
+	if !ok {
+		return nil, errors.NotFoundf("account %q", id)
+	}
+	c := *acct
+	return &c, nil
+}
+
+func (m *memStore) GetAccountByThumbprint(_ context.Context, thumbprint string) (*Account, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	for _, acct := range m.accounts {
+		if acct.Thumbprint == thumbprint {
+			c := *acct
+			return &c, nil
+		}
+	}
+	return nil, errors.NotFoundf("account with key %q", thumbprint)
+}
+
+func (m *memStore) PutOrder(_ context.Context, order *Order) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	c := *order
+	m.orders[order.ID] = &c
+	return nil
+}
+
+func (m *memStore) GetOrder(_ context.Context, id string) (*Order, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	order, ok := m.orders[id]
+	if !ok {
+		return nil, errors.NotFoundf("order %q", id)
+	}
+	c := *order
+	return &c, nil
+}
+
+func (m *memStore) ListOrders(_ context.Context, accountID string) ([]*Order, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var list []*Order
+	for _, order := range m.orders {
+		if order.AccountID == accountID {
+			c := *order
+			list = append(list, &c)
+		}
+	}
+	return list, nil
+}
+
+func (m *memStore) PutAuthorization(_ context.Context, authz *Authorization) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.authzs[authz.ID] = copyAuthorization(authz)
+	return nil
+}
+
+func (m *memStore) GetAuthorization(_ context.Context, id string) (*Authorization, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	authz, ok := m.authzs[id]
+	if !ok {
+		return nil, errors.NotFoundf("authorization %q", id)
+	}
+	return copyAuthorization(authz), nil
+}
+
+func (m *memStore) PutCertificate(_ context.Context, crt *Certificate) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	c := *crt
+	m.certs[crt.ID] = &c
+	return nil
+}
+
+func (m *memStore) GetCertificate(_ context.Context, id string) (*Certificate, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	crt, ok := m.certs[id]
+	if !ok {
+		return nil, errors.NotFoundf("certificate %q", id)
+	}
+	c := *crt
+	return &c, nil
+}
+
+func copyAuthorization(authz *Authorization) *Authorization {
+	c := *authz
+	c.Challenges = make([]*Challenge, len(authz.Challenges))
+	for i, ch := range authz.Challenges {
+		chc := *ch
+		c.Challenges[i] = &chc
+	}
+	return &c
+}
+
+// newID returns a random ID
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
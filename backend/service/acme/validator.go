@@ -0,0 +1,224 @@
+package acme
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Supported challenge types
+const (
+	ChallengeHTTP01 = "http-01"
+	ChallengeDNS01  = "dns-01"
+)
+
+// Validator validates the challenge response.
+// The returned error must be errors.NotValid if the response is incorrect,
+// any other error is treated as a failure to retrieve the response.
+type Validator interface {
+	// Validate returns an error if the key authorization
+	// is not provisioned for the domain
+	Validate(ctx context.Context, domain, token, keyAuthorization string) error
+}
+
+// TXTResolver provides the lookup of DNS TXT records,
+// net.Resolver implements the interface
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// maxHTTP01Redirects specifies the max number of redirects
+// followed by http-01 validation
+const maxHTTP01Redirects = 3
+
+// HTTP01Validator validates http-01 challenge, RFC 8555 8.3
+type HTTP01Validator struct {
+	// Client is HTTP client used to fetch the key authorization,
+	// if not provided then the client with 10s timeout is used,
+	// that connects only to public addresses and follows up to 3 redirects
+	Client *http.Client
+	// AllowedNetworks specifies the networks, that the default client
+	// is allowed to connect to in addition to public addresses
+	AllowedNetworks []*net.IPNet
+}
+
+// newHTTP01Client returns HTTP client, that refuses to connect to
+// non-public addresses outside of the allowed networks, as the domain
+// in the challenge is controlled by the ACME client,
+// and may resolve to the internal network
+func newHTTP01Client(allowed []*net.IPNet) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if ip := net.ParseIP(host); ip == nil || !(isPublicIP(ip) || containsIP(allowed, ip)) {
+				return errors.Errorf("connection to non-public address is not allowed: %s", host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   5 * time.Second,
+			ResponseHeaderTimeout: 5 * time.Second,
+			DisableKeepAlives:     true,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxHTTP01Redirects {
+				return errors.Errorf("stopped after %d redirects", maxHTTP01Redirects)
+			}
+			return nil
+		},
+	}
+}
+
+// privateNetworks are RFC 1918, RFC 6598 shared, and RFC 4193 unique local addresses
+var privateNetworks = parseCIDRs(
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"100.64.0.0/10",
+	"fc00::/7",
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	list, err := ParseNetworks(cidrs)
+	if err != nil {
+		panic(err)
+	}
+	return list
+}
+
+// ParseNetworks returns the list of networks in CIDR format
+func ParseNetworks(cidrs []string) ([]*net.IPNet, error) {
+	list := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid network %q", cidr)
+		}
+		list[i] = n
+	}
+	return list, nil
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, n := range networks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPublicIP returns false for loopback, private, link-local,
+// multicast and unspecified addresses
+func isPublicIP(ip net.IP) bool {
+	return !(containsIP(privateNetworks, ip) ||
+		ip.IsLoopback() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified())
+}
+
+// Validate fetches the key authorization from
+// http://{domain}/.well-known/acme-challenge/{token}
+func (v *HTTP01Validator) Validate(ctx context.Context, domain, token, keyAuthorization string) error {
+	client := v.Client
+	if client == nil {
+		client = newHTTP01Client(v.AllowedNetworks)
+	}
+
+	u := fmt.Sprintf("http://%s/.well-known/acme-challenge/%s", domain, token)
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Annotatef(err, "unable to fetch %s", u)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status code from %s: %d", u, res.StatusCode)
+	}
+
+	// the key authorization is short, limit the response size
+	body, err := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+	if err != nil {
+		return errors.Annotatef(err, "unable to read response from %s", u)
+	}
+
+	if got := strings.TrimSpace(string(body)); got != keyAuthorization {
+		return errors.NotValidf("key authorization %q", got)
+	}
+	return nil
+}
+
+// DNS01Validator validates dns-01 challenge, RFC 8555 8.4
+type DNS01Validator struct {
+	// Resolver is used to lookup TXT records,
+	// if not provided then net.DefaultResolver is used
+	Resolver TXTResolver
+}
+
+// Validate looks up TXT records of _acme-challenge.{domain},
+// one of them must be the digest of the key authorization
+func (v *DNS01Validator) Validate(ctx context.Context, domain, token, keyAuthorization string) error {
+	resolver := v.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	name := "_acme-challenge." + domain
+	records, err := resolver.LookupTXT(ctx, name)
+	if err != nil {
+		return errors.Annotatef(err, "unable to lookup TXT records for %s", name)
+	}
+
+	digest := sha256.Sum256([]byte(keyAuthorization))
+	expected := base64.RawURLEncoding.EncodeToString(digest[:])
+	for _, r := range records {
+		if r == expected {
+			return nil
+		}
+	}
+	return errors.NotValidf("TXT records for %s", name)
+}
+
+// NewResolver returns TXTResolver that uses the DNS server at
+// the address in host:port format
+func NewResolver(address string) TXTResolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{Timeout: 5 * time.Second}
+			return d.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// keyAuthorization returns the key authorization for the token, RFC 8555 8.1
+func keyAuthorization(token, thumbprint string) string {
+	return token + "." + thumbprint
+}
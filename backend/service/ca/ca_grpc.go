@@ -2,19 +2,235 @@ package ca
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/go-phorce/dolly/xhttp/identity"
+	"github.com/go-phorce/dolly/xpki/certutil"
 	pb "github.com/go-phorce/trusty/api/v1/trustypb"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/go-phorce/trusty/pkg/roles"
 	"github.com/juju/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ProfileInfo returns the certificate profile info
-func (s *Service) ProfileInfo(context.Context, *pb.CertProfileInfoRequest) (*pb.CertProfileInfo, error) {
-	return nil, errors.Errorf("not implemented")
+func (s *Service) ProfileInfo(ctx context.Context, req *pb.CertProfileInfoRequest) (*pb.CertProfileInfo, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing request")
+	}
+
+	issuer, err := s.getIssuer(req.Label, req.Profile)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	profileName := req.Profile
+	if profileName == "" {
+		profileName = "default"
+	}
+
+	return profileInfo(issuer.Label(), profileName, issuer.Profile(profileName)), nil
 }
 
 // CreateCertificate returns the certificate
-func (s *Service) CreateCertificate(context.Context, *pb.CreateCertificateRequest) (*pb.CertificateBundle, error) {
-	return nil, errors.Errorf("not implemented")
+func (s *Service) CreateCertificate(ctx context.Context, req *pb.CreateCertificateRequest) (*pb.CertificateBundle, error) {
+	if req == nil || req.Request == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing request")
+	}
+	if req.RequestFormat != pb.EncodingFormat_PEM {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported request format: %s", req.RequestFormat.String())
+	}
+
+	// the caller without identity is a guest
+	var callerID, contextID string
+	o := owner{role: identity.GuestRoleName}
+	if callerCtx := identity.FromContext(ctx); callerCtx != nil {
+		caller := callerCtx.Identity()
+		callerID = caller.String()
+		contextID = callerCtx.CorrelationID()
+		o = ownerFromIdentity(caller)
+	}
+
+	// the token binds the request to its profile and issuer
+	profileName, issuerLabel := req.Profile, req.IssuerLabel
+	var token *model.EnrollmentToken
+	if req.Token != "" {
+		var err error
+		token, err = s.checkEnrollmentToken(ctx, req)
+		if err != nil {
+			logger.Errorf("src=CreateCertificate, reason=token, profile=%s, err=[%v]",
+				req.Profile, errors.ErrorStack(err))
+			return nil, grpcError(err)
+		}
+		profileName = token.Profile
+		if token.IssuerLabel != "" {
+			issuerLabel = token.IssuerLabel
+		}
+	} else if o.role == "" || o.role == identity.GuestRoleName {
+		return nil, status.Error(codes.PermissionDenied, "enrollment token is required")
+	}
+
+	issuer, err := s.getIssuer(issuerLabel, profileName)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	if profile := issuer.Profile(profileName); profile != nil && profile.RejectSharedKeys {
+		err = s.checkSharedKey(ctx, o, req.Request)
+		if err != nil {
+			logger.Errorf("src=CreateCertificate, reason=shared_key, issuer=%s, profile=%s, err=[%v]",
+				issuer.Label(), profileName, errors.ErrorStack(err))
+			return nil, grpcError(err)
+		}
+	}
+
+	// the attributes are validated before signing,
+	// so the signed certificate can be registered
+	creq, err := parseRequest(req.Request)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	mcert := &model.Certificate{
+		OwnerID: o.id,
+		Subject: creq.Subject.String(),
+		Profile: profileName,
+		Role:    o.role,
+		Host:    o.name,
+	}
+	if err = mcert.ValidateAttributes(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid certificate request: %s", err.Error())
+	}
+
+	sreq := csr.SignRequest{
+		Request: req.Request,
+		Profile: profileName,
+	}
+	if req.NotBefore > 0 {
+		sreq.NotBefore = time.Unix(req.NotBefore, 0).UTC()
+	}
+	if req.NotAfter > 0 {
+		sreq.NotAfter = time.Unix(req.NotAfter, 0).UTC()
+	}
+
+	if req.DryRun {
+		tbs, findings, err := issuer.PreviewContext(ctx, sreq)
+		if err != nil {
+			logger.Errorf("src=CreateCertificate, reason=dry_run, issuer=%s, profile=%s, err=[%v]",
+				issuer.Label(), profileName, errors.ErrorStack(err))
+			return nil, grpcError(err)
+		}
+		logger.Infof("src=CreateCertificate, reason=dry_run, issuer=%s, profile=%s, subject=%q",
+			issuer.Label(), profileName, tbs.Subject.String())
+
+		return &pb.CertificateBundle{
+			NotBefore: tbs.NotBefore.Unix(),
+			NotAfter:  tbs.NotAfter.Unix(),
+			Preview:   certificatePreview(tbs, findings),
+		}, nil
+	}
+
+	// the token is consumed before signing, to be used only once,
+	// and released if the certificate is not issued
+	if token != nil {
+		token, err = s.useEnrollmentToken(ctx, token)
+		if err != nil {
+			logger.Errorf("src=CreateCertificate, reason=token, profile=%s, err=[%v]",
+				profileName, errors.ErrorStack(err))
+			return nil, grpcError(err)
+		}
+	}
+
+	cert, certPEM, err := issuer.SignContext(ctx, sreq)
+	if err != nil {
+		logger.Errorf("src=CreateCertificate, issuer=%s, profile=%s, err=[%v]",
+			issuer.Label(), profileName, errors.ErrorStack(err))
+		if token != nil {
+			s.releaseEnrollmentToken(ctx, token)
+		}
+		return nil, grpcError(err)
+	}
+
+	mcert.SKID = certutil.GetSubjectKeyID(cert)
+	mcert.IKID = certutil.GetAuthorityKeyID(cert)
+	mcert.SerialNumber = cert.SerialNumber.String()
+	mcert.NotBefore = cert.NotBefore.UTC()
+	mcert.NotAfter = cert.NotAfter.UTC()
+	mcert.Subject = cert.Subject.String()
+	mcert.Pem = string(certPEM)
+
+	registered, err := s.db.CreateCertificate(ctx, mcert)
+	if err != nil {
+		// the signed certificate is valid, and must not be lost
+		logger.Errorf("src=CreateCertificate, reason=db, issuer=%s, serial=%s, pem=%q, err=[%v]",
+			issuer.Label(), mcert.SerialNumber, mcert.Pem, errors.ErrorStack(err))
+		s.server.Audit(
+			trustyserver.EvtSourceCA,
+			trustyserver.EvtCertificateNotRegistered,
+			callerID,
+			contextID,
+			0,
+			fmt.Sprintf("issuer=%s, profile=%s, serial=%s, skid=%s, ikid=%s, subject=%q, err=%q",
+				issuer.Label(),
+				profileName,
+				mcert.SerialNumber,
+				mcert.SKID,
+				mcert.IKID,
+				mcert.Subject,
+				err.Error()),
+		)
+		return nil, status.Errorf(codes.Internal, "failed to register certificate: %s", err.Error())
+	}
+	mcert = registered
+
+	details := fmt.Sprintf("id=%d, issuer=%s, profile=%s, serial=%s, skid=%s, ikid=%s, subject=%q, notBefore=%s, notAfter=%s",
+		mcert.ID,
+		issuer.Label(),
+		profileName,
+		mcert.SerialNumber,
+		mcert.SKID,
+		mcert.IKID,
+		mcert.Subject,
+		mcert.NotBefore.Format(time.RFC3339),
+		mcert.NotAfter.Format(time.RFC3339))
+	if token != nil {
+		details += fmt.Sprintf(", token=%d", token.ID)
+	}
+
+	s.server.Audit(
+		trustyserver.EvtSourceCA,
+		trustyserver.EvtCertificateIssued,
+		callerID,
+		contextID,
+		0,
+		details,
+	)
+
+	res := &pb.CertificateBundle{
+		Certificate: string(certPEM),
+		NotBefore:   cert.NotBefore.Unix(),
+		NotAfter:    cert.NotAfter.Unix(),
+	}
+	if req.WithBundle {
+		bundle := issuer.Bundle()
+		res.Intermediates = bundle.CACertsPEM
+		res.Root = bundle.RootCertPEM
+	}
+
+	return res, nil
+}
+
+// EnrollCertificate returns the certificate, authorized by the enrollment token.
+// The method allows the callers without identity to bootstrap their first certificate
+func (s *Service) EnrollCertificate(ctx context.Context, req *pb.CreateCertificateRequest) (*pb.CertificateBundle, error) {
+	if req == nil || req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "enrollment token must be provided")
+	}
+	return s.CreateCertificate(ctx, req)
 }
 
 // Issuers returns the issuing CAs
@@ -37,3 +253,243 @@ func (s *Service) Issuers(context.Context, *pb.EmptyRequest) (*pb.IssuersInfoRes
 
 	return res, nil
 }
+
+// RevokeCertificate returns the revoked certificate.
+// When Skid is provided, all active certificates with the key are revoked,
+// and the most recent one is returned.
+func (s *Service) RevokeCertificate(ctx context.Context, req *pb.RevokeCertificateRequest) (*pb.RevokedCertificate, error) {
+	if req == nil || (req.Skid == "" && (req.Ikid == "" || req.SerialNumber == "")) {
+		return nil, status.Error(codes.InvalidArgument, "either skid, or ikid and serial_number must be provided")
+	}
+	if _, ok := pb.Reason_name[int32(req.Reason)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid reason: %d", req.Reason)
+	}
+
+	callerCtx := identity.FromContext(ctx)
+	if callerCtx == nil {
+		return nil, status.Error(codes.PermissionDenied, "the caller is not authenticated")
+	}
+	caller := callerCtx.Identity()
+	callerID := caller.String()
+	contextID := callerCtx.CorrelationID()
+	requestor := caller.Name()
+
+	var list model.Certificates
+	if req.Skid != "" {
+		var err error
+		list, err = s.db.ListCertificatesBySKID(ctx, req.Skid, time.Now().UTC())
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		if len(list) == 0 {
+			return nil, grpcError(errors.NotFoundf("certificate with skid %s", req.Skid))
+		}
+	} else {
+		crt, err := s.db.GetCertificate(ctx, req.Ikid, req.SerialNumber)
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		list = model.Certificates{crt}
+	}
+
+	// only admins can revoke certificates of other owners
+	if caller.Role() != roles.TrustyAdmin {
+		o := ownerFromIdentity(caller)
+		for _, crt := range list {
+			if !o.owns(crt) {
+				return nil, status.Error(codes.PermissionDenied, "the certificate is not owned by the caller")
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	toRevoke := make(model.RevokedCertificates, len(list))
+	for i, crt := range list {
+		toRevoke[i] = &model.RevokedCertificate{
+			Certificate: *crt,
+			RevokedAt:   now,
+			Reason:      int(req.Reason),
+			Requestor:   requestor,
+		}
+	}
+
+	revokedList, err := s.db.RevokeCertificates(ctx, toRevoke)
+	if err != nil {
+		logger.Errorf("src=RevokeCertificate, reason=db, skid=%s, ikid=%s, serial=%s, err=[%v]",
+			req.Skid, req.Ikid, req.SerialNumber, errors.ErrorStack(err))
+		if errors.IsNotFound(err) {
+			return nil, grpcError(err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke certificate: %s", err.Error())
+	}
+
+	var res *model.RevokedCertificate
+	for _, revoked := range revokedList {
+		s.server.Audit(
+			trustyserver.EvtSourceCA,
+			trustyserver.EvtCertificateRevoked,
+			callerID,
+			contextID,
+			0,
+			fmt.Sprintf("id=%d, serial=%s, skid=%s, ikid=%s, subject=%q, reason=%s",
+				revoked.Certificate.ID,
+				revoked.Certificate.SerialNumber,
+				revoked.Certificate.SKID,
+				revoked.Certificate.IKID,
+				revoked.Certificate.Subject,
+				req.Reason.String()),
+		)
+		if res == nil || revoked.Certificate.NotBefore.After(res.Certificate.NotBefore) {
+			res = revoked
+		}
+	}
+
+	// block the key only after the certificates are revoked
+	if req.Reason == pb.Reason_KEY_COMPROMISE {
+		err = s.blockCertificateKey(ctx, &res.Certificate, callerID, contextID, requestor)
+		if err != nil {
+			logger.Errorf("src=RevokeCertificate, reason=block_key, ikid=%s, serial=%s, err=[%v]",
+				res.Certificate.IKID, res.Certificate.SerialNumber, errors.ErrorStack(err))
+			return nil, status.Errorf(codes.Internal, "failed to block key: %s", err.Error())
+		}
+	}
+
+	return res.ToDto(), nil
+}
+
+// BlockKey adds the public key to the list of keys, that are not allowed to be certified
+func (s *Service) BlockKey(ctx context.Context, req *pb.BlockKeyRequest) (*pb.BlockedKey, error) {
+	if req == nil || (req.SpkiHash == "" && req.Pem == "") {
+		return nil, status.Error(codes.InvalidArgument, "either spki_hash or pem must be provided")
+	}
+	if _, ok := pb.Reason_name[int32(req.Reason)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid reason: %d", req.Reason)
+	}
+
+	hash, err := spkiHashFromRequest(req)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	var callerID, contextID, requestor string
+	if callerCtx := identity.FromContext(ctx); callerCtx != nil {
+		caller := callerCtx.Identity()
+		callerID = caller.String()
+		contextID = callerCtx.CorrelationID()
+		requestor = caller.Name()
+	}
+
+	key, err := s.blockKey(ctx, hash, req.Reason, callerID, contextID, requestor)
+	if err != nil {
+		logger.Errorf("src=BlockKey, reason=db, spki=%s, err=[%v]", hash, errors.ErrorStack(err))
+		return nil, status.Errorf(codes.Internal, "failed to block key: %s", err.Error())
+	}
+
+	return key.ToDto(), nil
+}
+
+// getIssuer returns the issuer by label if provided,
+// otherwise the issuer that serves the requested profile
+func (s *Service) getIssuer(label, profile string) (*authority.Issuer, error) {
+	if label == "" {
+		issuer, err := s.ca.GetIssuerByProfile(profile)
+		if err != nil {
+			return nil, errors.NewNotFound(err, "")
+		}
+		return issuer, nil
+	}
+
+	issuer, err := s.ca.GetIssuerByLabel(label)
+	if err != nil {
+		return nil, errors.NewNotFound(err, "")
+	}
+	if issuer.Profile(profile) == nil {
+		return nil, errors.BadRequestf("issuer %s does not support profile: %s", label, profile)
+	}
+	if other, err := s.ca.GetIssuerByProfile(profile); err == nil && other != issuer {
+		return nil, errors.Forbiddenf("profile %s is served by %s issuer", profile, other.Label())
+	}
+	return issuer, nil
+}
+
+// grpcError maps the error to gRPC status
+func grpcError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Internal
+	switch {
+	case errors.IsNotFound(err):
+		code = codes.NotFound
+	case errors.IsBadRequest(err), errors.IsNotValid(err), errors.IsNotSupported(err):
+		code = codes.InvalidArgument
+	case errors.IsForbidden(err), errors.IsUnauthorized(err):
+		code = codes.PermissionDenied
+	case errors.IsAlreadyExists(err):
+		code = codes.AlreadyExists
+	}
+	return status.Error(code, err.Error())
+}
+
+// profileInfo returns CertProfileInfo for the profile
+func profileInfo(issuer, name string, profile *authority.CertProfile) *pb.CertProfileInfo {
+	res := &pb.CertProfileInfo{
+		Issuer:       issuer,
+		Profile:      name,
+		Description:  profile.Description,
+		Usage:        profile.Usage,
+		Expiry:       profile.Expiry.String(),
+		Backdate:     profile.Backdate.String(),
+		OcspNoCheck:  profile.OCSPNoCheck,
+		AllowedNames: profile.AllowedCommonNames,
+		AllowedDns:   profile.AllowedDNS,
+		AllowedEmail: profile.AllowedEmail,
+		AllowedUri:   profile.AllowedURI,
+		CaConstraint: &pb.CAConstraint{
+			IsCa:           profile.CAConstraint.IsCA,
+			MaxPathLen:     int32(profile.CAConstraint.MaxPathLen),
+			MaxPathLenZero: profile.CAConstraint.MaxPathLenZero,
+		},
+		SpiffeTrustDomain: profile.SPIFFETrustDomain,
+		RejectSharedKeys:  profile.RejectSharedKeys,
+	}
+
+	if profile.AllowedCSRFields != nil {
+		res.AllowedFields = &pb.CSRAllowedFields{
+			Subject: profile.AllowedCSRFields.Subject,
+			Dns:     profile.AllowedCSRFields.DNSNames,
+			Ip:      profile.AllowedCSRFields.IPAddresses,
+			Email:   profile.AllowedCSRFields.EmailAddresses,
+			Uris:    profile.AllowedCSRFields.URIs,
+		}
+	}
+
+	if profile.AllowedKeys != nil {
+		res.AllowedKeys = &pb.KeyPolicy{
+			Algorithms:    profile.AllowedKeys.Algorithms,
+			MinRsaSize:    int32(profile.AllowedKeys.MinRSASize),
+			Curves:        profile.AllowedKeys.Curves,
+			MatchKeyUsage: profile.AllowedKeys.MatchKeyUsage,
+		}
+	}
+
+	for _, oid := range profile.AllowedExtensions {
+		res.AllowedExtensions = append(res.AllowedExtensions, oid.String())
+	}
+
+	for _, policy := range profile.Policies {
+		p := &pb.CertificatePolicy{
+			Id: policy.ID.String(),
+		}
+		for _, q := range policy.Qualifiers {
+			p.Qualifiers = append(p.Qualifiers, &pb.CertificatePolicyQualifier{
+				Type:  q.Type,
+				Value: q.Value,
+			})
+		}
+		res.Policies = append(res.Policies, p)
+	}
+
+	return res
+}
@@ -0,0 +1,105 @@
+package ca
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xhttp/header"
+	"github.com/go-phorce/dolly/xhttp/httperror"
+	"github.com/go-phorce/dolly/xhttp/marshal"
+	"github.com/juju/errors"
+	"go.mozilla.org/pkcs7"
+)
+
+// aiaMaxAge specifies max-age for caching of the issuer certificates
+const aiaMaxAge = 24 * time.Hour
+
+func (s *Service) crl() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p rest.Params) {
+		ikid := strings.TrimSuffix(p.ByName("issuer_id"), ".crl")
+		issuer, err := s.ca.GetIssuerByKeyID(ikid)
+		if err != nil {
+			marshal.WriteJSON(w, r, httperror.WithNotFound("issuer not found: %s", ikid))
+			return
+		}
+
+		crl, err := s.getCrl(r.Context(), issuer)
+		if err != nil {
+			logger.Errorf("src=crl, issuer=%s, err=[%v]", issuer.Label(), errors.ErrorStack(err))
+			marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to get CRL: %s", err.Error()).WithCause(err))
+			return
+		}
+
+		block, _ := pem.Decode([]byte(crl.Pem))
+		if block == nil {
+			marshal.WriteJSON(w, r, httperror.WithUnexpected("invalid CRL"))
+			return
+		}
+
+		w.Header().Set(header.ContentType, "application/pkix-crl")
+		w.Write(block.Bytes)
+	}
+}
+
+// aia serves the issuer certificate:
+// DER for no extension, .crt, .cer or .der;
+// PEM for .pem;
+// PKCS#7 certs-only chain for .p7c or .p7b
+func (s *Service) aia() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p rest.Params) {
+		id := p.ByName("issuer_id")
+		ext := path.Ext(id)
+		ikid := strings.TrimSuffix(id, ext)
+
+		issuer, err := s.ca.GetIssuerByKeyID(ikid)
+		if err != nil {
+			marshal.WriteJSON(w, r, httperror.WithNotFound("issuer not found: %s", ikid))
+			return
+		}
+
+		bundle := issuer.Bundle()
+		var body []byte
+		var contentType string
+
+		switch strings.ToLower(ext) {
+		case "", ".crt", ".cer", ".der":
+			body = bundle.Cert.Raw
+			contentType = "application/pkix-cert"
+		case ".pem":
+			body = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: bundle.Cert.Raw})
+			contentType = "application/x-pem-file"
+		case ".p7c", ".p7b":
+			var chain []byte
+			for _, c := range bundle.Chain {
+				chain = append(chain, c.Raw...)
+			}
+			body, err = pkcs7.DegenerateCertificate(chain)
+			if err != nil {
+				logger.Errorf("src=aia, issuer=%s, err=[%v]", issuer.Label(), errors.ErrorStack(err))
+				marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to create PKCS#7: %s", err.Error()).WithCause(err))
+				return
+			}
+			contentType = "application/pkcs7-mime"
+		default:
+			marshal.WriteJSON(w, r, httperror.WithNotFound("unsupported format: %s", ext))
+			return
+		}
+
+		digest := sha1.Sum(body)
+		w.Header().Set(header.ContentType, contentType)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(digest[:])+`"`)
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(aiaMaxAge/time.Second)))
+		w.Header().Set("Expires", time.Now().UTC().Add(aiaMaxAge).Format(http.TimeFormat))
+
+		// ServeContent handles If-None-Match and If-Modified-Since
+		http.ServeContent(w, r, "", bundle.Cert.NotBefore, bytes.NewReader(body))
+	}
+}
@@ -2,10 +2,13 @@ package ca
 
 import (
 	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/tasks"
 	"github.com/go-phorce/dolly/xlog"
+	v1 "github.com/go-phorce/trusty/api/v1"
 	pb "github.com/go-phorce/trusty/api/v1/trustypb"
 	"github.com/go-phorce/trusty/authority"
 	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/internal/db"
 	"google.golang.org/grpc"
 )
 
@@ -18,6 +21,7 @@ var logger = xlog.NewPackageLogger("github.com/go-phorce/trusty/backend/service"
 type Service struct {
 	server *trustyserver.TrustyServer
 	ca     *authority.Authority
+	db     db.Provider
 }
 
 // Factory returns a factory of the service
@@ -26,12 +30,16 @@ func Factory(server *trustyserver.TrustyServer) interface{} {
 		logger.Panic("status.Factory: invalid parameter")
 	}
 
-	return func(ca *authority.Authority) {
+	return func(ca *authority.Authority, db db.Provider, scheduler tasks.Scheduler) {
 		svc := &Service{
 			server: server,
 			ca:     ca,
+			db:     db,
 		}
 
+		ca.SetKeyBlocklist(db)
+
+		svc.registerCrlTasks(scheduler)
 		server.AddService(svc)
 	}
 }
@@ -52,6 +60,10 @@ func (s *Service) Close() {
 
 // RegisterRoute adds the Status API endpoints to the overall URL router
 func (s *Service) RegisterRoute(r rest.Router) {
+	r.GET(v1.PathForAIAByID, s.aia())
+	r.GET(v1.PathForCRLByID, s.crl())
+	r.GET(v1.PathForOCSPGet, s.ocspGet())
+	r.POST(v1.PathForOCSP, s.ocspPost())
 }
 
 // RegisterGRPC registers gRPC handler
@@ -1,22 +1,47 @@
 package ca_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/go-phorce/dolly/xhttp/header"
+	"github.com/go-phorce/dolly/xhttp/identity"
+	"github.com/go-phorce/dolly/xpki/certutil"
+	v1 "github.com/go-phorce/trusty/api/v1"
+	pb "github.com/go-phorce/trusty/api/v1/trustypb"
 	"github.com/go-phorce/trusty/backend/service/ca"
 	"github.com/go-phorce/trusty/backend/trustymain"
 	"github.com/go-phorce/trusty/backend/trustyserver"
 	"github.com/go-phorce/trusty/backend/trustyserver/embed"
 	"github.com/go-phorce/trusty/client"
+	"github.com/go-phorce/trusty/pkg/roles/jwtmapper"
 	"github.com/go-phorce/trusty/tests/testutils"
 	"github.com/juju/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mozilla.org/pkcs7"
+	"golang.org/x/crypto/ocsp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -24,6 +49,7 @@ var (
 	trustyClient *client.Client
 
 	projFolder = "../../../"
+	httpAddr   = testutils.CreateURLs("http", "")
 )
 
 // serviceFactories provides map of trustyserver.ServiceFactory
@@ -42,8 +68,6 @@ func TestMain(m *testing.M) {
 		panic(errors.Trace(err))
 	}
 
-	httpAddr := testutils.CreateURLs("http", "")
-
 	for i, httpCfg := range cfg.HTTPServers {
 		switch httpCfg.Name {
 		case "Health":
@@ -112,9 +136,795 @@ func TestIssuers(t *testing.T) {
 func TestProfileInfo(t *testing.T) {
 	_, err := trustyClient.Authority.ProfileInfo(context.Background(), nil)
 	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = trustyClient.Authority.ProfileInfo(context.Background(), &pb.CertProfileInfoRequest{Profile: "unknown"})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	res, err := trustyClient.Authority.ProfileInfo(context.Background(), &pb.CertProfileInfoRequest{
+		Label:   "TrustyCA",
+		Profile: "server",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "TrustyCA", res.Issuer)
+	assert.Equal(t, "server", res.Profile)
+	assert.Equal(t, "168h0m0s", res.Expiry)
+	assert.Equal(t, "30m0s", res.Backdate)
+	assert.Contains(t, res.Usage, "server auth")
+	assert.Equal(t, []string{"1.3.6.1.5.5.7.1.1"}, res.AllowedExtensions)
+	require.NotNil(t, res.CaConstraint)
+	assert.False(t, res.CaConstraint.IsCa)
 }
 
 func TestCreateCertificate(t *testing.T) {
-	_, err := trustyClient.Authority.CreateCertificate(context.Background(), nil)
+	peerCtx := callerContext("trusty-peer", "localhost", "")
+
+	_, err := trustyClient.Authority.CreateCertificate(peerCtx, nil)
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	csrPEM := createCSR(t, "localhost")
+
+	_, err = trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+		Request:     csrPEM,
+		Profile:     "server",
+		IssuerLabel: "wrong",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+		Request: csrPEM,
+		Profile: "unknown",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+		Request:     "invalid",
+		Profile:     "server",
+		IssuerLabel: "TrustyCA",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	res, err := trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+		Request:     csrPEM,
+		Profile:     "server",
+		IssuerLabel: "TrustyCA",
+		WithBundle:  true,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, res.Intermediates)
+	assert.NotEmpty(t, res.Root)
+
+	crt, err := certutil.ParseFromPEM([]byte(res.Certificate))
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", crt.Subject.CommonName)
+	assert.Contains(t, crt.ExtKeyUsage, x509.ExtKeyUsageServerAuth)
+
+	// issuer by profile
+	res, err = trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+		Request: csrPEM,
+		Profile: "server",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, res.Intermediates)
+	assert.Empty(t, res.Root)
+	crt, err = certutil.ParseFromPEM([]byte(res.Certificate))
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", crt.Subject.CommonName)
+
+	// requested validity is capped at the profile's expiry
+	notBefore := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	res, err = trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+		Request:   csrPEM,
+		Profile:   "server",
+		NotBefore: notBefore.Unix(),
+		NotAfter:  notBefore.Add(100 * 365 * 24 * time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	crt, err = certutil.ParseFromPEM([]byte(res.Certificate))
+	require.NoError(t, err)
+	assert.Equal(t, notBefore.Unix(), res.NotBefore)
+	assert.Equal(t, crt.NotBefore.Unix(), res.NotBefore)
+	assert.Equal(t, crt.NotAfter.Unix(), res.NotAfter)
+	assert.True(t, res.NotAfter < notBefore.Add(100*365*24*time.Hour).Unix())
+
+	// the certificate, that can not be registered, is not signed
+	_, err = trustyClient.Authority.CreateCertificate(
+		callerContext("trusty-peer", strings.Repeat("h", 161), ""),
+		&pb.CreateCertificateRequest{
+			Request: csrPEM,
+			Profile: "server",
+		})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// NotBefore can not exceed the profile backdate
+	_, err = trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+		Request:   csrPEM,
+		Profile:   "server",
+		NotBefore: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCreateCertificateDryRun(t *testing.T) {
+	peerCtx := callerContext("trusty-peer", "localhost", "")
+
+	csrPEM := createCSR(t, "localhost")
+
+	res, err := trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+		Request: csrPEM,
+		Profile: "server",
+		DryRun:  true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, res.Certificate)
+	assert.Empty(t, res.Intermediates)
+	require.NotNil(t, res.Preview)
+
+	preview := res.Preview
+	assert.Equal(t, "CN=localhost", preview.Subject)
+	assert.NotEmpty(t, preview.Issuer)
+	assert.Equal(t, res.NotBefore, preview.NotBefore)
+	assert.Equal(t, res.NotAfter, preview.NotAfter)
+	assert.True(t, preview.NotAfter > preview.NotBefore)
+	assert.Contains(t, preview.ExtKeyUsage, "server auth")
+	assert.NotEmpty(t, preview.Skid)
+	assert.NotEmpty(t, preview.Ikid)
+	assert.Nil(t, preview.CaConstraint)
+
+	_, err = trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+		Request: csrPEM,
+		Profile: "unknown",
+		DryRun:  true,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestRevokeCertificate(t *testing.T) {
+	peerCtx := callerContext("trusty-peer", "localhost", "")
+	adminCtx := callerContext("trusty-admin", "admin", "1")
+
+	_, err := trustyClient.Authority.RevokeCertificate(adminCtx, nil)
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = trustyClient.Authority.RevokeCertificate(adminCtx, &pb.RevokeCertificateRequest{
+		Ikid: "notfound",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = trustyClient.Authority.RevokeCertificate(adminCtx, &pb.RevokeCertificateRequest{
+		Skid:   "notfound",
+		Reason: pb.Reason(7),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = trustyClient.Authority.RevokeCertificate(adminCtx, &pb.RevokeCertificateRequest{
+		Skid: "notfound",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// the caller must be authenticated
+	_, err = trustyClient.Authority.RevokeCertificate(context.Background(), &pb.RevokeCertificateRequest{
+		Skid: "notfound",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	for _, bySKID := range []bool{true, false} {
+		csrPEM := createCSR(t, "localhost")
+		res, err := trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+			Request: csrPEM,
+			Profile: "server",
+		})
+		require.NoError(t, err)
+		crt, err := certutil.ParseFromPEM([]byte(res.Certificate))
+		require.NoError(t, err)
+
+		req := &pb.RevokeCertificateRequest{
+			Reason: pb.Reason_KEY_COMPROMISE,
+		}
+		if bySKID {
+			req.Skid = certutil.GetSubjectKeyID(crt)
+		} else {
+			req.Ikid = certutil.GetAuthorityKeyID(crt)
+			req.SerialNumber = crt.SerialNumber.String()
+		}
+
+		// only the owner, or admin can revoke
+		for _, ctx := range []context.Context{
+			context.Background(),
+			callerContext("trusty-peer", "peer", ""),
+			callerContext("trusty-client", "localhost", ""),
+			callerContext("trusty-peer", "localhost", "1234"),
+			callerContext(identity.GuestRoleName, "localhost", ""),
+		} {
+			_, err = trustyClient.Authority.RevokeCertificate(ctx, req)
+			require.Error(t, err)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		}
+
+		revokeCtx := adminCtx
+		if bySKID {
+			revokeCtx = peerCtx
+		}
+		revoked, err := trustyClient.Authority.RevokeCertificate(revokeCtx, req)
+		require.NoError(t, err)
+		require.NotNil(t, revoked.Certificate)
+		assert.Equal(t, pb.Reason_KEY_COMPROMISE, revoked.Reason)
+		assert.Equal(t, crt.SerialNumber.String(), revoked.Certificate.SerialNumber)
+		assert.Equal(t, res.Certificate, revoked.Certificate.Pem)
+		assert.NotZero(t, revoked.RevokedAt)
+
+		_, err = trustyClient.Authority.RevokeCertificate(revokeCtx, req)
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		// the compromised key is blocked
+		_, err = trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+			Request: csrPEM,
+			Profile: "server",
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	}
+}
+
+func TestRevokeCertificateBySKID(t *testing.T) {
+	peerCtx := callerContext("trusty-peer", "localhost", "")
+
+	// two certificates with the same key
+	csrPEM := createCSR(t, "localhost")
+	var certs []*x509.Certificate
+	for i := 0; i < 2; i++ {
+		res, err := trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+			Request: csrPEM,
+			Profile: "server",
+		})
+		require.NoError(t, err)
+		crt, err := certutil.ParseFromPEM([]byte(res.Certificate))
+		require.NoError(t, err)
+		certs = append(certs, crt)
+	}
+
+	_, err := trustyClient.Authority.RevokeCertificate(peerCtx, &pb.RevokeCertificateRequest{
+		Skid:   certutil.GetSubjectKeyID(certs[0]),
+		Reason: pb.Reason_KEY_COMPROMISE,
+	})
+	require.NoError(t, err)
+
+	// all certificates with the key are revoked
+	for _, crt := range certs {
+		_, err = trustyClient.Authority.RevokeCertificate(peerCtx, &pb.RevokeCertificateRequest{
+			Ikid:         certutil.GetAuthorityKeyID(crt),
+			SerialNumber: crt.SerialNumber.String(),
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	}
+}
+
+// TestRevokeCertificateAuthz calls the server over gRPC,
+// so the calls are authorized by the configured Authz rules
+func TestRevokeCertificateAuthz(t *testing.T) {
+	authority := grpcAuthority(t)
+
+	var serials []string
+	for i := 0; i < 2; i++ {
+		// the embedded client does not apply Authz,
+		// the certificate is issued to the user with ID 1001
+		res, err := trustyClient.Authority.CreateCertificate(
+			callerContext("trusty-peer", "user@trusty.com", "1001"),
+			&pb.CreateCertificateRequest{
+				Request: createCSR(t, "localhost"),
+				Profile: "server",
+			})
+		require.NoError(t, err)
+		crt, err := certutil.ParseFromPEM([]byte(res.Certificate))
+		require.NoError(t, err)
+		serials = append(serials, crt.SerialNumber.String())
+
+		if i == 0 {
+			// guest is not allowed
+			_, err = authority.RevokeCertificate(context.Background(), &pb.RevokeCertificateRequest{
+				Ikid:         certutil.GetAuthorityKeyID(crt),
+				SerialNumber: crt.SerialNumber.String(),
+			})
+			require.Error(t, err)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+			// another user is not allowed
+			_, err = authority.RevokeCertificate(jwtContext(t, "other@trusty.com", "1002"), &pb.RevokeCertificateRequest{
+				Ikid:         certutil.GetAuthorityKeyID(crt),
+				SerialNumber: crt.SerialNumber.String(),
+			})
+			require.Error(t, err)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		}
+
+		ctx := jwtContext(t, "user@trusty.com", "1001")
+		if i == 1 {
+			// admin is mapped by the configured JWT roles
+			ctx = jwtContext(t, "denis@ekspand.com", "1")
+		}
+		revoked, err := authority.RevokeCertificate(ctx, &pb.RevokeCertificateRequest{
+			Ikid:         certutil.GetAuthorityKeyID(crt),
+			SerialNumber: crt.SerialNumber.String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, serials[i], revoked.Certificate.SerialNumber)
+	}
+}
+
+func TestBlockKey(t *testing.T) {
+	_, err := trustyClient.Authority.BlockKey(context.Background(), nil)
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = trustyClient.Authority.BlockKey(context.Background(), &pb.BlockKeyRequest{
+		SpkiHash: "invalid",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = trustyClient.Authority.BlockKey(context.Background(), &pb.BlockKeyRequest{
+		Pem: "invalid",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = trustyClient.Authority.BlockKey(context.Background(), &pb.BlockKeyRequest{
+		Pem:    createCSR(t, "localhost"),
+		Reason: pb.Reason(7),
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	peerCtx := callerContext("trusty-peer", "localhost", "")
+	csrPEM := createCSR(t, "localhost")
+	res, err := trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+		Request: csrPEM,
+		Profile: "server",
+	})
+	require.NoError(t, err)
+
+	blocked, err := trustyClient.Authority.BlockKey(context.Background(), &pb.BlockKeyRequest{
+		Pem:    res.Certificate,
+		Reason: pb.Reason_KEY_COMPROMISE,
+	})
+	require.NoError(t, err)
+	assert.Len(t, blocked.SpkiHash, 64)
+	assert.Equal(t, pb.Reason_KEY_COMPROMISE, blocked.Reason)
+	assert.NotZero(t, blocked.CreatedAt)
+
+	// blocking by hash returns the existing entry
+	blocked2, err := trustyClient.Authority.BlockKey(context.Background(), &pb.BlockKeyRequest{
+		SpkiHash: blocked.SpkiHash,
+		Reason:   pb.Reason_SUPERSEDED,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, blocked.String(), blocked2.String())
+
+	_, err = trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+		Request: csrPEM,
+		Profile: "server",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestEnrollmentTokens(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := trustyClient.Authority.CreateEnrollmentToken(ctx, &pb.CreateEnrollmentTokenRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = trustyClient.Authority.CreateEnrollmentToken(ctx, &pb.CreateEnrollmentTokenRequest{
+		Profile: "server",
+		Expiry:  "invalid",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = trustyClient.Authority.CreateEnrollmentToken(ctx, &pb.CreateEnrollmentTokenRequest{
+		Profile: "server",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = trustyClient.Authority.CreateEnrollmentToken(ctx, &pb.CreateEnrollmentTokenRequest{
+		Profile:     "server",
+		NamePattern: "[",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = trustyClient.Authority.CreateEnrollmentToken(ctx, &pb.CreateEnrollmentTokenRequest{
+		Profile: "unknown",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	token, err := trustyClient.Authority.CreateEnrollmentToken(ctx, &pb.CreateEnrollmentTokenRequest{
+		Profile:     "server",
+		NamePattern: `^[a-z0-9\-]+\.trusty\.com$`,
+		Expiry:      "1h",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, token.Token)
+	assert.NotZero(t, token.Id)
+	assert.Equal(t, "server", token.Profile)
+	assert.Zero(t, token.UsedAt)
+	assert.True(t, token.ExpiresAt > token.CreatedAt)
+
+	list, err := trustyClient.Authority.ListEnrollmentTokens(ctx)
+	require.NoError(t, err)
+	found := false
+	for _, tk := range list.Tokens {
+		if tk.Id == token.Id {
+			found = true
+			assert.Empty(t, tk.Token)
+		}
+	}
+	assert.True(t, found)
+
+	// the caller without identity is a guest, that must provide the token
+	_, err = trustyClient.Authority.CreateCertificate(ctx, &pb.CreateCertificateRequest{
+		Request: createCSR(t, "host1.trusty.com"),
+		Profile: "server",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = trustyClient.Authority.EnrollCertificate(ctx, &pb.CreateCertificateRequest{
+		Request: createCSR(t, "host1.trusty.com"),
+		Profile: "server",
+	})
 	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = trustyClient.Authority.CreateCertificate(ctx, &pb.CreateCertificateRequest{
+		Request: createCSR(t, "host1.trusty.com"),
+		Token:   "invalid",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = trustyClient.Authority.CreateCertificate(ctx, &pb.CreateCertificateRequest{
+		Request: createCSR(t, "host1.trusty.com"),
+		Profile: "client",
+		Token:   token.Token,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = trustyClient.Authority.CreateCertificate(ctx, &pb.CreateCertificateRequest{
+		Request: createCSR(t, "localhost"),
+		Token:   token.Token,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// the pattern must match the entire name
+	_, err = trustyClient.Authority.CreateCertificate(ctx, &pb.CreateCertificateRequest{
+		Request: createCSR(t, "host1.trusty.com.attacker.net"),
+		Token:   token.Token,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// dry run does not consume the token
+	_, err = trustyClient.Authority.CreateCertificate(ctx, &pb.CreateCertificateRequest{
+		Request: createCSR(t, "host1.trusty.com"),
+		Token:   token.Token,
+		DryRun:  true,
+	})
+	require.NoError(t, err)
+
+	res, err := trustyClient.Authority.CreateCertificate(ctx, &pb.CreateCertificateRequest{
+		Request: createCSR(t, "host1.trusty.com"),
+		Token:   token.Token,
+	})
+	require.NoError(t, err)
+	crt, err := certutil.ParseFromPEM([]byte(res.Certificate))
+	require.NoError(t, err)
+	assert.Equal(t, "host1.trusty.com", crt.Subject.CommonName)
+	assert.Contains(t, crt.ExtKeyUsage, x509.ExtKeyUsageServerAuth)
+
+	// the token is used once
+	_, err = trustyClient.Authority.CreateCertificate(ctx, &pb.CreateCertificateRequest{
+		Request: createCSR(t, "host2.trusty.com"),
+		Token:   token.Token,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = trustyClient.Authority.RevokeEnrollmentToken(ctx, &pb.RevokeEnrollmentTokenRequest{})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	revoked, err := trustyClient.Authority.RevokeEnrollmentToken(ctx, &pb.RevokeEnrollmentTokenRequest{Id: token.Id})
+	require.NoError(t, err)
+	assert.Equal(t, token.Id, revoked.Id)
+	assert.NotZero(t, revoked.UsedAt)
+
+	_, err = trustyClient.Authority.RevokeEnrollmentToken(ctx, &pb.RevokeEnrollmentTokenRequest{Id: token.Id})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	anyName, err := trustyClient.Authority.CreateEnrollmentToken(ctx, &pb.CreateEnrollmentTokenRequest{
+		Profile:      "server",
+		AllowAnyName: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, anyName.NamePattern)
+
+	// the token is not consumed, if the certificate is not issued
+	blockedCSR := createCSR(t, "host3.trusty.com")
+	_, err = trustyClient.Authority.BlockKey(ctx, &pb.BlockKeyRequest{
+		Pem:    blockedCSR,
+		Reason: pb.Reason_KEY_COMPROMISE,
+	})
+	require.NoError(t, err)
+	_, err = trustyClient.Authority.EnrollCertificate(ctx, &pb.CreateCertificateRequest{
+		Request: blockedCSR,
+		Token:   anyName.Token,
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = trustyClient.Authority.EnrollCertificate(ctx, &pb.CreateCertificateRequest{
+		Request: createCSR(t, "host3.trusty.com"),
+		Token:   anyName.Token,
+	})
+	require.NoError(t, err)
+
+	_, err = trustyClient.Authority.RevokeEnrollmentToken(ctx, &pb.RevokeEnrollmentTokenRequest{Id: anyName.Id})
+	require.NoError(t, err)
+}
+
+// TestEnrollCertificateAuthz calls the server over gRPC without credentials,
+// so the calls are authorized by the configured Authz rules for guests
+func TestEnrollCertificateAuthz(t *testing.T) {
+	authority := grpcAuthority(t)
+	ctx := context.Background()
+
+	token, err := trustyClient.Authority.CreateEnrollmentToken(ctx, &pb.CreateEnrollmentTokenRequest{
+		Profile:     "server",
+		NamePattern: `host[0-9]+\.trusty\.com`,
+	})
+	require.NoError(t, err)
+
+	req := &pb.CreateCertificateRequest{
+		Request: createCSR(t, "host1.trusty.com"),
+		Token:   token.Token,
+	}
+
+	// guest is not allowed to create certificates
+	_, err = authority.CreateCertificate(ctx, req)
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = authority.EnrollCertificate(ctx, &pb.CreateCertificateRequest{
+		Request: createCSR(t, "host1.trusty.com"),
+		Token:   "invalid",
+	})
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	res, err := authority.EnrollCertificate(ctx, req)
+	require.NoError(t, err)
+	crt, err := certutil.ParseFromPEM([]byte(res.Certificate))
+	require.NoError(t, err)
+	assert.Equal(t, "host1.trusty.com", crt.Subject.CommonName)
+
+	// the token is used once
+	_, err = authority.EnrollCertificate(ctx, req)
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestCrl(t *testing.T) {
+	res, err := trustyClient.Authority.Issuers(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Issuers)
+
+	resp, err := http.Get(httpAddr + v1.PathForCRL + "/notfound.crl")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	for _, issuer := range res.Issuers {
+		crt, err := certutil.ParseFromPEM([]byte(issuer.Certificate))
+		require.NoError(t, err)
+
+		resp, err := http.Get(httpAddr + v1.PathForCRL + "/" + certutil.GetSubjectKeyID(crt) + ".crl")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/pkix-crl", resp.Header.Get(header.ContentType))
+
+		der, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		crl, err := x509.ParseCRL(der)
+		require.NoError(t, err)
+		assert.NoError(t, crt.CheckCRLSignature(crl))
+		assert.True(t, crl.TBSCertList.NextUpdate.After(time.Now()))
+	}
+}
+
+func TestAIA(t *testing.T) {
+	res, err := trustyClient.Authority.Issuers(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Issuers)
+
+	resp, err := http.Get(httpAddr + v1.PathForAIA + "/notfound.crt")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	for _, issuer := range res.Issuers {
+		crt, err := certutil.ParseFromPEM([]byte(issuer.Certificate))
+		require.NoError(t, err)
+		skid := certutil.GetSubjectKeyID(crt)
+
+		get := func(ext, contentType string) []byte {
+			resp, err := http.Get(httpAddr + v1.PathForAIA + "/" + skid + ext)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, contentType, resp.Header.Get(header.ContentType))
+			assert.NotEmpty(t, resp.Header.Get("Cache-Control"))
+			assert.NotEmpty(t, resp.Header.Get("ETag"))
+
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			return body
+		}
+
+		der := get(".crt", "application/pkix-cert")
+		assert.Equal(t, crt.Raw, der)
+
+		pemBytes := get(".pem", "application/x-pem-file")
+		c2, err := certutil.ParseFromPEM(pemBytes)
+		require.NoError(t, err)
+		assert.Equal(t, crt.Raw, c2.Raw)
+
+		p7 := get(".p7c", "application/pkcs7-mime")
+		msg, err := pkcs7.Parse(p7)
+		require.NoError(t, err)
+		require.NotEmpty(t, msg.Certificates)
+		assert.Equal(t, crt.Raw, msg.Certificates[0].Raw)
+	}
+}
+
+func TestOCSP(t *testing.T) {
+	peerCtx := callerContext("trusty-peer", "localhost", "")
+
+	res, err := trustyClient.Authority.CreateCertificate(peerCtx, &pb.CreateCertificateRequest{
+		Request: createCSR(t, "localhost"),
+		Profile: "server",
+	})
+	require.NoError(t, err)
+
+	crt, err := certutil.ParseFromPEM([]byte(res.Certificate))
+	require.NoError(t, err)
+	issuers, err := trustyClient.Authority.Issuers(context.Background())
+	require.NoError(t, err)
+
+	var issuer *x509.Certificate
+	for _, ii := range issuers.Issuers {
+		c, err := certutil.ParseFromPEM([]byte(ii.Certificate))
+		require.NoError(t, err)
+		if certutil.GetSubjectKeyID(c) == certutil.GetAuthorityKeyID(crt) {
+			issuer = c
+			break
+		}
+	}
+	require.NotNil(t, issuer)
+
+	query := func(get bool) *ocsp.Response {
+		der, err := ocsp.CreateRequest(crt, issuer, nil)
+		require.NoError(t, err)
+
+		var resp *http.Response
+		if get {
+			resp, err = http.Get(httpAddr + v1.PathForOCSP + "/" + url.PathEscape(base64.StdEncoding.EncodeToString(der)))
+		} else {
+			resp, err = http.Post(httpAddr+v1.PathForOCSP, "application/ocsp-request", bytes.NewReader(der))
+		}
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/ocsp-response", resp.Header.Get(header.ContentType))
+
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		ocspRes, err := ocsp.ParseResponse(body, issuer)
+		require.NoError(t, err)
+		assert.Equal(t, crt.SerialNumber, ocspRes.SerialNumber)
+		assert.True(t, ocspRes.NextUpdate.After(ocspRes.ThisUpdate))
+		return ocspRes
+	}
+
+	assert.Equal(t, ocsp.Good, query(false).Status)
+	assert.Equal(t, ocsp.Good, query(true).Status)
+
+	_, err = trustyClient.Authority.RevokeCertificate(callerContext("trusty-admin", "admin", "1"), &pb.RevokeCertificateRequest{
+		Skid:   certutil.GetSubjectKeyID(crt),
+		Reason: pb.Reason_SUPERSEDED,
+	})
+	require.NoError(t, err)
+
+	ocspRes := query(true)
+	assert.Equal(t, ocsp.Revoked, ocspRes.Status)
+	assert.Equal(t, ocsp.Superseded, ocspRes.RevocationReason)
+
+	resp, err := http.Post(httpAddr+v1.PathForOCSP, "application/ocsp-request", bytes.NewReader([]byte("invalid")))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, ocsp.MalformedRequestErrorResponse, body)
+}
+
+// callerContext returns context with the caller's identity,
+// as the embedded client does not apply the identity mappers
+func callerContext(role, name, userID string) context.Context {
+	return identity.AddToContext(context.Background(),
+		identity.NewRequestContext(identity.NewIdentity(role, name, userID)))
+}
+
+// grpcAuthority returns Authority client, connected to the test server over gRPC,
+// so the calls are mapped by the configured identity mappers and authorized by Authz
+func grpcAuthority(t *testing.T) pb.AuthorityClient {
+	u, err := url.Parse(httpAddr)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, u.Host, grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewAuthorityClient(conn)
+}
+
+// jwtContext returns context with the access token of the user,
+// signed with the configured JWT mapper
+func jwtContext(t *testing.T, email, userID string) context.Context {
+	p, err := jwtmapper.Load(filepath.Join(projFolder, "etc/dev/jwt-roles.dev.json"))
+	require.NoError(t, err)
+
+	auth, err := p.SignToken(&v1.UserInfo{ID: userID, Email: email}, "", time.Hour)
+	require.NoError(t, err)
+
+	return metadata.AppendToOutgoingContext(context.Background(),
+		"authorization", header.Bearer+" "+auth.AccessToken)
+}
+
+func createCSR(t *testing.T, cn string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: cn},
+		DNSNames: []string{cn},
+	}, key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
 }
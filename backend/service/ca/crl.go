@@ -0,0 +1,105 @@
+package ca
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/go-phorce/dolly/tasks"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+)
+
+// registerCrlTasks schedules CRL publishing for each issuer
+func (s *Service) registerCrlTasks(scheduler tasks.Scheduler) {
+	for _, issuer := range s.ca.Issuers() {
+		interval := crlTaskInterval(issuer.CrlRenewal())
+		task := tasks.NewTaskAtIntervals(interval, tasks.Minutes).
+			Do("crl-"+issuer.Label(), s.crlTask, issuer)
+		scheduler.Add(task)
+
+		logger.Infof("src=registerCrlTasks, issuer=%s, interval=%dm", issuer.Label(), interval)
+	}
+}
+
+// crlTaskInterval returns the interval in minutes to check the CRL,
+// which is a quarter of the renewal period, but not less than a minute
+func crlTaskInterval(renewal time.Duration) uint64 {
+	interval := uint64(renewal / time.Minute / 4)
+	if interval < 1 {
+		interval = 1
+	}
+	return interval
+}
+
+func (s *Service) crlTask(issuer *authority.Issuer) {
+	_, err := s.getCrl(context.Background(), issuer)
+	if err != nil {
+		logger.Errorf("src=crlTask, issuer=%s, err=[%v]", issuer.Label(), errors.ErrorStack(err))
+	}
+}
+
+// getCrl returns the current CRL of the issuer,
+// or publishes a new one if it does not exist or is within the renewal period
+func (s *Service) getCrl(ctx context.Context, issuer *authority.Issuer) (*model.Crl, error) {
+	crl, err := s.db.GetCrl(ctx, issuer.SubjectKID())
+	if err == nil && time.Now().Add(issuer.CrlRenewal()).Before(crl.NextUpdate) {
+		return crl, nil
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+
+	return s.publishCrl(ctx, issuer)
+}
+
+// publishCrl creates the CRL from the revoked certificates of the issuer
+func (s *Service) publishCrl(ctx context.Context, issuer *authority.Issuer) (*model.Crl, error) {
+	ikid := issuer.SubjectKID()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	revoked, err := s.db.ListRevokedCertificates(ctx, ikid, now)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	entries := make([]pkix.RevokedCertificate, 0, len(revoked))
+	for _, r := range revoked {
+		sn, ok := new(big.Int).SetString(r.Certificate.SerialNumber, 10)
+		if !ok {
+			logger.Errorf("src=publishCrl, reason=invalid_serial, issuer=%s, id=%d, serial=%s",
+				issuer.Label(), r.Certificate.ID, r.Certificate.SerialNumber)
+			continue
+		}
+		entry, err := authority.CRLEntry(sn, r.RevokedAt, r.Reason)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries = append(entries, entry)
+	}
+
+	nextUpdate := now.Add(issuer.CrlExpiry())
+	der, err := issuer.CreateCRL(entries, now, nextUpdate)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	crl, err := s.db.PutCrl(ctx, &model.Crl{
+		IKID:       ikid,
+		ThisUpdate: now,
+		NextUpdate: nextUpdate,
+		Issuer:     issuer.Bundle().Cert.Subject.String(),
+		Pem:        string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	logger.Infof("src=publishCrl, issuer=%s, ikid=%s, revoked=%d, nextUpdate=%s",
+		issuer.Label(), ikid, len(entries), nextUpdate.Format(time.RFC3339))
+
+	return crl, nil
+}
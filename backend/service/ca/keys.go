@@ -0,0 +1,135 @@
+package ca
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/go-phorce/trusty/api/v1/trustypb"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+)
+
+// blockKey registers the key as blocked, and audits the event
+func (s *Service) blockKey(ctx context.Context, spkiHash string, reason pb.Reason, callerID, contextID, requestor string) (*model.BlockedKey, error) {
+	key, err := s.db.BlockKey(ctx, &model.BlockedKey{
+		SPKIHash:  spkiHash,
+		Reason:    int(reason),
+		Requestor: requestor,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	s.server.Audit(
+		trustyserver.EvtSourceCA,
+		trustyserver.EvtKeyBlocked,
+		callerID,
+		contextID,
+		0,
+		fmt.Sprintf("id=%d, spki=%s, reason=%s",
+			key.ID,
+			key.SPKIHash,
+			pb.Reason(key.Reason).String()),
+	)
+	return key, nil
+}
+
+// blockCertificateKey blocks the key of the compromised certificate
+func (s *Service) blockCertificateKey(ctx context.Context, crt *model.Certificate, callerID, contextID, requestor string) error {
+	pub, err := publicKeyFromPEM(crt.Pem)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	hash, err := authority.SPKIHash(pub)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = s.blockKey(ctx, hash, pb.Reason_KEY_COMPROMISE, callerID, contextID, requestor)
+	return errors.Trace(err)
+}
+
+// checkSharedKey returns an error if the key in the certificate request
+// is bound to an active certificate, that is not owned by the caller
+func (s *Service) checkSharedKey(ctx context.Context, o owner, request string) error {
+	pub, err := publicKeyFromPEM(request)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	skid, err := authority.SubjectKeyID(pub)
+	if err != nil {
+		return errors.NewBadRequest(err, "invalid public key")
+	}
+
+	list, err := s.db.ListCertificatesBySKID(ctx, skid, time.Now().UTC())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, crt := range list {
+		if !o.owns(crt) {
+			return errors.Forbiddenf("the key is already certified for another owner: %s", skid)
+		}
+	}
+	return nil
+}
+
+// spkiHashFromRequest returns SPKI hash from the hash or PEM provided in the request
+func spkiHashFromRequest(req *pb.BlockKeyRequest) (string, error) {
+	if req.SpkiHash != "" {
+		hash := strings.ToLower(req.SpkiHash)
+		if b, err := hex.DecodeString(hash); err != nil || len(b) != 32 {
+			return "", errors.BadRequestf("invalid spki_hash: %s", req.SpkiHash)
+		}
+		return hash, nil
+	}
+
+	pub, err := publicKeyFromPEM(req.Pem)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	hash, err := authority.SPKIHash(pub)
+	if err != nil {
+		return "", errors.NewBadRequest(err, "invalid public key")
+	}
+	return hash, nil
+}
+
+// publicKeyFromPEM returns the public key from PEM encoded
+// public key, certificate, or certificate request
+func publicKeyFromPEM(val string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(val))
+	if block == nil {
+		return nil, errors.BadRequestf("invalid PEM")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.NewBadRequest(err, "failed to parse public key")
+		}
+		return pub, nil
+	case "CERTIFICATE":
+		crt, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.NewBadRequest(err, "failed to parse certificate")
+		}
+		return crt.PublicKey, nil
+	case "CERTIFICATE REQUEST", "NEW CERTIFICATE REQUEST":
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		if err != nil {
+			return nil, errors.NewBadRequest(err, "failed to parse certificate request")
+		}
+		return csr.PublicKey, nil
+	default:
+		return nil, errors.BadRequestf("unsupported PEM type: %s", block.Type)
+	}
+}
@@ -0,0 +1,74 @@
+package ca
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/internal/db"
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSharedKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "localhost"},
+	}, key)
+	require.NoError(t, err)
+	request := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+
+	skid, err := authority.SubjectKeyID(key.Public())
+	require.NoError(t, err)
+
+	s := &Service{db: &sharedKeysDB{certs: map[string]model.Certificates{}}}
+	ctx := context.Background()
+
+	// not certified yet
+	assert.NoError(t, s.checkSharedKey(ctx, owner{role: "trusty-peer", name: "host1"}, request))
+
+	// issued to the cert-mapped caller
+	s.db.(*sharedKeysDB).certs[skid] = model.Certificates{
+		{SKID: skid, Role: "trusty-peer", Host: "host1"},
+	}
+	assert.NoError(t, s.checkSharedKey(ctx, owner{role: "trusty-peer", name: "host1"}, request))
+
+	for _, o := range []owner{
+		{role: "trusty-peer", name: "host2"},
+		{role: "trusty-client", name: "host1"},
+		{id: 1001, role: "trusty-peer", name: "host1"},
+		{role: "guest", name: "host1"},
+		{},
+	} {
+		err = s.checkSharedKey(ctx, o, request)
+		require.Error(t, err, "%+v", o)
+		assert.True(t, errors.IsForbidden(err))
+	}
+
+	// issued to the JWT mapped user
+	s.db.(*sharedKeysDB).certs[skid] = model.Certificates{
+		{SKID: skid, OwnerID: 1001, Role: "trusty-client", Host: "user@trusty.com"},
+	}
+	assert.NoError(t, s.checkSharedKey(ctx, owner{id: 1001, role: "trusty-client", name: "user@trusty.com"}, request))
+	assert.Error(t, s.checkSharedKey(ctx, owner{id: 1002, role: "trusty-client", name: "user@trusty.com"}, request))
+	assert.Error(t, s.checkSharedKey(ctx, owner{role: "trusty-client", name: "user@trusty.com"}, request))
+}
+
+type sharedKeysDB struct {
+	db.Provider
+	certs map[string]model.Certificates
+}
+
+func (d *sharedKeysDB) ListCertificatesBySKID(_ context.Context, skid string, _ time.Time) (model.Certificates, error) {
+	return d.certs[skid], nil
+}
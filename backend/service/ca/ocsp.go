@@ -0,0 +1,132 @@
+package ca
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xhttp/header"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/juju/errors"
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	// ocspRequestContentType is Content-Type of OCSP request, RFC 6960 Appendix A
+	ocspRequestContentType = "application/ocsp-request"
+	// ocspResponseContentType is Content-Type of OCSP response, RFC 6960 Appendix A
+	ocspResponseContentType = "application/ocsp-response"
+	// maxOCSPRequestSize specifies the max size of OCSP request
+	maxOCSPRequestSize = 10 * 1024
+)
+
+// ocspGet handles GET {url}/{url-encoding of base-64 encoding of the DER encoding of the OCSPRequest}
+func (s *Service) ocspGet() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p rest.Params) {
+		// the path is already unescaped, but '+' may be decoded as space by some clients
+		b64 := strings.Replace(strings.TrimPrefix(p.ByName("request"), "/"), " ", "+", -1)
+		der, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			writeOCSP(w, ocsp.MalformedRequestErrorResponse, 0)
+			return
+		}
+		s.ocsp(w, r, der, true)
+	}
+}
+
+// ocspPost handles POST with DER encoded OCSPRequest
+func (s *Service) ocspPost() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ rest.Params) {
+		if ct := r.Header.Get(header.ContentType); ct != "" && ct != ocspRequestContentType {
+			writeOCSP(w, ocsp.MalformedRequestErrorResponse, 0)
+			return
+		}
+		der, err := ioutil.ReadAll(io.LimitReader(r.Body, maxOCSPRequestSize+1))
+		if err != nil || len(der) > maxOCSPRequestSize {
+			writeOCSP(w, ocsp.MalformedRequestErrorResponse, 0)
+			return
+		}
+		s.ocsp(w, r, der, false)
+	}
+}
+
+func (s *Service) ocsp(w http.ResponseWriter, r *http.Request, der []byte, cacheable bool) {
+	req, err := ocsp.ParseRequest(der)
+	if err != nil {
+		writeOCSP(w, ocsp.MalformedRequestErrorResponse, 0)
+		return
+	}
+
+	issuer, err := s.ca.GetIssuerByKeyHash(req.HashAlgorithm, req.IssuerKeyHash)
+	if err != nil {
+		writeOCSP(w, ocsp.UnauthorizedErrorResponse, 0)
+		return
+	}
+
+	res, err := s.ocspResponse(r.Context(), issuer, req.SerialNumber)
+	if err != nil {
+		logger.Errorf("src=ocsp, issuer=%s, serial=%s, err=[%v]",
+			issuer.Label(), req.SerialNumber.String(), errors.ErrorStack(err))
+		writeOCSP(w, ocsp.InternalErrorErrorResponse, 0)
+		return
+	}
+
+	var maxAge time.Duration
+	if cacheable {
+		maxAge = issuer.OcspExpiry()
+	}
+	writeOCSP(w, res, maxAge)
+}
+
+// ocspResponse returns signed OCSP response with the certificate status
+func (s *Service) ocspResponse(ctx context.Context, issuer *authority.Issuer, serial *big.Int) ([]byte, error) {
+	ikid := issuer.SubjectKID()
+	sn := serial.String()
+
+	template := ocsp.Response{
+		SerialNumber: serial,
+		Status:       ocsp.Unknown,
+	}
+
+	_, err := s.db.GetCertificate(ctx, ikid, sn)
+	if err == nil {
+		template.Status = ocsp.Good
+	} else if errors.IsNotFound(err) {
+		revoked, err := s.db.GetRevokedCertificate(ctx, ikid, sn)
+		if err == nil {
+			template.Status = ocsp.Revoked
+			template.RevokedAt = revoked.RevokedAt
+			template.RevocationReason = revoked.Reason
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+	} else {
+		return nil, errors.Trace(err)
+	}
+
+	der, err := issuer.SignOCSP(template)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return der, nil
+}
+
+// writeOCSP writes OCSP response, and caching headers if maxAge is provided
+func writeOCSP(w http.ResponseWriter, res []byte, maxAge time.Duration) {
+	w.Header().Set(header.ContentType, ocspResponseContentType)
+	if maxAge > 0 {
+		now := time.Now().UTC()
+		w.Header().Set("Last-Modified", now.Format(http.TimeFormat))
+		w.Header().Set("Expires", now.Add(maxAge).Format(http.TimeFormat))
+		w.Header().Set("Cache-Control",
+			fmt.Sprintf("max-age=%d, public, no-transform, must-revalidate", int(maxAge/time.Second)))
+	}
+	w.Write(res)
+}
@@ -0,0 +1,43 @@
+package ca
+
+import (
+	"strconv"
+
+	"github.com/go-phorce/dolly/xhttp/identity"
+	"github.com/go-phorce/trusty/internal/db/model"
+)
+
+// owner identifies the caller, that the certificates are issued to
+type owner struct {
+	// id is the user ID of the callers mapped by JWT,
+	// or 0 for the callers mapped by certificate or basic auth
+	id int64
+	// role and name are recorded as the certificate's Role and Host
+	role string
+	name string
+}
+
+// ownerFromIdentity returns the owner of the caller's certificates
+func ownerFromIdentity(caller identity.Identity) owner {
+	o := owner{
+		role: caller.Role(),
+		name: caller.Name(),
+	}
+	if id, err := strconv.ParseInt(caller.UserID(), 10, 64); err == nil {
+		o.id = id
+	}
+	return o
+}
+
+// owns returns true if the certificate is issued to the owner:
+// by the user ID if set, otherwise by the recorded role and name.
+// The guests do not own any certificate.
+func (o owner) owns(crt *model.Certificate) bool {
+	if o.id != 0 {
+		return crt.OwnerID == o.id
+	}
+	if o.role == "" || o.role == identity.GuestRoleName || o.name == "" {
+		return false
+	}
+	return crt.OwnerID == 0 && crt.Role == o.role && crt.Host == o.name
+}
@@ -0,0 +1,60 @@
+package ca
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+
+	"github.com/go-phorce/dolly/xpki/certutil"
+	pb "github.com/go-phorce/trusty/api/v1/trustypb"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/pkg/csr"
+)
+
+// certificatePreview returns the preview of the certificate to be issued
+func certificatePreview(tbs *x509.Certificate, findings []*authority.LintFinding) *pb.CertificatePreview {
+	res := &pb.CertificatePreview{
+		Subject:                certutil.NameToString(&tbs.Subject),
+		Issuer:                 certutil.NameToString(&tbs.Issuer),
+		DnsNames:               tbs.DNSNames,
+		EmailAddresses:         tbs.EmailAddresses,
+		NotBefore:              tbs.NotBefore.Unix(),
+		NotAfter:               tbs.NotAfter.Unix(),
+		KeyUsage:               csr.KeyUsageNames(tbs.KeyUsage),
+		ExtKeyUsage:            csr.ExtKeyUsageNames(tbs.ExtKeyUsage),
+		Skid:                   hex.EncodeToString(tbs.SubjectKeyId),
+		Ikid:                   hex.EncodeToString(tbs.AuthorityKeyId),
+		OcspServers:            tbs.OCSPServer,
+		CrlDistributionPoints:  tbs.CRLDistributionPoints,
+		IssuingCertificateUrls: tbs.IssuingCertificateURL,
+		PublicKeyAlgorithm:     tbs.PublicKeyAlgorithm.String(),
+		SignatureAlgorithm:     tbs.SignatureAlgorithm.String(),
+	}
+	if tbs.IsCA {
+		res.CaConstraint = &pb.CAConstraint{
+			IsCa:           true,
+			MaxPathLen:     int32(tbs.MaxPathLen),
+			MaxPathLenZero: tbs.MaxPathLenZero,
+		}
+	}
+	for _, ip := range tbs.IPAddresses {
+		res.IpAddresses = append(res.IpAddresses, ip.String())
+	}
+	for _, uri := range tbs.URIs {
+		res.Uris = append(res.Uris, uri.String())
+	}
+	for _, ext := range tbs.ExtraExtensions {
+		res.Extensions = append(res.Extensions, &pb.Extension{
+			Id:       ext.Id.String(),
+			Critical: ext.Critical,
+			Value:    hex.EncodeToString(ext.Value),
+		})
+	}
+	for _, f := range findings {
+		res.LintFindings = append(res.LintFindings, &pb.LintFinding{
+			Lint:     f.Lint,
+			Severity: string(f.Severity),
+			Message:  f.Message,
+		})
+	}
+	return res
+}
@@ -0,0 +1,270 @@
+package ca
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/go-phorce/dolly/xhttp/identity"
+	pb "github.com/go-phorce/trusty/api/v1/trustypb"
+	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultEnrollmentTokenExpiry specifies the default lifetime of enrollment tokens
+	defaultEnrollmentTokenExpiry = 24 * time.Hour
+	// maxEnrollmentTokenExpiry specifies the max lifetime of enrollment tokens
+	maxEnrollmentTokenExpiry = 30 * 24 * time.Hour
+)
+
+// CreateEnrollmentToken returns one-time token, that authorizes CreateCertificate request
+func (s *Service) CreateEnrollmentToken(ctx context.Context, req *pb.CreateEnrollmentTokenRequest) (*pb.EnrollmentToken, error) {
+	if req == nil || req.Profile == "" {
+		return nil, status.Error(codes.InvalidArgument, "profile must be provided")
+	}
+
+	expiry := defaultEnrollmentTokenExpiry
+	if req.Expiry != "" {
+		d, err := time.ParseDuration(req.Expiry)
+		if err != nil || d <= 0 || d > maxEnrollmentTokenExpiry {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid expiry: %s", req.Expiry)
+		}
+		expiry = d
+	}
+
+	if req.NamePattern == "" {
+		if !req.AllowAnyName {
+			return nil, status.Error(codes.InvalidArgument, "name_pattern must be provided, or allow_any_name must be set")
+		}
+	} else if _, err := namePatternRule(req.NamePattern); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid name_pattern: %s", err.Error())
+	}
+
+	// the token must be usable with the profile and the issuer
+	if _, err := s.getIssuer(req.IssuerLabel, req.Profile); err != nil {
+		return nil, grpcError(err)
+	}
+
+	token, err := newEnrollmentToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate token: %s", err.Error())
+	}
+
+	var callerID, contextID, requestor string
+	if callerCtx := identity.FromContext(ctx); callerCtx != nil {
+		caller := callerCtx.Identity()
+		callerID = caller.String()
+		contextID = callerCtx.CorrelationID()
+		requestor = caller.Name()
+	}
+
+	now := time.Now().UTC()
+	t, err := s.db.CreateEnrollmentToken(ctx, &model.EnrollmentToken{
+		TokenHash:   enrollmentTokenHash(token),
+		Profile:     req.Profile,
+		IssuerLabel: req.IssuerLabel,
+		NamePattern: req.NamePattern,
+		CreatedBy:   requestor,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(expiry),
+	})
+	if err != nil {
+		logger.Errorf("src=CreateEnrollmentToken, reason=db, profile=%s, err=[%v]", req.Profile, errors.ErrorStack(err))
+		return nil, status.Errorf(codes.Internal, "failed to create token: %s", err.Error())
+	}
+
+	s.server.Audit(
+		trustyserver.EvtSourceCA,
+		trustyserver.EvtEnrollmentTokenCreated,
+		callerID,
+		contextID,
+		0,
+		fmt.Sprintf("id=%d, profile=%s, issuer=%s, pattern=%q, expires=%s",
+			t.ID,
+			t.Profile,
+			t.IssuerLabel,
+			t.NamePattern,
+			t.ExpiresAt.Format(time.RFC3339)),
+	)
+
+	res := t.ToDto()
+	res.Token = token
+	return res, nil
+}
+
+// ListEnrollmentTokens returns the enrollment tokens, that are not expired
+func (s *Service) ListEnrollmentTokens(ctx context.Context, _ *pb.EmptyRequest) (*pb.EnrollmentTokensResponse, error) {
+	list, err := s.db.ListEnrollmentTokens(ctx, time.Now().UTC())
+	if err != nil {
+		logger.Errorf("src=ListEnrollmentTokens, reason=db, err=[%v]", errors.ErrorStack(err))
+		return nil, status.Errorf(codes.Internal, "failed to list tokens: %s", err.Error())
+	}
+	return list.ToDto(), nil
+}
+
+// RevokeEnrollmentToken removes the enrollment token
+func (s *Service) RevokeEnrollmentToken(ctx context.Context, req *pb.RevokeEnrollmentTokenRequest) (*pb.EnrollmentToken, error) {
+	if req == nil || req.Id <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id must be provided")
+	}
+
+	t, err := s.db.RevokeEnrollmentToken(ctx, req.Id)
+	if err != nil {
+		logger.Errorf("src=RevokeEnrollmentToken, reason=db, id=%d, err=[%v]", req.Id, errors.ErrorStack(err))
+		if errors.IsNotFound(err) {
+			return nil, grpcError(err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke token: %s", err.Error())
+	}
+
+	var callerID, contextID string
+	if callerCtx := identity.FromContext(ctx); callerCtx != nil {
+		callerID = callerCtx.Identity().String()
+		contextID = callerCtx.CorrelationID()
+	}
+
+	s.server.Audit(
+		trustyserver.EvtSourceCA,
+		trustyserver.EvtEnrollmentTokenRevoked,
+		callerID,
+		contextID,
+		0,
+		fmt.Sprintf("id=%d, profile=%s, issuer=%s, pattern=%q",
+			t.ID,
+			t.Profile,
+			t.IssuerLabel,
+			t.NamePattern),
+	)
+
+	return t.ToDto(), nil
+}
+
+// checkEnrollmentToken returns the enrollment token, if the request
+// matches its profile, issuer and name pattern
+func (s *Service) checkEnrollmentToken(ctx context.Context, req *pb.CreateCertificateRequest) (*model.EnrollmentToken, error) {
+	t, err := s.db.GetEnrollmentToken(ctx, enrollmentTokenHash(req.Token))
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.Forbiddenf("invalid enrollment token")
+		}
+		return nil, errors.Trace(err)
+	}
+	if t.UsedAt.Valid || !time.Now().Before(t.ExpiresAt) {
+		return nil, errors.Forbiddenf("invalid enrollment token")
+	}
+
+	if req.Profile != "" && req.Profile != t.Profile {
+		return nil, errors.Forbiddenf("the enrollment token is not valid for profile: %s", req.Profile)
+	}
+	if t.IssuerLabel != "" && req.IssuerLabel != "" && req.IssuerLabel != t.IssuerLabel {
+		return nil, errors.Forbiddenf("the enrollment token is not valid for issuer: %s", req.IssuerLabel)
+	}
+
+	if t.NamePattern != "" {
+		rule, err := namePatternRule(t.NamePattern)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid name pattern")
+		}
+		names, err := requestNames(req.Request)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, name := range names {
+			if !rule.MatchString(name) {
+				return nil, errors.Forbiddenf("the enrollment token is not valid for name: %s", name)
+			}
+		}
+	}
+
+	return t, nil
+}
+
+// useEnrollmentToken consumes the enrollment token,
+// and returns the token with the time it was used at
+func (s *Service) useEnrollmentToken(ctx context.Context, t *model.EnrollmentToken) (*model.EnrollmentToken, error) {
+	used, err := s.db.UseEnrollmentToken(ctx, t.TokenHash, time.Now().UTC())
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// used concurrently, or expired
+			return nil, errors.Forbiddenf("invalid enrollment token")
+		}
+		return nil, errors.Trace(err)
+	}
+	return used, nil
+}
+
+// releaseEnrollmentToken returns the consumed enrollment token,
+// when the certificate was not issued
+func (s *Service) releaseEnrollmentToken(ctx context.Context, t *model.EnrollmentToken) {
+	err := s.db.ReleaseEnrollmentToken(ctx, t.TokenHash, t.UsedAt.Time)
+	if err != nil {
+		logger.Errorf("src=releaseEnrollmentToken, id=%d, err=[%v]", t.ID, errors.ErrorStack(err))
+	}
+}
+
+// namePatternRule returns RegExp, that must match the entire name
+func namePatternRule(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+// parseRequest returns the parsed PEM encoded certificate request
+func parseRequest(request string) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode([]byte(request))
+	if block == nil {
+		return nil, errors.BadRequestf("invalid PEM")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, errors.NewBadRequest(err, "failed to parse certificate request")
+	}
+	return csr, nil
+}
+
+// requestNames returns the Common Name and Subject Alternative Names
+// from PEM encoded certificate request
+func requestNames(request string) ([]string, error) {
+	csr, err := parseRequest(request)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var names []string
+	if csr.Subject.CommonName != "" {
+		names = append(names, csr.Subject.CommonName)
+	}
+	names = append(names, csr.DNSNames...)
+	names = append(names, csr.EmailAddresses...)
+	for _, ip := range csr.IPAddresses {
+		names = append(names, ip.String())
+	}
+	for _, u := range csr.URIs {
+		names = append(names, u.String())
+	}
+	return names, nil
+}
+
+// newEnrollmentToken returns a random token
+func newEnrollmentToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Trace(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// enrollmentTokenHash returns hex encoded SHA-256 hash of the token
+func enrollmentTokenHash(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,513 @@
+package est
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xhttp/header"
+	"github.com/go-phorce/dolly/xhttp/httperror"
+	"github.com/go-phorce/dolly/xhttp/identity"
+	"github.com/go-phorce/dolly/xhttp/marshal"
+	"github.com/go-phorce/dolly/xpki/certutil"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/config"
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/juju/errors"
+	"go.mozilla.org/pkcs7"
+)
+
+// EST operations, RFC 7030 3.2.2
+const (
+	OpCACerts        = "cacerts"
+	OpSimpleEnroll   = "simpleenroll"
+	OpSimpleReenroll = "simplereenroll"
+	OpCSRAttrs       = "csrattrs"
+)
+
+// EST content types, RFC 7030 3.2.4
+const (
+	contentTypePKCS7Certs = "application/pkcs7-mime; smime-type=certs-only"
+	contentTypePKCS10     = "application/pkcs10"
+	contentTypeCSRAttrs   = "application/csrattrs"
+)
+
+// maxRequestSize limits the size of PKCS#10 request
+const maxRequestSize = 64 * 1024
+
+// realm is used in WWW-Authenticate header for HTTP Basic authentication
+const realm = "trusty"
+
+func (s *Service) handle() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, p rest.Params) {
+		// the path is {op} for the default label, or {label}/{op}
+		name, op := DefaultLabel, ""
+		parts := strings.Split(strings.Trim(p.ByName("op"), "/"), "/")
+		switch len(parts) {
+		case 1:
+			op = parts[0]
+		case 2:
+			name, op = parts[0], parts[1]
+		default:
+			marshal.WriteJSON(w, r, httperror.WithNotFound("EST operation not supported: %s", p.ByName("op")))
+			return
+		}
+
+		label := s.labels[name]
+		if label == nil {
+			marshal.WriteJSON(w, r, httperror.WithNotFound("EST label not found: %s", name))
+			return
+		}
+
+		var h func(http.ResponseWriter, *http.Request, *config.ESTLabel)
+		method := http.MethodPost
+		switch op {
+		case OpCACerts:
+			h, method = s.cacerts, http.MethodGet
+		case OpCSRAttrs:
+			h, method = s.csrattrs, http.MethodGet
+		case OpSimpleEnroll:
+			h = s.simpleenroll
+		case OpSimpleReenroll:
+			h = s.simplereenroll
+		default:
+			marshal.WriteJSON(w, r, httperror.WithNotFound("EST operation not supported: %s", op))
+			return
+		}
+
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			marshal.WriteJSON(w, r, httperror.New(http.StatusMethodNotAllowed, httperror.InvalidRequest, "%s is not allowed for %s", r.Method, op))
+			return
+		}
+
+		h(w, r, label)
+	}
+}
+
+// cacerts returns the CA certificates, RFC 7030 4.1
+func (s *Service) cacerts(w http.ResponseWriter, r *http.Request, label *config.ESTLabel) {
+	issuer, err := s.issuer(label)
+	if err != nil {
+		logger.Errorf("src=cacerts, label=%s, err=[%v]", label.Label, errors.ErrorStack(err))
+		marshal.WriteJSON(w, r, httperror.WithUnexpected("issuer not found for label: %s", label.Label))
+		return
+	}
+
+	bundle := issuer.Bundle()
+	certs := bundle.Chain
+	if bundle.RootCert != nil && !containsCert(certs, bundle.RootCert) {
+		certs = append(certs, bundle.RootCert)
+	}
+
+	body, err := certsOnly(certs...)
+	if err != nil {
+		logger.Errorf("src=cacerts, label=%s, err=[%v]", label.Label, errors.ErrorStack(err))
+		marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to create PKCS#7: %s", err.Error()).WithCause(err))
+		return
+	}
+	writeBase64(w, contentTypePKCS7Certs, body)
+}
+
+// simpleenroll issues a certificate for the authenticated client, RFC 7030 4.2.1
+func (s *Service) simpleenroll(w http.ResponseWriter, r *http.Request, label *config.ESTLabel) {
+	idn := identity.ForRequest(r).Identity()
+	role := idn.Role()
+	if role == "" || role == identity.GuestRoleName {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=%q", realm))
+		marshal.WriteJSON(w, r, httperror.WithUnauthorized("authentication is required"))
+		return
+	}
+	if len(label.Roles) > 0 && !contains(label.Roles, role) {
+		marshal.WriteJSON(w, r, httperror.WithForbidden("the %q role is not allowed to enroll with %q label", role, label.Label))
+		return
+	}
+
+	req, err := readCSR(r)
+	if err != nil {
+		marshal.WriteJSON(w, r, httperror.WithInvalidRequest(err.Error()))
+		return
+	}
+
+	s.enroll(w, r, label, req, idn.String())
+}
+
+// simplereenroll renews the certificate presented by the client
+// in TLS handshake, RFC 7030 4.2.2.
+// The request is authorized by the certificate being renewed,
+// which must be issued by the label's issuer, registered and not revoked.
+func (s *Service) simplereenroll(w http.ResponseWriter, r *http.Request, label *config.ESTLabel) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=%q", realm))
+		marshal.WriteJSON(w, r, httperror.WithUnauthorized("client certificate is required"))
+		return
+	}
+	peer := r.TLS.PeerCertificates[0]
+
+	issuer, err := s.issuer(label)
+	if err != nil {
+		logger.Errorf("src=simplereenroll, label=%s, err=[%v]", label.Label, errors.ErrorStack(err))
+		marshal.WriteJSON(w, r, httperror.WithUnexpected("issuer not found for label: %s", label.Label))
+		return
+	}
+	if err = peer.CheckSignatureFrom(issuer.Bundle().Cert); err != nil {
+		marshal.WriteJSON(w, r, httperror.WithForbidden("client certificate is not issued by %q", issuer.Label()))
+		return
+	}
+
+	// the certificate must be registered, the revoked certificates
+	// are removed from the registered ones
+	_, err = s.db.GetCertificate(r.Context(), issuer.SubjectKID(), peer.SerialNumber.String())
+	if err != nil {
+		if errors.IsNotFound(err) {
+			marshal.WriteJSON(w, r, httperror.WithForbidden("client certificate is revoked or not registered"))
+		} else {
+			logger.Errorf("src=simplereenroll, reason=db, label=%s, serial=%s, err=[%v]",
+				label.Label, peer.SerialNumber.String(), errors.ErrorStack(err))
+			marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to check client certificate").WithCause(err))
+		}
+		return
+	}
+
+	req, err := readCSR(r)
+	if err != nil {
+		marshal.WriteJSON(w, r, httperror.WithInvalidRequest(err.Error()))
+		return
+	}
+
+	// the Subject and SubjectAltName must be identical
+	// to the certificate being renewed, RFC 7030 4.2.2
+	if !bytes.Equal(req.RawSubject, peer.RawSubject) {
+		marshal.WriteJSON(w, r, httperror.WithInvalidRequest("the subject does not match the client certificate"))
+		return
+	}
+	if !equalStrings(req.DNSNames, peer.DNSNames) ||
+		!equalStrings(req.EmailAddresses, peer.EmailAddresses) ||
+		!equalIPs(req, peer) ||
+		!equalURIs(req, peer) {
+		marshal.WriteJSON(w, r, httperror.WithInvalidRequest("the subject alt names do not match the client certificate"))
+		return
+	}
+
+	s.enroll(w, r, label, req, "cert:"+peer.Subject.String())
+}
+
+func (s *Service) enroll(w http.ResponseWriter, r *http.Request, label *config.ESTLabel, req *x509.CertificateRequest, callerID string) {
+	issuer, err := s.issuer(label)
+	if err != nil {
+		logger.Errorf("src=enroll, label=%s, err=[%v]", label.Label, errors.ErrorStack(err))
+		marshal.WriteJSON(w, r, httperror.WithUnexpected("issuer not found for label: %s", label.Label))
+		return
+	}
+
+	// the attributes are validated before signing,
+	// so the signed certificate can be registered
+	mcert := &model.Certificate{
+		Subject: req.Subject.String(),
+		Profile: label.Profile,
+		Role:    ServiceName,
+	}
+	if err = mcert.ValidateAttributes(); err != nil {
+		marshal.WriteJSON(w, r, httperror.WithInvalidRequest("invalid certificate request: %s", err.Error()))
+		return
+	}
+
+	cert, certPEM, err := issuer.SignContext(r.Context(), csr.SignRequest{
+		Request: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: req.Raw})),
+		Profile: label.Profile,
+	})
+	if err != nil {
+		logger.Errorf("src=enroll, label=%s, issuer=%s, profile=%s, err=[%v]",
+			label.Label, issuer.Label(), label.Profile, errors.ErrorStack(err))
+		if errors.IsBadRequest(err) || errors.IsForbidden(err) || errors.IsNotValid(err) {
+			marshal.WriteJSON(w, r, httperror.WithInvalidRequest("unable to sign certificate: %s", err.Error()))
+		} else {
+			marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to sign certificate: %s", err.Error()).WithCause(err))
+		}
+		return
+	}
+
+	mcert.SKID = certutil.GetSubjectKeyID(cert)
+	mcert.IKID = certutil.GetAuthorityKeyID(cert)
+	mcert.SerialNumber = cert.SerialNumber.String()
+	mcert.NotBefore = cert.NotBefore.UTC()
+	mcert.NotAfter = cert.NotAfter.UTC()
+	mcert.Subject = cert.Subject.String()
+	mcert.Pem = string(certPEM)
+
+	registered, err := s.db.CreateCertificate(r.Context(), mcert)
+	if err != nil {
+		// the signed certificate is valid, and must not be lost
+		logger.Errorf("src=enroll, reason=db, label=%s, serial=%s, pem=%q, err=[%v]",
+			label.Label, mcert.SerialNumber, mcert.Pem, errors.ErrorStack(err))
+		s.server.Audit(
+			trustyserver.EvtSourceCA,
+			trustyserver.EvtCertificateNotRegistered,
+			callerID,
+			identity.ForRequest(r).CorrelationID(),
+			0,
+			fmt.Sprintf("issuer=%s, profile=%s, serial=%s, skid=%s, ikid=%s, subject=%q, label=%s, err=%q",
+				issuer.Label(),
+				label.Profile,
+				mcert.SerialNumber,
+				mcert.SKID,
+				mcert.IKID,
+				mcert.Subject,
+				label.Label,
+				err.Error(),
+			))
+		marshal.WriteJSON(w, r, httperror.WithUnexpected("failed to register certificate").WithCause(err))
+		return
+	}
+	mcert = registered
+
+	s.server.Audit(
+		trustyserver.EvtSourceCA,
+		trustyserver.EvtCertificateIssued,
+		callerID,
+		identity.ForRequest(r).CorrelationID(),
+		0,
+		fmt.Sprintf("id=%d, issuer=%s, profile=%s, serial=%s, skid=%s, ikid=%s, subject=%q, label=%s, notBefore=%s, notAfter=%s",
+			mcert.ID,
+			issuer.Label(),
+			label.Profile,
+			mcert.SerialNumber,
+			mcert.SKID,
+			mcert.IKID,
+			mcert.Subject,
+			label.Label,
+			mcert.NotBefore.Format(time.RFC3339),
+			mcert.NotAfter.Format(time.RFC3339),
+		))
+
+	body, err := certsOnly(cert)
+	if err != nil {
+		logger.Errorf("src=enroll, label=%s, err=[%v]", label.Label, errors.ErrorStack(err))
+		marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to create PKCS#7: %s", err.Error()).WithCause(err))
+		return
+	}
+	writeBase64(w, contentTypePKCS7Certs, body)
+}
+
+// csrattrs returns the CSR attributes derived from the key policy
+// of the label's profile, RFC 7030 4.5
+func (s *Service) csrattrs(w http.ResponseWriter, r *http.Request, label *config.ESTLabel) {
+	issuer, err := s.issuer(label)
+	if err != nil {
+		logger.Errorf("src=csrattrs, label=%s, err=[%v]", label.Label, errors.ErrorStack(err))
+		marshal.WriteJSON(w, r, httperror.WithUnexpected("issuer not found for label: %s", label.Label))
+		return
+	}
+
+	profile := issuer.Profile(label.Profile)
+	if profile == nil || profile.AllowedKeys == nil {
+		// no attributes are required
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	body, err := csrAttrs(profile.AllowedKeys)
+	if err != nil {
+		logger.Errorf("src=csrattrs, label=%s, err=[%v]", label.Label, errors.ErrorStack(err))
+		marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to create CSR attributes: %s", err.Error()).WithCause(err))
+		return
+	}
+	writeBase64(w, contentTypeCSRAttrs, body)
+}
+
+// readCSR returns the base64 encoded PKCS#10 request from the body
+func readCSR(r *http.Request) (*x509.CertificateRequest, error) {
+	if ct := r.Header.Get(header.ContentType); ct != "" {
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil || mt != contentTypePKCS10 {
+			return nil, errors.Errorf("unsupported content type: %s", ct)
+		}
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		return nil, errors.Annotate(err, "unable to read request")
+	}
+
+	der, err := decodeBase64(body)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid base64 encoding")
+	}
+
+	req, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid PKCS#10 request")
+	}
+	if err = req.CheckSignature(); err != nil {
+		return nil, errors.Annotate(err, "invalid CSR signature")
+	}
+	return req, nil
+}
+
+// certsOnly returns degenerate PKCS#7 with the certificates
+func certsOnly(certs ...*x509.Certificate) ([]byte, error) {
+	var chain []byte
+	for _, c := range certs {
+		chain = append(chain, c.Raw...)
+	}
+	return pkcs7.DegenerateCertificate(chain)
+}
+
+// Key algorithms and curves used in CSR attributes
+var (
+	oidPublicKeyRSA     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidPublicKeyECDSA   = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidPublicKeyEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+	oidCurves = map[string]asn1.ObjectIdentifier{
+		"P-256": {1, 2, 840, 10045, 3, 1, 7},
+		"P-384": {1, 3, 132, 0, 34},
+		"P-521": {1, 3, 132, 0, 35},
+	}
+)
+
+// csrAttribute is Attribute in AttrOrOID choice
+type csrAttribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.ObjectIdentifier `asn1:"set"`
+}
+
+// csrAttrs returns DER encoded CsrAttrs, which is SEQUENCE of AttrOrOID,
+// with the key algorithms allowed by the policy
+func csrAttrs(policy *authority.KeyPolicy) ([]byte, error) {
+	algs := policy.Algorithms
+	if len(algs) == 0 {
+		algs = []string{authority.KeyAlgorithmRSA, authority.KeyAlgorithmECDSA, authority.KeyAlgorithmEd25519}
+	}
+
+	var attrs []asn1.RawValue
+	for _, alg := range algs {
+		var val interface{}
+		switch strings.ToUpper(alg) {
+		case strings.ToUpper(authority.KeyAlgorithmRSA):
+			val = oidPublicKeyRSA
+		case strings.ToUpper(authority.KeyAlgorithmEd25519):
+			val = oidPublicKeyEd25519
+		case strings.ToUpper(authority.KeyAlgorithmECDSA):
+			curves := policy.Curves
+			if len(curves) == 0 {
+				curves = []string{"P-256", "P-384", "P-521"}
+			}
+			attr := csrAttribute{Type: oidPublicKeyECDSA}
+			for _, c := range curves {
+				if oid, ok := oidCurves[strings.ToUpper(c)]; ok {
+					attr.Values = append(attr.Values, oid)
+				}
+			}
+			val = attr
+		default:
+			return nil, errors.Errorf("unsupported key algorithm: %s", alg)
+		}
+
+		der, err := asn1.Marshal(val)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		attrs = append(attrs, asn1.RawValue{FullBytes: der})
+	}
+	return asn1.Marshal(attrs)
+}
+
+// writeBase64 writes the body with base64 Content-Transfer-Encoding,
+// RFC 7030 4.1.3
+func writeBase64(w http.ResponseWriter, contentType string, body []byte) {
+	w.Header().Set(header.ContentType, contentType)
+	w.Header().Set("Content-Transfer-Encoding", "base64")
+	w.WriteHeader(http.StatusOK)
+	w.Write(encodeBase64(body))
+}
+
+// encodeBase64 returns base64 encoding with 76 characters lines, RFC 2045
+func encodeBase64(b []byte) []byte {
+	s := base64.StdEncoding.EncodeToString(b)
+	var buf bytes.Buffer
+	for len(s) > 76 {
+		buf.WriteString(s[:76])
+		buf.WriteString("\r\n")
+		s = s[76:]
+	}
+	buf.WriteString(s)
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
+
+// decodeBase64 decodes base64 ignoring the line breaks and white spaces
+func decodeBase64(b []byte) ([]byte, error) {
+	s := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\r', '\n':
+			return -1
+		}
+		return r
+	}, string(b))
+	return base64.StdEncoding.DecodeString(s)
+}
+
+func containsCert(list []*x509.Certificate, c *x509.Certificate) bool {
+	for _, l := range list {
+		if l.Equal(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, val string) bool {
+	for _, l := range list {
+		if l == val {
+			return true
+		}
+	}
+	return false
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, v := range a {
+		if !contains(b, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalIPs(req *x509.CertificateRequest, crt *x509.Certificate) bool {
+	var a, b []string
+	for _, ip := range req.IPAddresses {
+		a = append(a, ip.String())
+	}
+	for _, ip := range crt.IPAddresses {
+		b = append(b, ip.String())
+	}
+	return equalStrings(a, b)
+}
+
+func equalURIs(req *x509.CertificateRequest, crt *x509.Certificate) bool {
+	var a, b []string
+	for _, u := range req.URIs {
+		a = append(a, u.String())
+	}
+	for _, u := range crt.URIs {
+		b = append(b, u.String())
+	}
+	return equalStrings(a, b)
+}
@@ -0,0 +1,105 @@
+package est
+
+import (
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xlog"
+	v1 "github.com/go-phorce/trusty/api/v1"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/config"
+	"github.com/go-phorce/trusty/internal/db"
+	"github.com/juju/errors"
+)
+
+// ServiceName provides the Service Name for this package
+const ServiceName = "est"
+
+// DefaultLabel specifies the label for requests without a label
+const DefaultLabel = "default"
+
+var logger = xlog.NewPackageLogger("github.com/go-phorce/trusty/backend/service", "est")
+
+// IssuerProvider provides the issuer by label or certificate profile,
+// authority.Authority implements the interface
+type IssuerProvider interface {
+	GetIssuerByLabel(label string) (*authority.Issuer, error)
+	GetIssuerByProfile(profile string) (*authority.Issuer, error)
+}
+
+// Service defines the EST service, RFC 7030
+type Service struct {
+	server *trustyserver.TrustyServer
+	ca     IssuerProvider
+	db     db.Provider
+	labels map[string]*config.ESTLabel
+}
+
+// Factory returns a factory of the service
+func Factory(server *trustyserver.TrustyServer) interface{} {
+	if server == nil {
+		logger.Panic("est.Factory: invalid parameter")
+	}
+
+	return func(cfg *config.Configuration, ca *authority.Authority, db db.Provider) error {
+		svc, err := newService(server, &cfg.EST, ca, db)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		server.AddService(svc)
+		return nil
+	}
+}
+
+func newService(
+	server *trustyserver.TrustyServer,
+	cfg *config.EST,
+	ca IssuerProvider,
+	db db.Provider,
+) (*Service, error) {
+	svc := &Service{
+		server: server,
+		ca:     ca,
+		db:     db,
+		labels: map[string]*config.ESTLabel{},
+	}
+
+	for i := range cfg.Labels {
+		l := &cfg.Labels[i]
+		if l.Label == "" || l.Profile == "" {
+			return nil, errors.Errorf("EST label and profile are required")
+		}
+		if _, ok := svc.labels[l.Label]; ok {
+			return nil, errors.Errorf("duplicate EST label: %s", l.Label)
+		}
+		svc.labels[l.Label] = l
+	}
+	return svc, nil
+}
+
+// Name returns the service name
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// IsReady indicates that the service is ready to serve its end-points
+func (s *Service) IsReady() bool {
+	return true
+}
+
+// Close the subservices and it's resources
+func (s *Service) Close() {
+}
+
+// RegisterRoute adds the EST API endpoints to the overall URL router
+func (s *Service) RegisterRoute(r rest.Router) {
+	r.GET(v1.PathForESTOperation, s.handle())
+	r.POST(v1.PathForESTOperation, s.handle())
+}
+
+// issuer returns the issuer for the label
+func (s *Service) issuer(label *config.ESTLabel) (*authority.Issuer, error) {
+	if label.Issuer != "" {
+		return s.ca.GetIssuerByLabel(label.Issuer)
+	}
+	return s.ca.GetIssuerByProfile(label.Profile)
+}
@@ -0,0 +1,540 @@
+package est
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/dolly/audit"
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xhttp/header"
+	"github.com/go-phorce/dolly/xhttp/identity"
+	"github.com/go-phorce/dolly/xpki/cryptoprov"
+	v1 "github.com/go-phorce/trusty/api/v1"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/config"
+	"github.com/go-phorce/trusty/internal/db"
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/go-phorce/trusty/pkg/roles"
+	"github.com/go-phorce/trusty/tests/testutils"
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mozilla.org/pkcs7"
+	"go.uber.org/dig"
+)
+
+type testSuite struct {
+	suite.Suite
+
+	server  *trustyserver.TrustyServer
+	svc     *Service
+	baseURL string
+	rootCA  *x509.Certificate
+	issuer  *authority.Issuer
+	db      *fakeDB
+}
+
+func TestEST(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) SetupSuite() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "[TEST] Trusty EST Root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	s.Require().NoError(err)
+	s.rootCA, err = x509.ParseCertificate(der)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		Profiles: map[string]*authority.CertProfile{
+			"server": {
+				Usage:  []string{"signing", "server auth"},
+				Expiry: csr.Duration(90 * 24 * time.Hour),
+				AllowedKeys: &authority.KeyPolicy{
+					Algorithms: []string{authority.KeyAlgorithmECDSA},
+					Curves:     []string{"P-256", "P-384"},
+				},
+			},
+			"client": {
+				Usage:  []string{"signing", "client auth"},
+				Expiry: csr.Duration(90 * 24 * time.Hour),
+			},
+		},
+	}
+	rootPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	s.issuer, err = authority.CreateIssuer("TrustyEST", caCfg, rootPEM, nil, nil, key)
+	s.Require().NoError(err)
+
+	p, err := roles.New("", "", "../../../pkg/roles/basicmapper/testdata/roles.json")
+	s.Require().NoError(err)
+	identity.SetGlobalIdentityMapper(p.IdentityMapper)
+
+	cfg := &config.EST{
+		Labels: []config.ESTLabel{
+			{Label: DefaultLabel, Profile: "server", Roles: []string{"trusty-client"}},
+			{Label: "client", Profile: "client", Issuer: "TrustyEST"},
+			{Label: "missing", Profile: "missing"},
+		},
+	}
+
+	s.db = &fakeDB{}
+	factories := map[string]trustyserver.ServiceFactory{
+		ServiceName: func(server *trustyserver.TrustyServer) interface{} {
+			return func() error {
+				svc, err := newService(server, cfg, fakeIssuers{s.issuer}, s.db)
+				if err != nil {
+					return err
+				}
+				server.AddService(svc)
+				return nil
+			}
+		},
+	}
+
+	c := dig.New()
+	c.Provide(func() (rest.Authz, audit.Auditor, *cryptoprov.Crypto, db.Provider) {
+		return nil, nil, nil, nil
+	})
+
+	s.baseURL = testutils.CreateURLs("http", "localhost")
+	s.server, err = trustyserver.StartTrusty(&config.HTTPServer{
+		Name:       "EST",
+		ListenURLs: []string{s.baseURL},
+		Services:   []string{ServiceName},
+	}, c, factories)
+	s.Require().NoError(err)
+	s.svc = s.server.Service(ServiceName).(*Service)
+
+	// wait for the server to start
+	for i := 0; i < 10; i++ {
+		if s.server.IsReady() {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func (s *testSuite) TearDownSuite() {
+	if s.server != nil {
+		s.server.Close()
+	}
+	identity.SetGlobalIdentityMapper(identity.GuestIdentityMapper)
+}
+
+func (s *testSuite) TestCACerts() {
+	for _, path := range []string{
+		v1.PathForEST + "/cacerts",
+		v1.PathForEST + "/client/cacerts",
+	} {
+		res, body := s.do(http.MethodGet, path, "", nil)
+		s.Require().Equal(http.StatusOK, res.StatusCode, path)
+		s.Equal(contentTypePKCS7Certs, res.Header.Get(header.ContentType))
+		s.Equal("base64", res.Header.Get("Content-Transfer-Encoding"))
+
+		certs := s.parseCerts(body)
+		s.Require().Len(certs, 1)
+		s.True(certs[0].Equal(s.rootCA))
+	}
+
+	res, _ := s.do(http.MethodGet, v1.PathForEST+"/unknown/cacerts", "", nil)
+	s.Equal(http.StatusNotFound, res.StatusCode)
+
+	res, _ = s.do(http.MethodGet, v1.PathForEST+"/serverkeygen", "", nil)
+	s.Equal(http.StatusNotFound, res.StatusCode)
+
+	res, _ = s.do(http.MethodGet, v1.PathForEST+"/client/cacerts/more", "", nil)
+	s.Equal(http.StatusNotFound, res.StatusCode)
+
+	res, _ = s.do(http.MethodPost, v1.PathForEST+"/cacerts", "", nil)
+	s.Equal(http.StatusMethodNotAllowed, res.StatusCode)
+	s.Equal(http.MethodGet, res.Header.Get("Allow"))
+
+	res, _ = s.do(http.MethodGet, v1.PathForEST+"/missing/cacerts", "", nil)
+	s.Equal(http.StatusInternalServerError, res.StatusCode)
+}
+
+func (s *testSuite) TestCSRAttrs() {
+	res, body := s.do(http.MethodGet, v1.PathForEST+"/csrattrs", "", nil)
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	s.Equal(contentTypeCSRAttrs, res.Header.Get(header.ContentType))
+
+	der, err := decodeBase64(body)
+	s.Require().NoError(err)
+
+	var attrs []asn1.RawValue
+	remaining, err := asn1.Unmarshal(der, &attrs)
+	s.Require().NoError(err)
+	s.Empty(remaining)
+	s.Require().Len(attrs, 1)
+
+	var attr csrAttribute
+	_, err = asn1.Unmarshal(attrs[0].FullBytes, &attr)
+	s.Require().NoError(err)
+	s.True(attr.Type.Equal(oidPublicKeyECDSA))
+	s.ElementsMatch([]asn1.ObjectIdentifier{oidCurves["P-256"], oidCurves["P-384"]}, attr.Values)
+
+	res, _ = s.do(http.MethodGet, v1.PathForEST+"/client/csrattrs", "", nil)
+	s.Equal(http.StatusNoContent, res.StatusCode)
+}
+
+func (s *testSuite) TestSimpleEnroll() {
+	path := v1.PathForEST + "/simpleenroll"
+	_, req := s.createCSR("est.trusty.com", "est.trusty.com")
+
+	res, _ := s.do(http.MethodPost, path, "", req)
+	s.Equal(http.StatusUnauthorized, res.StatusCode)
+	s.Equal(`Basic realm="trusty"`, res.Header.Get("WWW-Authenticate"))
+
+	res, _ = s.do(http.MethodPost, path, "est-client:admin-secret", req)
+	s.Equal(http.StatusUnauthorized, res.StatusCode)
+
+	res, _ = s.do(http.MethodPost, path, "admin:admin-secret", req)
+	s.Equal(http.StatusForbidden, res.StatusCode)
+
+	res, _ = s.do(http.MethodPost, path, "est-client:est-secret", []byte("not base64"))
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+
+	res, _ = s.do(http.MethodPost, path, "est-client:est-secret", []byte(base64.StdEncoding.EncodeToString([]byte("not CSR"))))
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+
+	res, body := s.do(http.MethodPost, path, "est-client:est-secret", req)
+	s.Require().Equal(http.StatusOK, res.StatusCode, string(body))
+	s.Equal(contentTypePKCS7Certs, res.Header.Get(header.ContentType))
+
+	certs := s.parseCerts(body)
+	s.Require().Len(certs, 1)
+	s.Equal("est.trusty.com", certs[0].Subject.CommonName)
+	s.Equal([]string{"est.trusty.com"}, certs[0].DNSNames)
+	s.NoError(certs[0].CheckSignatureFrom(s.rootCA))
+	s.NotNil(s.db.find(certs[0].SerialNumber.String()))
+
+	// the key is not allowed by the profile
+	res, _ = s.do(http.MethodPost, path, "est-client:est-secret", s.rsaCSR("est.trusty.com"))
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+
+	// any authenticated role is allowed by the client label
+	res, body = s.do(http.MethodPost, v1.PathForEST+"/client/simpleenroll", "admin:admin-secret", req)
+	s.Require().Equal(http.StatusOK, res.StatusCode, string(body))
+
+	// the certificate, that can not be registered, is not signed
+	count := len(s.db.certs)
+	_, long := s.createCSR(strings.Repeat("a", 300))
+	res, body = s.do(http.MethodPost, path, "est-client:est-secret", long)
+	s.Equal(http.StatusBadRequest, res.StatusCode, string(body))
+	s.Contains(string(body), "invalid subject")
+
+	// the certificate is not returned, if it is not registered
+	s.db.setError(errors.New("db is down"))
+	defer s.db.setError(nil)
+	res, body = s.do(http.MethodPost, path, "est-client:est-secret", req)
+	s.Equal(http.StatusInternalServerError, res.StatusCode, string(body))
+	s.Equal(count, len(s.db.certs))
+}
+
+func (s *testSuite) TestSimpleReenroll() {
+	key, req := s.createCSR("peer.trusty.com", "peer.trusty.com")
+	res, body := s.do(http.MethodPost, v1.PathForEST+"/simpleenroll", "est-client:est-secret", req)
+	s.Require().Equal(http.StatusOK, res.StatusCode, string(body))
+	peer := s.parseCerts(body)[0]
+
+	// the server does not use TLS
+	res, _ = s.do(http.MethodPost, v1.PathForEST+"/simplereenroll", "est-client:est-secret", req)
+	s.Equal(http.StatusUnauthorized, res.StatusCode)
+
+	h := s.svc.handle()
+	params := rest.Params{{Key: "op", Value: "/" + OpSimpleReenroll}}
+	reenroll := func(peer *x509.Certificate, body []byte) *httptest.ResponseRecorder {
+		r, err := http.NewRequest(http.MethodPost, v1.PathForEST+"/simplereenroll", strings.NewReader(string(body)))
+		s.Require().NoError(err)
+		r.Header.Set(header.ContentType, contentTypePKCS10)
+		r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{peer}}
+		w := httptest.NewRecorder()
+		h(w, r, params)
+		return w
+	}
+
+	_, other := s.createCSR("other.trusty.com", "other.trusty.com")
+	w := reenroll(peer, other)
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "the subject does not match the client certificate")
+
+	_, other = s.createCSRWithKey(key, "peer.trusty.com", "peer.trusty.com", "other.trusty.com")
+	w = reenroll(peer, other)
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "the subject alt names do not match the client certificate")
+
+	w = reenroll(s.selfSigned("peer.trusty.com"), req)
+	s.Equal(http.StatusForbidden, w.Code, w.Body.String())
+
+	// rekey
+	_, req = s.createCSR("peer.trusty.com", "peer.trusty.com")
+	w = reenroll(peer, req)
+	s.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+
+	certs := s.parseCerts(w.Body.Bytes())
+	s.Require().Len(certs, 1)
+	s.Equal(peer.Subject.String(), certs[0].Subject.String())
+	s.NotEqual(peer.SerialNumber, certs[0].SerialNumber)
+
+	// not registered
+	der, err := base64.StdEncoding.DecodeString(string(req))
+	s.Require().NoError(err)
+	unknown, _, err := s.issuer.Sign(csr.SignRequest{
+		Request: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})),
+		Profile: "server",
+	})
+	s.Require().NoError(err)
+	w = reenroll(unknown, req)
+	s.Equal(http.StatusForbidden, w.Code, w.Body.String())
+	s.Contains(w.Body.String(), "client certificate is revoked or not registered")
+
+	// revoked
+	registered := s.db.find(peer.SerialNumber.String())
+	s.Require().NotNil(registered)
+	_, err = s.db.RevokeCertificate(context.Background(), &model.RevokedCertificate{
+		Certificate: *registered,
+		RevokedAt:   time.Now(),
+	})
+	s.Require().NoError(err)
+	w = reenroll(peer, req)
+	s.Equal(http.StatusForbidden, w.Code, w.Body.String())
+	s.Contains(w.Body.String(), "client certificate is revoked or not registered")
+}
+
+func TestNewService(t *testing.T) {
+	_, err := newService(nil, &config.EST{
+		Labels: []config.ESTLabel{{Label: DefaultLabel}},
+	}, nil, nil)
+	require.Error(t, err)
+	assert.Equal(t, "EST label and profile are required", err.Error())
+
+	_, err = newService(nil, &config.EST{
+		Labels: []config.ESTLabel{
+			{Label: DefaultLabel, Profile: "server"},
+			{Label: DefaultLabel, Profile: "client"},
+		},
+	}, nil, nil)
+	require.Error(t, err)
+	assert.Equal(t, "duplicate EST label: default", err.Error())
+}
+
+func TestCSRAttrsPolicy(t *testing.T) {
+	der, err := csrAttrs(&authority.KeyPolicy{})
+	require.NoError(t, err)
+
+	var attrs []asn1.RawValue
+	_, err = asn1.Unmarshal(der, &attrs)
+	require.NoError(t, err)
+	require.Len(t, attrs, 3)
+
+	var oid asn1.ObjectIdentifier
+	_, err = asn1.Unmarshal(attrs[0].FullBytes, &oid)
+	require.NoError(t, err)
+	assert.True(t, oid.Equal(oidPublicKeyRSA))
+
+	var attr csrAttribute
+	_, err = asn1.Unmarshal(attrs[1].FullBytes, &attr)
+	require.NoError(t, err)
+	assert.Len(t, attr.Values, 3)
+
+	_, err = asn1.Unmarshal(attrs[2].FullBytes, &oid)
+	require.NoError(t, err)
+	assert.True(t, oid.Equal(oidPublicKeyEd25519))
+}
+
+func TestBase64(t *testing.T) {
+	data := make([]byte, 200)
+	rand.Read(data)
+
+	enc := encodeBase64(data)
+	for _, line := range strings.Split(strings.TrimSpace(string(enc)), "\r\n") {
+		assert.True(t, len(line) <= 76)
+	}
+
+	dec, err := decodeBase64(enc)
+	require.NoError(t, err)
+	assert.Equal(t, data, dec)
+}
+
+func (s *testSuite) do(method, path, auth string, body []byte) (*http.Response, []byte) {
+	req, err := http.NewRequest(method, s.baseURL+path, strings.NewReader(string(body)))
+	s.Require().NoError(err)
+	if body != nil {
+		req.Header.Set(header.ContentType, contentTypePKCS10)
+	}
+	if auth != "" {
+		parts := strings.SplitN(auth, ":", 2)
+		req.SetBasicAuth(parts[0], parts[1])
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	s.Require().NoError(err)
+	return res, b
+}
+
+func (s *testSuite) parseCerts(body []byte) []*x509.Certificate {
+	der, err := decodeBase64(body)
+	s.Require().NoError(err)
+
+	p7, err := pkcs7.Parse(der)
+	s.Require().NoError(err)
+	return p7.Certificates
+}
+
+// createCSR returns the key and base64 encoded CSR
+func (s *testSuite) createCSR(cn string, dnsNames ...string) (crypto.Signer, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	return s.createCSRWithKey(key, cn, dnsNames...)
+}
+
+func (s *testSuite) createCSRWithKey(key crypto.Signer, cn string, dnsNames ...string) (crypto.Signer, []byte) {
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: cn},
+		DNSNames: dnsNames,
+	}, key)
+	s.Require().NoError(err)
+	return key, encodeBase64(der)
+}
+
+func (s *testSuite) rsaCSR(cn string) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	_, req := s.createCSRWithKey(key, cn, cn)
+	return req
+}
+
+func (s *testSuite) selfSigned(cn string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	s.Require().NoError(err)
+	crt, err := x509.ParseCertificate(der)
+	s.Require().NoError(err)
+	return crt
+}
+
+type fakeIssuers []*authority.Issuer
+
+func (f fakeIssuers) GetIssuerByLabel(label string) (*authority.Issuer, error) {
+	for _, issuer := range f {
+		if issuer.Label() == label {
+			return issuer, nil
+		}
+	}
+	return nil, errors.NotFoundf("issuer %q", label)
+}
+
+func (f fakeIssuers) GetIssuerByProfile(profile string) (*authority.Issuer, error) {
+	for _, issuer := range f {
+		if issuer.Profile(profile) != nil {
+			return issuer, nil
+		}
+	}
+	return nil, errors.NotFoundf("issuer for profile %q", profile)
+}
+
+type fakeDB struct {
+	db.Provider
+
+	lock  sync.Mutex
+	certs []*model.Certificate
+	err   error
+}
+
+func (f *fakeDB) CreateCertificate(_ context.Context, crt *model.Certificate) (*model.Certificate, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	c := *crt
+	c.ID = int64(len(f.certs) + 1)
+	f.certs = append(f.certs, &c)
+	return &c, nil
+}
+
+func (f *fakeDB) GetCertificate(_ context.Context, ikid, sn string) (*model.Certificate, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, c := range f.certs {
+		if c.IKID == ikid && c.SerialNumber == sn {
+			return c, nil
+		}
+	}
+	return nil, errors.NotFoundf("certificate")
+}
+
+func (f *fakeDB) RevokeCertificate(_ context.Context, crt *model.RevokedCertificate) (*model.RevokedCertificate, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for i, c := range f.certs {
+		if c.IKID == crt.Certificate.IKID && c.SerialNumber == crt.Certificate.SerialNumber {
+			f.certs = append(f.certs[:i], f.certs[i+1:]...)
+			return crt, nil
+		}
+	}
+	return nil, errors.NotFoundf("certificate")
+}
+
+func (f *fakeDB) setError(err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.err = err
+}
+
+func (f *fakeDB) find(serial string) *model.Certificate {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, c := range f.certs {
+		if c.SerialNumber == serial {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package scep
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/go-phorce/trusty/internal/db"
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+)
+
+const (
+	// defaultChallengeExpiry specifies the default expiry of challenge passwords
+	defaultChallengeExpiry = 24 * time.Hour
+)
+
+// challenges provides one-time challenge passwords,
+// only the hash of the password is stored in the DB,
+// so the password can be used on any node.
+type challenges struct {
+	db      db.SCEPChallengesDb
+	profile string
+	expiry  time.Duration
+}
+
+func newChallenges(db db.SCEPChallengesDb, profile string, expiry time.Duration) *challenges {
+	if expiry <= 0 {
+		expiry = defaultChallengeExpiry
+	}
+	return &challenges{
+		db:      db,
+		profile: profile,
+		expiry:  expiry,
+	}
+}
+
+// New returns a new challenge password and its expiry
+func (c *challenges) New(ctx context.Context, requestor string) (string, time.Time, error) {
+	// hex encoding is used, as the password is sent in PrintableString
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, errors.Trace(err)
+	}
+	password := hex.EncodeToString(b)
+
+	now := time.Now().UTC()
+	ch, err := c.db.CreateSCEPChallenge(ctx, &model.SCEPChallenge{
+		ChallengeHash: challengeHash(password),
+		Profile:       c.profile,
+		CreatedBy:     requestor,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(c.expiry),
+	})
+	if err != nil {
+		return "", time.Time{}, errors.Trace(err)
+	}
+	return password, ch.ExpiresAt, nil
+}
+
+// Use consumes the challenge password, that was issued
+// for the profile and not used yet, the password can be used only once.
+// NotFound error is returned if the password is not valid
+func (c *challenges) Use(ctx context.Context, password string) error {
+	if password == "" {
+		return errors.NotFoundf("challenge")
+	}
+	ch, err := c.db.UseSCEPChallenge(ctx, challengeHash(password), time.Now().UTC())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ch.Profile != c.profile {
+		return errors.NotFoundf("challenge for profile %q", c.profile)
+	}
+	return nil
+}
+
+func challengeHash(password string) string {
+	h := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(h[:])
+}
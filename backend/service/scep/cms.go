@@ -0,0 +1,486 @@
+package scep
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"go.mozilla.org/pkcs7"
+)
+
+// SCEP message types, RFC 8894 3.2.1.2
+const (
+	msgTypeCertRep = "3"
+	msgTypePKCSReq = "19"
+)
+
+// SCEP pkiStatus values, RFC 8894 3.2.1.3
+const (
+	statusSuccess = "0"
+	statusFailure = "2"
+)
+
+// SCEP failInfo values, RFC 8894 3.2.1.4
+const (
+	failBadAlg          = "0"
+	failBadMessageCheck = "1"
+	failBadRequest      = "2"
+)
+
+var (
+	oidMessageType       = asn1.ObjectIdentifier{2, 16, 840, 1, 113733, 1, 9, 2}
+	oidPKIStatus         = asn1.ObjectIdentifier{2, 16, 840, 1, 113733, 1, 9, 3}
+	oidFailInfo          = asn1.ObjectIdentifier{2, 16, 840, 1, 113733, 1, 9, 4}
+	oidSenderNonce       = asn1.ObjectIdentifier{2, 16, 840, 1, 113733, 1, 9, 5}
+	oidRecipientNonce    = asn1.ObjectIdentifier{2, 16, 840, 1, 113733, 1, 9, 6}
+	oidTransactionID     = asn1.ObjectIdentifier{2, 16, 840, 1, 113733, 1, 9, 7}
+	oidChallengePassword = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 7}
+)
+
+// pkiMessage is SCEP request, RFC 8894 3.2
+type pkiMessage struct {
+	MessageType   string
+	TransactionID string
+	SenderNonce   []byte
+	// Signer is the certificate of the requester,
+	// the response is encrypted for its key
+	Signer          *x509.Certificate
+	DigestAlgorithm asn1.ObjectIdentifier
+	// Envelope is DER encoded pkcsPKIEnvelope
+	Envelope []byte
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+type issuerAndSerial struct {
+	IssuerName   asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type envelopedData struct {
+	Version              int
+	RecipientInfos       []recipientInfo `asn1:"set"`
+	EncryptedContentInfo encryptedContentInfo
+}
+
+type recipientInfo struct {
+	Version                int
+	IssuerAndSerialNumber  issuerAndSerial
+	KeyEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedKey           []byte
+}
+
+type encryptedContentInfo struct {
+	ContentType                asn1.ObjectIdentifier
+	ContentEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedContent           asn1.RawValue `asn1:"tag:0,optional"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      contentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type signerInfo struct {
+	Version                   int
+	IssuerAndSerialNumber     issuerAndSerial
+	DigestAlgorithm           pkix.AlgorithmIdentifier
+	AuthenticatedAttributes   asn1.RawValue `asn1:"optional"`
+	DigestEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedDigest           []byte
+}
+
+type attribute struct {
+	Type  asn1.ObjectIdentifier
+	Value asn1.RawValue
+}
+
+// parsePKIMessage returns SCEP request.
+// If the message is parsed, but the signature is invalid,
+// then the message is returned with an error, to reply with failure.
+func parsePKIMessage(der []byte) (*pkiMessage, error) {
+	p7, err := pkcs7.Parse(der)
+	if err != nil {
+		return nil, errors.Annotate(err, "unable to parse PKCS#7")
+	}
+
+	signer := p7.GetOnlySigner()
+	if signer == nil {
+		return nil, errors.New("the message must have one signer")
+	}
+
+	msg := &pkiMessage{
+		Signer:          signer,
+		DigestAlgorithm: p7.Signers[0].DigestAlgorithm.Algorithm,
+		Envelope:        p7.Content,
+	}
+	if err = p7.UnmarshalSignedAttribute(oidMessageType, &msg.MessageType); err != nil {
+		return nil, errors.Annotate(err, "invalid messageType")
+	}
+	if err = p7.UnmarshalSignedAttribute(oidTransactionID, &msg.TransactionID); err != nil {
+		return nil, errors.Annotate(err, "invalid transactionID")
+	}
+	if err = p7.UnmarshalSignedAttribute(oidSenderNonce, &msg.SenderNonce); err != nil {
+		return nil, errors.Annotate(err, "invalid senderNonce")
+	}
+
+	if err = p7.Verify(); err != nil {
+		return msg, errors.Annotate(err, "invalid signature")
+	}
+	return msg, nil
+}
+
+// decryptEnvelope returns the content of DER encoded EnvelopedData,
+// and the content encryption algorithm
+func decryptEnvelope(der []byte, cert *x509.Certificate, key crypto.Decrypter) ([]byte, asn1.ObjectIdentifier, error) {
+	var ci contentInfo
+	if _, err := asn1.Unmarshal(der, &ci); err != nil {
+		return nil, nil, errors.Annotate(err, "unable to parse envelope")
+	}
+	if !ci.ContentType.Equal(pkcs7.OIDEnvelopedData) {
+		return nil, nil, errors.Errorf("unexpected content type: %s", ci.ContentType)
+	}
+
+	var ed envelopedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &ed); err != nil {
+		return nil, nil, errors.Annotate(err, "unable to parse envelope")
+	}
+
+	var ri *recipientInfo
+	for i := range ed.RecipientInfos {
+		ias := ed.RecipientInfos[i].IssuerAndSerialNumber
+		if bytes.Equal(ias.IssuerName.FullBytes, cert.RawIssuer) && ias.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			ri = &ed.RecipientInfos[i]
+			break
+		}
+	}
+	if ri == nil {
+		return nil, nil, errors.New("the envelope is not encrypted for RA certificate")
+	}
+
+	contentKey, err := key.Decrypt(rand.Reader, ri.EncryptedKey, &rsa.PKCS1v15DecryptOptions{})
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "unable to decrypt the content key")
+	}
+
+	eci := ed.EncryptedContentInfo
+	ciphertext := eci.EncryptedContent.Bytes
+	if eci.EncryptedContent.IsCompound {
+		// constructed OCTET STRING
+		ciphertext = nil
+		rest := eci.EncryptedContent.Bytes
+		for len(rest) > 0 {
+			var part []byte
+			if rest, err = asn1.Unmarshal(rest, &part); err != nil {
+				return nil, nil, errors.Annotate(err, "invalid encrypted content")
+			}
+			ciphertext = append(ciphertext, part...)
+		}
+	}
+
+	alg := eci.ContentEncryptionAlgorithm.Algorithm
+	block, err := newCipher(alg, contentKey)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	var iv []byte
+	if _, err = asn1.Unmarshal(eci.ContentEncryptionAlgorithm.Parameters.FullBytes, &iv); err != nil {
+		return nil, nil, errors.Annotate(err, "invalid IV")
+	}
+	if len(iv) != block.BlockSize() || len(ciphertext) == 0 || len(ciphertext)%block.BlockSize() != 0 {
+		return nil, nil, errors.New("invalid encrypted content")
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+
+	plaintext, err = unpad(plaintext, block.BlockSize())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return plaintext, alg, nil
+}
+
+// encryptEnvelope returns DER encoded EnvelopedData with the content
+// encrypted for the recipient
+func encryptEnvelope(content []byte, recipient *x509.Certificate, alg asn1.ObjectIdentifier) ([]byte, error) {
+	pub, ok := recipient.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("unsupported recipient key: %T", recipient.PublicKey)
+	}
+
+	key := make([]byte, keySize(alg))
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Trace(err)
+	}
+	block, err := newCipher(alg, key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	iv := make([]byte, block.BlockSize())
+	if _, err = rand.Read(iv); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	plaintext := pad(content, block.BlockSize())
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, plaintext)
+
+	encryptedKey, err := rsa.EncryptPKCS1v15(rand.Reader, pub, key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	ivDER, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	ed := envelopedData{
+		RecipientInfos: []recipientInfo{
+			{
+				IssuerAndSerialNumber: issuerAndSerial{
+					IssuerName:   asn1.RawValue{FullBytes: recipient.RawIssuer},
+					SerialNumber: recipient.SerialNumber,
+				},
+				KeyEncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: pkcs7.OIDEncryptionAlgorithmRSA},
+				EncryptedKey:           encryptedKey,
+			},
+		},
+		EncryptedContentInfo: encryptedContentInfo{
+			ContentType: pkcs7.OIDData,
+			ContentEncryptionAlgorithm: pkix.AlgorithmIdentifier{
+				Algorithm:  alg,
+				Parameters: asn1.RawValue{FullBytes: ivDER},
+			},
+			EncryptedContent: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, Bytes: ciphertext},
+		},
+	}
+
+	return marshalContentInfo(pkcs7.OIDEnvelopedData, ed)
+}
+
+// signData returns DER encoded SignedData with the content,
+// signed by RSA key with the authenticated attributes
+func signData(content []byte, cert *x509.Certificate, signer crypto.Signer, digestAlg asn1.ObjectIdentifier, attrs []attribute) ([]byte, error) {
+	hash, digestAlg := digestHash(digestAlg)
+
+	h := hash.New()
+	h.Write(content)
+	digest := h.Sum(nil)
+
+	ci := contentInfo{ContentType: pkcs7.OIDData}
+	if len(content) > 0 {
+		octets, err := asn1.Marshal(content)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ci.Content = asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: octets}
+	}
+
+	attrs = append([]attribute{
+		newAttribute(pkcs7.OIDAttributeContentType, pkcs7.OIDData),
+		newAttribute(pkcs7.OIDAttributeMessageDigest, digest),
+		newAttribute(pkcs7.OIDAttributeSigningTime, time.Now().UTC()),
+	}, attrs...)
+
+	// DER encoding of SET OF requires sorted elements
+	var encoded [][]byte
+	for _, attr := range attrs {
+		if attr.Value.Bytes == nil {
+			return nil, errors.Errorf("invalid attribute: %s", attr.Type)
+		}
+		b, err := asn1.Marshal(attr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		encoded = append(encoded, b)
+	}
+	sort.Slice(encoded, func(i, j int) bool {
+		return bytes.Compare(encoded[i], encoded[j]) < 0
+	})
+	signedAttrs := bytes.Join(encoded, nil)
+
+	// the signature is computed over SET OF attributes, RFC 5652 5.4
+	set, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: signedAttrs})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	h = hash.New()
+	h.Write(set)
+	signature, err := signer.Sign(rand.Reader, h.Sum(nil), hash)
+	if err != nil {
+		return nil, errors.Annotate(err, "unable to sign")
+	}
+
+	sd := signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{{Algorithm: digestAlg}},
+		ContentInfo:      ci,
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: cert.Raw},
+		SignerInfos: []signerInfo{
+			{
+				Version: 1,
+				IssuerAndSerialNumber: issuerAndSerial{
+					IssuerName:   asn1.RawValue{FullBytes: cert.RawIssuer},
+					SerialNumber: cert.SerialNumber,
+				},
+				DigestAlgorithm:           pkix.AlgorithmIdentifier{Algorithm: digestAlg},
+				AuthenticatedAttributes:   asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signedAttrs},
+				DigestEncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: pkcs7.OIDEncryptionAlgorithmRSA},
+				EncryptedDigest:           signature,
+			},
+		},
+	}
+
+	return marshalContentInfo(pkcs7.OIDSignedData, sd)
+}
+
+// newAttribute returns the attribute with single value,
+// strings are encoded as PrintableString
+func newAttribute(typ asn1.ObjectIdentifier, val interface{}) attribute {
+	var b []byte
+	var err error
+	if s, ok := val.(string); ok {
+		b, err = asn1.MarshalWithParams(s, "printable")
+		if err != nil {
+			b, err = asn1.MarshalWithParams(s, "utf8")
+		}
+	} else {
+		b, err = asn1.Marshal(val)
+	}
+	if err != nil {
+		// the error is reported by signData
+		b = nil
+	}
+	return attribute{
+		Type:  typ,
+		Value: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: b},
+	}
+}
+
+// challengePassword returns the challengePassword attribute of the CSR,
+// RFC 2985 5.4.1
+func challengePassword(req *x509.CertificateRequest) (string, error) {
+	var tbs struct {
+		Version       int
+		Subject       asn1.RawValue
+		PublicKey     asn1.RawValue
+		RawAttributes []asn1.RawValue `asn1:"tag:0"`
+	}
+	if _, err := asn1.Unmarshal(req.RawTBSCertificateRequest, &tbs); err != nil {
+		return "", errors.Annotate(err, "unable to parse CSR")
+	}
+
+	for _, raw := range tbs.RawAttributes {
+		var attr struct {
+			Type   asn1.ObjectIdentifier
+			Values []asn1.RawValue `asn1:"set"`
+		}
+		if _, err := asn1.Unmarshal(raw.FullBytes, &attr); err != nil {
+			return "", errors.Annotate(err, "invalid CSR attribute")
+		}
+		if !attr.Type.Equal(oidChallengePassword) {
+			continue
+		}
+		if len(attr.Values) != 1 {
+			return "", errors.New("invalid challengePassword attribute")
+		}
+		var password string
+		if _, err := asn1.Unmarshal(attr.Values[0].FullBytes, &password); err != nil {
+			return "", errors.Annotate(err, "invalid challengePassword attribute")
+		}
+		return password, nil
+	}
+	return "", errors.NotFoundf("challengePassword")
+}
+
+func marshalContentInfo(typ asn1.ObjectIdentifier, content interface{}) ([]byte, error) {
+	b, err := asn1.Marshal(content)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return asn1.Marshal(contentInfo{
+		ContentType: typ,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: b},
+	})
+}
+
+// digestHash returns the hash for the digest algorithm,
+// SHA-256 is used for unsupported algorithms
+func digestHash(oid asn1.ObjectIdentifier) (crypto.Hash, asn1.ObjectIdentifier) {
+	switch {
+	case oid.Equal(pkcs7.OIDDigestAlgorithmSHA1):
+		return crypto.SHA1, oid
+	case oid.Equal(pkcs7.OIDDigestAlgorithmSHA512):
+		return crypto.SHA512, oid
+	}
+	return crypto.SHA256, pkcs7.OIDDigestAlgorithmSHA256
+}
+
+func newCipher(alg asn1.ObjectIdentifier, key []byte) (cipher.Block, error) {
+	if size := keySize(alg); size == 0 {
+		return nil, errors.NotSupportedf("content encryption algorithm %s", alg)
+	} else if len(key) != size {
+		return nil, errors.Errorf("invalid key size %d for %s", len(key), alg)
+	}
+
+	switch {
+	case alg.Equal(pkcs7.OIDEncryptionAlgorithmDESCBC):
+		return des.NewCipher(key)
+	case alg.Equal(pkcs7.OIDEncryptionAlgorithmDESEDE3CBC):
+		return des.NewTripleDESCipher(key)
+	}
+	return aes.NewCipher(key)
+}
+
+func keySize(alg asn1.ObjectIdentifier) int {
+	switch {
+	case alg.Equal(pkcs7.OIDEncryptionAlgorithmDESCBC):
+		return 8
+	case alg.Equal(pkcs7.OIDEncryptionAlgorithmDESEDE3CBC):
+		return 24
+	case alg.Equal(pkcs7.OIDEncryptionAlgorithmAES128CBC):
+		return 16
+	case alg.Equal(pkcs7.OIDEncryptionAlgorithmAES256CBC):
+		return 32
+	}
+	return 0
+}
+
+func pad(data []byte, blockSize int) []byte {
+	n := blockSize - len(data)%blockSize
+	return append(append([]byte{}, data...), bytes.Repeat([]byte{byte(n)}, n)...)
+}
+
+func unpad(data []byte, blockSize int) ([]byte, error) {
+	n := int(data[len(data)-1])
+	if n == 0 || n > blockSize || n > len(data) {
+		return nil, errors.New("invalid padding")
+	}
+	for _, b := range data[len(data)-n:] {
+		if int(b) != n {
+			return nil, errors.New("invalid padding")
+		}
+	}
+	return data[:len(data)-n], nil
+}
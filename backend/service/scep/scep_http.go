@@ -0,0 +1,381 @@
+package scep
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xhttp/header"
+	"github.com/go-phorce/dolly/xhttp/httperror"
+	"github.com/go-phorce/dolly/xhttp/identity"
+	"github.com/go-phorce/dolly/xhttp/marshal"
+	"github.com/go-phorce/dolly/xpki/certutil"
+	v1 "github.com/go-phorce/trusty/api/v1"
+	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/juju/errors"
+	"go.mozilla.org/pkcs7"
+)
+
+// SCEP operations, RFC 8894 4.1
+const (
+	OpGetCACert    = "GetCACert"
+	OpGetCACaps    = "GetCACaps"
+	OpPKIOperation = "PKIOperation"
+)
+
+// SCEP content types, RFC 8894 4
+const (
+	contentTypeCACaps     = "text/plain"
+	contentTypeCARACert   = "application/x-x509-ca-ra-cert"
+	contentTypePKIMessage = "application/x-pki-message"
+)
+
+// caps specifies the capabilities of the server, RFC 8894 3.5.2
+var caps = []string{
+	"POSTPKIOperation",
+	"SHA-1",
+	"SHA-256",
+	"SHA-512",
+	"AES",
+	"DES3",
+	"SCEPStandard",
+}
+
+// maxRequestSize limits the size of PKIOperation message
+const maxRequestSize = 64 * 1024
+
+func (s *Service) handle() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ rest.Params) {
+		op := r.URL.Query().Get("operation")
+		switch op {
+		case OpGetCACert:
+			s.getCACert(w, r)
+		case OpGetCACaps:
+			s.getCACaps(w, r)
+		case OpPKIOperation:
+			s.pkiOperation(w, r)
+		default:
+			marshal.WriteJSON(w, r, httperror.WithInvalidRequest("SCEP operation not supported: %q", op))
+		}
+	}
+}
+
+// getCACert returns the RA certificate and CA certificates, RFC 8894 4.2
+func (s *Service) getCACert(w http.ResponseWriter, r *http.Request) {
+	issuer, err := s.issuer()
+	if err != nil {
+		logger.Errorf("src=getCACert, profile=%s, err=[%v]", s.cfg.Profile, errors.ErrorStack(err))
+		marshal.WriteJSON(w, r, httperror.WithUnexpected("issuer not found for profile: %s", s.cfg.Profile))
+		return
+	}
+
+	bundle := issuer.Bundle()
+	chain := s.raCert.Raw
+	for _, c := range bundle.Chain {
+		chain = append(chain, c.Raw...)
+	}
+	if bundle.RootCert != nil && !containsCert(bundle.Chain, bundle.RootCert) {
+		chain = append(chain, bundle.RootCert.Raw...)
+	}
+
+	body, err := pkcs7.DegenerateCertificate(chain)
+	if err != nil {
+		logger.Errorf("src=getCACert, err=[%v]", errors.ErrorStack(err))
+		marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to create PKCS#7: %s", err.Error()).WithCause(err))
+		return
+	}
+	write(w, contentTypeCARACert, body)
+}
+
+// getCACaps returns the capabilities of the server, RFC 8894 3.5.2
+func (s *Service) getCACaps(w http.ResponseWriter, r *http.Request) {
+	write(w, contentTypeCACaps, []byte(strings.Join(caps, "\n")+"\n"))
+}
+
+// pkiOperation processes PKCSReq message, RFC 8894 4.3
+func (s *Service) pkiOperation(w http.ResponseWriter, r *http.Request) {
+	der, err := readMessage(r)
+	if err != nil {
+		marshal.WriteJSON(w, r, httperror.WithInvalidRequest(err.Error()))
+		return
+	}
+
+	msg, err := parsePKIMessage(der)
+	if msg == nil {
+		marshal.WriteJSON(w, r, httperror.WithInvalidRequest("invalid SCEP message: %s", err.Error()))
+		return
+	}
+	if err != nil {
+		logger.Warningf("src=pkiOperation, transactionID=%s, err=[%v]", msg.TransactionID, err.Error())
+		s.writeFailure(w, r, msg, failBadMessageCheck)
+		return
+	}
+
+	if msg.MessageType != msgTypePKCSReq {
+		logger.Warningf("src=pkiOperation, transactionID=%s, reason=unsupported_message_type, messageType=%s",
+			msg.TransactionID, msg.MessageType)
+		s.writeFailure(w, r, msg, failBadRequest)
+		return
+	}
+
+	csrDER, alg, err := decryptEnvelope(msg.Envelope, s.raCert, s.raDecrypter)
+	if err != nil {
+		logger.Warningf("src=pkiOperation, transactionID=%s, err=[%v]", msg.TransactionID, err.Error())
+		if errors.IsNotSupported(err) {
+			s.writeFailure(w, r, msg, failBadAlg)
+		} else {
+			s.writeFailure(w, r, msg, failBadMessageCheck)
+		}
+		return
+	}
+
+	req, err := x509.ParseCertificateRequest(csrDER)
+	if err == nil {
+		err = req.CheckSignature()
+	}
+	if err != nil {
+		logger.Warningf("src=pkiOperation, transactionID=%s, reason=invalid_csr, err=[%v]", msg.TransactionID, err.Error())
+		s.writeFailure(w, r, msg, failBadRequest)
+		return
+	}
+
+	// the attributes are validated before the challenge is used,
+	// so the signed certificate can be registered
+	mcert := &model.Certificate{
+		Subject: req.Subject.String(),
+		Profile: s.cfg.Profile,
+		Role:    ServiceName,
+	}
+	if err = mcert.ValidateAttributes(); err != nil {
+		logger.Warningf("src=pkiOperation, transactionID=%s, reason=invalid_csr, err=[%v]", msg.TransactionID, err.Error())
+		s.writeFailure(w, r, msg, failBadRequest)
+		return
+	}
+
+	password, err := challengePassword(req)
+	if err != nil {
+		logger.Warningf("src=pkiOperation, transactionID=%s, reason=invalid_challenge, err=[%v]", msg.TransactionID, err.Error())
+		s.writeFailure(w, r, msg, failBadRequest)
+		return
+	}
+	if err = s.challenges.Use(r.Context(), password); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Warningf("src=pkiOperation, transactionID=%s, subject=%q, reason=invalid_challenge",
+				msg.TransactionID, req.Subject.String())
+			s.writeFailure(w, r, msg, failBadRequest)
+		} else {
+			logger.Errorf("src=pkiOperation, transactionID=%s, reason=challenge, err=[%v]",
+				msg.TransactionID, errors.ErrorStack(err))
+			marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to check challenge").WithCause(err))
+		}
+		return
+	}
+
+	cert, err := s.enroll(r, req, msg, mcert)
+	if err != nil {
+		if errors.IsBadRequest(err) || errors.IsForbidden(err) || errors.IsNotValid(err) {
+			s.writeFailure(w, r, msg, failBadRequest)
+		} else {
+			marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to sign certificate: %s", err.Error()).WithCause(err))
+		}
+		return
+	}
+
+	body, err := s.certRepSuccess(msg, cert, alg)
+	if err != nil {
+		logger.Errorf("src=pkiOperation, transactionID=%s, err=[%v]", msg.TransactionID, errors.ErrorStack(err))
+		marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to create CertRep: %s", err.Error()).WithCause(err))
+		return
+	}
+	write(w, contentTypePKIMessage, body)
+}
+
+// enroll signs and registers the certificate with the validated attributes
+func (s *Service) enroll(r *http.Request, req *x509.CertificateRequest, msg *pkiMessage, mcert *model.Certificate) (*x509.Certificate, error) {
+	issuer, err := s.issuer()
+	if err != nil {
+		logger.Errorf("src=enroll, profile=%s, err=[%v]", s.cfg.Profile, errors.ErrorStack(err))
+		return nil, errors.Trace(err)
+	}
+
+	cert, certPEM, err := issuer.SignContext(r.Context(), csr.SignRequest{
+		Request: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: req.Raw})),
+		Profile: s.cfg.Profile,
+	})
+	if err != nil {
+		logger.Errorf("src=enroll, transactionID=%s, issuer=%s, profile=%s, err=[%v]",
+			msg.TransactionID, issuer.Label(), s.cfg.Profile, errors.ErrorStack(err))
+		return nil, errors.Trace(err)
+	}
+
+	mcert.SKID = certutil.GetSubjectKeyID(cert)
+	mcert.IKID = certutil.GetAuthorityKeyID(cert)
+	mcert.SerialNumber = cert.SerialNumber.String()
+	mcert.NotBefore = cert.NotBefore.UTC()
+	mcert.NotAfter = cert.NotAfter.UTC()
+	mcert.Subject = cert.Subject.String()
+	mcert.Pem = string(certPEM)
+
+	registered, err := s.db.CreateCertificate(r.Context(), mcert)
+	if err != nil {
+		// the signed certificate is valid, and must not be lost
+		logger.Errorf("src=enroll, reason=db, transactionID=%s, serial=%s, pem=%q, err=[%v]",
+			msg.TransactionID, mcert.SerialNumber, mcert.Pem, errors.ErrorStack(err))
+		s.server.Audit(
+			trustyserver.EvtSourceCA,
+			trustyserver.EvtCertificateNotRegistered,
+			"scep:"+msg.TransactionID,
+			identity.ForRequest(r).CorrelationID(),
+			0,
+			fmt.Sprintf("issuer=%s, profile=%s, serial=%s, skid=%s, ikid=%s, subject=%q, err=%q",
+				issuer.Label(),
+				s.cfg.Profile,
+				mcert.SerialNumber,
+				mcert.SKID,
+				mcert.IKID,
+				mcert.Subject,
+				err.Error(),
+			))
+		return nil, errors.Annotate(err, "failed to register certificate")
+	}
+	mcert = registered
+
+	s.server.Audit(
+		trustyserver.EvtSourceCA,
+		trustyserver.EvtCertificateIssued,
+		"scep:"+msg.TransactionID,
+		identity.ForRequest(r).CorrelationID(),
+		0,
+		fmt.Sprintf("id=%d, issuer=%s, profile=%s, serial=%s, skid=%s, ikid=%s, subject=%q, notBefore=%s, notAfter=%s",
+			mcert.ID,
+			issuer.Label(),
+			s.cfg.Profile,
+			mcert.SerialNumber,
+			mcert.SKID,
+			mcert.IKID,
+			mcert.Subject,
+			mcert.NotBefore.Format(time.RFC3339),
+			mcert.NotAfter.Format(time.RFC3339),
+		))
+
+	return cert, nil
+}
+
+// certRep returns CertRep message signed by RA, RFC 8894 3.3.2
+func (s *Service) certRep(msg *pkiMessage, status, failInfo string, envelope []byte) ([]byte, error) {
+	attrs := []attribute{
+		newAttribute(oidMessageType, msgTypeCertRep),
+		newAttribute(oidTransactionID, msg.TransactionID),
+		newAttribute(oidPKIStatus, status),
+		newAttribute(oidRecipientNonce, msg.SenderNonce),
+		newAttribute(oidSenderNonce, newNonce()),
+	}
+	if failInfo != "" {
+		attrs = append(attrs, newAttribute(oidFailInfo, failInfo))
+	}
+	return signData(envelope, s.raCert, s.raSigner, msg.DigestAlgorithm, attrs)
+}
+
+// certRepSuccess returns CertRep message with the issued certificate,
+// encrypted for the requester with the request's content encryption algorithm
+func (s *Service) certRepSuccess(msg *pkiMessage, cert *x509.Certificate, alg asn1.ObjectIdentifier) ([]byte, error) {
+	certs, err := pkcs7.DegenerateCertificate(cert.Raw)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	envelope, err := encryptEnvelope(certs, msg.Signer, alg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.certRep(msg, statusSuccess, "", envelope)
+}
+
+// writeFailure writes CertRep with FAILURE status, RFC 8894 3.3.2.2
+func (s *Service) writeFailure(w http.ResponseWriter, r *http.Request, msg *pkiMessage, failInfo string) {
+	body, err := s.certRep(msg, statusFailure, failInfo, nil)
+	if err != nil {
+		logger.Errorf("src=writeFailure, transactionID=%s, err=[%v]", msg.TransactionID, errors.ErrorStack(err))
+		marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to create CertRep: %s", err.Error()).WithCause(err))
+		return
+	}
+	write(w, contentTypePKIMessage, body)
+}
+
+// challenge creates one-time challenge password
+func (s *Service) challenge() rest.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ rest.Params) {
+		caller := identity.ForRequest(r).Identity()
+		password, expires, err := s.challenges.New(r.Context(), caller.Name())
+		if err != nil {
+			logger.Errorf("src=challenge, caller=%q, err=[%v]", caller.String(), errors.ErrorStack(err))
+			marshal.WriteJSON(w, r, httperror.WithUnexpected("unable to create challenge").WithCause(err))
+			return
+		}
+
+		logger.Infof("src=challenge, caller=%q, expires=%s",
+			caller.String(), expires.Format(time.RFC3339))
+
+		marshal.WriteJSON(w, r, &v1.SCEPChallengeResponse{
+			Challenge: password,
+			Expires:   expires,
+		})
+	}
+}
+
+// readMessage returns DER encoded PKIOperation message,
+// base64 encoded in message parameter for GET, or in the body for POST
+func readMessage(r *http.Request) ([]byte, error) {
+	if r.Method == http.MethodGet {
+		// '+' may be decoded as space if the parameter was not URL encoded
+		msg := strings.Replace(r.URL.Query().Get("message"), " ", "+", -1)
+		if msg == "" {
+			return nil, errors.New("missing message parameter")
+		}
+		der, err := base64.StdEncoding.DecodeString(msg)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid base64 encoding")
+		}
+		return der, nil
+	}
+
+	der, err := ioutil.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		return nil, errors.Annotate(err, "unable to read request")
+	}
+	if len(der) == 0 {
+		return nil, errors.New("empty request")
+	}
+	return der, nil
+}
+
+func write(w http.ResponseWriter, contentType string, body []byte) {
+	w.Header().Set(header.ContentType, contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func newNonce() []byte {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return b
+}
+
+func containsCert(list []*x509.Certificate, c *x509.Certificate) bool {
+	for _, l := range list {
+		if l.Equal(c) {
+			return true
+		}
+	}
+	return false
+}
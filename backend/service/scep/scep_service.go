@@ -0,0 +1,136 @@
+package scep
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"time"
+
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xlog"
+	"github.com/go-phorce/dolly/xpki/certutil"
+	"github.com/go-phorce/dolly/xpki/cryptoprov"
+	v1 "github.com/go-phorce/trusty/api/v1"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/config"
+	"github.com/go-phorce/trusty/internal/db"
+	"github.com/juju/errors"
+)
+
+// ServiceName provides the Service Name for this package
+const ServiceName = "scep"
+
+var logger = xlog.NewPackageLogger("github.com/go-phorce/trusty/backend/service", "scep")
+
+// IssuerProvider provides the issuer by label or certificate profile,
+// authority.Authority implements the interface
+type IssuerProvider interface {
+	GetIssuerByLabel(label string) (*authority.Issuer, error)
+	GetIssuerByProfile(profile string) (*authority.Issuer, error)
+}
+
+// Service defines the SCEP service, RFC 8894
+type Service struct {
+	server      *trustyserver.TrustyServer
+	cfg         *config.SCEP
+	ca          IssuerProvider
+	db          db.Provider
+	raCert      *x509.Certificate
+	raSigner    crypto.Signer
+	raDecrypter crypto.Decrypter
+	challenges  *challenges
+}
+
+// Factory returns a factory of the service
+func Factory(server *trustyserver.TrustyServer) interface{} {
+	if server == nil {
+		logger.Panic("scep.Factory: invalid parameter")
+	}
+
+	return func(cfg *config.Configuration, ca *authority.Authority, crypto *cryptoprov.Crypto, db db.Provider) error {
+		raCert, err := certutil.LoadFromPEM(cfg.SCEP.RACertFile)
+		if err != nil {
+			return errors.Annotate(err, "unable to load RA certificate")
+		}
+		raSigner, err := authority.NewSignerFromFromFile(crypto, cfg.SCEP.RAKeyFile)
+		if err != nil {
+			return errors.Annotate(err, "unable to load RA key")
+		}
+
+		svc, err := newService(server, &cfg.SCEP, ca, db, raCert, raSigner)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		server.AddService(svc)
+		return nil
+	}
+}
+
+func newService(
+	server *trustyserver.TrustyServer,
+	cfg *config.SCEP,
+	ca IssuerProvider,
+	db db.Provider,
+	raCert *x509.Certificate,
+	raSigner crypto.Signer,
+) (*Service, error) {
+	if cfg.Profile == "" {
+		return nil, errors.New("SCEP profile is required")
+	}
+
+	// the RA key is used to decrypt the requests,
+	// SCEP supports only RSA keys
+	pub, ok := raCert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("RA certificate must have RSA key: %T", raCert.PublicKey)
+	}
+	if signerPub, ok := raSigner.Public().(*rsa.PublicKey); !ok || pub.N.Cmp(signerPub.N) != 0 || pub.E != signerPub.E {
+		return nil, errors.New("RA key does not match RA certificate")
+	}
+	decrypter, ok := raSigner.(crypto.Decrypter)
+	if !ok {
+		return nil, errors.Errorf("RA key of %T type does not support crypto.Decrypter", raSigner)
+	}
+
+	svc := &Service{
+		server:      server,
+		cfg:         cfg,
+		ca:          ca,
+		db:          db,
+		raCert:      raCert,
+		raSigner:    raSigner,
+		raDecrypter: decrypter,
+		challenges:  newChallenges(db, cfg.Profile, time.Duration(cfg.ChallengeExpiry)),
+	}
+	return svc, nil
+}
+
+// Name returns the service name
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// IsReady indicates that the service is ready to serve its end-points
+func (s *Service) IsReady() bool {
+	return true
+}
+
+// Close the subservices and it's resources
+func (s *Service) Close() {
+}
+
+// RegisterRoute adds the SCEP API endpoints to the overall URL router
+func (s *Service) RegisterRoute(r rest.Router) {
+	r.GET(v1.PathForSCEP, s.handle())
+	r.POST(v1.PathForSCEP, s.handle())
+	r.POST(v1.PathForSCEPChallenge, s.challenge())
+}
+
+// issuer returns the issuer for SCEP enrollment
+func (s *Service) issuer() (*authority.Issuer, error) {
+	if s.cfg.Issuer != "" {
+		return s.ca.GetIssuerByLabel(s.cfg.Issuer)
+	}
+	return s.ca.GetIssuerByProfile(s.cfg.Profile)
+}
@@ -0,0 +1,587 @@
+package scep
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/dolly/audit"
+	"github.com/go-phorce/dolly/rest"
+	"github.com/go-phorce/dolly/xhttp/header"
+	"github.com/go-phorce/dolly/xpki/cryptoprov"
+	v1 "github.com/go-phorce/trusty/api/v1"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/backend/trustyserver"
+	"github.com/go-phorce/trusty/config"
+	"github.com/go-phorce/trusty/internal/db"
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/go-phorce/trusty/tests/testutils"
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.mozilla.org/pkcs7"
+	"go.uber.org/dig"
+)
+
+type testSuite struct {
+	suite.Suite
+
+	server  *trustyserver.TrustyServer
+	svc     *Service
+	baseURL string
+	rootCA  *x509.Certificate
+	raCert  *x509.Certificate
+	db      *fakeDB
+}
+
+func TestSCEP(t *testing.T) {
+	suite.Run(t, new(testSuite))
+}
+
+func (s *testSuite) SetupSuite() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "[TEST] Trusty SCEP Root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	s.Require().NoError(err)
+	s.rootCA, err = x509.ParseCertificate(der)
+	s.Require().NoError(err)
+
+	caCfg := &authority.Config{
+		Profiles: map[string]*authority.CertProfile{
+			"device": {
+				Usage:  []string{"signing", "key encipherment", "client auth"},
+				Expiry: csr.Duration(90 * 24 * time.Hour),
+			},
+		},
+	}
+	rootPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	issuer, err := authority.CreateIssuer("TrustySCEP", caCfg, rootPEM, nil, nil, key)
+	s.Require().NoError(err)
+
+	raKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	der, err = x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "[TEST] Trusty SCEP RA"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}, s.rootCA, raKey.Public(), key)
+	s.Require().NoError(err)
+	s.raCert, err = x509.ParseCertificate(der)
+	s.Require().NoError(err)
+
+	cfg := &config.SCEP{
+		Profile: "device",
+	}
+
+	s.db = &fakeDB{}
+	factories := map[string]trustyserver.ServiceFactory{
+		ServiceName: func(server *trustyserver.TrustyServer) interface{} {
+			return func() error {
+				svc, err := newService(server, cfg, fakeIssuers{issuer}, s.db, s.raCert, raKey)
+				if err != nil {
+					return err
+				}
+				server.AddService(svc)
+				return nil
+			}
+		},
+	}
+
+	c := dig.New()
+	c.Provide(func() (rest.Authz, audit.Auditor, *cryptoprov.Crypto, db.Provider) {
+		return nil, nil, nil, nil
+	})
+
+	s.baseURL = testutils.CreateURLs("http", "localhost")
+	s.server, err = trustyserver.StartTrusty(&config.HTTPServer{
+		Name:       "SCEP",
+		ListenURLs: []string{s.baseURL},
+		Services:   []string{ServiceName},
+	}, c, factories)
+	s.Require().NoError(err)
+	s.svc = s.server.Service(ServiceName).(*Service)
+
+	// wait for the server to start
+	for i := 0; i < 10; i++ {
+		if s.server.IsReady() {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func (s *testSuite) TearDownSuite() {
+	if s.server != nil {
+		s.server.Close()
+	}
+}
+
+func (s *testSuite) TestGetCACert() {
+	res, body := s.do(http.MethodGet, v1.PathForSCEP+"?operation="+OpGetCACert, nil)
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	s.Equal(contentTypeCARACert, res.Header.Get(header.ContentType))
+
+	p7, err := pkcs7.Parse(body)
+	s.Require().NoError(err)
+	s.Require().Len(p7.Certificates, 2)
+	s.True(p7.Certificates[0].Equal(s.raCert))
+	s.True(p7.Certificates[1].Equal(s.rootCA))
+}
+
+func (s *testSuite) TestGetCACaps() {
+	res, body := s.do(http.MethodGet, v1.PathForSCEP+"?operation="+OpGetCACaps, nil)
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	s.Equal(contentTypeCACaps, res.Header.Get(header.ContentType))
+	s.Contains(strings.Split(string(body), "\n"), "POSTPKIOperation")
+	s.Contains(strings.Split(string(body), "\n"), "SCEPStandard")
+
+	res, _ = s.do(http.MethodGet, v1.PathForSCEP+"?operation=GetCRL", nil)
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+}
+
+func (s *testSuite) TestChallenge() {
+	res, body := s.do(http.MethodPost, v1.PathForSCEPChallenge, nil)
+	s.Require().Equal(http.StatusOK, res.StatusCode, string(body))
+
+	var ch v1.SCEPChallengeResponse
+	s.Require().NoError(json.Unmarshal(body, &ch))
+	s.NotEmpty(ch.Challenge)
+	s.True(ch.Expires.After(time.Now()))
+}
+
+func (s *testSuite) TestPKCSReq() {
+	client := s.newClient("device.trusty.com")
+	password := s.challenge()
+
+	// POST
+	msg := client.pkcsReq(s.createCSR(client.key, "device.trusty.com", password), "tx1")
+	res, body := s.do(http.MethodPost, v1.PathForSCEP+"?operation="+OpPKIOperation, msg)
+	s.Require().Equal(http.StatusOK, res.StatusCode, string(body))
+	s.Equal(contentTypePKIMessage, res.Header.Get(header.ContentType))
+
+	p7 := client.certRep(body, "tx1", statusSuccess)
+	envelope, err := pkcs7.Parse(p7.Content)
+	s.Require().NoError(err)
+	certs, err := envelope.Decrypt(client.cert, client.key)
+	s.Require().NoError(err)
+	degenerate, err := pkcs7.Parse(certs)
+	s.Require().NoError(err)
+	s.Require().Len(degenerate.Certificates, 1)
+
+	crt := degenerate.Certificates[0]
+	s.Equal("device.trusty.com", crt.Subject.CommonName)
+	s.NoError(crt.CheckSignatureFrom(s.rootCA))
+	s.NotNil(s.db.find(crt.SerialNumber.String()))
+
+	// the challenge can be used only once
+	msg = client.pkcsReq(s.createCSR(client.key, "device.trusty.com", password), "tx2")
+	res, body = s.do(http.MethodPost, v1.PathForSCEP+"?operation="+OpPKIOperation, msg)
+	s.Require().Equal(http.StatusOK, res.StatusCode, string(body))
+	p7 = client.certRep(body, "tx2", statusFailure)
+	s.Empty(p7.Content)
+	var failInfo string
+	s.Require().NoError(p7.UnmarshalSignedAttribute(oidFailInfo, &failInfo))
+	s.Equal(failBadRequest, failInfo)
+
+	// GET
+	msg = client.pkcsReq(s.createCSR(client.key, "device.trusty.com", s.challenge()), "tx3")
+	res, body = s.do(http.MethodGet, v1.PathForSCEP+"?operation="+OpPKIOperation+"&message="+url.QueryEscape(base64.StdEncoding.EncodeToString(msg)), nil)
+	s.Require().Equal(http.StatusOK, res.StatusCode, string(body))
+	client.certRep(body, "tx3", statusSuccess)
+
+	// no challenge
+	msg = client.pkcsReq(s.createCSR(client.key, "device.trusty.com", ""), "tx4")
+	res, body = s.do(http.MethodPost, v1.PathForSCEP+"?operation="+OpPKIOperation, msg)
+	s.Require().Equal(http.StatusOK, res.StatusCode, string(body))
+	client.certRep(body, "tx4", statusFailure)
+
+	// the certificate, that can not be registered, is not signed,
+	// and the challenge is not used
+	password = s.challenge()
+	msg = client.pkcsReq(s.createCSR(client.key, strings.Repeat("a", 300), password), "tx5")
+	res, body = s.do(http.MethodPost, v1.PathForSCEP+"?operation="+OpPKIOperation, msg)
+	s.Require().Equal(http.StatusOK, res.StatusCode, string(body))
+	client.certRep(body, "tx5", statusFailure)
+
+	// the certificate is not returned, if it is not registered
+	count := s.db.count()
+	s.db.setError(errors.New("db is down"))
+	msg = client.pkcsReq(s.createCSR(client.key, "device.trusty.com", password), "tx6")
+	res, body = s.do(http.MethodPost, v1.PathForSCEP+"?operation="+OpPKIOperation, msg)
+	s.db.setError(nil)
+	s.Equal(http.StatusInternalServerError, res.StatusCode, string(body))
+	s.Equal(count, s.db.count())
+
+	res, _ = s.do(http.MethodPost, v1.PathForSCEP+"?operation="+OpPKIOperation, []byte("not PKCS#7"))
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+
+	res, _ = s.do(http.MethodGet, v1.PathForSCEP+"?operation="+OpPKIOperation, nil)
+	s.Equal(http.StatusBadRequest, res.StatusCode)
+}
+
+func TestNewService(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	_, err = newService(nil, &config.SCEP{}, nil, nil, selfSigned(t, rsaKey), rsaKey)
+	require.Error(t, err)
+	assert.Equal(t, "SCEP profile is required", err.Error())
+
+	cfg := &config.SCEP{Profile: "device"}
+	_, err = newService(nil, cfg, nil, nil, selfSigned(t, ecKey), ecKey)
+	require.Error(t, err)
+	assert.Equal(t, "RA certificate must have RSA key: *ecdsa.PublicKey", err.Error())
+
+	_, err = newService(nil, cfg, nil, nil, selfSigned(t, rsaKey), otherKey)
+	require.Error(t, err)
+	assert.Equal(t, "RA key does not match RA certificate", err.Error())
+
+	svc, err := newService(nil, cfg, nil, nil, selfSigned(t, rsaKey), rsaKey)
+	require.NoError(t, err)
+	assert.Equal(t, defaultChallengeExpiry, svc.challenges.expiry)
+}
+
+func TestChallenges(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeDB{}
+	c := newChallenges(fake, "device", time.Hour)
+	p1, expires, err := c.New(ctx, "admin")
+	require.NoError(t, err)
+	assert.True(t, expires.After(time.Now()))
+	p2, _, err := c.New(ctx, "admin")
+	require.NoError(t, err)
+	assert.NotEqual(t, p1, p2)
+
+	for _, p := range []string{"", "unknown"} {
+		err = c.Use(ctx, p)
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFound(err))
+	}
+
+	// the challenge is shared by the nodes
+	other := newChallenges(fake, "device", time.Hour)
+	assert.NoError(t, other.Use(ctx, p1))
+	assert.Error(t, c.Use(ctx, p1))
+
+	// expired
+	fake.challenges[challengeHash(p2)].ExpiresAt = time.Now().Add(-time.Second)
+	assert.Error(t, c.Use(ctx, p2))
+
+	// issued for another profile
+	p3, _, err := newChallenges(fake, "server", time.Hour).New(ctx, "admin")
+	require.NoError(t, err)
+	assert.Error(t, c.Use(ctx, p3))
+}
+
+func TestEnvelope(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	cert := selfSigned(t, key)
+
+	content := []byte("SCEP envelope content")
+	for _, alg := range []asn1.ObjectIdentifier{
+		pkcs7.OIDEncryptionAlgorithmDESCBC,
+		pkcs7.OIDEncryptionAlgorithmDESEDE3CBC,
+		pkcs7.OIDEncryptionAlgorithmAES128CBC,
+		pkcs7.OIDEncryptionAlgorithmAES256CBC,
+	} {
+		der, err := encryptEnvelope(content, cert, alg)
+		require.NoError(t, err, alg.String())
+
+		plain, decAlg, err := decryptEnvelope(der, cert, key)
+		require.NoError(t, err, alg.String())
+		assert.Equal(t, content, plain)
+		assert.True(t, alg.Equal(decAlg))
+
+		// compatible with PKCS#7 implementation
+		p7, err := pkcs7.Parse(der)
+		require.NoError(t, err)
+		plain, err = p7.Decrypt(cert, key)
+		require.NoError(t, err, alg.String())
+		assert.Equal(t, content, plain)
+	}
+
+	_, err = encryptEnvelope(content, cert, pkcs7.OIDEncryptionAlgorithmAES128GCM)
+	require.Error(t, err)
+	assert.True(t, errors.IsNotSupported(err))
+
+	_, _, err = decryptEnvelope([]byte("invalid"), cert, key)
+	require.Error(t, err)
+}
+
+func (s *testSuite) do(method, path string, body []byte) (*http.Response, []byte) {
+	req, err := http.NewRequest(method, s.baseURL+path, strings.NewReader(string(body)))
+	s.Require().NoError(err)
+	if body != nil {
+		req.Header.Set(header.ContentType, contentTypePKIMessage)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer res.Body.Close()
+
+	b, err := ioutil.ReadAll(res.Body)
+	s.Require().NoError(err)
+	return res, b
+}
+
+func (s *testSuite) challenge() string {
+	res, body := s.do(http.MethodPost, v1.PathForSCEPChallenge, nil)
+	s.Require().Equal(http.StatusOK, res.StatusCode, string(body))
+
+	var ch v1.SCEPChallengeResponse
+	s.Require().NoError(json.Unmarshal(body, &ch))
+	return ch.Challenge
+}
+
+// createCSR returns DER encoded CSR with challengePassword attribute
+func (s *testSuite) createCSR(key *rsa.PrivateKey, cn, password string) []byte {
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: cn},
+	}, key)
+	s.Require().NoError(err)
+	if password == "" {
+		return der
+	}
+
+	req, err := x509.ParseCertificateRequest(der)
+	s.Require().NoError(err)
+
+	pwd, err := asn1.MarshalWithParams(password, "printable")
+	s.Require().NoError(err)
+	attr, err := asn1.Marshal(struct {
+		Type   asn1.ObjectIdentifier
+		Values []asn1.RawValue `asn1:"set"`
+	}{oidChallengePassword, []asn1.RawValue{{FullBytes: pwd}}})
+	s.Require().NoError(err)
+
+	tbs, err := asn1.Marshal(struct {
+		Version    int
+		Subject    asn1.RawValue
+		PublicKey  asn1.RawValue
+		Attributes []asn1.RawValue `asn1:"tag:0"`
+	}{0, asn1.RawValue{FullBytes: req.RawSubject}, asn1.RawValue{FullBytes: req.RawSubjectPublicKeyInfo}, []asn1.RawValue{{FullBytes: attr}}})
+	s.Require().NoError(err)
+
+	digest := sha256.Sum256(tbs)
+	signature, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	s.Require().NoError(err)
+
+	der, err = asn1.Marshal(struct {
+		TBS       asn1.RawValue
+		Algorithm pkix.AlgorithmIdentifier
+		Signature asn1.BitString
+	}{
+		asn1.RawValue{FullBytes: tbs},
+		pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11}, Parameters: asn1.NullRawValue},
+		asn1.BitString{Bytes: signature, BitLength: len(signature) * 8},
+	})
+	s.Require().NoError(err)
+
+	req, err = x509.ParseCertificateRequest(der)
+	s.Require().NoError(err)
+	s.Require().NoError(req.CheckSignature())
+	return der
+}
+
+type testClient struct {
+	s     *testSuite
+	key   *rsa.PrivateKey
+	cert  *x509.Certificate
+	nonce []byte
+}
+
+func (s *testSuite) newClient(cn string) *testClient {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	return &testClient{
+		s:    s,
+		key:  key,
+		cert: selfSigned(s.T(), key),
+	}
+}
+
+// pkcsReq returns PKCSReq message
+func (c *testClient) pkcsReq(csr []byte, transactionID string) []byte {
+	pkcs7.ContentEncryptionAlgorithm = pkcs7.EncryptionAlgorithmAES128CBC
+	envelope, err := pkcs7.Encrypt(csr, []*x509.Certificate{c.s.raCert})
+	c.s.Require().NoError(err)
+
+	sd, err := pkcs7.NewSignedData(envelope)
+	c.s.Require().NoError(err)
+	sd.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+
+	c.nonce = newNonce()
+	err = sd.AddSigner(c.cert, c.key, pkcs7.SignerInfoConfig{
+		ExtraSignedAttributes: []pkcs7.Attribute{
+			{Type: oidMessageType, Value: msgTypePKCSReq},
+			{Type: oidTransactionID, Value: transactionID},
+			{Type: oidSenderNonce, Value: c.nonce},
+		},
+	})
+	c.s.Require().NoError(err)
+
+	der, err := sd.Finish()
+	c.s.Require().NoError(err)
+	return der
+}
+
+// certRep verifies CertRep message
+func (c *testClient) certRep(body []byte, transactionID, status string) *pkcs7.PKCS7 {
+	p7, err := pkcs7.Parse(body)
+	c.s.Require().NoError(err)
+	c.s.Require().NoError(p7.Verify())
+	c.s.True(p7.GetOnlySigner().Equal(c.s.raCert))
+
+	var msgType, txID, pkiStatus string
+	var recipientNonce []byte
+	c.s.Require().NoError(p7.UnmarshalSignedAttribute(oidMessageType, &msgType))
+	c.s.Require().NoError(p7.UnmarshalSignedAttribute(oidTransactionID, &txID))
+	c.s.Require().NoError(p7.UnmarshalSignedAttribute(oidPKIStatus, &pkiStatus))
+	c.s.Require().NoError(p7.UnmarshalSignedAttribute(oidRecipientNonce, &recipientNonce))
+	c.s.Equal(msgTypeCertRep, msgType)
+	c.s.Equal(transactionID, txID)
+	c.s.Require().Equal(status, pkiStatus)
+	c.s.Equal(c.nonce, recipientNonce)
+	return p7
+}
+
+func selfSigned(t *testing.T, key crypto.Signer) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "[TEST] SCEP client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	crt, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return crt
+}
+
+type fakeIssuers []*authority.Issuer
+
+func (f fakeIssuers) GetIssuerByLabel(label string) (*authority.Issuer, error) {
+	for _, issuer := range f {
+		if issuer.Label() == label {
+			return issuer, nil
+		}
+	}
+	return nil, errors.NotFoundf("issuer %q", label)
+}
+
+func (f fakeIssuers) GetIssuerByProfile(profile string) (*authority.Issuer, error) {
+	for _, issuer := range f {
+		if issuer.Profile(profile) != nil {
+			return issuer, nil
+		}
+	}
+	return nil, errors.NotFoundf("issuer for profile %q", profile)
+}
+
+type fakeDB struct {
+	db.Provider
+
+	lock       sync.Mutex
+	certs      []*model.Certificate
+	challenges map[string]*model.SCEPChallenge
+	err        error
+}
+
+func (f *fakeDB) CreateCertificate(_ context.Context, crt *model.Certificate) (*model.Certificate, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	c := *crt
+	c.ID = int64(len(f.certs) + 1)
+	f.certs = append(f.certs, &c)
+	return &c, nil
+}
+
+func (f *fakeDB) CreateSCEPChallenge(_ context.Context, ch *model.SCEPChallenge) (*model.SCEPChallenge, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.challenges == nil {
+		f.challenges = make(map[string]*model.SCEPChallenge)
+	}
+	c := *ch
+	c.ID = int64(len(f.challenges) + 1)
+	f.challenges[c.ChallengeHash] = &c
+	return &c, nil
+}
+
+func (f *fakeDB) UseSCEPChallenge(_ context.Context, challengeHash string, usedAt time.Time) (*model.SCEPChallenge, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	c := f.challenges[challengeHash]
+	if c == nil || c.UsedAt.Valid || !usedAt.Before(c.ExpiresAt) {
+		return nil, errors.NotFoundf("challenge")
+	}
+	c.UsedAt = sql.NullTime{Time: usedAt, Valid: true}
+	return c, nil
+}
+
+func (f *fakeDB) setError(err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.err = err
+}
+
+func (f *fakeDB) count() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.certs)
+}
+
+func (f *fakeDB) find(serial string) *model.Certificate {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, c := range f.certs {
+		if c.SerialNumber == serial {
+			return c
+		}
+	}
+	return nil
+}
@@ -17,8 +17,11 @@ import (
 	"github.com/go-phorce/dolly/xlog"
 	"github.com/go-phorce/dolly/xlog/logrotate"
 	"github.com/go-phorce/dolly/xpki/cryptoprov"
+	"github.com/go-phorce/trusty/backend/service/acme"
 	"github.com/go-phorce/trusty/backend/service/auth"
 	"github.com/go-phorce/trusty/backend/service/ca"
+	"github.com/go-phorce/trusty/backend/service/est"
+	"github.com/go-phorce/trusty/backend/service/scep"
 	"github.com/go-phorce/trusty/backend/service/status"
 	"github.com/go-phorce/trusty/backend/trustyserver"
 	"github.com/go-phorce/trusty/config"
@@ -36,8 +39,11 @@ const (
 
 // ServiceFactories provides map of trustyserver.ServiceFactory
 var ServiceFactories = map[string]trustyserver.ServiceFactory{
+	acme.ServiceName:   acme.Factory,
 	auth.ServiceName:   auth.Factory,
 	ca.ServiceName:     ca.Factory,
+	est.ServiceName:    est.Factory,
+	scep.ServiceName:   scep.Factory,
 	status.ServiceName: status.Factory,
 }
 
@@ -196,10 +196,11 @@ func provideAuthz(cfg *config.Configuration) (rest.Authz, *oauth2client.Client,
 			return nil, nil, nil, errors.Trace(err)
 		}
 	}
-	if cfg.Authz.JWTMapper != "" || cfg.Authz.CertMapper != "" {
+	if cfg.Authz.JWTMapper != "" || cfg.Authz.CertMapper != "" || cfg.Authz.BasicMapper != "" {
 		p, err := roles.New(
 			cfg.Authz.JWTMapper,
 			cfg.Authz.CertMapper,
+			cfg.Authz.BasicMapper,
 		)
 		if err != nil {
 			return nil, nil, nil, errors.Trace(err)
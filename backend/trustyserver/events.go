@@ -28,10 +28,19 @@ const (
 
 	// EvtCertificateIssued specifies audit event
 	EvtCertificateIssued = "certificate_issued"
+	// EvtCertificateNotRegistered specifies audit event,
+	// when the issued certificate could not be registered
+	EvtCertificateNotRegistered = "certificate_not_registered"
 	// EvtCertificateRevoked specifies audit event
 	EvtCertificateRevoked = "certificate_revoked"
 	// EvtCRLPublished specifies audit event
 	EvtCRLPublished = "crl_published"
+	// EvtKeyBlocked specifies audit event
+	EvtKeyBlocked = "key_blocked"
+	// EvtEnrollmentTokenCreated specifies audit event
+	EvtEnrollmentTokenCreated = "enrollment_token_created"
+	// EvtEnrollmentTokenRevoked specifies audit event
+	EvtEnrollmentTokenRevoked = "enrollment_token_revoked"
 
 	// EvtIssuerUnregistered specifies audit event
 	EvtIssuerUnregistered = "issuer_unregistered"
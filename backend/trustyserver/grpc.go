@@ -0,0 +1,260 @@
+package trustyserver
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-phorce/dolly/metrics"
+	"github.com/go-phorce/dolly/metrics/tags"
+	"github.com/go-phorce/dolly/xhttp/header"
+	"github.com/go-phorce/dolly/xhttp/identity"
+	"github.com/go-phorce/dolly/xlog"
+	"github.com/juju/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	keyForGRPCReqPerf       = []string{"grpc", "request", "perf"}
+	keyForGRPCReqSuccessful = []string{"grpc", "request", "status", "successful"}
+	keyForGRPCReqFailed     = []string{"grpc", "request", "status", "failed"}
+)
+
+// grpcInterceptor applies identity, authz, logging and metrics
+// to gRPC calls, that are not served by the HTTP handlers
+type grpcInterceptor struct {
+	s        *TrustyServer
+	identity http.Handler
+	authz    http.Handler
+	logger   xlog.Logger
+}
+
+func newGRPCInterceptor(s *TrustyServer) (*grpcInterceptor, error) {
+	i := &grpcInterceptor{
+		s:      s,
+		logger: logger,
+		// the context handler maps the identity with the global identity mapper,
+		// and calls the delegate only if the mapping succeeded
+		identity: identity.NewContextHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.(*statusWriter).rctx = identity.FromContext(r.Context())
+		})),
+	}
+	if pkg := s.cfg.GetPackageLogger(); pkg != "" {
+		i.logger = xlog.NewPackageLogger(pkg, "grpc")
+	}
+
+	if s.authz != nil {
+		// the authz handler calls the delegate only if the access is allowed
+		h, err := s.authz.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		i.authz = h
+	}
+
+	return i, nil
+}
+
+// unary returns grpc.UnaryServerInterceptor
+func (i *grpcInterceptor) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if identity.FromContext(ctx) != nil {
+		// served by grpcHandlerFunc, the HTTP handlers already applied
+		// identity, authz, logging and metrics
+		return handler(ctx, req)
+	}
+
+	start := time.Now().UTC()
+	r := newGRPCRequest(ctx, info.FullMethod)
+	rctx, err := i.requestContext(r)
+	if err != nil {
+		i.logger.Errorf("src=grpc, server=%s, method=%s, ip=%s, code=%s",
+			i.s.Name(), info.FullMethod, r.RemoteAddr, codes.Unauthenticated.String())
+		return nil, err
+	}
+	ctx = identity.AddToContext(ctx, rctx)
+	grpc.SetHeader(ctx, metadata.Pairs(header.XCorrelationID, rctx.CorrelationID()))
+
+	err = i.checkAccess(r.WithContext(ctx), rctx)
+	var res interface{}
+	if err == nil {
+		res, err = handler(ctx, req)
+	}
+
+	i.done(r, rctx, start, err)
+	return res, err
+}
+
+// stream returns grpc.StreamServerInterceptor
+func (i *grpcInterceptor) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := ss.Context()
+	if identity.FromContext(ctx) != nil {
+		// served by grpcHandlerFunc, the HTTP handlers already applied
+		// identity, authz, logging and metrics
+		return handler(srv, ss)
+	}
+
+	start := time.Now().UTC()
+	r := newGRPCRequest(ctx, info.FullMethod)
+	rctx, err := i.requestContext(r)
+	if err != nil {
+		i.logger.Errorf("src=grpc, server=%s, method=%s, ip=%s, code=%s",
+			i.s.Name(), info.FullMethod, r.RemoteAddr, codes.Unauthenticated.String())
+		return err
+	}
+	ctx = identity.AddToContext(ctx, rctx)
+	ss.SetHeader(metadata.Pairs(header.XCorrelationID, rctx.CorrelationID()))
+
+	err = i.checkAccess(r.WithContext(ctx), rctx)
+	if err == nil {
+		err = handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+
+	i.done(r, rctx, start, err)
+	return err
+}
+
+// requestContext returns the caller's context,
+// or Unauthenticated error if the identity mapper failed
+func (i *grpcInterceptor) requestContext(r *http.Request) (*identity.RequestContext, error) {
+	w := new(statusWriter)
+	i.identity.ServeHTTP(w, r)
+	if w.rctx == nil {
+		return nil, status.Error(codes.Unauthenticated, "failed to authenticate the caller")
+	}
+	return w.rctx, nil
+}
+
+// checkAccess returns PermissionDenied error,
+// if the caller's role is not allowed to call the method
+func (i *grpcInterceptor) checkAccess(r *http.Request, rctx *identity.RequestContext) error {
+	if i.authz == nil {
+		return nil
+	}
+
+	w := new(statusWriter)
+	i.authz.ServeHTTP(w, r)
+	if w.code != http.StatusOK {
+		return status.Errorf(codes.PermissionDenied, "the %q role is not allowed", rctx.Identity().Role())
+	}
+	return nil
+}
+
+// done logs the call and records its metrics
+func (i *grpcInterceptor) done(r *http.Request, rctx *identity.RequestContext, start time.Time, err error) {
+	dur := time.Since(start)
+	code := status.Code(err)
+	role := rctx.Identity().Role()
+
+	agent := r.Header.Get(header.UserAgent)
+	if agent == "" {
+		agent = "no-agent"
+	}
+
+	if code == codes.OK {
+		i.logger.Infof("src=grpc, server=%s, method=%s, role=%s, ip=%s, code=%s, duration=%d, agent=%q, correlation=%s",
+			i.s.Name(), r.URL.Path, role, rctx.ClientIP(), code.String(), dur.Milliseconds(), agent, rctx.CorrelationID())
+	} else {
+		i.logger.Errorf("src=grpc, server=%s, method=%s, role=%s, ip=%s, code=%s, duration=%d, agent=%q, correlation=%s, err=%q",
+			i.s.Name(), r.URL.Path, role, rctx.ClientIP(), code.String(), dur.Milliseconds(), agent, rctx.CorrelationID(), err.Error())
+	}
+
+	tags := []metrics.Tag{
+		{Name: tags.Role, Value: role},
+		{Name: tags.Status, Value: code.String()},
+		{Name: tags.URI, Value: r.URL.Path},
+	}
+
+	metrics.MeasureSince(keyForGRPCReqPerf, start, tags...)
+
+	if code == codes.OK {
+		metrics.IncrCounter(keyForGRPCReqSuccessful, 1, tags...)
+	} else {
+		metrics.IncrCounter(keyForGRPCReqFailed, 1, tags...)
+	}
+}
+
+// newGRPCRequest returns HTTP request for the gRPC call,
+// with the headers from the incoming metadata and TLS state of the peer,
+// so the identity mappers and authz can be applied to the call
+func newGRPCRequest(ctx context.Context, fullMethod string) *http.Request {
+	r := &http.Request{
+		Method:     http.MethodPost,
+		URL:        &url.URL{Path: fullMethod},
+		RequestURI: fullMethod,
+		Proto:      "HTTP/2.0",
+		ProtoMajor: 2,
+		Header:     make(http.Header),
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for k, vals := range md {
+			if strings.HasPrefix(k, ":") {
+				// pseudo-headers
+				continue
+			}
+			for _, v := range vals {
+				r.Header.Add(k, v)
+			}
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if p.Addr != nil {
+			r.RemoteAddr = p.Addr.String()
+		}
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			state := info.State
+			r.TLS = &state
+		}
+	}
+
+	return r.WithContext(ctx)
+}
+
+// serverStream overrides the context of grpc.ServerStream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context for this stream
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// statusWriter is http.ResponseWriter, that records the status code,
+// and the request context passed to the delegate handler
+type statusWriter struct {
+	header http.Header
+	code   int
+	rctx   *identity.RequestContext
+}
+
+func (w *statusWriter) Header() http.Header {
+	if w.header == nil {
+		w.header = make(http.Header)
+	}
+	return w.header
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	return len(b), nil
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+}
@@ -0,0 +1,151 @@
+package trustyserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/go-phorce/dolly/xhttp/authz"
+	"github.com/go-phorce/dolly/xhttp/identity"
+	"github.com/go-phorce/trusty/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCInterceptor(t *testing.T) {
+	identity.SetGlobalIdentityMapper(func(r *http.Request) (identity.Identity, error) {
+		role := r.Header.Get("x-test-role")
+		if role == "invalid" {
+			return nil, errors.New("invalid token")
+		}
+		if role != "" {
+			return identity.NewIdentity(role, "test", "1234"), nil
+		}
+		return identity.GuestIdentityMapper(r)
+	})
+	defer identity.SetGlobalIdentityMapper(identity.GuestIdentityMapper)
+
+	az, err := authz.New(&authz.Config{
+		AllowAny: []string{"/trustypb.Status"},
+		Allow:    []string{"/trustypb.Authority:trusty-peer"},
+	})
+	require.NoError(t, err)
+
+	s := &TrustyServer{
+		cfg:   config.HTTPServer{Name: "GRPCTest"},
+		authz: az,
+	}
+	i, err := newGRPCInterceptor(s)
+	require.NoError(t, err)
+
+	var callerCtx *identity.RequestContext
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		callerCtx = identity.FromContext(ctx)
+		return req, nil
+	}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 12345},
+	})
+
+	t.Run("guest", func(t *testing.T) {
+		callerCtx = nil
+		res, err := i.unary(ctx, "req", &grpc.UnaryServerInfo{FullMethod: "/trustypb.Status/Caller"}, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "req", res)
+		require.NotNil(t, callerCtx)
+		assert.Equal(t, identity.GuestRoleName, callerCtx.Identity().Role())
+		assert.Equal(t, "10.0.0.1", callerCtx.ClientIP())
+		assert.NotEmpty(t, callerCtx.CorrelationID())
+
+		callerCtx = nil
+		_, err = i.unary(ctx, "req", &grpc.UnaryServerInfo{FullMethod: "/trustypb.Authority/Issuers"}, handler)
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Nil(t, callerCtx)
+	})
+
+	t.Run("peer", func(t *testing.T) {
+		callerCtx = nil
+		mctx := metadata.NewIncomingContext(ctx, metadata.Pairs(
+			"x-test-role", "trusty-peer",
+			"x-correlation-id", "corr1234",
+		))
+		_, err := i.unary(mctx, "req", &grpc.UnaryServerInfo{FullMethod: "/trustypb.Authority/Issuers"}, handler)
+		require.NoError(t, err)
+		require.NotNil(t, callerCtx)
+		assert.Equal(t, "trusty-peer", callerCtx.Identity().Role())
+		assert.Equal(t, "test", callerCtx.Identity().Name())
+		assert.Equal(t, "corr1234", callerCtx.CorrelationID())
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		callerCtx = nil
+		mctx := metadata.NewIncomingContext(ctx, metadata.Pairs("x-test-role", "invalid"))
+		_, err := i.unary(mctx, "req", &grpc.UnaryServerInfo{FullMethod: "/trustypb.Status/Caller"}, handler)
+		require.Error(t, err)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Nil(t, callerCtx)
+
+		ss := &testServerStream{ctx: mctx}
+		err = i.stream(nil, ss, &grpc.StreamServerInfo{FullMethod: "/trustypb.Status/Stream"}, func(srv interface{}, ss grpc.ServerStream) error {
+			callerCtx = identity.FromContext(ss.Context())
+			return nil
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.Nil(t, callerCtx)
+	})
+
+	t.Run("served_by_http", func(t *testing.T) {
+		callerCtx = nil
+		rctx := identity.NewRequestContext(identity.NewIdentity(identity.GuestRoleName, "test", ""))
+		_, err := i.unary(identity.AddToContext(ctx, rctx), "req", &grpc.UnaryServerInfo{FullMethod: "/trustypb.Authority/Issuers"}, handler)
+		require.NoError(t, err)
+		assert.Equal(t, rctx, callerCtx)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		callerCtx = nil
+		streamHandler := func(srv interface{}, ss grpc.ServerStream) error {
+			callerCtx = identity.FromContext(ss.Context())
+			return nil
+		}
+
+		ss := &testServerStream{ctx: metadata.NewIncomingContext(ctx, metadata.Pairs("x-test-role", "trusty-peer"))}
+		err := i.stream(nil, ss, &grpc.StreamServerInfo{FullMethod: "/trustypb.Authority/Stream"}, streamHandler)
+		require.NoError(t, err)
+		require.NotNil(t, callerCtx)
+		assert.Equal(t, "trusty-peer", callerCtx.Identity().Role())
+		assert.NotEmpty(t, ss.header.Get("x-correlation-id"))
+
+		callerCtx = nil
+		ss = &testServerStream{ctx: ctx}
+		err = i.stream(nil, ss, &grpc.StreamServerInfo{FullMethod: "/trustypb.Authority/Stream"}, streamHandler)
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Nil(t, callerCtx)
+	})
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
@@ -329,7 +329,15 @@ func restRouter(s *TrustyServer) rest.Router {
 }
 
 func grpcServer(s *TrustyServer, tls *tls.Config, gopts ...grpc.ServerOption) *grpc.Server {
-	var opts []grpc.ServerOption
+	interceptor, err := newGRPCInterceptor(s)
+	if err != nil {
+		panic(errors.ErrorStack(err))
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(interceptor.unary),
+		grpc.ChainStreamInterceptor(interceptor.stream),
+	}
 	//opts = append(opts, grpc.CustomCodec(&codec{}))
 	/*
 		if tls != nil {
@@ -337,15 +345,6 @@ func grpcServer(s *TrustyServer, tls *tls.Config, gopts ...grpc.ServerOption) *g
 			opts = append(opts, grpc.Creds(bundle.TransportCredentials()))
 		}
 
-			opts = append(opts, grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-				newLogUnaryInterceptor(s),
-				newUnaryInterceptor(s),
-				grpc_prometheus.UnaryServerInterceptor,
-			)))
-			opts = append(opts, grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
-				newStreamInterceptor(s),
-				grpc_prometheus.StreamServerInterceptor,
-			)))
 			opts = append(opts, grpc.MaxRecvMsgSize(int(s.Cfg.MaxRequestBytes+grpcOverheadBytes)))
 			opts = append(opts, grpc.MaxSendMsgSize(maxSendBytes))
 			opts = append(opts, grpc.MaxConcurrentStreams(maxStreams))
@@ -3,8 +3,11 @@ package ca
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"strings"
 
 	"github.com/go-phorce/dolly/ctl"
+	pb "github.com/go-phorce/trusty/api/v1/trustypb"
 	"github.com/go-phorce/trusty/cli"
 	"github.com/go-phorce/trusty/pkg/print"
 	"github.com/juju/errors"
@@ -26,3 +29,122 @@ func Issuers(c ctl.Control, _ interface{}) error {
 	}
 	return nil
 }
+
+// GetProfileFlags defines flags for Profile command
+type GetProfileFlags struct {
+	// Label specifies the Issuer label
+	Label *string
+	// Profile specifies the profile name
+	Profile *string
+}
+
+// Profile shows the certificate profile
+func Profile(c ctl.Control, p interface{}) error {
+	flags := p.(*GetProfileFlags)
+
+	cli := c.(*cli.Cli)
+	res, err := cli.Client().Authority.ProfileInfo(context.Background(), &pb.CertProfileInfoRequest{
+		Label:   *flags.Label,
+		Profile: *flags.Profile,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if cli.IsJSON() {
+		ctl.WriteJSON(c.Writer(), res)
+		fmt.Fprint(c.Writer(), "\n")
+	} else {
+		print.CertProfileInfo(c.Writer(), res)
+	}
+	return nil
+}
+
+// RevokeFlags defines flags for Revoke command
+type RevokeFlags struct {
+	// Skid specifies Subject Key ID of the certificates to revoke
+	Skid *string
+	// Ikid specifies Issuer Key ID of the certificate
+	Ikid *string
+	// Serial specifies the certificate serial number
+	Serial *string
+	// Reason specifies the revocation reason
+	Reason *string
+}
+
+// Revoke revokes the certificate
+func Revoke(c ctl.Control, p interface{}) error {
+	flags := p.(*RevokeFlags)
+
+	reason := strings.ToUpper(strings.Replace(*flags.Reason, "-", "_", -1))
+	val, ok := pb.Reason_value[reason]
+	if !ok {
+		return errors.Errorf("unsupported reason: %s", *flags.Reason)
+	}
+
+	cli := c.(*cli.Cli)
+	res, err := cli.Client().Authority.RevokeCertificate(context.Background(), &pb.RevokeCertificateRequest{
+		Skid:         *flags.Skid,
+		Ikid:         *flags.Ikid,
+		SerialNumber: *flags.Serial,
+		Reason:       pb.Reason(val),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if cli.IsJSON() {
+		ctl.WriteJSON(c.Writer(), res)
+		fmt.Fprint(c.Writer(), "\n")
+	} else {
+		print.RevokedCertificate(c.Writer(), res)
+	}
+	return nil
+}
+
+// BlockKeyFlags defines flags for BlockKey command
+type BlockKeyFlags struct {
+	// SpkiHash specifies hex encoded SHA-256 hash of the SubjectPublicKeyInfo
+	SpkiHash *string
+	// PemFile specifies the file with the public key, the certificate, or the certificate request
+	PemFile *string
+	// Reason specifies the reason for blocking
+	Reason *string
+}
+
+// BlockKey blocks the public key
+func BlockKey(c ctl.Control, p interface{}) error {
+	flags := p.(*BlockKeyFlags)
+
+	reason := strings.ToUpper(strings.Replace(*flags.Reason, "-", "_", -1))
+	val, ok := pb.Reason_value[reason]
+	if !ok {
+		return errors.Errorf("unsupported reason: %s", *flags.Reason)
+	}
+
+	req := &pb.BlockKeyRequest{
+		SpkiHash: *flags.SpkiHash,
+		Reason:   pb.Reason(val),
+	}
+	if *flags.PemFile != "" {
+		pem, err := ioutil.ReadFile(*flags.PemFile)
+		if err != nil {
+			return errors.Annotate(err, "failed to load PEM file")
+		}
+		req.Pem = string(pem)
+	}
+
+	cli := c.(*cli.Cli)
+	res, err := cli.Client().Authority.BlockKey(context.Background(), req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if cli.IsJSON() {
+		ctl.WriteJSON(c.Writer(), res)
+		fmt.Fprint(c.Writer(), "\n")
+	} else {
+		print.BlockedKey(c.Writer(), res)
+	}
+	return nil
+}
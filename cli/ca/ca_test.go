@@ -57,6 +57,121 @@ func (s *testSuite) TestIssuers() {
 	}
 }
 
+func (s *testSuite) TestProfile() {
+	expectedResponse := new(trustypb.CertProfileInfo)
+	err := loadJSON("testdata/profile_info.json", expectedResponse)
+	s.Require().NoError(err)
+
+	s.MockAuthority = &mockpb.MockAuthorityServer{
+		Err:   nil,
+		Resps: []proto.Message{expectedResponse},
+	}
+	srv := s.SetupMockGRPC()
+	defer srv.Stop()
+
+	label := ""
+	profile := "server"
+	err = s.Run(ca.Profile, &ca.GetProfileFlags{
+		Label:   &label,
+		Profile: &profile,
+	})
+	s.Require().NoError(err)
+
+	if s.Cli.IsJSON() {
+		s.HasText("{\n\t\"allowed_extensions\": [\n\t\t\"1.3.6.1.5.5.7.1.1\"\n\t],\n\t\"backdate\": \"30m0s\",\n")
+	} else {
+		s.HasText("  Profile            | server ", "  Issuer             | TrustyCA ")
+	}
+}
+
+func (s *testSuite) TestRevoke() {
+	expectedResponse := &trustypb.RevokedCertificate{
+		Certificate: &trustypb.Certificate{
+			Skid:         "a729df568d6561174ab78ff973ecbc95502a4701",
+			Ikid:         "05060708",
+			SerialNumber: "655573355893373448628085603800327957133511654393",
+			Subject:      "CN=localhost",
+			Profile:      "server",
+		},
+		Reason: trustypb.Reason_KEY_COMPROMISE,
+	}
+
+	s.MockAuthority = &mockpb.MockAuthorityServer{
+		Err:   nil,
+		Resps: []proto.Message{expectedResponse},
+	}
+	srv := s.SetupMockGRPC()
+	defer srv.Stop()
+
+	empty := ""
+	skid := "a729df568d6561174ab78ff973ecbc95502a4701"
+	reason := "invalid"
+	flags := &ca.RevokeFlags{
+		Skid:   &skid,
+		Ikid:   &empty,
+		Serial: &empty,
+		Reason: &reason,
+	}
+	err := s.Run(ca.Revoke, flags)
+	s.Require().Error(err)
+	s.Equal("unsupported reason: invalid", err.Error())
+
+	reason = "key-compromise"
+	err = s.Run(ca.Revoke, flags)
+	s.Require().NoError(err)
+
+	if s.Cli.IsJSON() {
+		s.HasText("\t\"reason\": 1\n")
+	} else {
+		s.HasText("  Serial    | 655573355893373448628085603800327957133511654393 ", "  Reason    | KEY_COMPROMISE ")
+	}
+}
+
+func (s *testSuite) TestBlockKey() {
+	expectedResponse := &trustypb.BlockedKey{
+		SpkiHash:  "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c",
+		Reason:    trustypb.Reason_KEY_COMPROMISE,
+		Requestor: "admin",
+		CreatedAt: 1603152000,
+	}
+
+	s.MockAuthority = &mockpb.MockAuthorityServer{
+		Err:   nil,
+		Resps: []proto.Message{expectedResponse},
+	}
+	srv := s.SetupMockGRPC()
+	defer srv.Stop()
+
+	empty := ""
+	hash := "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c"
+	reason := "invalid"
+	flags := &ca.BlockKeyFlags{
+		SpkiHash: &hash,
+		PemFile:  &empty,
+		Reason:   &reason,
+	}
+	err := s.Run(ca.BlockKey, flags)
+	s.Require().Error(err)
+	s.Equal("unsupported reason: invalid", err.Error())
+
+	reason = "key-compromise"
+	pemFile := "notfound.pem"
+	flags.PemFile = &pemFile
+	err = s.Run(ca.BlockKey, flags)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "failed to load PEM file")
+
+	flags.PemFile = &empty
+	err = s.Run(ca.BlockKey, flags)
+	s.Require().NoError(err)
+
+	if s.Cli.IsJSON() {
+		s.HasText("\t\"reason\": 1,\n")
+	} else {
+		s.HasText("  SPKI hash | b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c ", "  Reason    | KEY_COMPROMISE ")
+	}
+}
+
 func loadJSON(filename string, v interface{}) error {
 	cfr, err := os.Open(filename)
 	if err != nil {
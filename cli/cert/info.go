@@ -0,0 +1,152 @@
+// Package cert provides commands to inspect and verify certificates
+package cert
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/go-phorce/dolly/ctl"
+	"github.com/go-phorce/dolly/xpki/certutil"
+	"github.com/go-phorce/trusty/cli"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/go-phorce/trusty/pkg/print"
+	"github.com/juju/errors"
+	"go.mozilla.org/pkcs7"
+)
+
+// InfoFlags specifies flags for Info command
+type InfoFlags struct {
+	// In specifies file name with certificates in PEM, DER or PKCS#7 format
+	In *string
+}
+
+// CertificateInfo provides certificate details for JSON output
+type CertificateInfo struct {
+	ID                    string    `json:"id"`
+	Subject               string    `json:"subject"`
+	Serial                string    `json:"serial"`
+	Issuer                string    `json:"issuer"`
+	IssuerID              string    `json:"issuer_id"`
+	NotBefore             time.Time `json:"not_before"`
+	NotAfter              time.Time `json:"not_after"`
+	DNSNames              []string  `json:"dns_names,omitempty"`
+	IPAddresses           []string  `json:"ip_addresses,omitempty"`
+	EmailAddresses        []string  `json:"email_addresses,omitempty"`
+	URIs                  []string  `json:"uris,omitempty"`
+	KeyUsage              []string  `json:"key_usage,omitempty"`
+	ExtKeyUsage           []string  `json:"ext_key_usage,omitempty"`
+	CRLDistributionPoints []string  `json:"crl_distribution_points,omitempty"`
+	OCSPServer            []string  `json:"ocsp_servers,omitempty"`
+	IssuingCertificateURL []string  `json:"issuing_certificates,omitempty"`
+	IsCA                  bool      `json:"is_ca"`
+	MaxPathLen            int       `json:"max_path_len,omitempty"`
+}
+
+// Info prints the certificates details
+func Info(c ctl.Control, p interface{}) error {
+	flags := p.(*InfoFlags)
+
+	data, err := c.(*cli.Cli).ReadFileOrStdin(*flags.In)
+	if err != nil {
+		return errors.Annotate(err, "read certificate")
+	}
+
+	list, err := ParseCertificates(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.(*cli.Cli).IsJSON() {
+		infos := make([]*CertificateInfo, len(list))
+		for i, crt := range list {
+			infos[i] = NewCertificateInfo(crt)
+		}
+		ctl.WriteJSON(c.Writer(), infos)
+		fmt.Fprint(c.Writer(), "\n")
+	} else if len(list) == 1 {
+		print.Certificate(c.Writer(), list[0])
+	} else {
+		print.Certificates(c.Writer(), list)
+	}
+
+	return nil
+}
+
+// ParseCertificates returns the list of certificates
+// from PEM, DER or PKCS#7 encoded data
+func ParseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var list []*x509.Certificate
+
+	if bytes.Contains(data, []byte("-----BEGIN")) {
+		for {
+			var block *pem.Block
+			block, data = pem.Decode(data)
+			if block == nil {
+				break
+			}
+			switch block.Type {
+			case "CERTIFICATE", "TRUSTED CERTIFICATE":
+				crt, err := x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					return nil, errors.Annotate(err, "failed to parse certificate")
+				}
+				list = append(list, crt)
+			case "PKCS7":
+				p7, err := pkcs7.Parse(block.Bytes)
+				if err != nil {
+					return nil, errors.Annotate(err, "failed to parse PKCS#7")
+				}
+				list = append(list, p7.Certificates...)
+			}
+		}
+	} else if len(data) > 0 {
+		var err error
+		list, err = x509.ParseCertificates(data)
+		if err != nil {
+			p7, p7err := pkcs7.Parse(data)
+			if p7err != nil {
+				return nil, errors.Annotate(err, "unsupported format")
+			}
+			list = p7.Certificates
+		}
+	}
+
+	if len(list) == 0 {
+		return nil, errors.New("no certificates found")
+	}
+	return list, nil
+}
+
+// NewCertificateInfo returns CertificateInfo
+func NewCertificateInfo(crt *x509.Certificate) *CertificateInfo {
+	info := &CertificateInfo{
+		ID:                    certutil.GetSubjectID(crt),
+		Subject:               certutil.NameToString(&crt.Subject),
+		Serial:                crt.SerialNumber.String(),
+		Issuer:                certutil.NameToString(&crt.Issuer),
+		IssuerID:              certutil.GetIssuerID(crt),
+		NotBefore:             crt.NotBefore.UTC(),
+		NotAfter:              crt.NotAfter.UTC(),
+		DNSNames:              crt.DNSNames,
+		EmailAddresses:        crt.EmailAddresses,
+		KeyUsage:              csr.KeyUsageNames(crt.KeyUsage),
+		ExtKeyUsage:           csr.ExtKeyUsageNames(crt.ExtKeyUsage),
+		CRLDistributionPoints: crt.CRLDistributionPoints,
+		OCSPServer:            crt.OCSPServer,
+		IssuingCertificateURL: crt.IssuingCertificateURL,
+		IsCA:                  crt.IsCA,
+	}
+	for _, ip := range crt.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	for _, uri := range crt.URIs {
+		info.URIs = append(info.URIs, uri.String())
+	}
+	if crt.IsCA {
+		info.MaxPathLen = crt.MaxPathLen
+	}
+	return info
+}
@@ -0,0 +1,83 @@
+package cert
+
+import (
+	"fmt"
+
+	"github.com/go-phorce/dolly/ctl"
+	"github.com/go-phorce/dolly/xpki/certutil"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/cli"
+	"github.com/juju/errors"
+)
+
+// LintFlags specifies flags for Lint command
+type LintFlags struct {
+	// Cert specifies file name with certificates to lint
+	Cert *string
+	// CAConfig specifies optional file name with ca-config,
+	// to use the lint severities of the profile
+	CAConfig *string
+	// Profile specifies the profile name from ca-config
+	Profile *string
+}
+
+// LintResult provides the lint findings for the certificate
+type LintResult struct {
+	ID       string                   `json:"id"`
+	Subject  string                   `json:"subject"`
+	Findings []*authority.LintFinding `json:"findings,omitempty"`
+}
+
+// Lint runs the certificate linter
+func Lint(c ctl.Control, p interface{}) error {
+	flags := p.(*LintFlags)
+	cli := c.(*cli.Cli)
+
+	certs, err := loadCertificates(cli, *flags.Cert)
+	if err != nil {
+		return errors.Annotate(err, "unable to load certificate")
+	}
+
+	var severities map[string]authority.LintSeverity
+	if *flags.CAConfig != "" {
+		cacfg, err := authority.LoadConfig(*flags.CAConfig)
+		if err != nil {
+			return errors.Annotate(err, "failed to load ca-config")
+		}
+		profile := cacfg.Profiles[*flags.Profile]
+		if profile == nil {
+			return errors.Errorf("profile not found: %s", *flags.Profile)
+		}
+		severities = profile.Lints
+	}
+
+	var results []*LintResult
+	var findings []*authority.LintFinding
+	for _, crt := range certs {
+		res := &LintResult{
+			ID:       certutil.GetSubjectID(crt),
+			Subject:  certutil.NameToString(&crt.Subject),
+			Findings: authority.Lint(crt, severities),
+		}
+		results = append(results, res)
+		findings = append(findings, res.Findings...)
+	}
+
+	if cli.IsJSON() {
+		ctl.WriteJSON(c.Writer(), results)
+		fmt.Fprint(c.Writer(), "\n")
+	} else {
+		w := c.Writer()
+		for _, res := range results {
+			fmt.Fprintf(w, "%s | %s\n", res.ID, res.Subject)
+			if len(res.Findings) == 0 {
+				fmt.Fprintf(w, "  - OK\n")
+			}
+			for _, f := range res.Findings {
+				fmt.Fprintf(w, "  - %s\n", f.String())
+			}
+		}
+	}
+
+	return authority.LintErrors(findings)
+}
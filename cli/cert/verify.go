@@ -0,0 +1,189 @@
+package cert
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-phorce/dolly/ctl"
+	"github.com/go-phorce/dolly/xpki/certutil"
+	"github.com/go-phorce/trusty/cli"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/juju/errors"
+)
+
+// VerifyFlags specifies flags for Verify command
+type VerifyFlags struct {
+	// Cert specifies file name with the certificate to verify,
+	// the rest of the certificates in the file are used as intermediates
+	Cert *string
+	// Roots specifies file name with the trusted root certificates
+	Roots *string
+	// Intermediates specifies optional file name with intermediate certificates
+	Intermediates *string
+	// Usages specifies optional coma separated list of extended key usages
+	// to verify, for example: server_auth,client_auth
+	Usages *string
+	// ExpiresIn specifies the duration to warn about
+	// certificates in the chain that are about to expire
+	ExpiresIn *time.Duration
+}
+
+// VerifyResult provides the result of Verify command
+type VerifyResult struct {
+	Chain    []*CertificateInfo `json:"chain,omitempty"`
+	Errors   []string           `json:"errors,omitempty"`
+	Warnings []string           `json:"warnings,omitempty"`
+}
+
+// Verify builds and validates the certificate chain
+func Verify(c ctl.Control, p interface{}) error {
+	flags := p.(*VerifyFlags)
+	cli := c.(*cli.Cli)
+
+	certs, err := loadCertificates(cli, *flags.Cert)
+	if err != nil {
+		return errors.Annotate(err, "unable to load certificate")
+	}
+	roots, err := loadCertificates(cli, *flags.Roots)
+	if err != nil {
+		return errors.Annotate(err, "unable to load roots")
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+		CurrentTime:   time.Now(),
+	}
+	for _, crt := range roots {
+		opts.Roots.AddCert(crt)
+	}
+	for _, crt := range certs[1:] {
+		opts.Intermediates.AddCert(crt)
+	}
+	if *flags.Intermediates != "" {
+		intermediates, err := loadCertificates(cli, *flags.Intermediates)
+		if err != nil {
+			return errors.Annotate(err, "unable to load intermediates")
+		}
+		for _, crt := range intermediates {
+			opts.Intermediates.AddCert(crt)
+		}
+	}
+	if *flags.Usages != "" {
+		opts.KeyUsages, err = parseExtKeyUsages(*flags.Usages)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	res := verifyChain(certs, opts, *flags.ExpiresIn)
+
+	if cli.IsJSON() {
+		ctl.WriteJSON(c.Writer(), res)
+		fmt.Fprint(c.Writer(), "\n")
+	} else {
+		printVerifyResult(c, res)
+	}
+
+	if len(res.Errors) > 0 {
+		return errors.New("certificate verification failed")
+	}
+	return nil
+}
+
+// verifyChain verifies the leaf certificate, and checks the validity period
+// and the key usage of all certificates in the chain.
+// If the chain can not be built, the provided certificates are checked.
+func verifyChain(certs []*x509.Certificate, opts x509.VerifyOptions, expiresIn time.Duration) *VerifyResult {
+	res := new(VerifyResult)
+
+	chain := certs
+	chains, err := certs[0].Verify(opts)
+	if invalid, ok := err.(x509.CertificateInvalidError); ok && invalid.Reason == x509.Expired {
+		// the validity period is reported below for each certificate,
+		// build the chain at the time when the leaf was issued,
+		// to report the errors of the chain itself
+		issuedAt := opts
+		issuedAt.CurrentTime = certs[0].NotBefore
+		chains, err = certs[0].Verify(issuedAt)
+	}
+	if err != nil {
+		res.Errors = append(res.Errors, err.Error())
+	} else {
+		chain = chains[0]
+	}
+
+	for idx, crt := range chain {
+		subject := certutil.NameToString(&crt.Subject)
+		if opts.CurrentTime.After(crt.NotAfter) {
+			res.Errors = append(res.Errors, fmt.Sprintf("certificate has expired on %s: %s",
+				crt.NotAfter.UTC().Format(time.RFC3339), subject))
+		} else if opts.CurrentTime.Before(crt.NotBefore) {
+			res.Errors = append(res.Errors, fmt.Sprintf("certificate is not valid before %s: %s",
+				crt.NotBefore.UTC().Format(time.RFC3339), subject))
+		} else if expiresIn > 0 && crt.NotAfter.Before(opts.CurrentTime.Add(expiresIn)) {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("certificate expires in %s: %s",
+				crt.NotAfter.Sub(opts.CurrentTime)/time.Minute*time.Minute, subject))
+		}
+
+		if idx > 0 {
+			if crt.KeyUsage != 0 && crt.KeyUsage&x509.KeyUsageCertSign == 0 {
+				res.Errors = append(res.Errors, fmt.Sprintf("issuer key usage does not allow cert sign: %s", subject))
+			}
+		} else if crt.IsCA && crt.KeyUsage != 0 && crt.KeyUsage&x509.KeyUsageCertSign == 0 {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("CA key usage does not allow cert sign: %s", subject))
+		}
+		res.Chain = append(res.Chain, NewCertificateInfo(crt))
+	}
+
+	return res
+}
+
+func printVerifyResult(c ctl.Control, res *VerifyResult) {
+	w := c.Writer()
+	fmt.Fprintf(w, "Chain:\n")
+	for _, crt := range res.Chain {
+		fmt.Fprintf(w, "  - %s | %s | %s\n", crt.ID, crt.Subject, crt.NotAfter.Format(time.RFC3339))
+	}
+	if len(res.Warnings) > 0 {
+		fmt.Fprintf(w, "Warnings:\n")
+		for _, s := range res.Warnings {
+			fmt.Fprintf(w, "  - %s\n", s)
+		}
+	}
+	if len(res.Errors) > 0 {
+		fmt.Fprintf(w, "Errors:\n")
+		for _, s := range res.Errors {
+			fmt.Fprintf(w, "  - %s\n", s)
+		}
+	} else {
+		fmt.Fprintf(w, "Verified: OK\n")
+	}
+}
+
+func loadCertificates(cli *cli.Cli, file string) ([]*x509.Certificate, error) {
+	data, err := cli.ReadFileOrStdin(file)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ParseCertificates(data)
+}
+
+// parseExtKeyUsages parses coma separated list of extended key usages
+func parseExtKeyUsages(usages string) ([]x509.ExtKeyUsage, error) {
+	var list []x509.ExtKeyUsage
+	for _, s := range strings.Split(usages, ",") {
+		name := strings.NewReplacer("_", " ", "-", " ").Replace(strings.ToLower(strings.TrimSpace(s)))
+		if name == "" {
+			continue
+		}
+		eku, ok := csr.ExtKeyUsage[name]
+		if !ok {
+			return nil, errors.Errorf("unsupported usage: %s", s)
+		}
+		list = append(list, eku)
+	}
+	return list, nil
+}
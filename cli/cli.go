@@ -46,7 +46,16 @@ func WithServer(defaultServerURL string) Option {
 		c.flags.server = serverFlag.String()
 		c.flags.retries = app.Flag("retries", "number of retries for connect failures").Default("0").Int()
 		c.flags.timeout = app.Flag("timeout", "timeout in seconds").Default("6").Int()
-		c.flags.ctJSON = app.Flag("json", "print responses as JSON").Bool()
+		WithJSON().applyOption(c)
+	})
+}
+
+// WithJSON specifies to enable --json flag
+func WithJSON() Option {
+	return optionFunc(func(c *Cli) {
+		if c.flags.ctJSON == nil {
+			c.flags.ctJSON = c.App().Flag("json", "print responses as JSON").Bool()
+		}
 	})
 }
 
@@ -351,3 +351,27 @@ func TestReadStdin(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, b)
 }
+
+func TestCLIWithJSON(t *testing.T) {
+	out := bytes.NewBuffer([]byte{})
+	app := ctl.NewApplication("cliapp", "test").
+		UsageWriter(out).
+		Writer(out).
+		ErrorWriter(out)
+
+	cli := cli.New(&ctl.ControlDefinition{
+		App:       app,
+		Output:    out,
+		ErrOutput: out,
+	}, cli.WithJSON(), cli.WithServer(""))
+	defer cli.Close()
+
+	app.Command("cmd", "Test command").
+		PreAction(cli.PopulateControl).
+		Action(cli.RegisterAction(cmdAction, nil))
+
+	assert.False(t, cli.IsJSON())
+	cli.Parse([]string{"cliapp", "--json", "cmd"})
+	assert.Equal(t, ctl.RCOkay, cli.ReturnCode(), "output: "+out.String())
+	assert.True(t, cli.IsJSON())
+}
@@ -0,0 +1,138 @@
+package crl_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/dolly/algorithms/guid"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/cli/crl"
+	"github.com/go-phorce/trusty/cli/csr"
+	"github.com/go-phorce/trusty/cli/testsuite"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const projFolder = "../../"
+
+type testSuite struct {
+	testsuite.Suite
+
+	tmpdir   string
+	rootCert string
+	rootKey  string
+}
+
+func TestCtlSuite(t *testing.T) {
+	s := new(testSuite)
+
+	s.tmpdir = filepath.Join(os.TempDir(), "/tests/trusty", "crl")
+	err := os.MkdirAll(s.tmpdir, 0777)
+	require.NoError(t, err)
+	defer os.RemoveAll(s.tmpdir)
+
+	s.WithHSM()
+	s.WithAppFlags([]string{"--hsm-cfg", "/tmp/trusty/softhsm/unittest_hsm.json"})
+	suite.Run(t, s)
+}
+
+func (s *testSuite) SetupSuite() {
+	s.Suite.SetupSuite()
+	err := s.Cli.EnsureCryptoProvider()
+	s.Require().NoError(err)
+
+	caConfig := projFolder + "etc/dev/ca-config.bootstrap.json"
+	csrProfile := projFolder + "etc/dev/csr/trusty_dev_root_ca.json"
+	profile := "ROOT"
+	label := "root" + guid.MustCreate()
+	output := filepath.Join(s.tmpdir, label)
+
+	err = s.Run(csr.Root, &csr.RootFlags{
+		CAConfig:   &caConfig,
+		CsrProfile: &csrProfile,
+		KeyLabel:   &label,
+		Profile:    &profile,
+		Output:     &output,
+	})
+	s.Require().NoError(err)
+
+	s.rootCert = output + ".pem"
+	s.rootKey = output + "-key.pem"
+}
+
+func (s *testSuite) TestSign() {
+	empty := ""
+	revoked := "testdata/revoked.json"
+	nextUpdate := 24 * time.Hour
+	format := "pem"
+
+	flags := &crl.SignFlags{
+		CACert:     &s.rootCert,
+		CAKey:      &s.rootKey,
+		Revoked:    &revoked,
+		PrevCRL:    &empty,
+		NextUpdate: &nextUpdate,
+		Format:     &format,
+		Output:     &empty,
+	}
+
+	// to stdout
+	err := s.Run(crl.Sign, flags)
+	s.Require().NoError(err)
+	s.HasText("-----BEGIN X509 CRL-----")
+	s.HasNoText("Number: ")
+
+	// unsupported format
+	format = "txt"
+	err = s.Run(crl.Sign, flags)
+	s.Require().Error(err)
+	s.Equal("unsupported format: txt", err.Error())
+
+	// DER requires output
+	format = "der"
+	err = s.Run(crl.Sign, flags)
+	s.Require().Error(err)
+	s.Equal("--out is required for DER format", err.Error())
+
+	// to file
+	format = "pem"
+	output1 := filepath.Join(s.tmpdir, guid.MustCreate()+".crl")
+	flags.Output = &output1
+	err = s.Run(crl.Sign, flags)
+	s.Require().NoError(err)
+	s.HasTextInFile(output1, "-----BEGIN X509 CRL-----")
+	s.HasText("Number: ", "  - 204570238945 | ")
+
+	list, err := crl.LoadCRL(output1)
+	s.Require().NoError(err)
+	prevNumber, err := authority.CRLNumber(list)
+	s.Require().NoError(err)
+	// time based number
+	s.InDelta(time.Now().Unix(), prevNumber.Int64(), 60)
+
+	// increment the number
+	revoked = "testdata/revoked.csv"
+	format = "der"
+	output2 := filepath.Join(s.tmpdir, guid.MustCreate()+".crl")
+	flags.PrevCRL = &output1
+	flags.Output = &output2
+	err = s.Run(crl.Sign, flags)
+	s.Require().NoError(err)
+	s.HasText(fmt.Sprintf("Number: %d\n", prevNumber.Int64()+1), "  - 3 | ")
+
+	list, err = crl.LoadCRL(output2)
+	s.Require().NoError(err)
+	s.Len(list.TBSCertList.RevokedCertificates, 3)
+	number, err := authority.CRLNumber(list)
+	s.Require().NoError(err)
+	s.Equal(prevNumber.Int64()+1, number.Int64())
+
+	// invalid next update
+	nextUpdate = 0
+	err = s.Run(crl.Sign, flags)
+	s.Require().Error(err)
+	s.Equal("invalid next update: 0s", err.Error())
+}
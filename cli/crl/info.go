@@ -0,0 +1,107 @@
+package crl
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"time"
+
+	"github.com/go-phorce/dolly/ctl"
+	pb "github.com/go-phorce/trusty/api/v1/trustypb"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/cli"
+	"github.com/go-phorce/trusty/pkg/print"
+	"github.com/juju/errors"
+	"go.mozilla.org/pkcs7"
+)
+
+// InfoFlags specifies flags for Info command
+type InfoFlags struct {
+	// In specifies file name with CRL in PEM, DER or PKCS#7 format
+	In *string
+}
+
+// ListInfo provides CRL details for JSON output
+type ListInfo struct {
+	Issuer     string          `json:"issuer"`
+	Number     string          `json:"number,omitempty"`
+	ThisUpdate time.Time       `json:"this_update"`
+	NextUpdate time.Time       `json:"next_update"`
+	Revoked    []*RevokedEntry `json:"revoked,omitempty"`
+}
+
+// Info prints the CRL details
+func Info(c ctl.Control, p interface{}) error {
+	flags := p.(*InfoFlags)
+
+	data, err := c.(*cli.Cli).ReadFileOrStdin(*flags.In)
+	if err != nil {
+		return errors.Annotate(err, "read CRL")
+	}
+
+	crl, err := ParseCRL(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.(*cli.Cli).IsJSON() {
+		info, err := NewListInfo(crl)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ctl.WriteJSON(c.Writer(), info)
+		fmt.Fprint(c.Writer(), "\n")
+	} else {
+		print.CertificateList(c.Writer(), crl)
+	}
+
+	return nil
+}
+
+// ParseCRL parses CRL in PEM, DER or PKCS#7 format
+func ParseCRL(data []byte) (*pkix.CertificateList, error) {
+	crl, err := x509.ParseCRL(data)
+	if err != nil {
+		p7, p7err := pkcs7.Parse(data)
+		if p7err != nil || len(p7.CRLs) == 0 {
+			return nil, errors.Annotate(err, "failed to parse CRL")
+		}
+		crl = &p7.CRLs[0]
+	}
+	return crl, nil
+}
+
+// NewListInfo returns ListInfo
+func NewListInfo(crl *pkix.CertificateList) (*ListInfo, error) {
+	info := &ListInfo{
+		Issuer:     crl.TBSCertList.Issuer.String(),
+		ThisUpdate: crl.TBSCertList.ThisUpdate.UTC(),
+		NextUpdate: crl.TBSCertList.NextUpdate.UTC(),
+	}
+
+	number, err := authority.CRLNumber(crl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if number != nil {
+		info.Number = number.String()
+	}
+
+	for _, r := range crl.TBSCertList.RevokedCertificates {
+		entry := &RevokedEntry{
+			Serial:    r.SerialNumber.String(),
+			RevokedAt: r.RevocationTime.UTC().Format(time.RFC3339),
+		}
+		for _, ext := range r.Extensions {
+			if ext.Id.Equal(authority.OIDExtensionReasonCode) {
+				var reason asn1.Enumerated
+				if _, err := asn1.Unmarshal(ext.Value, &reason); err == nil {
+					entry.Reason = pb.Reason(reason).String()
+				}
+			}
+		}
+		info.Revoked = append(info.Revoked, entry)
+	}
+	return info, nil
+}
@@ -0,0 +1,98 @@
+package crl_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/dolly/algorithms/guid"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/cli/crl"
+	"github.com/go-phorce/trusty/cli/testsuite"
+	"github.com/stretchr/testify/suite"
+)
+
+type infoSuite struct {
+	testsuite.Suite
+
+	der []byte
+}
+
+func TestInfoSuite(t *testing.T) {
+	suite.Run(t, new(infoSuite))
+}
+
+func TestInfoSuiteWithJSON(t *testing.T) {
+	s := new(infoSuite)
+	s.WithAppFlags([]string{"--json"})
+	suite.Run(t, s)
+}
+
+func (s *infoSuite) SetupSuite() {
+	s.Suite.SetupSuite()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	now := time.Now().UTC()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "[TEST] Trusty Root CA"},
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	s.Require().NoError(err)
+	ca, err := x509.ParseCertificate(der)
+	s.Require().NoError(err)
+
+	entry, err := authority.CRLEntry(big.NewInt(204570238945), now.Add(-time.Minute), 1)
+	s.Require().NoError(err)
+
+	s.der, err = x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:              big.NewInt(12),
+		ThisUpdate:          now,
+		NextUpdate:          now.Add(time.Hour),
+		RevokedCertificates: []pkix.RevokedCertificate{entry},
+	}, ca, key)
+	s.Require().NoError(err)
+}
+
+func (s *infoSuite) TestInfo() {
+	pemFile := filepath.Join(os.TempDir(), guid.MustCreate()+".crl")
+	defer os.Remove(pemFile)
+	err := ioutil.WriteFile(pemFile, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: s.der}), 0644)
+	s.Require().NoError(err)
+
+	derFile := filepath.Join(os.TempDir(), guid.MustCreate()+".crl")
+	defer os.Remove(derFile)
+	err = ioutil.WriteFile(derFile, s.der, 0644)
+	s.Require().NoError(err)
+
+	for _, in := range []string{pemFile, derFile} {
+		err = s.Run(crl.Info, &crl.InfoFlags{In: &in})
+		s.Require().NoError(err)
+		if s.Cli.IsJSON() {
+			s.HasText(`"number": "12"`, `"serial": "204570238945"`, `"reason": "KEY_COMPROMISE"`)
+		} else {
+			s.HasText("Number: 12\n", "  - 204570238945 | ", " | KEY_COMPROMISE\n")
+		}
+	}
+
+	in := "testdata/revoked.csv"
+	err = s.Run(crl.Info, &crl.InfoFlags{In: &in})
+	s.Require().Error(err)
+}
@@ -0,0 +1,122 @@
+package crl
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/go-phorce/trusty/api/v1/trustypb"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/juju/errors"
+)
+
+// RevokedEntry specifies the revoked certificate
+type RevokedEntry struct {
+	// Serial specifies the certificate serial number,
+	// in decimal, or hex format with 0x prefix
+	Serial string `json:"serial"`
+	// RevokedAt specifies the revocation time in RFC3339 format
+	RevokedAt string `json:"revoked_at"`
+	// Reason specifies the revocation reason, as a name or a number
+	Reason string `json:"reason"`
+}
+
+// ParseRevoked parses the list of revoked certificates,
+// provided as JSON array of RevokedEntry,
+// or CSV with serial,revoked_at,reason columns
+func ParseRevoked(data []byte) ([]pkix.RevokedCertificate, error) {
+	var entries []RevokedEntry
+
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		err := json.Unmarshal(data, &entries)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid JSON")
+		}
+	} else {
+		r := csv.NewReader(bytes.NewReader(data))
+		r.Comment = '#'
+		r.FieldsPerRecord = -1
+		r.TrimLeadingSpace = true
+		for {
+			rec, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, errors.Annotate(err, "invalid CSV")
+			}
+			if len(rec) < 2 || len(rec) > 3 {
+				return nil, errors.Errorf("invalid CSV record: %s", strings.Join(rec, ","))
+			}
+			// skip header
+			if len(entries) == 0 && strings.EqualFold(rec[0], "serial") {
+				continue
+			}
+			entry := RevokedEntry{
+				Serial:    rec[0],
+				RevokedAt: rec[1],
+			}
+			if len(rec) == 3 {
+				entry.Reason = rec[2]
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	list := make([]pkix.RevokedCertificate, len(entries))
+	for i, e := range entries {
+		serial, err := parseSerial(e.Serial)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		revokedAt, err := time.Parse(time.RFC3339, e.RevokedAt)
+		if err != nil {
+			return nil, errors.Errorf("invalid revocation time for %s: %s", e.Serial, e.RevokedAt)
+		}
+		reason, err := parseReason(e.Reason)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		list[i], err = authority.CRLEntry(serial, revokedAt, reason)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return list, nil
+}
+
+func parseSerial(val string) (*big.Int, error) {
+	base := 10
+	s := strings.TrimSpace(val)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		base = 16
+		s = s[2:]
+	}
+	serial, ok := new(big.Int).SetString(s, base)
+	if !ok || serial.Sign() <= 0 {
+		return nil, errors.Errorf("invalid serial: %s", val)
+	}
+	return serial, nil
+}
+
+func parseReason(val string) (int, error) {
+	if val == "" {
+		return 0, nil
+	}
+	if n, err := strconv.Atoi(val); err == nil {
+		if _, ok := pb.Reason_name[int32(n)]; ok {
+			return n, nil
+		}
+	} else if n, ok := pb.Reason_value[strings.ToUpper(strings.Replace(val, "-", "_", -1))]; ok {
+		return int(n), nil
+	}
+	return 0, errors.Errorf("unsupported reason: %s", val)
+}
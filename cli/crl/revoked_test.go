@@ -0,0 +1,54 @@
+package crl_test
+
+import (
+	"encoding/asn1"
+	"io/ioutil"
+	"testing"
+
+	"github.com/go-phorce/trusty/cli/crl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRevoked(t *testing.T) {
+	for _, file := range []string{"testdata/revoked.json", "testdata/revoked.csv"} {
+		data, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+
+		list, err := crl.ParseRevoked(data)
+		require.NoError(t, err, file)
+		require.True(t, len(list) >= 2, file)
+
+		assert.Equal(t, "204570238945", list[0].SerialNumber.String())
+		assert.Equal(t, "2020-10-20T00:00:00Z", list[0].RevocationTime.Format("2006-01-02T15:04:05Z07:00"))
+		require.Len(t, list[0].Extensions, 1)
+
+		var reason asn1.Enumerated
+		_, err = asn1.Unmarshal(list[0].Extensions[0].Value, &reason)
+		require.NoError(t, err)
+		assert.Equal(t, asn1.Enumerated(1), reason)
+
+		assert.Equal(t, "31", list[1].SerialNumber.String())
+	}
+
+	tcases := []struct {
+		data string
+		err  string
+	}{
+		{`[{"serial": "x", "revoked_at": "2020-10-20T00:00:00Z"}]`, "invalid serial: x"},
+		{`[{"serial": "1", "revoked_at": "yesterday"}]`, "invalid revocation time for 1: yesterday"},
+		{`[{"serial": "1", "revoked_at": "2020-10-20T00:00:00Z", "reason": "lost"}]`, "unsupported reason: lost"},
+		{`[{"serial": "1", "revoked_at": "2020-10-20T00:00:00Z", "reason": "7"}]`, "unsupported reason: 7"},
+		{`1`, "invalid CSV record: 1"},
+		{`[`, "invalid JSON: unexpected end of JSON input"},
+	}
+	for _, tc := range tcases {
+		_, err := crl.ParseRevoked([]byte(tc.data))
+		require.Error(t, err, tc.data)
+		assert.Equal(t, tc.err, err.Error())
+	}
+
+	list, err := crl.ParseRevoked(nil)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
@@ -0,0 +1,147 @@
+package crl
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/go-phorce/dolly/ctl"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/cli"
+	"github.com/go-phorce/trusty/config"
+	"github.com/go-phorce/trusty/pkg/print"
+	"github.com/juju/errors"
+)
+
+// SignFlags specifies flags for Sign command
+type SignFlags struct {
+	// CACert specifies file name with CA cert
+	CACert *string
+	// CAKey specifies file name with CA key
+	CAKey *string
+	// Revoked specifies file name with the list of revoked certificates,
+	// in JSON or CSV format
+	Revoked *string
+	// PrevCRL specifies file name with the previous CRL,
+	// to increment the CRL number.
+	// If not set, the CRL number is based on the current time
+	PrevCRL *string
+	// NextUpdate specifies the duration for the next update
+	NextUpdate *time.Duration
+	// Format specifies the output format: pem or der
+	Format *string
+	// Output specifies the optional file name for the CRL,
+	// if not set, the PEM encoded CRL will be printed to STDOUT,
+	// otherwise the CRL will be written to the file,
+	// and its content will be printed to STDOUT
+	Output *string
+}
+
+// Sign creates a CRL signed by the CA
+func Sign(c ctl.Control, p interface{}) error {
+	flags := p.(*SignFlags)
+
+	cryptoprov, _ := c.(*cli.Cli).CryptoProv()
+	if cryptoprov == nil {
+		return errors.Errorf("unsupported command for this crypto provider")
+	}
+
+	format := strings.ToLower(*flags.Format)
+	if format != "pem" && format != "der" {
+		return errors.Errorf("unsupported format: %s", *flags.Format)
+	}
+	if format == "der" && *flags.Output == "" {
+		return errors.Errorf("--out is required for DER format")
+	}
+	if *flags.NextUpdate <= 0 {
+		return errors.Errorf("invalid next update: %s", flags.NextUpdate.String())
+	}
+
+	var revoked []pkix.RevokedCertificate
+	if *flags.Revoked != "" {
+		data, err := c.(*cli.Cli).ReadFileOrStdin(*flags.Revoked)
+		if err != nil {
+			return errors.Annotate(err, "read revoked list")
+		}
+		revoked, err = ParseRevoked(data)
+		if err != nil {
+			return errors.Annotate(err, "invalid revoked list")
+		}
+	}
+
+	isscfg := &config.Issuer{
+		CertFile: *flags.CACert,
+		KeyFile:  *flags.CAKey,
+	}
+
+	issuer, err := authority.NewIssuer(isscfg, &authority.Config{}, cryptoprov)
+	if err != nil {
+		return errors.Annotate(err, "create issuer")
+	}
+
+	thisUpdate := time.Now().UTC().Truncate(time.Second)
+
+	// use the same time based CRL number as the server does,
+	// so it is monotonic across the runs without the previous CRL
+	number := big.NewInt(thisUpdate.Unix())
+	if *flags.PrevCRL != "" {
+		prev, err := LoadCRL(*flags.PrevCRL)
+		if err != nil {
+			return errors.Annotate(err, "load previous CRL")
+		}
+		err = issuer.Bundle().Cert.CheckCRLSignature(prev)
+		if err != nil {
+			return errors.Annotate(err, "previous CRL is not signed by the CA")
+		}
+		prevNumber, err := authority.CRLNumber(prev)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if prevNumber != nil {
+			number = prevNumber.Add(prevNumber, big.NewInt(1))
+		}
+	}
+
+	der, err := issuer.CreateCRLWithNumber(revoked, number, thisUpdate, thisUpdate.Add(*flags.NextUpdate))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	out := der
+	if format == "pem" {
+		out = pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+	}
+
+	if *flags.Output == "" {
+		// the output may be redirected to a file,
+		// so do not mix the CRL with its content
+		c.Writer().Write(out)
+		return nil
+	}
+
+	err = ioutil.WriteFile(*flags.Output, out, 0664)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	crl, err := x509.ParseCRL(der)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	print.CertificateList(c.Writer(), crl)
+
+	return nil
+}
+
+// LoadCRL loads CRL in PEM, DER or PKCS#7 format
+func LoadCRL(file string) (*pkix.CertificateList, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ParseCRL(data)
+}
@@ -1,8 +1,10 @@
 package csr_test
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/go-phorce/dolly/algorithms/guid"
@@ -43,26 +45,44 @@ func (s *testSuite) SetupSuite() {
 	s.createRootCA()
 }
 
-/*
 func (s *testSuite) TestRootCA() {
+	caConfig := projFolder + "etc/dev/ca-config.bootstrap.json"
 	csrFile := ""
 	label := "*"
 	output := ""
+	profile := "ROOT"
 
 	// no csr file
 	err := s.Run(csr.Root, &csr.RootFlags{
+		CAConfig:   &caConfig,
 		CsrProfile: &csrFile,
 		KeyLabel:   &label,
+		Profile:    &profile,
 		Output:     &output,
 	})
 	s.Error(err)
 
-	// test root, to stdout
+	// not CA profile
 	csrFile = projFolder + "etc/dev/csr/trusty_dev_root_ca.json"
+	profile = "server"
+	err = s.Run(csr.Root, &csr.RootFlags{
+		CAConfig:   &caConfig,
+		CsrProfile: &csrFile,
+		KeyLabel:   &label,
+		Profile:    &profile,
+		Output:     &output,
+	})
+	s.Require().Error(err)
+	s.Equal("profile is not CA profile: server", err.Error())
+
+	// test root, to stdout
 	label = "test_root"
+	profile = "ROOT"
 	err = s.Run(csr.Root, &csr.RootFlags{
+		CAConfig:   &caConfig,
 		CsrProfile: &csrFile,
 		KeyLabel:   &label,
+		Profile:    &profile,
 		Output:     &output,
 	})
 	s.Require().NoError(err)
@@ -71,8 +91,10 @@ func (s *testSuite) TestRootCA() {
 	output = filepath.Join(s.tmpdir, guid.MustCreate())
 	label = "key*"
 	err = s.Run(csr.Root, &csr.RootFlags{
+		CAConfig:   &caConfig,
 		CsrProfile: &csrFile,
 		KeyLabel:   &label,
+		Profile:    &profile,
 		Output:     &output,
 	})
 	s.Require().NoError(err)
@@ -82,7 +104,73 @@ func (s *testSuite) TestRootCA() {
 	s.HasTextInFile(cert, "CERTIFICATE")
 	s.HasTextInFile(key, "pkcs11")
 }
-*/
+
+func (s *testSuite) TestIntermediate() {
+	s.createRootCA()
+
+	caConfig := projFolder + "etc/dev/ca-config.bootstrap.json"
+	empty := ""
+
+	// Level 1 CA
+	csrProfile := projFolder + "etc/dev/csr/trusty_dev_issuer1_ca.json"
+	label := "issuer1*"
+	profile := "L1_CA"
+	output1 := filepath.Join(s.tmpdir, "issuer1"+guid.MustCreate())
+	err := s.Run(csr.Intermediate, &csr.IntermediateFlags{
+		CACert:     &s.rootCert,
+		CAKey:      &s.rootKey,
+		CABundle:   &empty,
+		CAConfig:   &caConfig,
+		CsrProfile: &csrProfile,
+		KeyLabel:   &label,
+		Profile:    &profile,
+		Output:     &output1,
+	})
+	s.Require().NoError(err)
+	s.HasTextInFile(output1+".pem", "CERTIFICATE")
+	s.HasTextInFile(output1+"-key.pem", "pkcs11")
+	s.HasTextInFile(output1+"-chain.pem", "CERTIFICATE")
+
+	// Level 2 CA
+	cert1 := output1 + ".pem"
+	key1 := output1 + "-key.pem"
+	chain1 := output1 + "-chain.pem"
+	csrProfile = projFolder + "etc/dev/csr/trusty_dev_issuer2_ca.json"
+	label = "issuer2*"
+	profile = "L2_CA"
+	output2 := filepath.Join(s.tmpdir, "issuer2"+guid.MustCreate())
+	err = s.Run(csr.Intermediate, &csr.IntermediateFlags{
+		CACert:     &cert1,
+		CAKey:      &key1,
+		CABundle:   &empty,
+		CAConfig:   &caConfig,
+		CsrProfile: &csrProfile,
+		KeyLabel:   &label,
+		Profile:    &profile,
+		Output:     &output2,
+	})
+	s.Require().NoError(err)
+	s.HasTextInFile(output2+"-chain.pem", "CERTIFICATE")
+
+	chain, err := ioutil.ReadFile(output2 + "-chain.pem")
+	s.Require().NoError(err)
+	s.Equal(2, strings.Count(string(chain), "BEGIN CERTIFICATE"))
+
+	// not CA profile
+	profile = "server"
+	err = s.Run(csr.Intermediate, &csr.IntermediateFlags{
+		CACert:     &cert1,
+		CAKey:      &key1,
+		CABundle:   &chain1,
+		CAConfig:   &caConfig,
+		CsrProfile: &csrProfile,
+		KeyLabel:   &label,
+		Profile:    &profile,
+		Output:     &output2,
+	})
+	s.Require().Error(err)
+	s.Equal("profile is not CA profile: server", err.Error())
+}
 
 func (s *testSuite) TestCreate() {
 	csrFile := projFolder + "etc/dev/csr/trusty_dev_client.json"
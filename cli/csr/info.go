@@ -0,0 +1,121 @@
+package csr
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/go-phorce/dolly/ctl"
+	"github.com/go-phorce/dolly/xpki/certutil"
+	"github.com/go-phorce/trusty/cli"
+	"github.com/go-phorce/trusty/pkg/print"
+	"github.com/juju/errors"
+)
+
+// InfoFlags specifies flags for Info command
+type InfoFlags struct {
+	// In specifies file name with CSR in PEM or DER format
+	In *string
+}
+
+// RequestInfo provides certificate request details for JSON output
+type RequestInfo struct {
+	Subject            string   `json:"subject"`
+	DNSNames           []string `json:"dns_names,omitempty"`
+	IPAddresses        []string `json:"ip_addresses,omitempty"`
+	EmailAddresses     []string `json:"email_addresses,omitempty"`
+	URIs               []string `json:"uris,omitempty"`
+	Extensions         []string `json:"extensions,omitempty"`
+	PublicKeyAlgorithm string   `json:"public_key_algorithm"`
+	SignatureAlgorithm string   `json:"signature_algorithm"`
+}
+
+// Info prints the certificate request details
+func Info(c ctl.Control, p interface{}) error {
+	flags := p.(*InfoFlags)
+
+	data, err := c.(*cli.Cli).ReadFileOrStdin(*flags.In)
+	if err != nil {
+		return errors.Annotate(err, "read CSR")
+	}
+
+	csrs, err := ParseCertificateRequests(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.(*cli.Cli).IsJSON() {
+		infos := make([]*RequestInfo, len(csrs))
+		for i, csr := range csrs {
+			infos[i] = NewRequestInfo(csr)
+		}
+		ctl.WriteJSON(c.Writer(), infos)
+		fmt.Fprint(c.Writer(), "\n")
+	} else {
+		for idx, csr := range csrs {
+			if len(csrs) > 1 {
+				fmt.Fprintf(c.Writer(), "==================================== %d ====================================\n", 1+idx)
+			}
+			print.CertificateRequest(c.Writer(), csr)
+		}
+	}
+
+	return nil
+}
+
+// ParseCertificateRequests returns the list of certificate requests
+// from PEM or DER encoded data
+func ParseCertificateRequests(data []byte) ([]*x509.CertificateRequest, error) {
+	var list []*x509.CertificateRequest
+
+	if bytes.Contains(data, []byte("-----BEGIN")) {
+		for {
+			var block *pem.Block
+			block, data = pem.Decode(data)
+			if block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST" {
+				continue
+			}
+			csr, err := x509.ParseCertificateRequest(block.Bytes)
+			if err != nil {
+				return nil, errors.Annotate(err, "failed to parse CSR")
+			}
+			list = append(list, csr)
+		}
+	} else if len(data) > 0 {
+		csr, err := x509.ParseCertificateRequest(data)
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to parse CSR")
+		}
+		list = append(list, csr)
+	}
+
+	if len(list) == 0 {
+		return nil, errors.New("no certificate requests found")
+	}
+	return list, nil
+}
+
+// NewRequestInfo returns RequestInfo
+func NewRequestInfo(csr *x509.CertificateRequest) *RequestInfo {
+	info := &RequestInfo{
+		Subject:            certutil.NameToString(&csr.Subject),
+		DNSNames:           csr.DNSNames,
+		EmailAddresses:     csr.EmailAddresses,
+		PublicKeyAlgorithm: csr.PublicKeyAlgorithm.String(),
+		SignatureAlgorithm: csr.SignatureAlgorithm.String(),
+	}
+	for _, ip := range csr.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	for _, uri := range csr.URIs {
+		info.URIs = append(info.URIs, uri.String())
+	}
+	for _, ext := range csr.Extensions {
+		info.Extensions = append(info.Extensions, ext.Id.String())
+	}
+	return info
+}
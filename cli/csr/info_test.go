@@ -0,0 +1,75 @@
+package csr_test
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-phorce/dolly/algorithms/guid"
+	"github.com/go-phorce/trusty/cli/csr"
+	"github.com/go-phorce/trusty/cli/testsuite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type infoSuite struct {
+	testsuite.Suite
+}
+
+func TestInfoSuite(t *testing.T) {
+	suite.Run(t, new(infoSuite))
+}
+
+func TestInfoSuiteWithJSON(t *testing.T) {
+	s := new(infoSuite)
+	s.WithAppFlags([]string{"--json"})
+	suite.Run(t, s)
+}
+
+func (s *infoSuite) TestInfo() {
+	in := projFolder + "pkg/print/testdata/trusty_dev_peer.csr"
+	err := s.Run(csr.Info, &csr.InfoFlags{In: &in})
+	s.Require().NoError(err)
+	if s.Cli.IsJSON() {
+		s.HasText(`"subject": "C=US, L=WA, O=trusty.com, CN=localhost"`, `"public_key_algorithm": "RSA"`)
+	} else {
+		s.HasText("Subject: C=US, L=WA, O=trusty.com, CN=localhost\n")
+	}
+
+	// DER
+	pemData, err := ioutil.ReadFile(in)
+	s.Require().NoError(err)
+	block, _ := pem.Decode(pemData)
+	s.Require().NotNil(block)
+
+	in = filepath.Join(os.TempDir(), guid.MustCreate()+".der")
+	defer os.Remove(in)
+	err = ioutil.WriteFile(in, block.Bytes, 0644)
+	s.Require().NoError(err)
+
+	err = s.Run(csr.Info, &csr.InfoFlags{In: &in})
+	s.Require().NoError(err)
+	s.HasText("CN=localhost")
+
+	in = projFolder + "cli/csr/info_test.go"
+	err = s.Run(csr.Info, &csr.InfoFlags{In: &in})
+	s.Require().Error(err)
+}
+
+func TestParseCertificateRequests(t *testing.T) {
+	_, err := csr.ParseCertificateRequests([]byte("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n"))
+	require.Error(t, err)
+	assert.Equal(t, "no certificate requests found", err.Error())
+
+	pem1, err := ioutil.ReadFile(projFolder + "pkg/print/testdata/trusty_dev_peer.csr")
+	require.NoError(t, err)
+	pem2, err := ioutil.ReadFile(projFolder + "pkg/print/testdata/trusty_dev_root_ca.csr")
+	require.NoError(t, err)
+
+	list, err := csr.ParseCertificateRequests(append(pem1, pem2...))
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+}
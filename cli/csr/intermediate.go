@@ -0,0 +1,150 @@
+package csr
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/go-phorce/dolly/ctl"
+	"github.com/go-phorce/dolly/xpki/certutil"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/cli"
+	"github.com/go-phorce/trusty/config"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/juju/errors"
+)
+
+// IntermediateFlags specifies flags for Intermediate command
+type IntermediateFlags struct {
+	// CACert specifies file name with the parent CA cert
+	CACert *string
+	// CAKey specifies file name with the parent CA key
+	CAKey *string
+	// CABundle specifies file name with the parent CA intermediate certs
+	CABundle *string
+	// CAConfig specifies file name with ca-config
+	CAConfig *string
+	// CsrProfile specifies file name with CSR profile
+	CsrProfile *string
+	// KeyLabel specifies name for generated key
+	KeyLabel *string
+	// Profile specifies the CA profile name from ca-config
+	Profile *string
+	// Output specifies the optional prefix for output files,
+	// if not set, the output will be printed to STDOUT only
+	Output *string
+}
+
+// Intermediate generates a key and creates intermediate CA certificate,
+// signed by the parent CA
+func Intermediate(c ctl.Control, p interface{}) error {
+	flags := p.(*IntermediateFlags)
+
+	cryptoprov, defaultCrypto := c.(*cli.Cli).CryptoProv()
+	if cryptoprov == nil {
+		return errors.Errorf("unsupported command for this crypto provider")
+	}
+
+	csrf, err := c.(*cli.Cli).ReadFileOrStdin(*flags.CsrProfile)
+	if err != nil {
+		return errors.Annotate(err, "read CSR profile")
+	}
+
+	prov := csr.NewProvider(defaultCrypto)
+	req := csr.CertificateRequest{
+		KeyRequest: prov.NewKeyRequest(prefixKeyLabel(*flags.KeyLabel), "ECDSA", 256, csr.SigningKey),
+	}
+
+	err = json.Unmarshal(csrf, &req)
+	if err != nil {
+		return errors.Annotate(err, "invalid CSR profile")
+	}
+
+	cacfg, err := loadCAConfig(*flags.CAConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	profile := *flags.Profile
+	if err = ensureCAProfile(cacfg, profile); err != nil {
+		return errors.Trace(err)
+	}
+
+	isscfg := &config.Issuer{
+		CertFile: *flags.CACert,
+		KeyFile:  *flags.CAKey,
+	}
+
+	issuer, err := authority.NewIssuer(isscfg, cacfg, cryptoprov)
+	if err != nil {
+		return errors.Annotate(err, "create issuer")
+	}
+
+	csrPEM, key, _, _, err := prov.CreateRequestAndExportKey(&req)
+	if err != nil {
+		return errors.Annotate(err, "process CSR")
+	}
+
+	_, certPEM, err := issuer.Sign(csr.SignRequest{
+		SAN:     req.SAN,
+		Request: string(csrPEM),
+		Profile: profile,
+	})
+	if err != nil {
+		return errors.Annotate(err, "sign request")
+	}
+
+	var bundlePEM []byte
+	if flags.CABundle != nil && *flags.CABundle != "" {
+		bundlePEM, err = ioutil.ReadFile(*flags.CABundle)
+		if err != nil {
+			return errors.Annotate(err, "failed to load ca-bundle")
+		}
+	}
+
+	chainPEM, err := buildChain(certPEM, *flags.CACert, bundlePEM)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if *flags.Output == "" {
+		PrintCert(c.Writer(), key, csrPEM, certPEM)
+	} else {
+		err = SaveCert(*flags.Output, key, csrPEM, certPEM)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = ioutil.WriteFile(*flags.Output+"-chain.pem", chainPEM, 0664)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	return nil
+}
+
+// buildChain returns PEM encoded chain of the certificate,
+// followed by the parent CA certificate, if it's not self-signed,
+// and the parent's CA bundle
+func buildChain(certPEM []byte, caCertFile string, bundlePEM []byte) ([]byte, error) {
+	caPEM, err := ioutil.ReadFile(caCertFile)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to load CA cert")
+	}
+	caCert, err := certutil.ParseFromPEM(caPEM)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to parse CA cert")
+	}
+
+	chain := bytes.NewBuffer(bytes.TrimSpace(certPEM))
+	chain.WriteString("\n")
+	if !bytes.Equal(caCert.RawSubject, caCert.RawIssuer) {
+		chain.Write(bytes.TrimSpace(caPEM))
+		chain.WriteString("\n")
+	}
+	if len(bundlePEM) > 0 {
+		chain.Write(bytes.TrimSpace(bundlePEM))
+		chain.WriteString("\n")
+	}
+	return chain.Bytes(), nil
+}
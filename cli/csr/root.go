@@ -0,0 +1,103 @@
+package csr
+
+import (
+	"encoding/json"
+
+	"github.com/go-phorce/dolly/ctl"
+	"github.com/go-phorce/trusty/authority"
+	"github.com/go-phorce/trusty/cli"
+	"github.com/go-phorce/trusty/pkg/csr"
+	"github.com/juju/errors"
+)
+
+// RootFlags specifies flags for Root command
+type RootFlags struct {
+	// CAConfig specifies file name with ca-config
+	CAConfig *string
+	// CsrProfile specifies file name with CSR profile
+	CsrProfile *string
+	// KeyLabel specifies name for generated key
+	KeyLabel *string
+	// Profile specifies the profile name from ca-config
+	Profile *string
+	// Output specifies the optional prefix for output files,
+	// if not set, the output will be printed to STDOUT only
+	Output *string
+}
+
+// Root generates a key and creates a self-signed certificate
+func Root(c ctl.Control, p interface{}) error {
+	flags := p.(*RootFlags)
+
+	cryptoprov, defaultCrypto := c.(*cli.Cli).CryptoProv()
+	if cryptoprov == nil {
+		return errors.Errorf("unsupported command for this crypto provider")
+	}
+
+	csrf, err := c.(*cli.Cli).ReadFileOrStdin(*flags.CsrProfile)
+	if err != nil {
+		return errors.Annotate(err, "read CSR profile")
+	}
+
+	prov := csr.NewProvider(defaultCrypto)
+	req := csr.CertificateRequest{
+		KeyRequest: prov.NewKeyRequest(prefixKeyLabel(*flags.KeyLabel), "ECDSA", 256, csr.SigningKey),
+	}
+
+	err = json.Unmarshal(csrf, &req)
+	if err != nil {
+		return errors.Annotate(err, "invalid CSR profile")
+	}
+
+	cacfg, err := loadCAConfig(*flags.CAConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	profile := *flags.Profile
+	if err = ensureCAProfile(cacfg, profile); err != nil {
+		return errors.Trace(err)
+	}
+
+	certPEM, csrPEM, key, err := authority.NewRoot(profile, cacfg, defaultCrypto, &req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if *flags.Output == "" {
+		PrintCert(c.Writer(), key, csrPEM, certPEM)
+	} else {
+		err = SaveCert(*flags.Output, key, csrPEM, certPEM)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	return nil
+}
+
+// loadCAConfig loads and validates ca-config
+func loadCAConfig(file string) (*authority.Config, error) {
+	cacfg, err := authority.LoadConfig(file)
+	if err != nil {
+		return nil, errors.Annotate(err, "ca-config")
+	}
+	err = cacfg.Validate()
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid ca-config")
+	}
+	return cacfg, nil
+}
+
+// ensureCAProfile returns an error if the profile is not found,
+// or it does not allow to issue CA certificates
+func ensureCAProfile(cacfg *authority.Config, profile string) error {
+	p := cacfg.Profiles[profile]
+	if p == nil {
+		return errors.Errorf("profile not found: %s", profile)
+	}
+	if !p.CAConstraint.IsCA {
+		return errors.Errorf("profile is not CA profile: %s", profile)
+	}
+	return nil
+}
@@ -122,7 +122,7 @@ func (s *Suite) SetupSuite() {
 
 	flags := []string{"cliapp", "-V", "-D"}
 
-	var ops []cli.Option
+	ops := []cli.Option{cli.WithJSON()}
 
 	if s.withFileServer || s.withGRPC {
 		ops = append(ops, cli.WithServer(""))
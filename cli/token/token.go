@@ -0,0 +1,95 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-phorce/dolly/ctl"
+	pb "github.com/go-phorce/trusty/api/v1/trustypb"
+	"github.com/go-phorce/trusty/cli"
+	"github.com/go-phorce/trusty/pkg/print"
+	"github.com/juju/errors"
+)
+
+// CreateFlags defines flags for Create command
+type CreateFlags struct {
+	// Profile specifies the certificate profile
+	Profile *string
+	// IssuerLabel specifies the optional Issuer label
+	IssuerLabel *string
+	// NamePattern specifies RegExp for Common Name and Subject Alternative Names
+	NamePattern *string
+	// AllowAnyName specifies to create the token without NamePattern
+	AllowAnyName *bool
+	// Expiry specifies the token lifetime
+	Expiry *string
+}
+
+// Create creates one-time enrollment token
+func Create(c ctl.Control, p interface{}) error {
+	flags := p.(*CreateFlags)
+
+	cli := c.(*cli.Cli)
+	res, err := cli.Client().Authority.CreateEnrollmentToken(context.Background(), &pb.CreateEnrollmentTokenRequest{
+		Profile:      *flags.Profile,
+		IssuerLabel:  *flags.IssuerLabel,
+		NamePattern:  *flags.NamePattern,
+		Expiry:       *flags.Expiry,
+		AllowAnyName: *flags.AllowAnyName,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if cli.IsJSON() {
+		ctl.WriteJSON(c.Writer(), res)
+		fmt.Fprint(c.Writer(), "\n")
+	} else {
+		print.EnrollmentToken(c.Writer(), res)
+	}
+	return nil
+}
+
+// List shows the enrollment tokens
+func List(c ctl.Control, _ interface{}) error {
+	cli := c.(*cli.Cli)
+	res, err := cli.Client().Authority.ListEnrollmentTokens(context.Background())
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if cli.IsJSON() {
+		ctl.WriteJSON(c.Writer(), res)
+		fmt.Fprint(c.Writer(), "\n")
+	} else {
+		print.EnrollmentTokens(c.Writer(), res.Tokens)
+	}
+	return nil
+}
+
+// RevokeFlags defines flags for Revoke command
+type RevokeFlags struct {
+	// ID specifies the token ID
+	ID *int64
+}
+
+// Revoke revokes the enrollment token
+func Revoke(c ctl.Control, p interface{}) error {
+	flags := p.(*RevokeFlags)
+
+	cli := c.(*cli.Cli)
+	res, err := cli.Client().Authority.RevokeEnrollmentToken(context.Background(), &pb.RevokeEnrollmentTokenRequest{
+		Id: *flags.ID,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if cli.IsJSON() {
+		ctl.WriteJSON(c.Writer(), res)
+		fmt.Fprint(c.Writer(), "\n")
+	} else {
+		print.EnrollmentToken(c.Writer(), res)
+	}
+	return nil
+}
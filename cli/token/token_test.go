@@ -0,0 +1,126 @@
+package token_test
+
+import (
+	"testing"
+
+	"github.com/go-phorce/trusty/api/v1/trustypb"
+	"github.com/go-phorce/trusty/cli/testsuite"
+	"github.com/go-phorce/trusty/cli/token"
+	"github.com/go-phorce/trusty/tests/mockpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/suite"
+)
+
+type testSuite struct {
+	testsuite.Suite
+}
+
+func TestCtlSuite(t *testing.T) {
+	s := new(testSuite)
+	s.WithGRPC()
+	suite.Run(t, s)
+}
+
+func TestCtlSuiteWithJSON(t *testing.T) {
+	s := new(testSuite)
+	s.WithGRPC().WithAppFlags([]string{"--json"})
+	suite.Run(t, s)
+}
+
+func (s *testSuite) TestCreate() {
+	expectedResponse := &trustypb.EnrollmentToken{
+		Id:          1234,
+		Token:       "gG3Q2Yv0pJ7mX9rT4kLwZb8sH1nC5eUaVdIoFqRyMxE",
+		Profile:     "server",
+		NamePattern: `^.*\.trusty\.com$`,
+		CreatedBy:   "admin",
+		CreatedAt:   1603152000,
+		ExpiresAt:   1603238400,
+	}
+
+	s.MockAuthority = &mockpb.MockAuthorityServer{
+		Err:   nil,
+		Resps: []proto.Message{expectedResponse},
+	}
+	srv := s.SetupMockGRPC()
+	defer srv.Stop()
+
+	empty := ""
+	profile := "server"
+	pattern := `^.*\.trusty\.com$`
+	expiry := "24h"
+	anyName := false
+	err := s.Run(token.Create, &token.CreateFlags{
+		Profile:      &profile,
+		IssuerLabel:  &empty,
+		NamePattern:  &pattern,
+		Expiry:       &expiry,
+		AllowAnyName: &anyName,
+	})
+	s.Require().NoError(err)
+
+	if s.Cli.IsJSON() {
+		s.HasText("\t\"token\": \"gG3Q2Yv0pJ7mX9rT4kLwZb8sH1nC5eUaVdIoFqRyMxE\"\n")
+	} else {
+		s.HasText("  Token        | gG3Q2Yv0pJ7mX9rT4kLwZb8sH1nC5eUaVdIoFqRyMxE ", "  Profile      | server ")
+	}
+}
+
+func (s *testSuite) TestList() {
+	expectedResponse := &trustypb.EnrollmentTokensResponse{
+		Tokens: []*trustypb.EnrollmentToken{
+			{
+				Id:        1234,
+				Profile:   "server",
+				CreatedBy: "admin",
+				CreatedAt: 1603152000,
+				ExpiresAt: 1603238400,
+			},
+		},
+	}
+
+	s.MockAuthority = &mockpb.MockAuthorityServer{
+		Err:   nil,
+		Resps: []proto.Message{expectedResponse},
+	}
+	srv := s.SetupMockGRPC()
+	defer srv.Stop()
+
+	err := s.Run(token.List, nil)
+	s.Require().NoError(err)
+
+	if s.Cli.IsJSON() {
+		s.HasText("{\n\t\"tokens\": [\n\t\t{\n\t\t\t\"created_at\": 1603152000,\n")
+	} else {
+		s.HasText("  ID         | 1234 ", "  Expires    | 2020-10-21T00:00:00Z ")
+	}
+}
+
+func (s *testSuite) TestRevoke() {
+	expectedResponse := &trustypb.EnrollmentToken{
+		Id:        1234,
+		Profile:   "server",
+		CreatedBy: "admin",
+		CreatedAt: 1603152000,
+		ExpiresAt: 1603238400,
+	}
+
+	s.MockAuthority = &mockpb.MockAuthorityServer{
+		Err:   nil,
+		Resps: []proto.Message{expectedResponse},
+	}
+	srv := s.SetupMockGRPC()
+	defer srv.Stop()
+
+	id := int64(1234)
+	err := s.Run(token.Revoke, &token.RevokeFlags{
+		ID: &id,
+	})
+	s.Require().NoError(err)
+
+	if s.Cli.IsJSON() {
+		s.HasText("\t\"id\": 1234,\n")
+	} else {
+		s.HasText("  ID         | 1234 ", "  Profile    | server ")
+	}
+}
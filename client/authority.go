@@ -37,11 +37,41 @@ func (c *authorityClient) CreateCertificate(ctx context.Context, in *pb.CreateCe
 	return c.remote.CreateCertificate(ctx, in, c.callOpts...)
 }
 
+// EnrollCertificate returns the certificate, authorized by the enrollment token
+func (c *authorityClient) EnrollCertificate(ctx context.Context, in *pb.CreateCertificateRequest) (*pb.CertificateBundle, error) {
+	return c.remote.EnrollCertificate(ctx, in, c.callOpts...)
+}
+
 // Issuers returns the issuing CAs
 func (c *authorityClient) Issuers(ctx context.Context) (*pb.IssuersInfoResponse, error) {
 	return c.remote.Issuers(ctx, emptyReq, c.callOpts...)
 }
 
+// RevokeCertificate returns the revoked certificate
+func (c *authorityClient) RevokeCertificate(ctx context.Context, in *pb.RevokeCertificateRequest) (*pb.RevokedCertificate, error) {
+	return c.remote.RevokeCertificate(ctx, in, c.callOpts...)
+}
+
+// BlockKey adds the public key to the list of keys, that are not allowed to be certified
+func (c *authorityClient) BlockKey(ctx context.Context, in *pb.BlockKeyRequest) (*pb.BlockedKey, error) {
+	return c.remote.BlockKey(ctx, in, c.callOpts...)
+}
+
+// CreateEnrollmentToken returns one-time token, that authorizes CreateCertificate request
+func (c *authorityClient) CreateEnrollmentToken(ctx context.Context, in *pb.CreateEnrollmentTokenRequest) (*pb.EnrollmentToken, error) {
+	return c.remote.CreateEnrollmentToken(ctx, in, c.callOpts...)
+}
+
+// ListEnrollmentTokens returns the enrollment tokens, that are not expired
+func (c *authorityClient) ListEnrollmentTokens(ctx context.Context) (*pb.EnrollmentTokensResponse, error) {
+	return c.remote.ListEnrollmentTokens(ctx, emptyReq, c.callOpts...)
+}
+
+// RevokeEnrollmentToken removes the enrollment token
+func (c *authorityClient) RevokeEnrollmentToken(ctx context.Context, in *pb.RevokeEnrollmentTokenRequest) (*pb.EnrollmentToken, error) {
+	return c.remote.RevokeEnrollmentToken(ctx, in, c.callOpts...)
+}
+
 type retryAuthorityClient struct {
 	authority pb.AuthorityClient
 }
@@ -65,7 +95,37 @@ func (c *retryAuthorityClient) CreateCertificate(ctx context.Context, in *pb.Cre
 	return c.authority.CreateCertificate(ctx, in, opts...)
 }
 
+// EnrollCertificate returns the certificate, authorized by the enrollment token
+func (c *retryAuthorityClient) EnrollCertificate(ctx context.Context, in *pb.CreateCertificateRequest, opts ...grpc.CallOption) (*pb.CertificateBundle, error) {
+	return c.authority.EnrollCertificate(ctx, in, opts...)
+}
+
 // Issuers returns the issuing CAs
 func (c *retryAuthorityClient) Issuers(ctx context.Context, in *pb.EmptyRequest, opts ...grpc.CallOption) (*pb.IssuersInfoResponse, error) {
 	return c.authority.Issuers(ctx, in, opts...)
 }
+
+// RevokeCertificate returns the revoked certificate
+func (c *retryAuthorityClient) RevokeCertificate(ctx context.Context, in *pb.RevokeCertificateRequest, opts ...grpc.CallOption) (*pb.RevokedCertificate, error) {
+	return c.authority.RevokeCertificate(ctx, in, opts...)
+}
+
+// BlockKey adds the public key to the list of keys, that are not allowed to be certified
+func (c *retryAuthorityClient) BlockKey(ctx context.Context, in *pb.BlockKeyRequest, opts ...grpc.CallOption) (*pb.BlockedKey, error) {
+	return c.authority.BlockKey(ctx, in, opts...)
+}
+
+// CreateEnrollmentToken returns one-time token, that authorizes CreateCertificate request
+func (c *retryAuthorityClient) CreateEnrollmentToken(ctx context.Context, in *pb.CreateEnrollmentTokenRequest, opts ...grpc.CallOption) (*pb.EnrollmentToken, error) {
+	return c.authority.CreateEnrollmentToken(ctx, in, opts...)
+}
+
+// ListEnrollmentTokens returns the enrollment tokens, that are not expired
+func (c *retryAuthorityClient) ListEnrollmentTokens(ctx context.Context, in *pb.EmptyRequest, opts ...grpc.CallOption) (*pb.EnrollmentTokensResponse, error) {
+	return c.authority.ListEnrollmentTokens(ctx, in, opts...)
+}
+
+// RevokeEnrollmentToken removes the enrollment token
+func (c *retryAuthorityClient) RevokeEnrollmentToken(ctx context.Context, in *pb.RevokeEnrollmentTokenRequest, opts ...grpc.CallOption) (*pb.EnrollmentToken, error) {
+	return c.authority.RevokeEnrollmentToken(ctx, in, opts...)
+}
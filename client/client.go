@@ -76,8 +76,20 @@ type Authority interface {
 	ProfileInfo(ctx context.Context, in *pb.CertProfileInfoRequest) (*pb.CertProfileInfo, error)
 	// CreateCertificate returns the certificate
 	CreateCertificate(ctx context.Context, in *pb.CreateCertificateRequest) (*pb.CertificateBundle, error)
+	// EnrollCertificate returns the certificate, authorized by the enrollment token
+	EnrollCertificate(ctx context.Context, in *pb.CreateCertificateRequest) (*pb.CertificateBundle, error)
 	// Issuers returns the issuing CAs
 	Issuers(ctx context.Context) (*pb.IssuersInfoResponse, error)
+	// RevokeCertificate returns the revoked certificate
+	RevokeCertificate(ctx context.Context, in *pb.RevokeCertificateRequest) (*pb.RevokedCertificate, error)
+	// BlockKey adds the public key to the list of keys, that are not allowed to be certified
+	BlockKey(ctx context.Context, in *pb.BlockKeyRequest) (*pb.BlockedKey, error)
+	// CreateEnrollmentToken returns one-time token, that authorizes CreateCertificate request
+	CreateEnrollmentToken(ctx context.Context, in *pb.CreateEnrollmentTokenRequest) (*pb.EnrollmentToken, error)
+	// ListEnrollmentTokens returns the enrollment tokens, that are not expired
+	ListEnrollmentTokens(ctx context.Context) (*pb.EnrollmentTokensResponse, error)
+	// RevokeEnrollmentToken removes the enrollment token
+	RevokeEnrollmentToken(ctx context.Context, in *pb.RevokeEnrollmentTokenRequest) (*pb.EnrollmentToken, error)
 }
 
 // Client provides and manages an trusty v1 client session.
@@ -26,7 +26,37 @@ func (s *authoritySrv2C) CreateCertificate(ctx context.Context, in *pb.CreateCer
 	return s.srv.CreateCertificate(ctx, in)
 }
 
+// EnrollCertificate returns the certificate, authorized by the enrollment token
+func (s *authoritySrv2C) EnrollCertificate(ctx context.Context, in *pb.CreateCertificateRequest, opts ...grpc.CallOption) (*pb.CertificateBundle, error) {
+	return s.srv.EnrollCertificate(ctx, in)
+}
+
 // Issuers returns the issuing CAs
 func (s *authoritySrv2C) Issuers(ctx context.Context, in *pb.EmptyRequest, opts ...grpc.CallOption) (*pb.IssuersInfoResponse, error) {
 	return s.srv.Issuers(ctx, in)
 }
+
+// RevokeCertificate returns the revoked certificate
+func (s *authoritySrv2C) RevokeCertificate(ctx context.Context, in *pb.RevokeCertificateRequest, opts ...grpc.CallOption) (*pb.RevokedCertificate, error) {
+	return s.srv.RevokeCertificate(ctx, in)
+}
+
+// BlockKey adds the public key to the list of keys, that are not allowed to be certified
+func (s *authoritySrv2C) BlockKey(ctx context.Context, in *pb.BlockKeyRequest, opts ...grpc.CallOption) (*pb.BlockedKey, error) {
+	return s.srv.BlockKey(ctx, in)
+}
+
+// CreateEnrollmentToken returns one-time token, that authorizes CreateCertificate request
+func (s *authoritySrv2C) CreateEnrollmentToken(ctx context.Context, in *pb.CreateEnrollmentTokenRequest, opts ...grpc.CallOption) (*pb.EnrollmentToken, error) {
+	return s.srv.CreateEnrollmentToken(ctx, in)
+}
+
+// ListEnrollmentTokens returns the enrollment tokens, that are not expired
+func (s *authoritySrv2C) ListEnrollmentTokens(ctx context.Context, in *pb.EmptyRequest, opts ...grpc.CallOption) (*pb.EnrollmentTokensResponse, error) {
+	return s.srv.ListEnrollmentTokens(ctx, in)
+}
+
+// RevokeEnrollmentToken removes the enrollment token
+func (s *authoritySrv2C) RevokeEnrollmentToken(ctx context.Context, in *pb.RevokeEnrollmentTokenRequest, opts ...grpc.CallOption) (*pb.EnrollmentToken, error) {
+	return s.srv.RevokeEnrollmentToken(ctx, in)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/go-phorce/dolly/ctl"
 	"github.com/go-phorce/dolly/xlog"
 	"github.com/go-phorce/trusty/cli"
+	"github.com/go-phorce/trusty/cli/cert"
+	"github.com/go-phorce/trusty/cli/crl"
 	"github.com/go-phorce/trusty/cli/csr"
 	"github.com/go-phorce/trusty/version"
 )
@@ -41,24 +43,70 @@ func realMain(args []string, out io.Writer, errout io.Writer) ctl.ReturnCode {
 			Output: out,
 		},
 		cli.WithHsmCfg(),
+		cli.WithJSON(),
 	)
 	defer cli.Close()
 
-	// csr self-sign|create|sign|gencert
+	// cert info|verify|lint
+	cmdCert := app.Command("cert", "certificate commands").
+		PreAction(cli.PopulateControl)
+
+	certInfoFlags := new(cert.InfoFlags)
+	cmdCertInfo := cmdCert.Command("info", "prints certificates in PEM, DER or PKCS#7 format").
+		Action(cli.RegisterAction(cert.Info, certInfoFlags))
+	certInfoFlags.In = cmdCertInfo.Flag("in", "certificate file, or - for STDIN").Required().String()
+
+	certVerifyFlags := new(cert.VerifyFlags)
+	cmdCertVerify := cmdCert.Command("verify", "builds and verifies certificate chain").
+		Action(cli.RegisterAction(cert.Verify, certVerifyFlags))
+	certVerifyFlags.Cert = cmdCertVerify.Flag("cert", "certificate file, other certificates in the file are used as intermediates").Required().String()
+	certVerifyFlags.Roots = cmdCertVerify.Flag("roots", "trusted root certificates file").Required().String()
+	certVerifyFlags.Intermediates = cmdCertVerify.Flag("intermediates", "optional, intermediate certificates file").String()
+	certVerifyFlags.Usages = cmdCertVerify.Flag("usages", "optional, coma separated list of extended key usages to verify: server_auth,client_auth,code_signing...").String()
+	certVerifyFlags.ExpiresIn = cmdCertVerify.Flag("expires-in", "warn for certificates expiring within the duration").Default("720h").Duration()
+
+	certLintFlags := new(cert.LintFlags)
+	cmdCertLint := cmdCert.Command("lint", "checks certificates for RFC 5280 and CA/Browser Forum violations").
+		Action(cli.RegisterAction(cert.Lint, certLintFlags))
+	certLintFlags.Cert = cmdCertLint.Flag("cert", "certificate file").Required().String()
+	certLintFlags.CAConfig = cmdCertLint.Flag("ca-config", "optional, CA configuration file to use lint severities of the profile").String()
+	certLintFlags.Profile = cmdCertLint.Flag("profile", "certificate profile").Default("default").String()
+
+	// csr info|self-sign|intermediate|create|sign|gencert
 	cmdCSR := app.Command("csr", "CSR commands").
-		PreAction(cli.PopulateControl).
-		PreAction(cli.EnsureCryptoProvider)
-		/*
-			createRootFlags := new(csr.RootFlags)
-			cmdCreateRoot := cmdCSR.Command("self-sign", "generates key and creates self-signed certificate").
-				Action(cli.RegisterAction(csr.Root, createRootFlags))
-			createRootFlags.CsrProfile = cmdCreateRoot.Flag("csr-profile", "CSR profile file").Required().String()
-			createRootFlags.KeyLabel = cmdCreateRoot.Flag("key-label", "label for generated key").String()
-			createRootFlags.Output = cmdCreateRoot.Flag("out", "specifies the optional prefix for output files").String()
-		*/
+		PreAction(cli.PopulateControl)
+
+	csrInfoFlags := new(csr.InfoFlags)
+	cmdCsrInfo := cmdCSR.Command("info", "prints certificate request in PEM or DER format").
+		Action(cli.RegisterAction(csr.Info, csrInfoFlags))
+	csrInfoFlags.In = cmdCsrInfo.Flag("in", "CSR file, or - for STDIN").Required().String()
+
+	createRootFlags := new(csr.RootFlags)
+	cmdCreateRoot := cmdCSR.Command("self-sign", "generates key and creates self-signed certificate").
+		PreAction(cli.EnsureCryptoProvider).
+		Action(cli.RegisterAction(csr.Root, createRootFlags))
+	createRootFlags.CAConfig = cmdCreateRoot.Flag("ca-config", "CA configuration file").Required().String()
+	createRootFlags.CsrProfile = cmdCreateRoot.Flag("csr-profile", "CSR profile file").Required().String()
+	createRootFlags.KeyLabel = cmdCreateRoot.Flag("key-label", "label for generated key").String()
+	createRootFlags.Profile = cmdCreateRoot.Flag("profile", "certificate profile").Default("ROOT").String()
+	createRootFlags.Output = cmdCreateRoot.Flag("out", "specifies the optional prefix for output files").String()
+
+	createIntFlags := new(csr.IntermediateFlags)
+	cmdCreateInt := cmdCSR.Command("intermediate", "generates key and creates intermediate CA certificate signed by provided CA key").
+		PreAction(cli.EnsureCryptoProvider).
+		Action(cli.RegisterAction(csr.Intermediate, createIntFlags))
+	createIntFlags.CAConfig = cmdCreateInt.Flag("ca-config", "CA configuration file").Required().String()
+	createIntFlags.CACert = cmdCreateInt.Flag("ca-cert", "CA certificate").Required().String()
+	createIntFlags.CAKey = cmdCreateInt.Flag("ca-key", "CA key").Required().String()
+	createIntFlags.CABundle = cmdCreateInt.Flag("ca-bundle", "optional, intermediate CA certificates of the CA to be included in the chain").String()
+	createIntFlags.CsrProfile = cmdCreateInt.Flag("csr-profile", "CSR profile file").Required().String()
+	createIntFlags.KeyLabel = cmdCreateInt.Flag("key-label", "label for generated key").String()
+	createIntFlags.Profile = cmdCreateInt.Flag("profile", "CA certificate profile").Required().String()
+	createIntFlags.Output = cmdCreateInt.Flag("out", "specifies the optional prefix for output files").String()
 
 	createCSRFlags := new(csr.CreateFlags)
 	cmdCreateCSR := cmdCSR.Command("create", "generates key and creates certificate request").
+		PreAction(cli.EnsureCryptoProvider).
 		Action(cli.RegisterAction(csr.Create, createCSRFlags))
 	createCSRFlags.CsrProfile = cmdCreateCSR.Flag("csr-profile", "CSR profile file").Required().String()
 	createCSRFlags.KeyLabel = cmdCreateCSR.Flag("key-label", "label for generated key").String()
@@ -66,6 +114,7 @@ func realMain(args []string, out io.Writer, errout io.Writer) ctl.ReturnCode {
 
 	signCSRFlags := new(csr.SignFlags)
 	cmdSignCSR := cmdCSR.Command("sign", "signs certificate request with provided CA key").
+		PreAction(cli.EnsureCryptoProvider).
 		Action(cli.RegisterAction(csr.Sign, signCSRFlags))
 	signCSRFlags.Csr = cmdSignCSR.Flag("csr", "CSR file to be signed").Required().String()
 	signCSRFlags.CAConfig = cmdSignCSR.Flag("ca-config", "CA configuration file").Required().String()
@@ -77,6 +126,7 @@ func realMain(args []string, out io.Writer, errout io.Writer) ctl.ReturnCode {
 
 	genCertFlags := new(csr.GenCertFlags)
 	cmdGenCertCSR := cmdCSR.Command("gencert", "creates certificate with provided CA key").
+		PreAction(cli.EnsureCryptoProvider).
 		Action(cli.RegisterAction(csr.GenCert, genCertFlags))
 	genCertFlags.SelfSign = cmdGenCertCSR.Flag("self-sign", "enables to create a self-signed certificate").Bool()
 	genCertFlags.CsrProfile = cmdGenCertCSR.Flag("csr-profile", "CSR file to be signed").Required().String()
@@ -88,6 +138,27 @@ func realMain(args []string, out io.Writer, errout io.Writer) ctl.ReturnCode {
 	genCertFlags.SAN = cmdGenCertCSR.Flag("SAN", "coma separated list of SAN to be added to certificate").String()
 	genCertFlags.Output = cmdGenCertCSR.Flag("out", "specifies the optional prefix for output files").String()
 
+	// crl info|sign
+	cmdCRL := app.Command("crl", "CRL commands").
+		PreAction(cli.PopulateControl)
+
+	crlInfoFlags := new(crl.InfoFlags)
+	cmdCrlInfo := cmdCRL.Command("info", "prints CRL in PEM, DER or PKCS#7 format").
+		Action(cli.RegisterAction(crl.Info, crlInfoFlags))
+	crlInfoFlags.In = cmdCrlInfo.Flag("in", "CRL file, or - for STDIN").Required().String()
+
+	signCRLFlags := new(crl.SignFlags)
+	cmdSignCRL := cmdCRL.Command("sign", "creates CRL signed by provided CA key").
+		PreAction(cli.EnsureCryptoProvider).
+		Action(cli.RegisterAction(crl.Sign, signCRLFlags))
+	signCRLFlags.CACert = cmdSignCRL.Flag("ca-cert", "CA certificate").Required().String()
+	signCRLFlags.CAKey = cmdSignCRL.Flag("ca-key", "CA key").Required().String()
+	signCRLFlags.Revoked = cmdSignCRL.Flag("revoked", "optional, file with revoked certificates in JSON or CSV format: serial,revoked_at,reason").String()
+	signCRLFlags.PrevCRL = cmdSignCRL.Flag("prev-crl", "optional, previous CRL file to increment the CRL number").String()
+	signCRLFlags.NextUpdate = cmdSignCRL.Flag("next-update", "duration for the next update").Default("720h").Duration()
+	signCRLFlags.Format = cmdSignCRL.Flag("format", "output format: pem, der").Default("pem").String()
+	signCRLFlags.Output = cmdSignCRL.Flag("out", "specifies the optional output file, required for DER format").String()
+
 	cli.Parse(args)
 	return cli.ReturnCode()
 }
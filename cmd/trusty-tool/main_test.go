@@ -85,3 +85,31 @@ func TestGoVersion(t *testing.T) {
 func (s *testSuite) TestCsrRoot() {
 	s.Equal(ctl.RCUsage, s.run("csr", "self-sign"))
 }
+
+func (s *testSuite) TestCsrIntermediate() {
+	s.Equal(ctl.RCUsage, s.run("csr", "intermediate"))
+}
+
+func (s *testSuite) TestCrlSign() {
+	s.Equal(ctl.RCUsage, s.run("crl", "sign"))
+}
+
+func (s *testSuite) TestCertInfo() {
+	s.Equal(ctl.RCUsage, s.run("cert", "info"))
+}
+
+func (s *testSuite) TestCertVerify() {
+	s.Equal(ctl.RCUsage, s.run("cert", "verify"))
+}
+
+func (s *testSuite) TestCsrInfo() {
+	s.Equal(ctl.RCUsage, s.run("csr", "info"))
+}
+
+func (s *testSuite) TestCrlInfo() {
+	s.Equal(ctl.RCUsage, s.run("crl", "info"))
+}
+
+func (s *testSuite) TestCertLint() {
+	s.Equal(ctl.RCUsage, s.run("cert", "lint"))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/go-phorce/trusty/cli"
 	"github.com/go-phorce/trusty/cli/ca"
 	"github.com/go-phorce/trusty/cli/status"
+	"github.com/go-phorce/trusty/cli/token"
 	"github.com/go-phorce/trusty/version"
 )
 
@@ -76,6 +77,48 @@ func realMain(args []string, out io.Writer, errout io.Writer) ctl.ReturnCode {
 	cmdCA.Command("issuers", "show the issuing CAs").
 		Action(cli.RegisterAction(ca.Issuers, nil))
 
+	getProfileFlags := new(ca.GetProfileFlags)
+	cmdGetProfile := cmdCA.Command("profile", "show the certificate profile").
+		Action(cli.RegisterAction(ca.Profile, getProfileFlags))
+	getProfileFlags.Profile = cmdGetProfile.Flag("name", "profile name").Default("default").String()
+	getProfileFlags.Label = cmdGetProfile.Flag("label", "optional, issuer label").String()
+
+	revokeFlags := new(ca.RevokeFlags)
+	cmdRevoke := cmdCA.Command("revoke", "revoke the certificate").
+		Action(cli.RegisterAction(ca.Revoke, revokeFlags))
+	revokeFlags.Skid = cmdRevoke.Flag("skid", "Subject Key ID, all active certificates with the key are revoked").String()
+	revokeFlags.Ikid = cmdRevoke.Flag("ikid", "Issuer Key ID of the certificate, required with --serial").String()
+	revokeFlags.Serial = cmdRevoke.Flag("serial", "serial number of the certificate, required with --ikid").String()
+	revokeFlags.Reason = cmdRevoke.Flag("reason", "revocation reason: unspecified, key_compromise, ca_compromise, affiliation_changed, superseded, cessation_of_operation, certificate_hold, remove_from_crl, privilege_withdrawn, aa_compromise").Default("unspecified").String()
+
+	blockKeyFlags := new(ca.BlockKeyFlags)
+	cmdBlockKey := cmdCA.Command("block-key", "block the public key from being certified").
+		Action(cli.RegisterAction(ca.BlockKey, blockKeyFlags))
+	blockKeyFlags.SpkiHash = cmdBlockKey.Flag("spki", "hex encoded SHA-256 hash of the SubjectPublicKeyInfo").String()
+	blockKeyFlags.PemFile = cmdBlockKey.Flag("pem", "file with the public key, certificate, or certificate request, required if --spki is not provided").String()
+	blockKeyFlags.Reason = cmdBlockKey.Flag("reason", "reason: unspecified, key_compromise, ca_compromise, affiliation_changed, superseded, cessation_of_operation, privilege_withdrawn, aa_compromise").Default("key_compromise").String()
+
+	cmdToken := app.Command("token", "enrollment tokens operations").
+		PreAction(cli.PopulateControl).
+		PreAction(cli.EnsureClient)
+
+	createTokenFlags := new(token.CreateFlags)
+	cmdCreateToken := cmdToken.Command("create", "create one-time enrollment token").
+		Action(cli.RegisterAction(token.Create, createTokenFlags))
+	createTokenFlags.Profile = cmdCreateToken.Flag("profile", "certificate profile").Required().String()
+	createTokenFlags.IssuerLabel = cmdCreateToken.Flag("issuer", "optional, issuer label").String()
+	createTokenFlags.NamePattern = cmdCreateToken.Flag("pattern", "RegExp that must match the entire Common Name and all Subject Alternative Names, required if --any-name is not set").String()
+	createTokenFlags.AllowAnyName = cmdCreateToken.Flag("any-name", "allow the token without --pattern, that is valid for any name").Bool()
+	createTokenFlags.Expiry = cmdCreateToken.Flag("expiry", "token lifetime, for example: 1h, 24h").Default("24h").String()
+
+	cmdToken.Command("list", "list the enrollment tokens").
+		Action(cli.RegisterAction(token.List, nil))
+
+	revokeTokenFlags := new(token.RevokeFlags)
+	cmdRevokeToken := cmdToken.Command("revoke", "revoke the enrollment token").
+		Action(cli.RegisterAction(token.Revoke, revokeTokenFlags))
+	revokeTokenFlags.ID = cmdRevokeToken.Flag("id", "token ID").Required().Int64()
+
 	cli.Parse(args)
 	return cli.ReturnCode()
 }
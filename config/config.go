@@ -66,6 +66,73 @@ func (d Duration) TimeDuration() time.Duration {
 	return time.Duration(d)
 }
 
+// ACME contains configuration for ACME service
+type ACME struct {
+
+	// BaseURL specifies the public URL of the server to build ACME resource URLs, if not provided then it is built from the request
+	BaseURL string
+
+	// Profile specifies the certificate profile for ACME orders
+	Profile string
+
+	// OrderExpiry specifies value in 24h format for duration of pending orders and authorizations
+	OrderExpiry Duration
+
+	// DNSResolver specifies the address of DNS server in host:port format for dns-01 validation, if not provided then the system resolver is used
+	DNSResolver string
+
+	// AllowedNetworks specifies the list of networks in CIDR format, that http-01 validation is allowed to connect to in addition to public addresses
+	AllowedNetworks []string
+}
+
+func (c *ACME) overrideFrom(o *ACME) {
+	overrideString(&c.BaseURL, &o.BaseURL)
+	overrideString(&c.Profile, &o.Profile)
+	overrideDuration(&c.OrderExpiry, &o.OrderExpiry)
+	overrideString(&c.DNSResolver, &o.DNSResolver)
+	overrideStrings(&c.AllowedNetworks, &o.AllowedNetworks)
+
+}
+
+// ACMEConfig contains configuration for ACMEConfig service
+type ACMEConfig interface {
+	// BaseURL specifies the public URL of the server to build ACME resource URLs, if not provided then it is built from the request
+	GetBaseURL() string
+	// Profile specifies the certificate profile for ACME orders
+	GetProfile() string
+	// OrderExpiry specifies value in 24h format for duration of pending orders and authorizations
+	GetOrderExpiry() time.Duration
+	// DNSResolver specifies the address of DNS server in host:port format for dns-01 validation, if not provided then the system resolver is used
+	GetDNSResolver() string
+	// AllowedNetworks specifies the list of networks in CIDR format, that http-01 validation is allowed to connect to in addition to public addresses
+	GetAllowedNetworks() []string
+}
+
+// GetBaseURL specifies the public URL of the server to build ACME resource URLs, if not provided then it is built from the request
+func (c *ACME) GetBaseURL() string {
+	return c.BaseURL
+}
+
+// GetProfile specifies the certificate profile for ACME orders
+func (c *ACME) GetProfile() string {
+	return c.Profile
+}
+
+// GetOrderExpiry specifies value in 24h format for duration of pending orders and authorizations
+func (c *ACME) GetOrderExpiry() time.Duration {
+	return c.OrderExpiry.TimeDuration()
+}
+
+// GetDNSResolver specifies the address of DNS server in host:port format for dns-01 validation, if not provided then the system resolver is used
+func (c *ACME) GetDNSResolver() string {
+	return c.DNSResolver
+}
+
+// GetAllowedNetworks specifies the list of networks in CIDR format, that http-01 validation is allowed to connect to in addition to public addresses
+func (c *ACME) GetAllowedNetworks() []string {
+	return c.AllowedNetworks
+}
+
 // Authority contains configuration info for CA
 type Authority struct {
 
@@ -124,6 +191,9 @@ type Authz struct {
 	// JWTMapper specifies location of the config file for JWT based identity.
 	JWTMapper string
 
+	// BasicMapper specifies location of the config file for HTTP Basic based identity.
+	BasicMapper string
+
 	// OAuthClient specifies the configuration file for OAuth client.
 	OAuthClient string
 }
@@ -138,6 +208,7 @@ func (c *Authz) overrideFrom(o *Authz) {
 	overrideString(&c.CertMapper, &o.CertMapper)
 	overrideString(&c.APIKeyMapper, &o.APIKeyMapper)
 	overrideString(&c.JWTMapper, &o.JWTMapper)
+	overrideString(&c.BasicMapper, &o.BasicMapper)
 	overrideString(&c.OAuthClient, &o.OAuthClient)
 
 }
@@ -162,6 +233,8 @@ type AuthzConfig interface {
 	GetAPIKeyMapper() string
 	// JWTMapper specifies location of the config file for JWT based identity.
 	GetJWTMapper() string
+	// BasicMapper specifies location of the config file for HTTP Basic based identity.
+	GetBasicMapper() string
 	// OAuthClient specifies the configuration file for OAuth client.
 	GetOAuthClient() string
 }
@@ -211,6 +284,11 @@ func (c *Authz) GetJWTMapper() string {
 	return c.JWTMapper
 }
 
+// GetBasicMapper specifies location of the config file for HTTP Basic based identity.
+func (c *Authz) GetBasicMapper() string {
+	return c.BasicMapper
+}
+
 // GetOAuthClient specifies the configuration file for OAuth client.
 func (c *Authz) GetOAuthClient() string {
 	return c.OAuthClient
@@ -458,6 +536,15 @@ type Configuration struct {
 	// Authority contains configuration info for CA
 	Authority Authority
 
+	// ACME contains configuration for ACME service
+	ACME ACME
+
+	// EST contains configuration for EST service
+	EST EST
+
+	// SCEP contains configuration for SCEP service
+	SCEP SCEP
+
 	// SQL specifies the configuration for SQL provider
 	SQL SQL
 }
@@ -476,6 +563,9 @@ func (c *Configuration) overrideFrom(o *Configuration) {
 	c.TrustyClient.overrideFrom(&o.TrustyClient)
 	overrideStrings(&c.VIPs, &o.VIPs)
 	c.Authority.overrideFrom(&o.Authority)
+	c.ACME.overrideFrom(&o.ACME)
+	c.EST.overrideFrom(&o.EST)
+	c.SCEP.overrideFrom(&o.SCEP)
 	c.SQL.overrideFrom(&o.SQL)
 
 }
@@ -500,6 +590,42 @@ func (c *CryptoProv) overrideFrom(o *CryptoProv) {
 
 }
 
+// EST contains configuration for EST service
+type EST struct {
+
+	// Labels specifies the list of EST labels, the label "default" is used for requests without a label
+	Labels []ESTLabel
+}
+
+func (c *EST) overrideFrom(o *EST) {
+	overrideESTLabelSlice(&c.Labels, &o.Labels)
+
+}
+
+// ESTLabel maps EST label to a certificate profile and issuer
+type ESTLabel struct {
+
+	// Label specifies the EST label in /.well-known/est/{label} path
+	Label string
+
+	// Profile specifies the certificate profile
+	Profile string
+
+	// Issuer specifies the label of the issuer, if not provided then the issuer is found by the profile
+	Issuer string
+
+	// Roles specifies the list of roles allowed to enroll, if not provided then any authenticated role is allowed
+	Roles []string
+}
+
+func (c *ESTLabel) overrideFrom(o *ESTLabel) {
+	overrideString(&c.Label, &o.Label)
+	overrideString(&c.Profile, &o.Profile)
+	overrideString(&c.Issuer, &o.Issuer)
+	overrideStrings(&c.Roles, &o.Roles)
+
+}
+
 // HTTPServer contains the configuration of the HTTP API Service
 type HTTPServer struct {
 
@@ -690,6 +816,12 @@ type Issuer struct {
 	// RootBundleFile specifies location of the Trusted Root CA file
 	RootBundleFile string
 
+	// OCSPCertFile specifies location of the delegated OCSP signing cert, if not provided then the Issuer signs OCSP responses
+	OCSPCertFile string
+
+	// OCSPKeyFile specifies location of the delegated OCSP signing key
+	OCSPKeyFile string
+
 	// CRLExpiry specifies value in 72h format for duration of CRL next update time
 	CRLExpiry Duration
 
@@ -698,6 +830,9 @@ type Issuer struct {
 
 	// CRLRenewal specifies value in 8h format for duration of CRL renewal before next update time
 	CRLRenewal Duration
+
+	// Profiles specifies the list of ca-config profiles served by the issuer
+	Profiles []string
 }
 
 func (c *Issuer) overrideFrom(o *Issuer) {
@@ -708,9 +843,12 @@ func (c *Issuer) overrideFrom(o *Issuer) {
 	overrideString(&c.KeyFile, &o.KeyFile)
 	overrideString(&c.CABundleFile, &o.CABundleFile)
 	overrideString(&c.RootBundleFile, &o.RootBundleFile)
+	overrideString(&c.OCSPCertFile, &o.OCSPCertFile)
+	overrideString(&c.OCSPKeyFile, &o.OCSPKeyFile)
 	overrideDuration(&c.CRLExpiry, &o.CRLExpiry)
 	overrideDuration(&c.OCSPExpiry, &o.OCSPExpiry)
 	overrideDuration(&c.CRLRenewal, &o.CRLRenewal)
+	overrideStrings(&c.Profiles, &o.Profiles)
 
 }
 
@@ -730,12 +868,18 @@ type IssuerConfig interface {
 	GetCABundleFile() string
 	// RootBundleFile specifies location of the Trusted Root CA file
 	GetRootBundleFile() string
+	// OCSPCertFile specifies location of the delegated OCSP signing cert, if not provided then the Issuer signs OCSP responses
+	GetOCSPCertFile() string
+	// OCSPKeyFile specifies location of the delegated OCSP signing key
+	GetOCSPKeyFile() string
 	// CRLExpiry specifies value in 72h format for duration of CRL next update time
 	GetCRLExpiry() time.Duration
 	// OCSPExpiry specifies value in 8h format for duration of OCSP next update time
 	GetOCSPExpiry() time.Duration
 	// CRLRenewal specifies value in 8h format for duration of CRL renewal before next update time
 	GetCRLRenewal() time.Duration
+	// Profiles specifies the list of ca-config profiles served by the issuer
+	GetProfiles() []string
 }
 
 // GetDisabled specifies if the certificate disabled to use
@@ -773,6 +917,16 @@ func (c *Issuer) GetRootBundleFile() string {
 	return c.RootBundleFile
 }
 
+// GetOCSPCertFile specifies location of the delegated OCSP signing cert, if not provided then the Issuer signs OCSP responses
+func (c *Issuer) GetOCSPCertFile() string {
+	return c.OCSPCertFile
+}
+
+// GetOCSPKeyFile specifies location of the delegated OCSP signing key
+func (c *Issuer) GetOCSPKeyFile() string {
+	return c.OCSPKeyFile
+}
+
 // GetCRLExpiry specifies value in 72h format for duration of CRL next update time
 func (c *Issuer) GetCRLExpiry() time.Duration {
 	return c.CRLExpiry.TimeDuration()
@@ -788,6 +942,11 @@ func (c *Issuer) GetCRLRenewal() time.Duration {
 	return c.CRLRenewal.TimeDuration()
 }
 
+// GetProfiles specifies the list of ca-config profiles served by the issuer
+func (c *Issuer) GetProfiles() []string {
+	return c.Profiles
+}
+
 // Logger contains information about the configuration of a logger/log rotation
 type Logger struct {
 
@@ -887,6 +1046,73 @@ func (c *RepoLogLevel) overrideFrom(o *RepoLogLevel) {
 
 }
 
+// SCEP contains configuration for SCEP service
+type SCEP struct {
+
+	// Profile specifies the certificate profile for SCEP enrollment
+	Profile string
+
+	// Issuer specifies the label of the issuer, if not provided then the issuer is found by the profile
+	Issuer string
+
+	// RACertFile specifies location of the RA certificate, the RA key must be RSA
+	RACertFile string
+
+	// RAKeyFile specifies location of the RA key, PEM encoded or PKCS#11 Uri
+	RAKeyFile string
+
+	// ChallengeExpiry specifies value in 24h format for duration of one-time challenge passwords
+	ChallengeExpiry Duration
+}
+
+func (c *SCEP) overrideFrom(o *SCEP) {
+	overrideString(&c.Profile, &o.Profile)
+	overrideString(&c.Issuer, &o.Issuer)
+	overrideString(&c.RACertFile, &o.RACertFile)
+	overrideString(&c.RAKeyFile, &o.RAKeyFile)
+	overrideDuration(&c.ChallengeExpiry, &o.ChallengeExpiry)
+
+}
+
+// SCEPConfig contains configuration for SCEPConfig service
+type SCEPConfig interface {
+	// Profile specifies the certificate profile for SCEP enrollment
+	GetProfile() string
+	// Issuer specifies the label of the issuer, if not provided then the issuer is found by the profile
+	GetIssuer() string
+	// RACertFile specifies location of the RA certificate, the RA key must be RSA
+	GetRACertFile() string
+	// RAKeyFile specifies location of the RA key, PEM encoded or PKCS#11 Uri
+	GetRAKeyFile() string
+	// ChallengeExpiry specifies value in 24h format for duration of one-time challenge passwords
+	GetChallengeExpiry() time.Duration
+}
+
+// GetProfile specifies the certificate profile for SCEP enrollment
+func (c *SCEP) GetProfile() string {
+	return c.Profile
+}
+
+// GetIssuer specifies the label of the issuer, if not provided then the issuer is found by the profile
+func (c *SCEP) GetIssuer() string {
+	return c.Issuer
+}
+
+// GetRACertFile specifies location of the RA certificate, the RA key must be RSA
+func (c *SCEP) GetRACertFile() string {
+	return c.RACertFile
+}
+
+// GetRAKeyFile specifies location of the RA key, PEM encoded or PKCS#11 Uri
+func (c *SCEP) GetRAKeyFile() string {
+	return c.RAKeyFile
+}
+
+// GetChallengeExpiry specifies value in 24h format for duration of one-time challenge passwords
+func (c *SCEP) GetChallengeExpiry() time.Duration {
+	return c.ChallengeExpiry.TimeDuration()
+}
+
 // SQL specifies the configuration for SQL provider.
 type SQL struct {
 
@@ -1053,6 +1279,12 @@ func overrideDuration(d, o *Duration) {
 	}
 }
 
+func overrideESTLabelSlice(d, o *[]ESTLabel) {
+	if len(*o) > 0 {
+		*d = *o
+	}
+}
+
 func overrideHTTPServerSlice(d, o *[]HTTPServer) {
 	if len(*o) > 0 {
 		*d = *o
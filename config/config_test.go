@@ -96,6 +96,28 @@ func Test_overrideDuration(t *testing.T) {
 	require.Equal(t, d, o, "overrideDuration should of overriden the value but didn't. value %v, expecting %v", d, o)
 }
 
+func Test_overrideESTLabelSlice(t *testing.T) {
+	d := []ESTLabel{
+		{
+			Label:   "one",
+			Profile: "one",
+			Issuer:  "one",
+			Roles:   []string{"a"}},
+	}
+	var zero []ESTLabel
+	overrideESTLabelSlice(&d, &zero)
+	require.NotEqual(t, d, zero, "overrideESTLabelSlice shouldn't have overriden the value as the override is the default/zero value. value now %v", d)
+	o := []ESTLabel{
+		{
+			Label:   "two",
+			Profile: "two",
+			Issuer:  "two",
+			Roles:   []string{"b", "b"}},
+	}
+	overrideESTLabelSlice(&d, &o)
+	require.Equal(t, d, o, "overrideESTLabelSlice should of overriden the value but didn't. value %v, expecting %v", d, o)
+}
+
 func Test_overrideHTTPServerSlice(t *testing.T) {
 	d := []HTTPServer{
 		{
@@ -190,9 +212,12 @@ func Test_overrideIssuerSlice(t *testing.T) {
 			KeyFile:        "one",
 			CABundleFile:   "one",
 			RootBundleFile: "one",
+			OCSPCertFile:   "one",
+			OCSPKeyFile:    "one",
 			CRLExpiry:      Duration(time.Second),
 			OCSPExpiry:     Duration(time.Second),
-			CRLRenewal:     Duration(time.Second)},
+			CRLRenewal:     Duration(time.Second),
+			Profiles:       []string{"a"}},
 	}
 	var zero []Issuer
 	overrideIssuerSlice(&d, &zero)
@@ -206,9 +231,12 @@ func Test_overrideIssuerSlice(t *testing.T) {
 			KeyFile:        "two",
 			CABundleFile:   "two",
 			RootBundleFile: "two",
+			OCSPCertFile:   "two",
+			OCSPKeyFile:    "two",
 			CRLExpiry:      Duration(time.Minute),
 			OCSPExpiry:     Duration(time.Minute),
-			CRLRenewal:     Duration(time.Minute)},
+			CRLRenewal:     Duration(time.Minute),
+			Profiles:       []string{"b", "b"}},
 	}
 	overrideIssuerSlice(&d, &o)
 	require.Equal(t, d, o, "overrideIssuerSlice should of overriden the value but didn't. value %v, expecting %v", d, o)
@@ -254,6 +282,59 @@ func Test_overrideStrings(t *testing.T) {
 	require.Equal(t, d, o, "overrideStrings should of overriden the value but didn't. value %v, expecting %v", d, o)
 }
 
+func TestACME_overrideFrom(t *testing.T) {
+	orig := ACME{
+		BaseURL:         "one",
+		Profile:         "one",
+		OrderExpiry:     Duration(time.Second),
+		DNSResolver:     "one",
+		AllowedNetworks: []string{"a"}}
+	dest := orig
+	var zero ACME
+	dest.overrideFrom(&zero)
+	require.Equal(t, dest, orig, "ACME.overrideFrom shouldn't have overriden the value as the override is the default/zero value. value now %#v", dest)
+	o := ACME{
+		BaseURL:         "two",
+		Profile:         "two",
+		OrderExpiry:     Duration(time.Minute),
+		DNSResolver:     "two",
+		AllowedNetworks: []string{"b", "b"}}
+	dest.overrideFrom(&o)
+	require.Equal(t, dest, o, "ACME.overrideFrom should have overriden the value as the override. value now %#v, expecting %#v", dest, o)
+	o2 := ACME{
+		BaseURL: "one"}
+	dest.overrideFrom(&o2)
+	exp := o
+
+	exp.BaseURL = o2.BaseURL
+	require.Equal(t, dest, exp, "ACME.overrideFrom should have overriden the field BaseURL. value now %#v, expecting %#v", dest, exp)
+}
+
+func TestACME_Getters(t *testing.T) {
+	orig := ACME{
+		BaseURL:         "one",
+		Profile:         "one",
+		OrderExpiry:     Duration(time.Second),
+		DNSResolver:     "one",
+		AllowedNetworks: []string{"a"}}
+
+	gv0 := orig.GetBaseURL()
+	require.Equal(t, orig.BaseURL, gv0, "ACME.GetBaseURLCfg() does not match")
+
+	gv1 := orig.GetProfile()
+	require.Equal(t, orig.Profile, gv1, "ACME.GetProfileCfg() does not match")
+
+	gv2 := orig.GetOrderExpiry()
+	require.Equal(t, orig.OrderExpiry.TimeDuration(), gv2, "ACME.GetOrderExpiry() does not match")
+
+	gv3 := orig.GetDNSResolver()
+	require.Equal(t, orig.DNSResolver, gv3, "ACME.GetDNSResolverCfg() does not match")
+
+	gv4 := orig.GetAllowedNetworks()
+	require.Equal(t, orig.AllowedNetworks, gv4, "ACME.GetAllowedNetworksCfg() does not match")
+
+}
+
 func TestAuthority_overrideFrom(t *testing.T) {
 	orig := Authority{
 		CAConfig:          "one",
@@ -269,9 +350,12 @@ func TestAuthority_overrideFrom(t *testing.T) {
 				KeyFile:        "one",
 				CABundleFile:   "one",
 				RootBundleFile: "one",
+				OCSPCertFile:   "one",
+				OCSPKeyFile:    "one",
 				CRLExpiry:      Duration(time.Second),
 				OCSPExpiry:     Duration(time.Second),
-				CRLRenewal:     Duration(time.Second)},
+				CRLRenewal:     Duration(time.Second),
+				Profiles:       []string{"a"}},
 		}}
 	dest := orig
 	var zero Authority
@@ -291,9 +375,12 @@ func TestAuthority_overrideFrom(t *testing.T) {
 				KeyFile:        "two",
 				CABundleFile:   "two",
 				RootBundleFile: "two",
+				OCSPCertFile:   "two",
+				OCSPKeyFile:    "two",
 				CRLExpiry:      Duration(time.Minute),
 				OCSPExpiry:     Duration(time.Minute),
-				CRLRenewal:     Duration(time.Minute)},
+				CRLRenewal:     Duration(time.Minute),
+				Profiles:       []string{"b", "b"}},
 		}}
 	dest.overrideFrom(&o)
 	require.Equal(t, dest, o, "Authority.overrideFrom should have overriden the value as the override. value now %#v, expecting %#v", dest, o)
@@ -317,6 +404,7 @@ func TestAuthz_overrideFrom(t *testing.T) {
 		CertMapper:    "one",
 		APIKeyMapper:  "one",
 		JWTMapper:     "one",
+		BasicMapper:   "one",
 		OAuthClient:   "one"}
 	dest := orig
 	var zero Authz
@@ -332,6 +420,7 @@ func TestAuthz_overrideFrom(t *testing.T) {
 		CertMapper:    "two",
 		APIKeyMapper:  "two",
 		JWTMapper:     "two",
+		BasicMapper:   "two",
 		OAuthClient:   "two"}
 	dest.overrideFrom(&o)
 	require.Equal(t, dest, o, "Authz.overrideFrom should have overriden the value as the override. value now %#v, expecting %#v", dest, o)
@@ -355,6 +444,7 @@ func TestAuthz_Getters(t *testing.T) {
 		CertMapper:    "one",
 		APIKeyMapper:  "one",
 		JWTMapper:     "one",
+		BasicMapper:   "one",
 		OAuthClient:   "one"}
 
 	gv0 := orig.GetAllow()
@@ -384,8 +474,11 @@ func TestAuthz_Getters(t *testing.T) {
 	gv8 := orig.GetJWTMapper()
 	require.Equal(t, orig.JWTMapper, gv8, "Authz.GetJWTMapperCfg() does not match")
 
-	gv9 := orig.GetOAuthClient()
-	require.Equal(t, orig.OAuthClient, gv9, "Authz.GetOAuthClientCfg() does not match")
+	gv9 := orig.GetBasicMapper()
+	require.Equal(t, orig.BasicMapper, gv9, "Authz.GetBasicMapperCfg() does not match")
+
+	gv10 := orig.GetOAuthClient()
+	require.Equal(t, orig.OAuthClient, gv10, "Authz.GetOAuthClientCfg() does not match")
 
 }
 
@@ -555,6 +648,7 @@ func TestConfiguration_overrideFrom(t *testing.T) {
 			CertMapper:    "one",
 			APIKeyMapper:  "one",
 			JWTMapper:     "one",
+			BasicMapper:   "one",
 			OAuthClient:   "one"},
 		Logger: Logger{
 			Directory:  "one",
@@ -625,10 +719,33 @@ func TestConfiguration_overrideFrom(t *testing.T) {
 					KeyFile:        "one",
 					CABundleFile:   "one",
 					RootBundleFile: "one",
+					OCSPCertFile:   "one",
+					OCSPKeyFile:    "one",
 					CRLExpiry:      Duration(time.Second),
 					OCSPExpiry:     Duration(time.Second),
-					CRLRenewal:     Duration(time.Second)},
+					CRLRenewal:     Duration(time.Second),
+					Profiles:       []string{"a"}},
+			}},
+		ACME: ACME{
+			BaseURL:         "one",
+			Profile:         "one",
+			OrderExpiry:     Duration(time.Second),
+			DNSResolver:     "one",
+			AllowedNetworks: []string{"a"}},
+		EST: EST{
+			Labels: []ESTLabel{
+				{
+					Label:   "one",
+					Profile: "one",
+					Issuer:  "one",
+					Roles:   []string{"a"}},
 			}},
+		SCEP: SCEP{
+			Profile:         "one",
+			Issuer:          "one",
+			RACertFile:      "one",
+			RAKeyFile:       "one",
+			ChallengeExpiry: Duration(time.Second)},
 		SQL: SQL{
 			Driver:        "one",
 			DataSource:    "one",
@@ -660,6 +777,7 @@ func TestConfiguration_overrideFrom(t *testing.T) {
 			CertMapper:    "two",
 			APIKeyMapper:  "two",
 			JWTMapper:     "two",
+			BasicMapper:   "two",
 			OAuthClient:   "two"},
 		Logger: Logger{
 			Directory:  "two",
@@ -730,10 +848,33 @@ func TestConfiguration_overrideFrom(t *testing.T) {
 					KeyFile:        "two",
 					CABundleFile:   "two",
 					RootBundleFile: "two",
+					OCSPCertFile:   "two",
+					OCSPKeyFile:    "two",
 					CRLExpiry:      Duration(time.Minute),
 					OCSPExpiry:     Duration(time.Minute),
-					CRLRenewal:     Duration(time.Minute)},
+					CRLRenewal:     Duration(time.Minute),
+					Profiles:       []string{"b", "b"}},
+			}},
+		ACME: ACME{
+			BaseURL:         "two",
+			Profile:         "two",
+			OrderExpiry:     Duration(time.Minute),
+			DNSResolver:     "two",
+			AllowedNetworks: []string{"b", "b"}},
+		EST: EST{
+			Labels: []ESTLabel{
+				{
+					Label:   "two",
+					Profile: "two",
+					Issuer:  "two",
+					Roles:   []string{"b", "b"}},
 			}},
+		SCEP: SCEP{
+			Profile:         "two",
+			Issuer:          "two",
+			RACertFile:      "two",
+			RAKeyFile:       "two",
+			ChallengeExpiry: Duration(time.Minute)},
 		SQL: SQL{
 			Driver:        "two",
 			DataSource:    "two",
@@ -773,6 +914,70 @@ func TestCryptoProv_overrideFrom(t *testing.T) {
 	require.Equal(t, dest, exp, "CryptoProv.overrideFrom should have overriden the field Default. value now %#v, expecting %#v", dest, exp)
 }
 
+func TestEST_overrideFrom(t *testing.T) {
+	orig := EST{
+		Labels: []ESTLabel{
+			{
+				Label:   "one",
+				Profile: "one",
+				Issuer:  "one",
+				Roles:   []string{"a"}},
+		}}
+	dest := orig
+	var zero EST
+	dest.overrideFrom(&zero)
+	require.Equal(t, dest, orig, "EST.overrideFrom shouldn't have overriden the value as the override is the default/zero value. value now %#v", dest)
+	o := EST{
+		Labels: []ESTLabel{
+			{
+				Label:   "two",
+				Profile: "two",
+				Issuer:  "two",
+				Roles:   []string{"b", "b"}},
+		}}
+	dest.overrideFrom(&o)
+	require.Equal(t, dest, o, "EST.overrideFrom should have overriden the value as the override. value now %#v, expecting %#v", dest, o)
+	o2 := EST{
+		Labels: []ESTLabel{
+			{
+				Label:   "one",
+				Profile: "one",
+				Issuer:  "one",
+				Roles:   []string{"a"}},
+		}}
+	dest.overrideFrom(&o2)
+	exp := o
+
+	exp.Labels = o2.Labels
+	require.Equal(t, dest, exp, "EST.overrideFrom should have overriden the field Labels. value now %#v, expecting %#v", dest, exp)
+}
+
+func TestESTLabel_overrideFrom(t *testing.T) {
+	orig := ESTLabel{
+		Label:   "one",
+		Profile: "one",
+		Issuer:  "one",
+		Roles:   []string{"a"}}
+	dest := orig
+	var zero ESTLabel
+	dest.overrideFrom(&zero)
+	require.Equal(t, dest, orig, "ESTLabel.overrideFrom shouldn't have overriden the value as the override is the default/zero value. value now %#v", dest)
+	o := ESTLabel{
+		Label:   "two",
+		Profile: "two",
+		Issuer:  "two",
+		Roles:   []string{"b", "b"}}
+	dest.overrideFrom(&o)
+	require.Equal(t, dest, o, "ESTLabel.overrideFrom should have overriden the value as the override. value now %#v, expecting %#v", dest, o)
+	o2 := ESTLabel{
+		Label: "one"}
+	dest.overrideFrom(&o2)
+	exp := o
+
+	exp.Label = o2.Label
+	require.Equal(t, dest, exp, "ESTLabel.overrideFrom should have overriden the field Label. value now %#v, expecting %#v", dest, exp)
+}
+
 func TestHTTPServer_overrideFrom(t *testing.T) {
 	orig := HTTPServer{
 		Name:       "one",
@@ -937,9 +1142,12 @@ func TestIssuer_overrideFrom(t *testing.T) {
 		KeyFile:        "one",
 		CABundleFile:   "one",
 		RootBundleFile: "one",
+		OCSPCertFile:   "one",
+		OCSPKeyFile:    "one",
 		CRLExpiry:      Duration(time.Second),
 		OCSPExpiry:     Duration(time.Second),
-		CRLRenewal:     Duration(time.Second)}
+		CRLRenewal:     Duration(time.Second),
+		Profiles:       []string{"a"}}
 	dest := orig
 	var zero Issuer
 	dest.overrideFrom(&zero)
@@ -952,9 +1160,12 @@ func TestIssuer_overrideFrom(t *testing.T) {
 		KeyFile:        "two",
 		CABundleFile:   "two",
 		RootBundleFile: "two",
+		OCSPCertFile:   "two",
+		OCSPKeyFile:    "two",
 		CRLExpiry:      Duration(time.Minute),
 		OCSPExpiry:     Duration(time.Minute),
-		CRLRenewal:     Duration(time.Minute)}
+		CRLRenewal:     Duration(time.Minute),
+		Profiles:       []string{"b", "b"}}
 	dest.overrideFrom(&o)
 	require.Equal(t, dest, o, "Issuer.overrideFrom should have overriden the value as the override. value now %#v, expecting %#v", dest, o)
 	o2 := Issuer{
@@ -975,9 +1186,12 @@ func TestIssuer_Getters(t *testing.T) {
 		KeyFile:        "one",
 		CABundleFile:   "one",
 		RootBundleFile: "one",
+		OCSPCertFile:   "one",
+		OCSPKeyFile:    "one",
 		CRLExpiry:      Duration(time.Second),
 		OCSPExpiry:     Duration(time.Second),
-		CRLRenewal:     Duration(time.Second)}
+		CRLRenewal:     Duration(time.Second),
+		Profiles:       []string{"a"}}
 
 	gv0 := orig.GetDisabled()
 	require.Equal(t, orig.Disabled, &gv0, "Issuer.GetDisabled() does not match")
@@ -1000,14 +1214,23 @@ func TestIssuer_Getters(t *testing.T) {
 	gv6 := orig.GetRootBundleFile()
 	require.Equal(t, orig.RootBundleFile, gv6, "Issuer.GetRootBundleFileCfg() does not match")
 
-	gv7 := orig.GetCRLExpiry()
-	require.Equal(t, orig.CRLExpiry.TimeDuration(), gv7, "Issuer.GetCRLExpiry() does not match")
+	gv7 := orig.GetOCSPCertFile()
+	require.Equal(t, orig.OCSPCertFile, gv7, "Issuer.GetOCSPCertFileCfg() does not match")
+
+	gv8 := orig.GetOCSPKeyFile()
+	require.Equal(t, orig.OCSPKeyFile, gv8, "Issuer.GetOCSPKeyFileCfg() does not match")
+
+	gv9 := orig.GetCRLExpiry()
+	require.Equal(t, orig.CRLExpiry.TimeDuration(), gv9, "Issuer.GetCRLExpiry() does not match")
 
-	gv8 := orig.GetOCSPExpiry()
-	require.Equal(t, orig.OCSPExpiry.TimeDuration(), gv8, "Issuer.GetOCSPExpiry() does not match")
+	gv10 := orig.GetOCSPExpiry()
+	require.Equal(t, orig.OCSPExpiry.TimeDuration(), gv10, "Issuer.GetOCSPExpiry() does not match")
 
-	gv9 := orig.GetCRLRenewal()
-	require.Equal(t, orig.CRLRenewal.TimeDuration(), gv9, "Issuer.GetCRLRenewal() does not match")
+	gv11 := orig.GetCRLRenewal()
+	require.Equal(t, orig.CRLRenewal.TimeDuration(), gv11, "Issuer.GetCRLRenewal() does not match")
+
+	gv12 := orig.GetProfiles()
+	require.Equal(t, orig.Profiles, gv12, "Issuer.GetProfilesCfg() does not match")
 
 }
 
@@ -1111,6 +1334,59 @@ func TestRepoLogLevel_overrideFrom(t *testing.T) {
 	require.Equal(t, dest, exp, "RepoLogLevel.overrideFrom should have overriden the field Repo. value now %#v, expecting %#v", dest, exp)
 }
 
+func TestSCEP_overrideFrom(t *testing.T) {
+	orig := SCEP{
+		Profile:         "one",
+		Issuer:          "one",
+		RACertFile:      "one",
+		RAKeyFile:       "one",
+		ChallengeExpiry: Duration(time.Second)}
+	dest := orig
+	var zero SCEP
+	dest.overrideFrom(&zero)
+	require.Equal(t, dest, orig, "SCEP.overrideFrom shouldn't have overriden the value as the override is the default/zero value. value now %#v", dest)
+	o := SCEP{
+		Profile:         "two",
+		Issuer:          "two",
+		RACertFile:      "two",
+		RAKeyFile:       "two",
+		ChallengeExpiry: Duration(time.Minute)}
+	dest.overrideFrom(&o)
+	require.Equal(t, dest, o, "SCEP.overrideFrom should have overriden the value as the override. value now %#v, expecting %#v", dest, o)
+	o2 := SCEP{
+		Profile: "one"}
+	dest.overrideFrom(&o2)
+	exp := o
+
+	exp.Profile = o2.Profile
+	require.Equal(t, dest, exp, "SCEP.overrideFrom should have overriden the field Profile. value now %#v, expecting %#v", dest, exp)
+}
+
+func TestSCEP_Getters(t *testing.T) {
+	orig := SCEP{
+		Profile:         "one",
+		Issuer:          "one",
+		RACertFile:      "one",
+		RAKeyFile:       "one",
+		ChallengeExpiry: Duration(time.Second)}
+
+	gv0 := orig.GetProfile()
+	require.Equal(t, orig.Profile, gv0, "SCEP.GetProfileCfg() does not match")
+
+	gv1 := orig.GetIssuer()
+	require.Equal(t, orig.Issuer, gv1, "SCEP.GetIssuerCfg() does not match")
+
+	gv2 := orig.GetRACertFile()
+	require.Equal(t, orig.RACertFile, gv2, "SCEP.GetRACertFileCfg() does not match")
+
+	gv3 := orig.GetRAKeyFile()
+	require.Equal(t, orig.RAKeyFile, gv3, "SCEP.GetRAKeyFileCfg() does not match")
+
+	gv4 := orig.GetChallengeExpiry()
+	require.Equal(t, orig.ChallengeExpiry.TimeDuration(), gv4, "SCEP.GetChallengeExpiry() does not match")
+
+}
+
 func TestSQL_overrideFrom(t *testing.T) {
 	orig := SQL{
 		Driver:        "one",
@@ -1288,6 +1564,7 @@ func Test_LoadOverrides(t *testing.T) {
 				CertMapper:    "two",
 				APIKeyMapper:  "two",
 				JWTMapper:     "two",
+				BasicMapper:   "two",
 				OAuthClient:   "two"},
 			Logger: Logger{
 				Directory:  "two",
@@ -1358,10 +1635,33 @@ func Test_LoadOverrides(t *testing.T) {
 						KeyFile:        "two",
 						CABundleFile:   "two",
 						RootBundleFile: "two",
+						OCSPCertFile:   "two",
+						OCSPKeyFile:    "two",
 						CRLExpiry:      Duration(time.Minute),
 						OCSPExpiry:     Duration(time.Minute),
-						CRLRenewal:     Duration(time.Minute)},
+						CRLRenewal:     Duration(time.Minute),
+						Profiles:       []string{"b", "b"}},
+				}},
+			ACME: ACME{
+				BaseURL:         "two",
+				Profile:         "two",
+				OrderExpiry:     Duration(time.Minute),
+				DNSResolver:     "two",
+				AllowedNetworks: []string{"b", "b"}},
+			EST: EST{
+				Labels: []ESTLabel{
+					{
+						Label:   "two",
+						Profile: "two",
+						Issuer:  "two",
+						Roles:   []string{"b", "b"}},
 				}},
+			SCEP: SCEP{
+				Profile:         "two",
+				Issuer:          "two",
+				RACertFile:      "two",
+				RAKeyFile:       "two",
+				ChallengeExpiry: Duration(time.Minute)},
 			SQL: SQL{
 				Driver:        "two",
 				DataSource:    "two",
@@ -1391,6 +1691,7 @@ func Test_LoadOverrides(t *testing.T) {
 					CertMapper:    "three",
 					APIKeyMapper:  "three",
 					JWTMapper:     "three",
+					BasicMapper:   "three",
 					OAuthClient:   "three"},
 				Logger: Logger{
 					Directory:  "three",
@@ -1461,10 +1762,33 @@ func Test_LoadOverrides(t *testing.T) {
 							KeyFile:        "three",
 							CABundleFile:   "three",
 							RootBundleFile: "three",
+							OCSPCertFile:   "three",
+							OCSPKeyFile:    "three",
 							CRLExpiry:      Duration(time.Hour),
 							OCSPExpiry:     Duration(time.Hour),
-							CRLRenewal:     Duration(time.Hour)},
+							CRLRenewal:     Duration(time.Hour),
+							Profiles:       []string{"c", "c", "c"}},
+					}},
+				ACME: ACME{
+					BaseURL:         "three",
+					Profile:         "three",
+					OrderExpiry:     Duration(time.Hour),
+					DNSResolver:     "three",
+					AllowedNetworks: []string{"c", "c", "c"}},
+				EST: EST{
+					Labels: []ESTLabel{
+						{
+							Label:   "three",
+							Profile: "three",
+							Issuer:  "three",
+							Roles:   []string{"c", "c", "c"}},
 					}},
+				SCEP: SCEP{
+					Profile:         "three",
+					Issuer:          "three",
+					RACertFile:      "three",
+					RAKeyFile:       "three",
+					ChallengeExpiry: Duration(time.Hour)},
 				SQL: SQL{
 					Driver:        "three",
 					DataSource:    "three",
@@ -1551,6 +1875,7 @@ func Test_LoadCustomJSON(t *testing.T) {
 				CertMapper:    "two",
 				APIKeyMapper:  "two",
 				JWTMapper:     "two",
+				BasicMapper:   "two",
 				OAuthClient:   "two"},
 			Logger: Logger{
 				Directory:  "two",
@@ -1621,10 +1946,33 @@ func Test_LoadCustomJSON(t *testing.T) {
 						KeyFile:        "two",
 						CABundleFile:   "two",
 						RootBundleFile: "two",
+						OCSPCertFile:   "two",
+						OCSPKeyFile:    "two",
 						CRLExpiry:      Duration(time.Minute),
 						OCSPExpiry:     Duration(time.Minute),
-						CRLRenewal:     Duration(time.Minute)},
+						CRLRenewal:     Duration(time.Minute),
+						Profiles:       []string{"b", "b"}},
 				}},
+			ACME: ACME{
+				BaseURL:         "two",
+				Profile:         "two",
+				OrderExpiry:     Duration(time.Minute),
+				DNSResolver:     "two",
+				AllowedNetworks: []string{"b", "b"}},
+			EST: EST{
+				Labels: []ESTLabel{
+					{
+						Label:   "two",
+						Profile: "two",
+						Issuer:  "two",
+						Roles:   []string{"b", "b"}},
+				}},
+			SCEP: SCEP{
+				Profile:         "two",
+				Issuer:          "two",
+				RACertFile:      "two",
+				RAKeyFile:       "two",
+				ChallengeExpiry: Duration(time.Minute)},
 			SQL: SQL{
 				Driver:        "two",
 				DataSource:    "two",
@@ -1654,6 +2002,7 @@ func Test_LoadCustomJSON(t *testing.T) {
 					CertMapper:    "three",
 					APIKeyMapper:  "three",
 					JWTMapper:     "three",
+					BasicMapper:   "three",
 					OAuthClient:   "three"},
 				Logger: Logger{
 					Directory:  "three",
@@ -1724,10 +2073,33 @@ func Test_LoadCustomJSON(t *testing.T) {
 							KeyFile:        "three",
 							CABundleFile:   "three",
 							RootBundleFile: "three",
+							OCSPCertFile:   "three",
+							OCSPKeyFile:    "three",
 							CRLExpiry:      Duration(time.Hour),
 							OCSPExpiry:     Duration(time.Hour),
-							CRLRenewal:     Duration(time.Hour)},
+							CRLRenewal:     Duration(time.Hour),
+							Profiles:       []string{"c", "c", "c"}},
+					}},
+				ACME: ACME{
+					BaseURL:         "three",
+					Profile:         "three",
+					OrderExpiry:     Duration(time.Hour),
+					DNSResolver:     "three",
+					AllowedNetworks: []string{"c", "c", "c"}},
+				EST: EST{
+					Labels: []ESTLabel{
+						{
+							Label:   "three",
+							Profile: "three",
+							Issuer:  "three",
+							Roles:   []string{"c", "c", "c"}},
 					}},
+				SCEP: SCEP{
+					Profile:         "three",
+					Issuer:          "three",
+					RACertFile:      "three",
+					RAKeyFile:       "three",
+					ChallengeExpiry: Duration(time.Hour)},
 				SQL: SQL{
 					Driver:        "three",
 					DataSource:    "three",
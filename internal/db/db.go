@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/go-phorce/dolly/fileutil"
 	"github.com/go-phorce/dolly/xlog"
@@ -35,9 +36,75 @@ type UsersDb interface {
 	LoginUser(ctx context.Context, user *model.User) (*model.User, error)
 }
 
+// CertificatesDb defines an interface for CRUD operations on Certificates
+type CertificatesDb interface {
+	// CreateCertificate registers Certificate
+	CreateCertificate(ctx context.Context, crt *model.Certificate) (*model.Certificate, error)
+	// GetCertificate returns registered Certificate by issuer key ID and serial number
+	GetCertificate(ctx context.Context, ikid, sn string) (*model.Certificate, error)
+	// GetCertificateBySKID returns the most recent Certificate with the Subject Key ID
+	GetCertificateBySKID(ctx context.Context, skid string) (*model.Certificate, error)
+	// ListCertificates returns list of Certificate info for the owner
+	ListCertificates(ctx context.Context, ownerID int64, limit int) (model.Certificates, error)
+	// ListCertificatesBySKID returns list of Certificate with the Subject Key ID,
+	// that are not expired at the specified time
+	ListCertificatesBySKID(ctx context.Context, skid string, notAfter time.Time) (model.Certificates, error)
+	// RevokeCertificate removes Certificate and creates RevokedCertificate
+	RevokeCertificate(ctx context.Context, crt *model.RevokedCertificate) (*model.RevokedCertificate, error)
+	// RevokeCertificates removes Certificates and creates RevokedCertificates
+	// in one transaction, if any of the certificates is not found,
+	// then none of them is revoked
+	RevokeCertificates(ctx context.Context, list model.RevokedCertificates) (model.RevokedCertificates, error)
+	// GetRevokedCertificate returns revoked Certificate by issuer key ID and serial number
+	GetRevokedCertificate(ctx context.Context, ikid, sn string) (*model.RevokedCertificate, error)
+	// ListRevokedCertificates returns revoked certificates of the issuer,
+	// that are not expired at the specified time
+	ListRevokedCertificates(ctx context.Context, ikid string, notAfter time.Time) (model.RevokedCertificates, error)
+	// PutCrl registers the CRL, or replaces the existing CRL of the issuer
+	PutCrl(ctx context.Context, crl *model.Crl) (*model.Crl, error)
+	// GetCrl returns the CRL of the issuer
+	GetCrl(ctx context.Context, ikid string) (*model.Crl, error)
+	// BlockKey registers the blocked key,
+	// or returns the existing one if the key is already blocked
+	BlockKey(ctx context.Context, key *model.BlockedKey) (*model.BlockedKey, error)
+	// IsKeyBlocked returns true if the key with SPKI hash is blocked
+	IsKeyBlocked(ctx context.Context, spkiHash string) (bool, error)
+}
+
+// EnrollmentTokensDb defines an interface for CRUD operations on EnrollmentTokens
+type EnrollmentTokensDb interface {
+	// CreateEnrollmentToken registers the enrollment token
+	CreateEnrollmentToken(ctx context.Context, token *model.EnrollmentToken) (*model.EnrollmentToken, error)
+	// GetEnrollmentToken returns the enrollment token by hash
+	GetEnrollmentToken(ctx context.Context, tokenHash string) (*model.EnrollmentToken, error)
+	// UseEnrollmentToken marks the enrollment token as used,
+	// if it is not used and not expired at the specified time
+	UseEnrollmentToken(ctx context.Context, tokenHash string, usedAt time.Time) (*model.EnrollmentToken, error)
+	// ReleaseEnrollmentToken marks the enrollment token as not used,
+	// if it was consumed at the specified time
+	ReleaseEnrollmentToken(ctx context.Context, tokenHash string, usedAt time.Time) error
+	// ListEnrollmentTokens returns the enrollment tokens,
+	// that are not expired at the specified time
+	ListEnrollmentTokens(ctx context.Context, notAfter time.Time) (model.EnrollmentTokens, error)
+	// RevokeEnrollmentToken removes the enrollment token
+	RevokeEnrollmentToken(ctx context.Context, id int64) (*model.EnrollmentToken, error)
+}
+
+// SCEPChallengesDb defines an interface for CRUD operations on SCEPChallenges
+type SCEPChallengesDb interface {
+	// CreateSCEPChallenge registers the challenge password
+	CreateSCEPChallenge(ctx context.Context, challenge *model.SCEPChallenge) (*model.SCEPChallenge, error)
+	// UseSCEPChallenge marks the challenge password as used,
+	// if it is not used and not expired at the specified time
+	UseSCEPChallenge(ctx context.Context, challengeHash string, usedAt time.Time) (*model.SCEPChallenge, error)
+}
+
 // Provider represents SQL client instance
 type Provider interface {
 	UsersDb
+	CertificatesDb
+	EnrollmentTokensDb
+	SCEPChallengesDb
 
 	// DB returns underlying DB connection
 	DB() *sql.DB
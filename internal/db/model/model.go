@@ -2,9 +2,12 @@ package model
 
 import (
 	"database/sql"
+	"regexp"
 	"strconv"
+	"time"
 
 	v1 "github.com/go-phorce/trusty/api/v1"
+	pb "github.com/go-phorce/trusty/api/v1/trustypb"
 	"github.com/juju/errors"
 )
 
@@ -13,6 +16,13 @@ const (
 	MaxLenForName     = 64
 	MaxLenForEmail    = 160
 	MaxLenForShortURL = 256
+	MaxLenForSerial   = 64
+	MaxLenForKeyID    = 64
+	MaxLenForSubject  = 260
+	MaxLenForProfile  = 32
+	MaxLenForRole     = 32
+	MaxLenForHost     = 160
+	MaxLenForPattern  = 256
 )
 
 // Validator provides schema validation interface
@@ -86,6 +96,271 @@ func (u *User) Validate() error {
 	return nil
 }
 
+// Certificate provides X509 Certificate information
+type Certificate struct {
+	ID           int64     `db:"id"`
+	OwnerID      int64     `db:"owner_id"`
+	SKID         string    `db:"skid"`
+	IKID         string    `db:"ikid"`
+	SerialNumber string    `db:"sn"`
+	NotBefore    time.Time `db:"notbefore"`
+	NotAfter     time.Time `db:"notafter"`
+	Subject      string    `db:"subject"`
+	Profile      string    `db:"profile"`
+	Role         string    `db:"role"`
+	Host         string    `db:"host"`
+	Pem          string    `db:"pem"`
+}
+
+// Certificates defines a list of Certificate
+type Certificates []*Certificate
+
+// ToDto converts model to pb.Certificate DTO
+func (c *Certificate) ToDto() *pb.Certificate {
+	return &pb.Certificate{
+		Id:           c.ID,
+		OwnerId:      c.OwnerID,
+		Skid:         c.SKID,
+		Ikid:         c.IKID,
+		SerialNumber: c.SerialNumber,
+		NotBefore:    c.NotBefore.Unix(),
+		NotAfter:     c.NotAfter.Unix(),
+		Subject:      c.Subject,
+		Profile:      c.Profile,
+		Pem:          c.Pem,
+	}
+}
+
+// Validate returns error if the model is not valid
+func (c *Certificate) Validate() error {
+	if c.SKID == "" || len(c.SKID) > MaxLenForKeyID {
+		return errors.Errorf("invalid SKID: %q", c.SKID)
+	}
+	if c.IKID == "" || len(c.IKID) > MaxLenForKeyID {
+		return errors.Errorf("invalid IKID: %q", c.IKID)
+	}
+	if c.SerialNumber == "" || len(c.SerialNumber) > MaxLenForSerial {
+		return errors.Errorf("invalid serial number: %q", c.SerialNumber)
+	}
+	if err := c.ValidateAttributes(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.Pem == "" {
+		return errors.Errorf("invalid PEM")
+	}
+	return nil
+}
+
+// ValidateAttributes returns error if the attributes,
+// that are known before the certificate is signed, are not valid
+func (c *Certificate) ValidateAttributes() error {
+	// the subject can be empty for certificates with SAN only
+	if len(c.Subject) > MaxLenForSubject {
+		return errors.Errorf("invalid subject: %q", c.Subject)
+	}
+	if len(c.Profile) > MaxLenForProfile {
+		return errors.Errorf("invalid profile: %q", c.Profile)
+	}
+	if len(c.Role) > MaxLenForRole {
+		return errors.Errorf("invalid role: %q", c.Role)
+	}
+	if len(c.Host) > MaxLenForHost {
+		return errors.Errorf("invalid host: %q", c.Host)
+	}
+	return nil
+}
+
+// RevokedCertificate provides revoked X509 Certificate information
+type RevokedCertificate struct {
+	Certificate Certificate `db:"certificate"`
+	RevokedAt   time.Time   `db:"revoked_at"`
+	Reason      int         `db:"reason"`
+	Requestor   string      `db:"requestor"`
+}
+
+// RevokedCertificates defines a list of RevokedCertificate
+type RevokedCertificates []*RevokedCertificate
+
+// ToDto converts model to pb.RevokedCertificate DTO
+func (r *RevokedCertificate) ToDto() *pb.RevokedCertificate {
+	return &pb.RevokedCertificate{
+		Certificate: r.Certificate.ToDto(),
+		RevokedAt:   r.RevokedAt.Unix(),
+		Reason:      pb.Reason(r.Reason),
+	}
+}
+
+// Validate returns error if the model is not valid
+func (r *RevokedCertificate) Validate() error {
+	if err := r.Certificate.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	// RFC 5280: value 7 is not used
+	if r.Reason < 0 || r.Reason > 10 || r.Reason == 7 {
+		return errors.Errorf("invalid reason: %d", r.Reason)
+	}
+	if len(r.Requestor) > MaxLenForHost {
+		return errors.Errorf("invalid requestor: %q", r.Requestor)
+	}
+	return nil
+}
+
+// Crl provides X509 CRL information
+type Crl struct {
+	ID         int64     `db:"id"`
+	IKID       string    `db:"ikid"`
+	ThisUpdate time.Time `db:"thisupdate"`
+	NextUpdate time.Time `db:"nextupdate"`
+	Issuer     string    `db:"issuer"`
+	Pem        string    `db:"pem"`
+}
+
+// Validate returns error if the model is not valid
+func (c *Crl) Validate() error {
+	if c.IKID == "" || len(c.IKID) > MaxLenForKeyID {
+		return errors.Errorf("invalid IKID: %q", c.IKID)
+	}
+	if c.Issuer == "" || len(c.Issuer) > MaxLenForSubject {
+		return errors.Errorf("invalid issuer: %q", c.Issuer)
+	}
+	if c.Pem == "" {
+		return errors.Errorf("invalid PEM")
+	}
+	return nil
+}
+
+// BlockedKey provides the public key, that is not allowed to be certified
+type BlockedKey struct {
+	ID        int64     `db:"id"`
+	SPKIHash  string    `db:"spki_hash"`
+	Reason    int       `db:"reason"`
+	Requestor string    `db:"requestor"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ToDto converts model to pb.BlockedKey DTO
+func (k *BlockedKey) ToDto() *pb.BlockedKey {
+	return &pb.BlockedKey{
+		SpkiHash:  k.SPKIHash,
+		Reason:    pb.Reason(k.Reason),
+		Requestor: k.Requestor,
+		CreatedAt: k.CreatedAt.Unix(),
+	}
+}
+
+// Validate returns error if the model is not valid
+func (k *BlockedKey) Validate() error {
+	if k.SPKIHash == "" || len(k.SPKIHash) > MaxLenForKeyID {
+		return errors.Errorf("invalid SPKI hash: %q", k.SPKIHash)
+	}
+	if k.Reason < 0 || k.Reason > 10 || k.Reason == 7 {
+		return errors.Errorf("invalid reason: %d", k.Reason)
+	}
+	if len(k.Requestor) > MaxLenForHost {
+		return errors.Errorf("invalid requestor: %q", k.Requestor)
+	}
+	return nil
+}
+
+// EnrollmentToken provides one-time token, that authorizes the certificate request,
+// only the hash of the token is stored
+type EnrollmentToken struct {
+	ID          int64        `db:"id"`
+	TokenHash   string       `db:"token_hash"`
+	Profile     string       `db:"profile"`
+	IssuerLabel string       `db:"issuer_label"`
+	NamePattern string       `db:"name_pattern"`
+	CreatedBy   string       `db:"created_by"`
+	CreatedAt   time.Time    `db:"created_at"`
+	ExpiresAt   time.Time    `db:"expires_at"`
+	UsedAt      sql.NullTime `db:"used_at"`
+}
+
+// EnrollmentTokens defines a list of EnrollmentToken
+type EnrollmentTokens []*EnrollmentToken
+
+// ToDto converts model to pb.EnrollmentToken DTO
+func (t *EnrollmentToken) ToDto() *pb.EnrollmentToken {
+	res := &pb.EnrollmentToken{
+		Id:          t.ID,
+		Profile:     t.Profile,
+		IssuerLabel: t.IssuerLabel,
+		NamePattern: t.NamePattern,
+		CreatedBy:   t.CreatedBy,
+		CreatedAt:   t.CreatedAt.Unix(),
+		ExpiresAt:   t.ExpiresAt.Unix(),
+	}
+	if t.UsedAt.Valid {
+		res.UsedAt = t.UsedAt.Time.Unix()
+	}
+	return res
+}
+
+// ToDto converts model to pb.EnrollmentTokensResponse DTO
+func (list EnrollmentTokens) ToDto() *pb.EnrollmentTokensResponse {
+	res := &pb.EnrollmentTokensResponse{
+		Tokens: make([]*pb.EnrollmentToken, len(list)),
+	}
+	for i, t := range list {
+		res.Tokens[i] = t.ToDto()
+	}
+	return res
+}
+
+// Validate returns error if the model is not valid
+func (t *EnrollmentToken) Validate() error {
+	if t.TokenHash == "" || len(t.TokenHash) > MaxLenForKeyID {
+		return errors.Errorf("invalid token hash: %q", t.TokenHash)
+	}
+	if t.Profile == "" || len(t.Profile) > MaxLenForProfile {
+		return errors.Errorf("invalid profile: %q", t.Profile)
+	}
+	if len(t.IssuerLabel) > MaxLenForName {
+		return errors.Errorf("invalid issuer label: %q", t.IssuerLabel)
+	}
+	if len(t.NamePattern) > MaxLenForPattern {
+		return errors.Errorf("invalid name pattern: %q", t.NamePattern)
+	}
+	if _, err := regexp.Compile(t.NamePattern); err != nil {
+		return errors.Errorf("invalid name pattern: %q", t.NamePattern)
+	}
+	if len(t.CreatedBy) > MaxLenForHost {
+		return errors.Errorf("invalid requestor: %q", t.CreatedBy)
+	}
+	if t.ExpiresAt.IsZero() {
+		return errors.Errorf("invalid expiry")
+	}
+	return nil
+}
+
+// SCEPChallenge provides one-time SCEP challenge password
+type SCEPChallenge struct {
+	ID            int64        `db:"id"`
+	ChallengeHash string       `db:"challenge_hash"`
+	Profile       string       `db:"profile"`
+	CreatedBy     string       `db:"created_by"`
+	CreatedAt     time.Time    `db:"created_at"`
+	ExpiresAt     time.Time    `db:"expires_at"`
+	UsedAt        sql.NullTime `db:"used_at"`
+}
+
+// Validate returns error if the model is not valid
+func (c *SCEPChallenge) Validate() error {
+	if c.ChallengeHash == "" || len(c.ChallengeHash) > MaxLenForKeyID {
+		return errors.Errorf("invalid challenge hash: %q", c.ChallengeHash)
+	}
+	if c.Profile == "" || len(c.Profile) > MaxLenForProfile {
+		return errors.Errorf("invalid profile: %q", c.Profile)
+	}
+	if len(c.CreatedBy) > MaxLenForHost {
+		return errors.Errorf("invalid requestor: %q", c.CreatedBy)
+	}
+	if c.ExpiresAt.IsZero() {
+		return errors.Errorf("invalid expiry")
+	}
+	return nil
+}
+
 // NullInt64 from *int64
 func NullInt64(val *int64) sql.NullInt64 {
 	if val == nil {
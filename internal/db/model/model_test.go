@@ -1,9 +1,12 @@
 package model_test
 
 import (
+	"database/sql"
 	"fmt"
 	"testing"
+	"time"
 
+	pb "github.com/go-phorce/trusty/api/v1/trustypb"
 	"github.com/go-phorce/trusty/internal/db/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -45,3 +48,192 @@ func TestUser(t *testing.T) {
 	assert.Equal(t, u.Company, dto.Company)
 	assert.Equal(t, u.AvatarURL, dto.AvatarURL)
 }
+
+func TestCertificate(t *testing.T) {
+	tcases := []struct {
+		m   *model.Certificate
+		err string
+	}{
+		{&model.Certificate{}, "invalid SKID: \"\""},
+		{&model.Certificate{SKID: longVal}, fmt.Sprintf("invalid SKID: %q", longVal)},
+		{&model.Certificate{SKID: "s1"}, "invalid IKID: \"\""},
+		{&model.Certificate{SKID: "s1", IKID: "i1"}, "invalid serial number: \"\""},
+		{&model.Certificate{SKID: "s1", IKID: "i1", SerialNumber: longVal}, fmt.Sprintf("invalid serial number: %q", longVal)},
+		{&model.Certificate{SKID: "s1", IKID: "i1", SerialNumber: "1"}, "invalid PEM"},
+		{&model.Certificate{SKID: "s1", IKID: "i1", SerialNumber: "1", Subject: longURL}, fmt.Sprintf("invalid subject: %q", longURL)},
+		{&model.Certificate{SKID: "s1", IKID: "i1", SerialNumber: "1", Subject: "CN=s"}, "invalid PEM"},
+		{&model.Certificate{SKID: "s1", IKID: "i1", SerialNumber: "1", Subject: "CN=s", Pem: "pem", Profile: longVal}, fmt.Sprintf("invalid profile: %q", longVal)},
+		{&model.Certificate{SKID: "s1", IKID: "i1", SerialNumber: "1", Subject: "CN=s", Pem: "pem", Role: longVal}, fmt.Sprintf("invalid role: %q", longVal)},
+		{&model.Certificate{SKID: "s1", IKID: "i1", SerialNumber: "1", Subject: "CN=s", Pem: "pem", Host: longURL}, fmt.Sprintf("invalid host: %q", longURL)},
+		{&model.Certificate{SKID: "s1", IKID: "i1", SerialNumber: "1", Subject: "CN=s", Pem: "pem", Profile: "server", Role: "peer", Host: "localhost"}, ""},
+		{&model.Certificate{SKID: "s1", IKID: "i1", SerialNumber: "1", Pem: "pem"}, ""},
+	}
+	for _, tc := range tcases {
+		err := tc.m.Validate()
+		if tc.err != "" {
+			require.Error(t, err)
+			assert.Equal(t, tc.err, err.Error())
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	// the attributes are validated before signing
+	assert.NoError(t, (&model.Certificate{Subject: "CN=s", Profile: "server", Role: "peer", Host: "localhost"}).ValidateAttributes())
+	assert.EqualError(t, (&model.Certificate{Subject: longURL}).ValidateAttributes(), fmt.Sprintf("invalid subject: %q", longURL))
+	assert.EqualError(t, (&model.Certificate{Host: longURL}).ValidateAttributes(), fmt.Sprintf("invalid host: %q", longURL))
+}
+
+func TestRevokedCertificate(t *testing.T) {
+	crt := model.Certificate{SKID: "s1", IKID: "i1", SerialNumber: "1", Subject: "CN=s", Pem: "pem"}
+	tcases := []struct {
+		m   *model.RevokedCertificate
+		err string
+	}{
+		{&model.RevokedCertificate{}, "invalid SKID: \"\""},
+		{&model.RevokedCertificate{Certificate: crt, Reason: -1}, "invalid reason: -1"},
+		{&model.RevokedCertificate{Certificate: crt, Reason: 7}, "invalid reason: 7"},
+		{&model.RevokedCertificate{Certificate: crt, Reason: 11}, "invalid reason: 11"},
+		{&model.RevokedCertificate{Certificate: crt, Reason: 1, Requestor: longURL}, fmt.Sprintf("invalid requestor: %q", longURL)},
+		{&model.RevokedCertificate{Certificate: crt, Reason: 1, Requestor: "admin"}, ""},
+	}
+	for _, tc := range tcases {
+		err := tc.m.Validate()
+		if tc.err != "" {
+			require.Error(t, err)
+			assert.Equal(t, tc.err, err.Error())
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestCrl(t *testing.T) {
+	tcases := []struct {
+		m   *model.Crl
+		err string
+	}{
+		{&model.Crl{}, "invalid IKID: \"\""},
+		{&model.Crl{IKID: longVal}, fmt.Sprintf("invalid IKID: %q", longVal)},
+		{&model.Crl{IKID: "i1"}, "invalid issuer: \"\""},
+		{&model.Crl{IKID: "i1", Issuer: "CN=ca"}, "invalid PEM"},
+		{&model.Crl{IKID: "i1", Issuer: "CN=ca", Pem: "pem"}, ""},
+	}
+	for _, tc := range tcases {
+		err := tc.m.Validate()
+		if tc.err != "" {
+			require.Error(t, err)
+			assert.Equal(t, tc.err, err.Error())
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestBlockedKey(t *testing.T) {
+	tcases := []struct {
+		m   *model.BlockedKey
+		err string
+	}{
+		{&model.BlockedKey{}, "invalid SPKI hash: \"\""},
+		{&model.BlockedKey{SPKIHash: longVal}, fmt.Sprintf("invalid SPKI hash: %q", longVal)},
+		{&model.BlockedKey{SPKIHash: "h1", Reason: 7}, "invalid reason: 7"},
+		{&model.BlockedKey{SPKIHash: "h1", Reason: 1, Requestor: longURL}, fmt.Sprintf("invalid requestor: %q", longURL)},
+		{&model.BlockedKey{SPKIHash: "h1", Reason: 1, Requestor: "admin"}, ""},
+	}
+	for _, tc := range tcases {
+		err := tc.m.Validate()
+		if tc.err != "" {
+			require.Error(t, err)
+			assert.Equal(t, tc.err, err.Error())
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	now := time.Now().UTC()
+	k := &model.BlockedKey{SPKIHash: "h1", Reason: 1, Requestor: "admin", CreatedAt: now}
+	dto := k.ToDto()
+	assert.Equal(t, "h1", dto.SpkiHash)
+	assert.Equal(t, pb.Reason_KEY_COMPROMISE, dto.Reason)
+	assert.Equal(t, "admin", dto.Requestor)
+	assert.Equal(t, now.Unix(), dto.CreatedAt)
+}
+
+func TestEnrollmentToken(t *testing.T) {
+	now := time.Now().UTC()
+	tcases := []struct {
+		m   *model.EnrollmentToken
+		err string
+	}{
+		{&model.EnrollmentToken{}, "invalid token hash: \"\""},
+		{&model.EnrollmentToken{TokenHash: longVal}, fmt.Sprintf("invalid token hash: %q", longVal)},
+		{&model.EnrollmentToken{TokenHash: "h1"}, "invalid profile: \"\""},
+		{&model.EnrollmentToken{TokenHash: "h1", Profile: longVal}, fmt.Sprintf("invalid profile: %q", longVal)},
+		{&model.EnrollmentToken{TokenHash: "h1", Profile: "server", IssuerLabel: longVal}, fmt.Sprintf("invalid issuer label: %q", longVal)},
+		{&model.EnrollmentToken{TokenHash: "h1", Profile: "server", NamePattern: longURL}, fmt.Sprintf("invalid name pattern: %q", longURL)},
+		{&model.EnrollmentToken{TokenHash: "h1", Profile: "server", NamePattern: "^(host"}, "invalid name pattern: \"^(host\""},
+		{&model.EnrollmentToken{TokenHash: "h1", Profile: "server", CreatedBy: longURL}, fmt.Sprintf("invalid requestor: %q", longURL)},
+		{&model.EnrollmentToken{TokenHash: "h1", Profile: "server"}, "invalid expiry"},
+		{&model.EnrollmentToken{TokenHash: "h1", Profile: "server", NamePattern: `^host\.trusty\.com$`, ExpiresAt: now}, ""},
+	}
+	for _, tc := range tcases {
+		err := tc.m.Validate()
+		if tc.err != "" {
+			require.Error(t, err)
+			assert.Equal(t, tc.err, err.Error())
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	tk := &model.EnrollmentToken{
+		ID:          1,
+		TokenHash:   "h1",
+		Profile:     "server",
+		IssuerLabel: "TrustyCA",
+		NamePattern: `^host\.trusty\.com$`,
+		CreatedBy:   "admin",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+	list := model.EnrollmentTokens{tk}
+	dto := list.ToDto()
+	require.Len(t, dto.Tokens, 1)
+	assert.Equal(t, int64(1), dto.Tokens[0].Id)
+	assert.Empty(t, dto.Tokens[0].Token)
+	assert.Equal(t, "server", dto.Tokens[0].Profile)
+	assert.Equal(t, "TrustyCA", dto.Tokens[0].IssuerLabel)
+	assert.Equal(t, `^host\.trusty\.com$`, dto.Tokens[0].NamePattern)
+	assert.Equal(t, "admin", dto.Tokens[0].CreatedBy)
+	assert.Equal(t, now.Unix(), dto.Tokens[0].CreatedAt)
+	assert.Equal(t, now.Add(time.Hour).Unix(), dto.Tokens[0].ExpiresAt)
+	assert.Equal(t, int64(0), dto.Tokens[0].UsedAt)
+
+	tk.UsedAt = sql.NullTime{Time: now, Valid: true}
+	assert.Equal(t, now.Unix(), tk.ToDto().UsedAt)
+}
+
+func TestSCEPChallenge(t *testing.T) {
+	tcases := []struct {
+		m   *model.SCEPChallenge
+		err string
+	}{
+		{&model.SCEPChallenge{}, "invalid challenge hash: \"\""},
+		{&model.SCEPChallenge{ChallengeHash: longVal}, fmt.Sprintf("invalid challenge hash: %q", longVal)},
+		{&model.SCEPChallenge{ChallengeHash: "h1"}, "invalid profile: \"\""},
+		{&model.SCEPChallenge{ChallengeHash: "h1", Profile: longVal}, fmt.Sprintf("invalid profile: %q", longVal)},
+		{&model.SCEPChallenge{ChallengeHash: "h1", Profile: "device", CreatedBy: longURL}, fmt.Sprintf("invalid requestor: %q", longURL)},
+		{&model.SCEPChallenge{ChallengeHash: "h1", Profile: "device"}, "invalid expiry"},
+		{&model.SCEPChallenge{ChallengeHash: "h1", Profile: "device", ExpiresAt: time.Now()}, ""},
+	}
+	for _, tc := range tcases {
+		err := tc.m.Validate()
+		if tc.err != "" {
+			require.Error(t, err)
+			assert.Equal(t, tc.err, err.Error())
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
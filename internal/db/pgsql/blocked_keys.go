@@ -0,0 +1,58 @@
+package pgsql
+
+import (
+	"context"
+
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+)
+
+// BlockKey registers the blocked key,
+// or returns the existing one if the key is already blocked
+func (p *Provider) BlockKey(ctx context.Context, key *model.BlockedKey) (*model.BlockedKey, error) {
+	id, err := p.NextID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	err = model.Validate(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res := new(model.BlockedKey)
+
+	err = p.db.QueryRowContext(ctx, `
+		INSERT INTO blocked_keys(id,spki_hash,reason,requestor,created_at)
+			VALUES($1, $2, $3, $4, $5)
+		ON CONFLICT (spki_hash)
+		DO UPDATE
+			SET spki_hash=blocked_keys.spki_hash
+		RETURNING id,spki_hash,reason,requestor,created_at
+		;`, id, key.SPKIHash, key.Reason, key.Requestor, key.CreatedAt.UTC(),
+	).Scan(&res.ID,
+		&res.SPKIHash,
+		&res.Reason,
+		&res.Requestor,
+		&res.CreatedAt,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res.CreatedAt = res.CreatedAt.UTC()
+	return res, nil
+}
+
+// IsKeyBlocked returns true if the key with SPKI hash is blocked
+func (p *Provider) IsKeyBlocked(ctx context.Context, spkiHash string) (bool, error) {
+	var blocked bool
+	err := p.db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM blocked_keys WHERE spki_hash = $1)
+		;`, spkiHash,
+	).Scan(&blocked)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return blocked, nil
+}
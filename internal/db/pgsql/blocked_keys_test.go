@@ -0,0 +1,50 @@
+package pgsql_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BlockedKeys(t *testing.T) {
+	id, err := provider.NextID()
+	require.NoError(t, err)
+
+	hash := fmt.Sprintf("spki-%d", id)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	blocked, err := provider.IsKeyBlocked(ctx, hash)
+	require.NoError(t, err)
+	assert.False(t, blocked)
+
+	key := &model.BlockedKey{
+		SPKIHash:  hash,
+		Reason:    1,
+		Requestor: "admin",
+		CreatedAt: now,
+	}
+
+	res, err := provider.BlockKey(ctx, key)
+	require.NoError(t, err)
+	assert.NotEqual(t, int64(0), res.ID)
+	key.ID = res.ID
+	assert.Equal(t, *key, *res)
+
+	blocked, err = provider.IsKeyBlocked(ctx, hash)
+	require.NoError(t, err)
+	assert.True(t, blocked)
+
+	// the existing key is returned
+	res, err = provider.BlockKey(ctx, &model.BlockedKey{
+		SPKIHash:  hash,
+		Reason:    4,
+		Requestor: "other",
+		CreatedAt: now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, *key, *res)
+}
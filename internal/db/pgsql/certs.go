@@ -0,0 +1,173 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+)
+
+// CreateCertificate registers Certificate
+func (p *Provider) CreateCertificate(ctx context.Context, crt *model.Certificate) (*model.Certificate, error) {
+	id, err := p.NextID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	err = model.Validate(crt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res := new(model.Certificate)
+
+	err = p.db.QueryRowContext(ctx, `
+		INSERT INTO certificates(id,owner_id,skid,ikid,sn,notbefore,notafter,subject,pem,profile,role,host)
+			VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id,owner_id,skid,ikid,sn,notbefore,notafter,subject,pem,profile,role,host
+		;`, id, crt.OwnerID, crt.SKID, crt.IKID, crt.SerialNumber,
+		crt.NotBefore.UTC(), crt.NotAfter.UTC(),
+		crt.Subject, crt.Pem, crt.Profile, crt.Role, crt.Host,
+	).Scan(&res.ID,
+		&res.OwnerID,
+		&res.SKID,
+		&res.IKID,
+		&res.SerialNumber,
+		&res.NotBefore,
+		&res.NotAfter,
+		&res.Subject,
+		&res.Pem,
+		&res.Profile,
+		&res.Role,
+		&res.Host,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res.NotAfter = res.NotAfter.UTC()
+	res.NotBefore = res.NotBefore.UTC()
+	return res, nil
+}
+
+// GetCertificate returns registered Certificate by issuer key ID and serial number
+func (p *Provider) GetCertificate(ctx context.Context, ikid, sn string) (*model.Certificate, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT id,owner_id,skid,ikid,sn,notbefore,notafter,subject,pem,profile,role,host
+		FROM certificates
+		WHERE ikid = $1 AND sn = $2
+		;`, ikid, sn)
+
+	return scanCertificate(row)
+}
+
+// GetCertificateBySKID returns the most recent Certificate with the Subject Key ID
+func (p *Provider) GetCertificateBySKID(ctx context.Context, skid string) (*model.Certificate, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT id,owner_id,skid,ikid,sn,notbefore,notafter,subject,pem,profile,role,host
+		FROM certificates
+		WHERE skid = $1
+		ORDER BY notbefore DESC
+		LIMIT 1
+		;`, skid)
+
+	return scanCertificate(row)
+}
+
+// ListCertificates returns list of Certificate info for the owner
+func (p *Provider) ListCertificates(ctx context.Context, ownerID int64, limit int) (model.Certificates, error) {
+	if limit <= 0 || limit > defaultLimitOfRows {
+		limit = defaultLimitOfRows
+	}
+
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id,owner_id,skid,ikid,sn,notbefore,notafter,subject,pem,profile,role,host
+		FROM certificates
+		WHERE owner_id = $1
+		ORDER BY id
+		LIMIT $2
+		;`, ownerID, limit)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer rows.Close()
+
+	return scanCertificates(rows)
+}
+
+// ListCertificatesBySKID returns list of Certificate with the Subject Key ID,
+// that are not expired at the specified time
+func (p *Provider) ListCertificatesBySKID(ctx context.Context, skid string, notAfter time.Time) (model.Certificates, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id,owner_id,skid,ikid,sn,notbefore,notafter,subject,pem,profile,role,host
+		FROM certificates
+		WHERE skid = $1 AND notafter > $2
+		ORDER BY id
+		LIMIT $3
+		;`, skid, notAfter.UTC(), defaultLimitOfRows)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer rows.Close()
+
+	return scanCertificates(rows)
+}
+
+func scanCertificates(rows *sql.Rows) (model.Certificates, error) {
+	list := make(model.Certificates, 0, 100)
+
+	for rows.Next() {
+		r := new(model.Certificate)
+		err := rows.Scan(
+			&r.ID,
+			&r.OwnerID,
+			&r.SKID,
+			&r.IKID,
+			&r.SerialNumber,
+			&r.NotBefore,
+			&r.NotAfter,
+			&r.Subject,
+			&r.Pem,
+			&r.Profile,
+			&r.Role,
+			&r.Host,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		r.NotAfter = r.NotAfter.UTC()
+		r.NotBefore = r.NotBefore.UTC()
+		list = append(list, r)
+	}
+
+	return list, nil
+}
+
+func scanCertificate(row *sql.Row) (*model.Certificate, error) {
+	res := new(model.Certificate)
+	err := row.Scan(
+		&res.ID,
+		&res.OwnerID,
+		&res.SKID,
+		&res.IKID,
+		&res.SerialNumber,
+		&res.NotBefore,
+		&res.NotAfter,
+		&res.Subject,
+		&res.Pem,
+		&res.Profile,
+		&res.Role,
+		&res.Host,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.NotFoundf("certificate")
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	res.NotAfter = res.NotAfter.UTC()
+	res.NotBefore = res.NotBefore.UTC()
+	return res, nil
+}
@@ -0,0 +1,77 @@
+package pgsql_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Certificates(t *testing.T) {
+	id, err := provider.NextID()
+	require.NoError(t, err)
+
+	ownerID := int64(id)
+	skid := fmt.Sprintf("skid-%d", id)
+	ikid := fmt.Sprintf("ikid-%d", id)
+	sn := fmt.Sprintf("%d", id)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	crt := &model.Certificate{
+		OwnerID:      ownerID,
+		SKID:         skid,
+		IKID:         ikid,
+		SerialNumber: sn,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		Subject:      "CN=localhost",
+		Pem:          "pem",
+		Profile:      "server",
+		Role:         "trusty-peer",
+		Host:         "localhost",
+	}
+
+	res, err := provider.CreateCertificate(ctx, crt)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.NotEqual(t, int64(0), res.ID)
+	crt.ID = res.ID
+	assert.Equal(t, *crt, *res)
+
+	_, err = provider.CreateCertificate(ctx, crt)
+	require.Error(t, err, "duplicate issuer and serial")
+
+	r2, err := provider.GetCertificate(ctx, ikid, sn)
+	require.NoError(t, err)
+	assert.Equal(t, *crt, *r2)
+
+	r3, err := provider.GetCertificateBySKID(ctx, skid)
+	require.NoError(t, err)
+	assert.Equal(t, *crt, *r3)
+
+	_, err = provider.GetCertificate(ctx, ikid, "notfound")
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+
+	_, err = provider.GetCertificateBySKID(ctx, "notfound")
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+
+	list, err := provider.ListCertificates(ctx, ownerID, 0)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, *crt, *list[0])
+
+	list, err = provider.ListCertificatesBySKID(ctx, skid, now)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, *crt, *list[0])
+
+	list, err = provider.ListCertificatesBySKID(ctx, skid, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
@@ -0,0 +1,85 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+)
+
+// CreateSCEPChallenge registers the challenge password
+func (p *Provider) CreateSCEPChallenge(ctx context.Context, challenge *model.SCEPChallenge) (*model.SCEPChallenge, error) {
+	id, err := p.NextID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	err = model.Validate(challenge)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res := new(model.SCEPChallenge)
+
+	err = p.db.QueryRowContext(ctx, `
+		INSERT INTO scep_challenges(id,challenge_hash,profile,created_by,created_at,expires_at)
+			VALUES($1, $2, $3, $4, $5, $6)
+		RETURNING id,challenge_hash,profile,created_by,created_at,expires_at,used_at
+		;`, id, challenge.ChallengeHash, challenge.Profile, challenge.CreatedBy,
+		challenge.CreatedAt.UTC(), challenge.ExpiresAt.UTC(),
+	).Scan(&res.ID,
+		&res.ChallengeHash,
+		&res.Profile,
+		&res.CreatedBy,
+		&res.CreatedAt,
+		&res.ExpiresAt,
+		&res.UsedAt,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return challengeToUTC(res), nil
+}
+
+// UseSCEPChallenge marks the challenge password as used,
+// if it is not used and not expired at the specified time.
+// The challenge is consumed atomically, NotFound error is returned
+// if the challenge does not exist, already used or expired
+func (p *Provider) UseSCEPChallenge(ctx context.Context, challengeHash string, usedAt time.Time) (*model.SCEPChallenge, error) {
+	res := new(model.SCEPChallenge)
+
+	err := p.db.QueryRowContext(ctx, `
+		UPDATE scep_challenges
+			SET used_at = $2
+		WHERE challenge_hash = $1 AND used_at IS NULL AND expires_at > $2
+		RETURNING id,challenge_hash,profile,created_by,created_at,expires_at,used_at
+		;`, challengeHash, usedAt.UTC(),
+	).Scan(&res.ID,
+		&res.ChallengeHash,
+		&res.Profile,
+		&res.CreatedBy,
+		&res.CreatedAt,
+		&res.ExpiresAt,
+		&res.UsedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.NotFoundf("challenge")
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return challengeToUTC(res), nil
+}
+
+func challengeToUTC(c *model.SCEPChallenge) *model.SCEPChallenge {
+	c.CreatedAt = c.CreatedAt.UTC()
+	c.ExpiresAt = c.ExpiresAt.UTC()
+	if c.UsedAt.Valid {
+		c.UsedAt.Time = c.UsedAt.Time.UTC()
+	}
+	return c
+}
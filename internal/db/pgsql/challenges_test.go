@@ -0,0 +1,54 @@
+package pgsql_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SCEPChallenges(t *testing.T) {
+	id, err := provider.NextID()
+	require.NoError(t, err)
+
+	hash := fmt.Sprintf("challenge-%d", id)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	_, err = provider.UseSCEPChallenge(ctx, hash, now)
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+
+	challenge := &model.SCEPChallenge{
+		ChallengeHash: hash,
+		Profile:       "device",
+		CreatedBy:     "admin",
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(time.Hour),
+	}
+
+	res, err := provider.CreateSCEPChallenge(ctx, challenge)
+	require.NoError(t, err)
+	assert.NotEqual(t, int64(0), res.ID)
+	challenge.ID = res.ID
+	assert.Equal(t, *challenge, *res)
+
+	// expired
+	_, err = provider.UseSCEPChallenge(ctx, hash, now.Add(2*time.Hour))
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+
+	res, err = provider.UseSCEPChallenge(ctx, hash, now)
+	require.NoError(t, err)
+	require.True(t, res.UsedAt.Valid)
+	assert.Equal(t, now, res.UsedAt.Time)
+	assert.Equal(t, "device", res.Profile)
+
+	// the challenge can be used only once
+	_, err = provider.UseSCEPChallenge(ctx, hash, now)
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+}
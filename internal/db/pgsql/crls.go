@@ -0,0 +1,74 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+)
+
+// PutCrl registers the CRL, or replaces the existing CRL of the issuer
+func (p *Provider) PutCrl(ctx context.Context, crl *model.Crl) (*model.Crl, error) {
+	id, err := p.NextID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	err = model.Validate(crl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res := new(model.Crl)
+
+	err = p.db.QueryRowContext(ctx, `
+		INSERT INTO crls(id,ikid,thisupdate,nextupdate,issuer,pem)
+			VALUES($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (ikid)
+		DO UPDATE
+			SET thisupdate=$3, nextupdate=$4, issuer=$5, pem=$6
+		RETURNING id,ikid,thisupdate,nextupdate,issuer,pem
+		;`, id, crl.IKID, crl.ThisUpdate.UTC(), crl.NextUpdate.UTC(), crl.Issuer, crl.Pem,
+	).Scan(&res.ID,
+		&res.IKID,
+		&res.ThisUpdate,
+		&res.NextUpdate,
+		&res.Issuer,
+		&res.Pem,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res.ThisUpdate = res.ThisUpdate.UTC()
+	res.NextUpdate = res.NextUpdate.UTC()
+	return res, nil
+}
+
+// GetCrl returns the CRL of the issuer
+func (p *Provider) GetCrl(ctx context.Context, ikid string) (*model.Crl, error) {
+	res := new(model.Crl)
+	err := p.db.QueryRowContext(ctx, `
+		SELECT id,ikid,thisupdate,nextupdate,issuer,pem
+		FROM crls
+		WHERE ikid = $1
+		;`, ikid,
+	).Scan(&res.ID,
+		&res.IKID,
+		&res.ThisUpdate,
+		&res.NextUpdate,
+		&res.Issuer,
+		&res.Pem,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.NotFoundf("CRL")
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res.ThisUpdate = res.ThisUpdate.UTC()
+	res.NextUpdate = res.NextUpdate.UTC()
+	return res, nil
+}
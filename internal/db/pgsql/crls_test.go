@@ -0,0 +1,55 @@
+package pgsql_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Crls(t *testing.T) {
+	id, err := provider.NextID()
+	require.NoError(t, err)
+
+	ikid := fmt.Sprintf("ikid-%d", id)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	_, err = provider.GetCrl(ctx, ikid)
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+
+	crl := &model.Crl{
+		IKID:       ikid,
+		ThisUpdate: now,
+		NextUpdate: now.Add(time.Hour),
+		Issuer:     "CN=ca",
+		Pem:        "pem1",
+	}
+
+	res, err := provider.PutCrl(ctx, crl)
+	require.NoError(t, err)
+	assert.NotEqual(t, int64(0), res.ID)
+	crl.ID = res.ID
+	assert.Equal(t, *crl, *res)
+
+	r2, err := provider.GetCrl(ctx, ikid)
+	require.NoError(t, err)
+	assert.Equal(t, *crl, *r2)
+
+	// replace
+	crl.ThisUpdate = now.Add(time.Hour)
+	crl.NextUpdate = now.Add(2 * time.Hour)
+	crl.Pem = "pem2"
+
+	res, err = provider.PutCrl(ctx, crl)
+	require.NoError(t, err)
+	assert.Equal(t, *crl, *res)
+
+	r2, err = provider.GetCrl(ctx, ikid)
+	require.NoError(t, err)
+	assert.Equal(t, *crl, *r2)
+}
@@ -0,0 +1,186 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+)
+
+// RevokeCertificate removes Certificate and creates RevokedCertificate
+func (p *Provider) RevokeCertificate(ctx context.Context, crt *model.RevokedCertificate) (*model.RevokedCertificate, error) {
+	list, err := p.RevokeCertificates(ctx, model.RevokedCertificates{crt})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return list[0], nil
+}
+
+// RevokeCertificates removes Certificates and creates RevokedCertificates
+// in one transaction, if any of the certificates is not found,
+// then none of them is revoked
+func (p *Provider) RevokeCertificates(ctx context.Context, list model.RevokedCertificates) (model.RevokedCertificates, error) {
+	for _, crt := range list {
+		err := model.Validate(crt)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Rollback is no-op after Commit
+	defer tx.Rollback()
+
+	res := make(model.RevokedCertificates, 0, len(list))
+	for _, crt := range list {
+		r, err := revokeCertificate(ctx, tx, crt)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		res = append(res, r)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return res, nil
+}
+
+func revokeCertificate(ctx context.Context, tx *sql.Tx, crt *model.RevokedCertificate) (*model.RevokedCertificate, error) {
+	r, err := tx.ExecContext(ctx, `DELETE FROM certificates WHERE id=$1;`, crt.Certificate.ID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	count, err := r.RowsAffected()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if count != 1 {
+		return nil, errors.NotFoundf("certificate %d", crt.Certificate.ID)
+	}
+
+	c := crt.Certificate
+	res := new(model.RevokedCertificate)
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO revoked(id,owner_id,skid,ikid,sn,notbefore,notafter,subject,pem,profile,role,host,revoked_at,reason,requestor)
+			VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING id,owner_id,skid,ikid,sn,notbefore,notafter,subject,pem,profile,role,host,revoked_at,reason,requestor
+		;`, c.ID, c.OwnerID, c.SKID, c.IKID, c.SerialNumber,
+		c.NotBefore.UTC(), c.NotAfter.UTC(),
+		c.Subject, c.Pem, c.Profile, c.Role, c.Host,
+		crt.RevokedAt.UTC(), crt.Reason, crt.Requestor,
+	).Scan(&res.Certificate.ID,
+		&res.Certificate.OwnerID,
+		&res.Certificate.SKID,
+		&res.Certificate.IKID,
+		&res.Certificate.SerialNumber,
+		&res.Certificate.NotBefore,
+		&res.Certificate.NotAfter,
+		&res.Certificate.Subject,
+		&res.Certificate.Pem,
+		&res.Certificate.Profile,
+		&res.Certificate.Role,
+		&res.Certificate.Host,
+		&res.RevokedAt,
+		&res.Reason,
+		&res.Requestor,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res.Certificate.NotAfter = res.Certificate.NotAfter.UTC()
+	res.Certificate.NotBefore = res.Certificate.NotBefore.UTC()
+	res.RevokedAt = res.RevokedAt.UTC()
+	return res, nil
+}
+
+// ListRevokedCertificates returns revoked certificates of the issuer,
+// that are not expired at the specified time
+func (p *Provider) ListRevokedCertificates(ctx context.Context, ikid string, notAfter time.Time) (model.RevokedCertificates, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id,owner_id,skid,ikid,sn,notbefore,notafter,subject,pem,profile,role,host,revoked_at,reason,requestor
+		FROM revoked
+		WHERE ikid = $1 AND notafter > $2
+		ORDER BY id
+		;`, ikid, notAfter.UTC())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer rows.Close()
+
+	list := make(model.RevokedCertificates, 0, 100)
+
+	for rows.Next() {
+		r := new(model.RevokedCertificate)
+		err = rows.Scan(
+			&r.Certificate.ID,
+			&r.Certificate.OwnerID,
+			&r.Certificate.SKID,
+			&r.Certificate.IKID,
+			&r.Certificate.SerialNumber,
+			&r.Certificate.NotBefore,
+			&r.Certificate.NotAfter,
+			&r.Certificate.Subject,
+			&r.Certificate.Pem,
+			&r.Certificate.Profile,
+			&r.Certificate.Role,
+			&r.Certificate.Host,
+			&r.RevokedAt,
+			&r.Reason,
+			&r.Requestor,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		r.Certificate.NotAfter = r.Certificate.NotAfter.UTC()
+		r.Certificate.NotBefore = r.Certificate.NotBefore.UTC()
+		r.RevokedAt = r.RevokedAt.UTC()
+		list = append(list, r)
+	}
+
+	return list, nil
+}
+
+// GetRevokedCertificate returns revoked Certificate by issuer key ID and serial number
+func (p *Provider) GetRevokedCertificate(ctx context.Context, ikid, sn string) (*model.RevokedCertificate, error) {
+	res := new(model.RevokedCertificate)
+	err := p.db.QueryRowContext(ctx, `
+		SELECT id,owner_id,skid,ikid,sn,notbefore,notafter,subject,pem,profile,role,host,revoked_at,reason,requestor
+		FROM revoked
+		WHERE ikid = $1 AND sn = $2
+		;`, ikid, sn,
+	).Scan(&res.Certificate.ID,
+		&res.Certificate.OwnerID,
+		&res.Certificate.SKID,
+		&res.Certificate.IKID,
+		&res.Certificate.SerialNumber,
+		&res.Certificate.NotBefore,
+		&res.Certificate.NotAfter,
+		&res.Certificate.Subject,
+		&res.Certificate.Pem,
+		&res.Certificate.Profile,
+		&res.Certificate.Role,
+		&res.Certificate.Host,
+		&res.RevokedAt,
+		&res.Reason,
+		&res.Requestor,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.NotFoundf("revoked certificate")
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res.Certificate.NotAfter = res.Certificate.NotAfter.UTC()
+	res.Certificate.NotBefore = res.Certificate.NotBefore.UTC()
+	res.RevokedAt = res.RevokedAt.UTC()
+	return res, nil
+}
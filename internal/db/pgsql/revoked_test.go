@@ -0,0 +1,120 @@
+package pgsql_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RevokeCertificate(t *testing.T) {
+	id, err := provider.NextID()
+	require.NoError(t, err)
+
+	ownerID := int64(id)
+	ikid := fmt.Sprintf("ikid-%d", id)
+	sn := fmt.Sprintf("%d", id)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	crt, err := provider.CreateCertificate(ctx, &model.Certificate{
+		OwnerID:      ownerID,
+		SKID:         fmt.Sprintf("skid-%d", id),
+		IKID:         ikid,
+		SerialNumber: sn,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		Subject:      "CN=localhost",
+		Pem:          "pem",
+		Profile:      "server",
+		Role:         "trusty-peer",
+		Host:         "localhost",
+	})
+	require.NoError(t, err)
+
+	revoked := &model.RevokedCertificate{
+		Certificate: *crt,
+		RevokedAt:   now,
+		Reason:      1,
+		Requestor:   "admin",
+	}
+	res, err := provider.RevokeCertificate(ctx, revoked)
+	require.NoError(t, err)
+	assert.Equal(t, *revoked, *res)
+
+	r2, err := provider.GetRevokedCertificate(ctx, ikid, sn)
+	require.NoError(t, err)
+	assert.Equal(t, *revoked, *r2)
+
+	_, err = provider.GetRevokedCertificate(ctx, ikid, "notfound")
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+
+	list, err := provider.ListRevokedCertificates(ctx, ikid, now)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, *revoked, *list[0])
+
+	list, err = provider.ListRevokedCertificates(ctx, ikid, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	_, err = provider.GetCertificate(ctx, ikid, sn)
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+
+	_, err = provider.RevokeCertificate(ctx, revoked)
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func Test_RevokeCertificates(t *testing.T) {
+	id, err := provider.NextID()
+	require.NoError(t, err)
+
+	skid := fmt.Sprintf("skid-%d", id)
+	ikid := fmt.Sprintf("ikid-%d", id)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	var list model.RevokedCertificates
+	for i := 0; i < 2; i++ {
+		crt, err := provider.CreateCertificate(ctx, &model.Certificate{
+			OwnerID:      int64(id),
+			SKID:         skid,
+			IKID:         ikid,
+			SerialNumber: fmt.Sprintf("%d-%d", id, i),
+			NotBefore:    now.Add(-time.Hour),
+			NotAfter:     now.Add(time.Hour),
+			Subject:      "CN=localhost",
+			Pem:          "pem",
+			Profile:      "server",
+			Role:         "trusty-peer",
+			Host:         "localhost",
+		})
+		require.NoError(t, err)
+		list = append(list, &model.RevokedCertificate{
+			Certificate: *crt,
+			RevokedAt:   now,
+			Reason:      1,
+			Requestor:   "admin",
+		})
+	}
+
+	res, err := provider.RevokeCertificates(ctx, list)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, *list[0], *res[0])
+	assert.Equal(t, *list[1], *res[1])
+
+	active, err := provider.ListCertificatesBySKID(ctx, skid, now)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+
+	// none is revoked, if one is not found
+	_, err = provider.RevokeCertificates(ctx, list)
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -0,0 +1,209 @@
+package pgsql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+)
+
+// CreateEnrollmentToken registers the enrollment token
+func (p *Provider) CreateEnrollmentToken(ctx context.Context, token *model.EnrollmentToken) (*model.EnrollmentToken, error) {
+	id, err := p.NextID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	err = model.Validate(token)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res := new(model.EnrollmentToken)
+
+	err = p.db.QueryRowContext(ctx, `
+		INSERT INTO enrollment_tokens(id,token_hash,profile,issuer_label,name_pattern,created_by,created_at,expires_at)
+			VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id,token_hash,profile,issuer_label,name_pattern,created_by,created_at,expires_at,used_at
+		;`, id, token.TokenHash, token.Profile, token.IssuerLabel, token.NamePattern, token.CreatedBy,
+		token.CreatedAt.UTC(), token.ExpiresAt.UTC(),
+	).Scan(&res.ID,
+		&res.TokenHash,
+		&res.Profile,
+		&res.IssuerLabel,
+		&res.NamePattern,
+		&res.CreatedBy,
+		&res.CreatedAt,
+		&res.ExpiresAt,
+		&res.UsedAt,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return toUTC(res), nil
+}
+
+// GetEnrollmentToken returns the enrollment token by hash
+func (p *Provider) GetEnrollmentToken(ctx context.Context, tokenHash string) (*model.EnrollmentToken, error) {
+	res := new(model.EnrollmentToken)
+
+	err := p.db.QueryRowContext(ctx, `
+		SELECT id,token_hash,profile,issuer_label,name_pattern,created_by,created_at,expires_at,used_at
+		FROM enrollment_tokens
+		WHERE token_hash = $1
+		;`, tokenHash,
+	).Scan(&res.ID,
+		&res.TokenHash,
+		&res.Profile,
+		&res.IssuerLabel,
+		&res.NamePattern,
+		&res.CreatedBy,
+		&res.CreatedAt,
+		&res.ExpiresAt,
+		&res.UsedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.NotFoundf("enrollment token")
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return toUTC(res), nil
+}
+
+// UseEnrollmentToken marks the enrollment token as used,
+// if it is not used and not expired at the specified time.
+// The token is consumed atomically, NotFound error is returned
+// if the token does not exist, already used or expired
+func (p *Provider) UseEnrollmentToken(ctx context.Context, tokenHash string, usedAt time.Time) (*model.EnrollmentToken, error) {
+	res := new(model.EnrollmentToken)
+
+	err := p.db.QueryRowContext(ctx, `
+		UPDATE enrollment_tokens
+			SET used_at = $2
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+		RETURNING id,token_hash,profile,issuer_label,name_pattern,created_by,created_at,expires_at,used_at
+		;`, tokenHash, usedAt.UTC(),
+	).Scan(&res.ID,
+		&res.TokenHash,
+		&res.Profile,
+		&res.IssuerLabel,
+		&res.NamePattern,
+		&res.CreatedBy,
+		&res.CreatedAt,
+		&res.ExpiresAt,
+		&res.UsedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.NotFoundf("enrollment token")
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return toUTC(res), nil
+}
+
+// ReleaseEnrollmentToken marks the enrollment token as not used,
+// if it was consumed at the specified time,
+// for example when the certificate was not issued.
+// NotFound error is returned if the token does not exist,
+// or was not consumed at the specified time
+func (p *Provider) ReleaseEnrollmentToken(ctx context.Context, tokenHash string, usedAt time.Time) error {
+	res, err := p.db.ExecContext(ctx, `
+		UPDATE enrollment_tokens
+			SET used_at = NULL
+		WHERE token_hash = $1 AND used_at = $2
+		;`, tokenHash, usedAt.UTC())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if count == 0 {
+		return errors.NotFoundf("enrollment token")
+	}
+	return nil
+}
+
+// ListEnrollmentTokens returns the enrollment tokens,
+// that are not expired at the specified time
+func (p *Provider) ListEnrollmentTokens(ctx context.Context, notAfter time.Time) (model.EnrollmentTokens, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id,token_hash,profile,issuer_label,name_pattern,created_by,created_at,expires_at,used_at
+		FROM enrollment_tokens
+		WHERE expires_at > $1
+		ORDER BY id
+		LIMIT $2
+		;`, notAfter.UTC(), defaultLimitOfRows)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer rows.Close()
+
+	list := make(model.EnrollmentTokens, 0, 100)
+	for rows.Next() {
+		r := new(model.EnrollmentToken)
+		err = rows.Scan(
+			&r.ID,
+			&r.TokenHash,
+			&r.Profile,
+			&r.IssuerLabel,
+			&r.NamePattern,
+			&r.CreatedBy,
+			&r.CreatedAt,
+			&r.ExpiresAt,
+			&r.UsedAt,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		list = append(list, toUTC(r))
+	}
+
+	return list, nil
+}
+
+// RevokeEnrollmentToken removes the enrollment token
+func (p *Provider) RevokeEnrollmentToken(ctx context.Context, id int64) (*model.EnrollmentToken, error) {
+	res := new(model.EnrollmentToken)
+
+	err := p.db.QueryRowContext(ctx, `
+		DELETE FROM enrollment_tokens
+		WHERE id = $1
+		RETURNING id,token_hash,profile,issuer_label,name_pattern,created_by,created_at,expires_at,used_at
+		;`, id,
+	).Scan(&res.ID,
+		&res.TokenHash,
+		&res.Profile,
+		&res.IssuerLabel,
+		&res.NamePattern,
+		&res.CreatedBy,
+		&res.CreatedAt,
+		&res.ExpiresAt,
+		&res.UsedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.NotFoundf("enrollment token")
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return toUTC(res), nil
+}
+
+func toUTC(t *model.EnrollmentToken) *model.EnrollmentToken {
+	t.CreatedAt = t.CreatedAt.UTC()
+	t.ExpiresAt = t.ExpiresAt.UTC()
+	if t.UsedAt.Valid {
+		t.UsedAt.Time = t.UsedAt.Time.UTC()
+	}
+	return t
+}
@@ -0,0 +1,94 @@
+package pgsql_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/trusty/internal/db/model"
+	"github.com/juju/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EnrollmentTokens(t *testing.T) {
+	id, err := provider.NextID()
+	require.NoError(t, err)
+
+	hash := fmt.Sprintf("token-%d", id)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	_, err = provider.GetEnrollmentToken(ctx, hash)
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+
+	token := &model.EnrollmentToken{
+		TokenHash:   hash,
+		Profile:     "server",
+		IssuerLabel: "TrustyCA",
+		NamePattern: `^host\.trusty\.com$`,
+		CreatedBy:   "admin",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+
+	res, err := provider.CreateEnrollmentToken(ctx, token)
+	require.NoError(t, err)
+	assert.NotEqual(t, int64(0), res.ID)
+	token.ID = res.ID
+	assert.Equal(t, *token, *res)
+
+	res, err = provider.GetEnrollmentToken(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, *token, *res)
+
+	list, err := provider.ListEnrollmentTokens(ctx, now)
+	require.NoError(t, err)
+	assert.Contains(t, list, token)
+
+	list, err = provider.ListEnrollmentTokens(ctx, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.NotContains(t, list, token)
+
+	// expired
+	_, err = provider.UseEnrollmentToken(ctx, hash, now.Add(2*time.Hour))
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+
+	res, err = provider.UseEnrollmentToken(ctx, hash, now)
+	require.NoError(t, err)
+	require.True(t, res.UsedAt.Valid)
+	assert.Equal(t, now, res.UsedAt.Time)
+
+	// the token can be used only once
+	_, err = provider.UseEnrollmentToken(ctx, hash, now)
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+
+	// released, if the certificate was not issued
+	err = provider.ReleaseEnrollmentToken(ctx, hash, now.Add(time.Second))
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+
+	err = provider.ReleaseEnrollmentToken(ctx, hash, now)
+	require.NoError(t, err)
+
+	res, err = provider.GetEnrollmentToken(ctx, hash)
+	require.NoError(t, err)
+	assert.False(t, res.UsedAt.Valid)
+
+	_, err = provider.UseEnrollmentToken(ctx, hash, now)
+	require.NoError(t, err)
+
+	res, err = provider.RevokeEnrollmentToken(ctx, token.ID)
+	require.NoError(t, err)
+	assert.Equal(t, token.ID, res.ID)
+
+	_, err = provider.RevokeEnrollmentToken(ctx, token.ID)
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+
+	_, err = provider.GetEnrollmentToken(ctx, hash)
+	require.Error(t, err)
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"net"
 	"net/mail"
+	"net/url"
 	"strings"
 	"time"
 
@@ -35,6 +36,7 @@ type AllowedFields struct {
 	DNSNames       bool `json:"dns"`
 	IPAddresses    bool `json:"ip"`
 	EmailAddresses bool `json:"email"`
+	URIs           bool `json:"uris"`
 }
 
 // CertificatePolicy represents the ASN.1 PolicyInformation structure from
@@ -198,6 +200,7 @@ func Parse(csrBytes []byte) (*x509.Certificate, error) {
 		DNSNames:           csrv.DNSNames,
 		IPAddresses:        csrv.IPAddresses,
 		EmailAddresses:     csrv.EmailAddresses,
+		URIs:               csrv.URIs,
 	}
 
 	for _, val := range csrv.Extensions {
@@ -292,18 +295,21 @@ func replaceSliceIfEmpty(replaced, newContents *[]string) {
 	}
 }
 
-// SetSAN fills template's IPAddresses, EmailAddresses, and DNSNames with the
+// SetSAN fills template's IPAddresses, EmailAddresses, URIs and DNSNames with the
 // content of SAN, if it is not nil.
 func SetSAN(template *x509.Certificate, SAN []string) {
 	if SAN != nil {
 		template.IPAddresses = []net.IP{}
 		template.EmailAddresses = []string{}
 		template.DNSNames = []string{}
+		template.URIs = []*url.URL{}
 	}
 
 	for _, san := range SAN {
 		if ip := net.ParseIP(san); ip != nil {
 			template.IPAddresses = append(template.IPAddresses, ip)
+		} else if uri := parseURI(san); uri != nil {
+			template.URIs = append(template.URIs, uri)
 		} else if email, err := mail.ParseAddress(san); err == nil && email != nil {
 			template.EmailAddresses = append(template.EmailAddresses, email.Address)
 		} else {
@@ -311,3 +317,16 @@ func SetSAN(template *x509.Certificate, SAN []string) {
 		}
 	}
 }
+
+// parseURI returns URL if SAN is in scheme://host/path format,
+// or nil otherwise
+func parseURI(san string) *url.URL {
+	if !strings.Contains(san, "://") {
+		return nil
+	}
+	uri, err := url.Parse(san)
+	if err != nil || uri.Scheme == "" {
+		return nil
+	}
+	return uri
+}
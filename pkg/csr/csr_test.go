@@ -154,8 +154,13 @@ func TestSetSAN(t *testing.T) {
 		"127.0.0.1",
 		"::0",
 		"ca@trusty.com",
+		"spiffe://trusty.com/ns/default/sa/trusty",
+		"https://trusty.com/users/1",
 	})
 	assert.Len(t, template.DNSNames, 2)
 	assert.Len(t, template.EmailAddresses, 1)
 	assert.Len(t, template.IPAddresses, 2)
+	require.Len(t, template.URIs, 2)
+	assert.Equal(t, "spiffe://trusty.com/ns/default/sa/trusty", template.URIs[0].String())
+	assert.Equal(t, "https://trusty.com/users/1", template.URIs[1].String())
 }
@@ -120,6 +120,8 @@ func (c *Provider) GenerateKeyAndRequest(req *CertificateRequest) (csrPEM []byte
 	for _, san := range req.SAN {
 		if ip := net.ParseIP(san); ip != nil {
 			template.IPAddresses = append(template.IPAddresses, ip)
+		} else if uri := parseURI(san); uri != nil {
+			template.URIs = append(template.URIs, uri)
 		} else if email, err := mail.ParseAddress(san); err == nil && email != nil {
 			template.EmailAddresses = append(template.EmailAddresses, email.Address)
 		} else {
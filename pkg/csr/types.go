@@ -64,6 +64,52 @@ var ExtKeyUsage = map[string]x509.ExtKeyUsage{
 	"netscape sgc":     x509.ExtKeyUsageNetscapeServerGatedCrypto,
 }
 
+// keyUsageNames provides the names of key usages in the bit order,
+// without aliases
+var keyUsageNames = []string{
+	"digital signature",
+	"content commitment",
+	"key encipherment",
+	"data encipherment",
+	"key agreement",
+	"cert sign",
+	"crl sign",
+	"encipher only",
+	"decipher only",
+}
+
+// KeyUsageNames returns the names of key usages
+func KeyUsageNames(ku x509.KeyUsage) []string {
+	var names []string
+	for _, name := range keyUsageNames {
+		if ku&KeyUsage[name] != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ExtKeyUsageNames returns the names of extended key usages
+func ExtKeyUsageNames(list []x509.ExtKeyUsage) []string {
+	var names []string
+	for _, eku := range list {
+		names = append(names, extKeyUsageName(eku))
+	}
+	return names
+}
+
+func extKeyUsageName(eku x509.ExtKeyUsage) string {
+	if eku == x509.ExtKeyUsageEmailProtection {
+		return "email protection"
+	}
+	for name, val := range ExtKeyUsage {
+		if val == eku {
+			return name
+		}
+	}
+	return fmt.Sprintf("unknown(%d)", eku)
+}
+
 // OID is the asn1's ObjectIdentifier, provide a custom
 // JSON marshal / unmarshal.
 type OID asn1.ObjectIdentifier
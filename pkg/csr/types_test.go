@@ -1,6 +1,7 @@
 package csr
 
 import (
+	"crypto/x509"
 	"encoding/json"
 	"testing"
 	"time"
@@ -86,3 +87,17 @@ func TestOIDJSONDecode(t *testing.T) {
 		assert.Equal(t, tc.err, err.Error())
 	}
 }
+
+func TestKeyUsageNames(t *testing.T) {
+	assert.Equal(t, []string{"digital signature", "cert sign"},
+		KeyUsageNames(x509.KeyUsageDigitalSignature|x509.KeyUsageCertSign))
+	assert.Empty(t, KeyUsageNames(0))
+
+	assert.Equal(t, []string{"server auth", "client auth", "email protection", "unknown(100)"},
+		ExtKeyUsageNames([]x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+			x509.ExtKeyUsageEmailProtection,
+			x509.ExtKeyUsage(100),
+		}))
+}
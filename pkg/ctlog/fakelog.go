@@ -0,0 +1,154 @@
+// Package ctlog provides in-process Certificate Transparency log for testing
+package ctlog
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-phorce/dolly/xlog"
+	ct "github.com/google/certificate-transparency-go"
+	cttls "github.com/google/certificate-transparency-go/tls"
+	"github.com/juju/errors"
+)
+
+var logger = xlog.NewPackageLogger("github.com/go-phorce/trusty/pkg", "ctlog")
+
+// FakeLog provides in-process RFC 6962 log,
+// that issues Signed Certificate Timestamps for submitted precertificates.
+// FakeLog can be used as authority.CTSubmitter, or served over HTTP.
+type FakeLog struct {
+	key   *ecdsa.PrivateKey
+	logID [sha256.Size]byte
+
+	lock    sync.Mutex
+	entries []*ct.MerkleTreeLeaf
+}
+
+// NewFakeLog returns a new instance of FakeLog with generated log key
+func NewFakeLog() (*FakeLog, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	spki, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &FakeLog{
+		key:   key,
+		logID: sha256.Sum256(spki),
+	}, nil
+}
+
+// PublicKey returns the public key of the log,
+// that can be used to verify SCTs
+func (l *FakeLog) PublicKey() crypto.PublicKey {
+	return l.key.Public()
+}
+
+// LogID returns the log ID
+func (l *FakeLog) LogID() [sha256.Size]byte {
+	return l.logID
+}
+
+// Entries returns the submitted entries
+func (l *FakeLog) Entries() []*ct.MerkleTreeLeaf {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	list := make([]*ct.MerkleTreeLeaf, len(l.entries))
+	copy(list, l.entries)
+	return list
+}
+
+// SubmitPrecert adds the precertificate chain to the log,
+// logURL is ignored
+func (l *FakeLog) SubmitPrecert(_ context.Context, logURL string, chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	return l.AddPreChain(chain)
+}
+
+// AddPreChain adds the precertificate chain to the log,
+// and returns Signed Certificate Timestamp
+func (l *FakeLog) AddPreChain(chain []ct.ASN1Cert) (*ct.SignedCertificateTimestamp, error) {
+	timestamp := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+
+	leaf, err := ct.MerkleTreeLeafFromRawChain(chain, ct.PrecertLogEntryType, timestamp)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid precertificate chain")
+	}
+
+	sct := &ct.SignedCertificateTimestamp{
+		SCTVersion: ct.V1,
+		LogID:      ct.LogID{KeyID: l.logID},
+		Timestamp:  timestamp,
+	}
+
+	input, err := ct.SerializeSCTSignatureInput(*sct, ct.LogEntry{Leaf: *leaf})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	sig, err := cttls.CreateSignature(*l.key, cttls.SHA256, input)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to sign SCT")
+	}
+	sct.Signature = ct.DigitallySigned(sig)
+
+	l.lock.Lock()
+	l.entries = append(l.entries, leaf)
+	l.lock.Unlock()
+
+	return sct, nil
+}
+
+// ServeHTTP serves add-pre-chain RFC 6962 end-point
+func (l *FakeLog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != ct.AddPreChainPath {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req ct.AddChainRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	chain := make([]ct.ASN1Cert, len(req.Chain))
+	for i, der := range req.Chain {
+		chain[i] = ct.ASN1Cert{Data: der}
+	}
+
+	sct, err := l.AddPreChain(chain)
+	if err != nil {
+		logger.Errorf("src=ServeHTTP, err=[%v]", errors.ErrorStack(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sig, err := cttls.Marshal(sct.Signature)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&ct.AddChainResponse{
+		SCTVersion: sct.SCTVersion,
+		ID:         sct.LogID.KeyID[:],
+		Timestamp:  sct.Timestamp,
+		Signature:  sig,
+	})
+}
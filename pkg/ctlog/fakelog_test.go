@@ -0,0 +1,113 @@
+package ctlog_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-phorce/trusty/pkg/ctlog"
+	ct "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/client"
+	"github.com/google/certificate-transparency-go/jsonclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeLog(t *testing.T) {
+	fake, err := ctlog.NewFakeLog()
+	require.NoError(t, err)
+
+	chain := precertChain(t)
+
+	sct, err := fake.SubmitPrecert(context.Background(), "https://localhost", chain)
+	require.NoError(t, err)
+	assert.Equal(t, ct.V1, sct.SCTVersion)
+	assert.Equal(t, fake.LogID(), sct.LogID.KeyID)
+	require.Len(t, fake.Entries(), 1)
+
+	verifier, err := ct.NewSignatureVerifier(fake.PublicKey())
+	require.NoError(t, err)
+	err = verifier.VerifySCTSignature(*sct, ct.LogEntry{Leaf: *fake.Entries()[0]})
+	assert.NoError(t, err)
+
+	_, err = fake.SubmitPrecert(context.Background(), "https://localhost", chain[:1])
+	assert.Error(t, err)
+}
+
+func TestFakeLogHTTP(t *testing.T) {
+	fake, err := ctlog.NewFakeLog()
+	require.NoError(t, err)
+
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	pubDER, err := x509.MarshalPKIXPublicKey(fake.PublicKey())
+	require.NoError(t, err)
+
+	lc, err := client.New(server.URL, nil, jsonclient.Options{PublicKeyDER: pubDER})
+	require.NoError(t, err)
+
+	chain := precertChain(t)
+	sct, err := lc.AddPreChain(context.Background(), chain)
+	require.NoError(t, err)
+	assert.Equal(t, fake.LogID(), sct.LogID.KeyID)
+
+	err = lc.VerifySCTSignature(*sct, ct.PrecertLogEntryType, chain)
+	assert.NoError(t, err)
+
+	res, err := http.Get(server.URL + ct.AddPreChainPath)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+// precertChain returns a chain of poisoned precertificate and its issuer
+func precertChain(t *testing.T) []ct.ASN1Cert {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	now := time.Now()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "[TEST] CT CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "trusty.com"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{
+			{
+				Id:       asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 3},
+				Critical: true,
+				Value:    asn1.NullBytes,
+			},
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	require.NoError(t, err)
+
+	return []ct.ASN1Cert{{Data: der}, {Data: caDER}}
+}
@@ -3,11 +3,14 @@ package print
 import (
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"fmt"
 	"io"
+	"math/big"
 	"time"
 
 	"github.com/go-phorce/dolly/xpki/certutil"
+	"github.com/go-phorce/trusty/api/v1/trustypb"
 	"golang.org/x/crypto/ocsp"
 )
 
@@ -102,17 +105,39 @@ func CertificateList(w io.Writer, crl *pkix.CertificateList) {
 	fmt.Fprintf(w, "Issuer: %s\n", crl.TBSCertList.Issuer.String())
 	fmt.Fprintf(w, "Issued: %s (%s ago)\n", crl.TBSCertList.ThisUpdate.Local().String(), issuedIn.String())
 	fmt.Fprintf(w, "Expires: %s (in %s)\n", crl.TBSCertList.NextUpdate.Local().String(), expiresIn.String())
+	for _, ext := range crl.TBSCertList.Extensions {
+		if ext.Id.Equal(oidExtensionCRLNumber) {
+			number := new(big.Int)
+			if _, err := asn1.Unmarshal(ext.Value, &number); err == nil {
+				fmt.Fprintf(w, "Number: %s\n", number.String())
+			}
+		}
+	}
 
 	if len(crl.TBSCertList.RevokedCertificates) > 0 {
 		fmt.Fprintf(w, "Revoked:\n")
 		for _, r := range crl.TBSCertList.RevokedCertificates {
-			fmt.Fprintf(w, "  - %s | %s\n",
+			fmt.Fprintf(w, "  - %s | %s",
 				r.SerialNumber.String(),
 				r.RevocationTime.Local().Format(time.RFC3339))
+			for _, ext := range r.Extensions {
+				var reason asn1.Enumerated
+				if ext.Id.Equal(oidExtensionReasonCode) {
+					if _, err := asn1.Unmarshal(ext.Value, &reason); err == nil {
+						fmt.Fprintf(w, " | %s", trustypb.Reason(reason).String())
+					}
+				}
+			}
+			fmt.Fprintln(w)
 		}
 	}
 }
 
+var (
+	oidExtensionCRLNumber  = asn1.ObjectIdentifier{2, 5, 29, 20}
+	oidExtensionReasonCode = asn1.ObjectIdentifier{2, 5, 29, 21}
+)
+
 var ocspStatusCode = map[int]string{
 	ocsp.Good:    "good",
 	ocsp.Revoked: "revoked",
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -140,3 +141,159 @@ func Issuers(w io.Writer, issuers []*trustypb.IssuerInfo, withPem bool) {
 	}
 	fmt.Fprintln(w)
 }
+
+// CertProfileInfo prints trustypb.CertProfileInfo
+func CertProfileInfo(w io.Writer, r *trustypb.CertProfileInfo) {
+	table := tablewriter.NewWriter(w)
+	table.SetBorder(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Append([]string{"Profile", r.Profile})
+	table.Append([]string{"Issuer", r.Issuer})
+	if r.Description != "" {
+		table.Append([]string{"Description", r.Description})
+	}
+	table.Append([]string{"Usage", strings.Join(r.Usage, ", ")})
+	table.Append([]string{"Expiry", r.Expiry})
+	table.Append([]string{"Backdate", r.Backdate})
+	if r.CaConstraint != nil && r.CaConstraint.IsCa {
+		table.Append([]string{"CA", fmt.Sprintf("MaxPathLen=%d, MaxPathLenZero=%t",
+			r.CaConstraint.MaxPathLen, r.CaConstraint.MaxPathLenZero)})
+	}
+	if r.OcspNoCheck {
+		table.Append([]string{"OCSP no check", "true"})
+	}
+	if r.AllowedNames != "" {
+		table.Append([]string{"Allowed CN", r.AllowedNames})
+	}
+	if r.AllowedDns != "" {
+		table.Append([]string{"Allowed DNS", r.AllowedDns})
+	}
+	if r.AllowedEmail != "" {
+		table.Append([]string{"Allowed Email", r.AllowedEmail})
+	}
+	if r.AllowedUri != "" {
+		table.Append([]string{"Allowed URI", r.AllowedUri})
+	}
+	if r.SpiffeTrustDomain != "" {
+		table.Append([]string{"SPIFFE domain", r.SpiffeTrustDomain})
+	}
+	if r.AllowedFields != nil {
+		var fields []string
+		if r.AllowedFields.Subject {
+			fields = append(fields, "subject")
+		}
+		if r.AllowedFields.Dns {
+			fields = append(fields, "dns")
+		}
+		if r.AllowedFields.Ip {
+			fields = append(fields, "ip")
+		}
+		if r.AllowedFields.Email {
+			fields = append(fields, "email")
+		}
+		if r.AllowedFields.Uris {
+			fields = append(fields, "uris")
+		}
+		table.Append([]string{"Allowed CSR fields", strings.Join(fields, ", ")})
+	}
+	if r.AllowedKeys != nil {
+		var keys []string
+		if len(r.AllowedKeys.Algorithms) > 0 {
+			keys = append(keys, strings.Join(r.AllowedKeys.Algorithms, ", "))
+		}
+		if r.AllowedKeys.MinRsaSize > 0 {
+			keys = append(keys, fmt.Sprintf("RSA>=%d", r.AllowedKeys.MinRsaSize))
+		}
+		if len(r.AllowedKeys.Curves) > 0 {
+			keys = append(keys, strings.Join(r.AllowedKeys.Curves, ", "))
+		}
+		if r.AllowedKeys.MatchKeyUsage {
+			keys = append(keys, "match key usage")
+		}
+		table.Append([]string{"Allowed keys", strings.Join(keys, "; ")})
+	}
+	if r.RejectSharedKeys {
+		table.Append([]string{"Reject shared keys", "yes"})
+	}
+	if len(r.AllowedExtensions) > 0 {
+		table.Append([]string{"Allowed extensions", strings.Join(r.AllowedExtensions, ", ")})
+	}
+	for _, p := range r.Policies {
+		table.Append([]string{"Policy", p.Id})
+		for _, q := range p.Qualifiers {
+			table.Append([]string{"", fmt.Sprintf("%s: %s", q.Type, q.Value)})
+		}
+	}
+	table.Render()
+	fmt.Fprintln(w)
+}
+
+// RevokedCertificate prints RevokedCertificate
+func RevokedCertificate(w io.Writer, r *trustypb.RevokedCertificate) {
+	table := tablewriter.NewWriter(w)
+	table.SetBorder(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	if c := r.Certificate; c != nil {
+		table.Append([]string{"Subject", c.Subject})
+		table.Append([]string{"ID", c.Skid})
+		table.Append([]string{"Issuer ID", c.Ikid})
+		table.Append([]string{"Serial", c.SerialNumber})
+		table.Append([]string{"Profile", c.Profile})
+		table.Append([]string{"Issued", time.Unix(c.NotBefore, 0).UTC().Format(time.RFC3339)})
+		table.Append([]string{"Expires", time.Unix(c.NotAfter, 0).UTC().Format(time.RFC3339)})
+	}
+	table.Append([]string{"Revoked", time.Unix(r.RevokedAt, 0).UTC().Format(time.RFC3339)})
+	table.Append([]string{"Reason", r.Reason.String()})
+	table.Render()
+	fmt.Fprintln(w)
+}
+
+// BlockedKey prints BlockedKey
+func BlockedKey(w io.Writer, r *trustypb.BlockedKey) {
+	table := tablewriter.NewWriter(w)
+	table.SetBorder(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Append([]string{"SPKI hash", r.SpkiHash})
+	table.Append([]string{"Blocked", time.Unix(r.CreatedAt, 0).UTC().Format(time.RFC3339)})
+	table.Append([]string{"Reason", r.Reason.String()})
+	if r.Requestor != "" {
+		table.Append([]string{"Requestor", r.Requestor})
+	}
+	table.Render()
+	fmt.Fprintln(w)
+}
+
+// EnrollmentToken prints EnrollmentToken
+func EnrollmentToken(w io.Writer, r *trustypb.EnrollmentToken) {
+	table := tablewriter.NewWriter(w)
+	table.SetBorder(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Append([]string{"ID", strconv.FormatInt(r.Id, 10)})
+	if r.Token != "" {
+		table.Append([]string{"Token", r.Token})
+	}
+	table.Append([]string{"Profile", r.Profile})
+	if r.IssuerLabel != "" {
+		table.Append([]string{"Issuer", r.IssuerLabel})
+	}
+	if r.NamePattern != "" {
+		table.Append([]string{"Name pattern", r.NamePattern})
+	}
+	if r.CreatedBy != "" {
+		table.Append([]string{"Created by", r.CreatedBy})
+	}
+	table.Append([]string{"Created", time.Unix(r.CreatedAt, 0).UTC().Format(time.RFC3339)})
+	table.Append([]string{"Expires", time.Unix(r.ExpiresAt, 0).UTC().Format(time.RFC3339)})
+	if r.UsedAt > 0 {
+		table.Append([]string{"Used", time.Unix(r.UsedAt, 0).UTC().Format(time.RFC3339)})
+	}
+	table.Render()
+	fmt.Fprintln(w)
+}
+
+// EnrollmentTokens prints the list of EnrollmentToken
+func EnrollmentTokens(w io.Writer, list []*trustypb.EnrollmentToken) {
+	for _, r := range list {
+		EnrollmentToken(w, r)
+	}
+}
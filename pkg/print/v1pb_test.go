@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
@@ -141,6 +142,11 @@ func Test_CertificateList(t *testing.T) {
 	require.NoError(t, err)
 
 	sn := big.NewInt(204570238945)
+	number, err := asn1.Marshal(big.NewInt(12))
+	require.NoError(t, err)
+	reason, err := asn1.Marshal(asn1.Enumerated(1))
+	require.NoError(t, err)
+
 	res := &pkix.CertificateList{
 		TBSCertList: pkix.TBSCertificateList{
 			Version:    1,
@@ -151,6 +157,16 @@ func Test_CertificateList(t *testing.T) {
 					SerialNumber:   sn,
 					RevocationTime: producedAt,
 				},
+				{
+					SerialNumber:   big.NewInt(2),
+					RevocationTime: producedAt,
+					Extensions: []pkix.Extension{
+						{Id: asn1.ObjectIdentifier{2, 5, 29, 21}, Value: reason},
+					},
+				},
+			},
+			Extensions: []pkix.Extension{
+				{Id: asn1.ObjectIdentifier{2, 5, 29, 20}, Value: number},
 			},
 		},
 	}
@@ -158,7 +174,10 @@ func Test_CertificateList(t *testing.T) {
 	w := bytes.NewBuffer([]byte{})
 	print.CertificateList(w, res)
 	out := string(w.Bytes())
+	assert.Contains(t, out, "Number: 12\n")
 	assert.Contains(t, out, "Revoked:\n")
+	assert.Contains(t, out, "  - 204570238945 | ")
+	assert.Contains(t, out, " | KEY_COMPROMISE\n")
 }
 
 func Test_OCSPResponse(t *testing.T) {
@@ -209,3 +228,123 @@ func loadJSON(filename string, v interface{}) error {
 	}
 	return nil
 }
+
+func TestCertProfileInfo(t *testing.T) {
+	r := &trustypb.CertProfileInfo{
+		Issuer:      "TrustyCA",
+		Profile:     "server",
+		Description: "server TLS profile",
+		Usage:       []string{"signing", "server auth"},
+		Expiry:      "168h0m0s",
+		Backdate:    "30m0s",
+		AllowedDns:  "^.*\\.trusty\\.com$",
+		AllowedUri:  "^spiffe://trusty\\.com/.*$",
+		AllowedFields: &trustypb.CSRAllowedFields{
+			Subject: true,
+			Dns:     true,
+			Uris:    true,
+		},
+		SpiffeTrustDomain: "trusty.com",
+		AllowedKeys: &trustypb.KeyPolicy{
+			Algorithms: []string{"RSA", "ECDSA"},
+			MinRsaSize: 2048,
+			Curves:     []string{"P-256"},
+		},
+		RejectSharedKeys:  true,
+		AllowedExtensions: []string{"1.3.6.1.5.5.7.1.1"},
+		CaConstraint:      &trustypb.CAConstraint{},
+	}
+
+	w := bytes.NewBuffer([]byte{})
+
+	print.CertProfileInfo(w, r)
+
+	out := string(w.Bytes())
+	assert.Contains(t, out, "  Profile            | server ")
+	assert.Contains(t, out, "  Issuer             | TrustyCA ")
+	assert.Contains(t, out, "  Usage              | signing, server auth ")
+	assert.Contains(t, out, "  Expiry             | 168h0m0s ")
+	assert.Contains(t, out, "  Allowed DNS        | ^.*\\.trusty\\.com$ ")
+	assert.Contains(t, out, "  Allowed URI        | ^spiffe://trusty\\.com/.*$ ")
+	assert.Contains(t, out, "  SPIFFE domain      | trusty.com ")
+	assert.Contains(t, out, "  Allowed CSR fields | subject, dns, uris ")
+	assert.Contains(t, out, "  Allowed keys       | RSA, ECDSA; RSA>=2048; P-256 ")
+	assert.Contains(t, out, "  Reject shared keys | yes ")
+	assert.Contains(t, out, "  Allowed extensions | 1.3.6.1.5.5.7.1.1 ")
+	assert.NotContains(t, out, "MaxPathLen")
+}
+
+func TestRevokedCertificate(t *testing.T) {
+	r := &trustypb.RevokedCertificate{
+		Certificate: &trustypb.Certificate{
+			Skid:         "a729df568d6561174ab78ff973ecbc95502a4701",
+			Ikid:         "05060708",
+			SerialNumber: "655573355893373448628085603800327957133511654393",
+			Subject:      "CN=localhost",
+			Profile:      "server",
+			NotBefore:    1603065600,
+			NotAfter:     1603670400,
+		},
+		RevokedAt: 1603152000,
+		Reason:    trustypb.Reason_KEY_COMPROMISE,
+	}
+
+	w := bytes.NewBuffer([]byte{})
+
+	print.RevokedCertificate(w, r)
+
+	out := string(w.Bytes())
+	assert.Contains(t, out, "  Subject   | CN=localhost ")
+	assert.Contains(t, out, "  Serial    | 655573355893373448628085603800327957133511654393 ")
+	assert.Contains(t, out, "  Issued    | 2020-10-19T00:00:00Z ")
+	assert.Contains(t, out, "  Expires   | 2020-10-26T00:00:00Z ")
+	assert.Contains(t, out, "  Revoked   | 2020-10-20T00:00:00Z ")
+	assert.Contains(t, out, "  Reason    | KEY_COMPROMISE ")
+}
+
+func TestBlockedKey(t *testing.T) {
+	r := &trustypb.BlockedKey{
+		SpkiHash:  "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c",
+		Reason:    trustypb.Reason_KEY_COMPROMISE,
+		Requestor: "admin",
+		CreatedAt: 1603152000,
+	}
+
+	w := bytes.NewBuffer([]byte{})
+
+	print.BlockedKey(w, r)
+
+	out := string(w.Bytes())
+	assert.Contains(t, out, "  SPKI hash | b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c ")
+	assert.Contains(t, out, "  Blocked   | 2020-10-20T00:00:00Z ")
+	assert.Contains(t, out, "  Reason    | KEY_COMPROMISE ")
+	assert.Contains(t, out, "  Requestor | admin ")
+}
+
+func TestEnrollmentToken(t *testing.T) {
+	r := &trustypb.EnrollmentToken{
+		Id:          1234,
+		Token:       "gG3Q2Yv0pJ7mX9rT4kLwZb8sH1nC5eUaVdIoFqRyMxE",
+		Profile:     "server",
+		IssuerLabel: "TrustyCA",
+		NamePattern: `^.*\.trusty\.com$`,
+		CreatedBy:   "admin",
+		CreatedAt:   1603152000,
+		ExpiresAt:   1603238400,
+	}
+
+	w := bytes.NewBuffer([]byte{})
+
+	print.EnrollmentTokens(w, []*trustypb.EnrollmentToken{r})
+
+	out := string(w.Bytes())
+	assert.Contains(t, out, "  ID           | 1234 ")
+	assert.Contains(t, out, "  Token        | gG3Q2Yv0pJ7mX9rT4kLwZb8sH1nC5eUaVdIoFqRyMxE ")
+	assert.Contains(t, out, "  Profile      | server ")
+	assert.Contains(t, out, "  Issuer       | TrustyCA ")
+	assert.Contains(t, out, `  Name pattern | ^.*\.trusty\.com$ `)
+	assert.Contains(t, out, "  Created by   | admin ")
+	assert.Contains(t, out, "  Created      | 2020-10-20T00:00:00Z ")
+	assert.Contains(t, out, "  Expires      | 2020-10-21T00:00:00Z ")
+	assert.NotContains(t, out, "Used")
+}
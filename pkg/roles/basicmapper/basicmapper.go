@@ -0,0 +1,114 @@
+package basicmapper
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/go-phorce/dolly/xhttp/header"
+	"github.com/go-phorce/dolly/xhttp/identity"
+	"github.com/go-phorce/dolly/xlog"
+	"github.com/juju/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ProviderName is identifier for role mapper provider
+const ProviderName = "basic"
+
+var logger = xlog.NewPackageLogger("github.com/go-phorce/trusty/pkg", "basicmapper")
+
+// User provides credentials and role of the user
+type User struct {
+	// Name of the user
+	Name string `json:"name"`
+	// PasswordHash is bcrypt hash of the password
+	PasswordHash string `json:"password_hash"`
+	// Role of the user
+	Role string `json:"role"`
+}
+
+// Config provides mapping of users to roles
+type Config struct {
+	// Users is a list of users with HTTP Basic credentials
+	Users []User `json:"users"`
+}
+
+// Provider of HTTP Basic identity
+type Provider struct {
+	users map[string]User
+}
+
+// LoadConfig returns configuration loaded from a file
+func LoadConfig(file string) (*Config, error) {
+	if file == "" {
+		return &Config{}, nil
+	}
+
+	jsonFile, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var config Config
+	err = json.Unmarshal(jsonFile, &config)
+	if err != nil {
+		return nil, errors.Annotatef(err, "unable to unmarshal %q", file)
+	}
+
+	return &config, nil
+}
+
+// Load returns new Provider
+func Load(cfgfile string) (*Provider, error) {
+	cfg, err := LoadConfig(cfgfile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return New(cfg)
+}
+
+// New returns new Provider
+func New(cfg *Config) (*Provider, error) {
+	p := &Provider{
+		users: map[string]User{},
+	}
+
+	for _, user := range cfg.Users {
+		if user.Name == "" || user.Role == "" {
+			return nil, errors.Errorf("name and role are required")
+		}
+		if _, err := bcrypt.Cost([]byte(user.PasswordHash)); err != nil {
+			return nil, errors.Errorf("invalid password hash for %q user", user.Name)
+		}
+		if _, ok := p.users[user.Name]; ok {
+			return nil, errors.Errorf("duplicate user: %q", user.Name)
+		}
+		p.users[user.Name] = user
+	}
+	return p, nil
+}
+
+// Applicable returns true if the request has autherization data applicable to the provider
+func (p *Provider) Applicable(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get(header.Authorization), "Basic ")
+}
+
+// IdentityMapper interface
+func (p *Provider) IdentityMapper(r *http.Request) (identity.Identity, error) {
+	name, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, nil
+	}
+
+	user, ok := p.users[name]
+	if !ok {
+		return nil, errors.Errorf("api=IdentityMapper, user=%q, reason='user not found'", name)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, errors.Errorf("api=IdentityMapper, user=%q, reason='invalid password'", name)
+	}
+
+	logger.Infof("api=IdentityMapper, user=%q, role=%s", name, user.Role)
+	return identity.NewIdentity(user.Role, name, ""), nil
+}
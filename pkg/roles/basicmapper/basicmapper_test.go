@@ -0,0 +1,94 @@
+package basicmapper_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-phorce/trusty/pkg/roles/basicmapper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Config(t *testing.T) {
+	_, err := basicmapper.Load("testdata/missing.json")
+	require.Error(t, err)
+	assert.Equal(t, "open testdata/missing.json: no such file or directory", err.Error())
+
+	_, err = basicmapper.Load("testdata/roles_corrupted.1.json")
+	require.Error(t, err)
+	assert.Equal(t, `unable to unmarshal "testdata/roles_corrupted.1.json": invalid character ']' looking for beginning of object key string`, err.Error())
+
+	_, err = basicmapper.Load("testdata/roles_corrupted.2.json")
+	require.Error(t, err)
+	assert.Equal(t, `invalid password hash for "est-client" user`, err.Error())
+
+	_, err = basicmapper.Load("")
+	require.NoError(t, err)
+
+	cfg, err := basicmapper.LoadConfig("testdata/roles.json")
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(cfg.Users))
+
+	_, err = basicmapper.New(&basicmapper.Config{
+		Users: []basicmapper.User{{Name: "user"}},
+	})
+	require.Error(t, err)
+	assert.Equal(t, "name and role are required", err.Error())
+
+	cfg.Users = append(cfg.Users, cfg.Users[0])
+	_, err = basicmapper.New(cfg)
+	require.Error(t, err)
+	assert.Equal(t, `duplicate user: "est-client"`, err.Error())
+}
+
+func Test_identity(t *testing.T) {
+	p, err := basicmapper.Load("testdata/roles.json")
+	require.NoError(t, err)
+
+	t.Run("not_applicable", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodGet, "/", nil)
+		assert.False(t, p.Applicable(r))
+
+		id, err := p.IdentityMapper(r)
+		require.NoError(t, err)
+		assert.Nil(t, id)
+
+		r.Header.Set("Authorization", "Bearer token")
+		assert.False(t, p.Applicable(r))
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodGet, "/", nil)
+		r.SetBasicAuth("unknown", "est-secret")
+		assert.True(t, p.Applicable(r))
+
+		_, err := p.IdentityMapper(r)
+		require.Error(t, err)
+		assert.Equal(t, `api=IdentityMapper, user="unknown", reason='user not found'`, err.Error())
+	})
+
+	t.Run("invalid_password", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodGet, "/", nil)
+		r.SetBasicAuth("est-client", "admin-secret")
+
+		_, err := p.IdentityMapper(r)
+		require.Error(t, err)
+		assert.Equal(t, `api=IdentityMapper, user="est-client", reason='invalid password'`, err.Error())
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodGet, "/", nil)
+		r.SetBasicAuth("est-client", "est-secret")
+
+		id, err := p.IdentityMapper(r)
+		require.NoError(t, err)
+		require.NotNil(t, id)
+		assert.Equal(t, "trusty-client", id.Role())
+		assert.Equal(t, "est-client", id.Name())
+
+		r.SetBasicAuth("admin", "admin-secret")
+		id, err = p.IdentityMapper(r)
+		require.NoError(t, err)
+		assert.Equal(t, "trusty-admin/admin", id.String())
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-phorce/dolly/xhttp/identity"
 	"github.com/go-phorce/dolly/xlog"
+	"github.com/go-phorce/trusty/pkg/roles/basicmapper"
 	"github.com/go-phorce/trusty/pkg/roles/certmapper"
 	"github.com/go-phorce/trusty/pkg/roles/jwtmapper"
 	"github.com/juju/errors"
@@ -31,12 +32,13 @@ type IdentityProvider interface {
 
 // Provider for authz identity
 type Provider struct {
-	CertMapper *certmapper.Provider
-	JwtMapper  *jwtmapper.Provider
+	CertMapper  *certmapper.Provider
+	JwtMapper   *jwtmapper.Provider
+	BasicMapper *basicmapper.Provider
 }
 
 // New returns Authz provider instance
-func New(jwtMapper, certMapper string) (*Provider, error) {
+func New(jwtMapper, certMapper, basicMapper string) (*Provider, error) {
 	var err error
 	prov := new(Provider)
 
@@ -52,6 +54,12 @@ func New(jwtMapper, certMapper string) (*Provider, error) {
 			return nil, errors.Annotatef(err, "failed to load JWT mapper")
 		}
 	}
+	if basicMapper != "" {
+		prov.BasicMapper, err = basicmapper.Load(basicMapper)
+		if err != nil {
+			return nil, errors.Annotatef(err, "failed to load basic mapper %s", basicMapper)
+		}
+	}
 	return prov, nil
 }
 
@@ -60,6 +68,9 @@ func (p *Provider) IdentityMapper(r *http.Request) (identity.Identity, error) {
 	if p.JwtMapper != nil && p.JwtMapper.Applicable(r) {
 		return p.JwtMapper.IdentityMapper(r)
 	}
+	if p.BasicMapper != nil && p.BasicMapper.Applicable(r) {
+		return p.BasicMapper.IdentityMapper(r)
+	}
 	if p.CertMapper != nil && p.CertMapper.Applicable(r) {
 		return p.CertMapper.IdentityMapper(r)
 	}
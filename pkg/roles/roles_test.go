@@ -14,7 +14,7 @@ import (
 )
 
 func Test_Empty(t *testing.T) {
-	p, err := roles.New("", "")
+	p, err := roles.New("", "", "")
 	require.NoError(t, err)
 
 	r, _ := http.NewRequest(http.MethodGet, "/", nil)
@@ -25,19 +25,24 @@ func Test_Empty(t *testing.T) {
 }
 
 func Test_Notfound(t *testing.T) {
-	_, err := roles.New("", "missing_roles.json")
+	_, err := roles.New("", "missing_roles.json", "")
 	require.Error(t, err)
 	assert.Equal(t, "failed to load cert mapper missing_roles.json: open missing_roles.json: no such file or directory", err.Error())
 
-	_, err = roles.New("missing_roles.json", "")
+	_, err = roles.New("missing_roles.json", "", "")
 	require.Error(t, err)
 	assert.Equal(t, "failed to load JWT mapper: open missing_roles.json: no such file or directory", err.Error())
+
+	_, err = roles.New("", "", "missing_roles.json")
+	require.Error(t, err)
+	assert.Equal(t, "failed to load basic mapper missing_roles.json: open missing_roles.json: no such file or directory", err.Error())
 }
 
 func Test_All(t *testing.T) {
 	p, err := roles.New(
 		"jwtmapper/testdata/roles.json",
-		"certmapper/testdata/roles.json")
+		"certmapper/testdata/roles.json",
+		"basicmapper/testdata/roles.json")
 	require.NoError(t, err)
 
 	t.Run("trusty-client", func(t *testing.T) {
@@ -68,4 +73,11 @@ func Test_All(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "trusty-client/ra-*.trusty.com", id.String())
 	})
+	t.Run("basic", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodGet, "/", nil)
+		r.SetBasicAuth("est-client", "est-secret")
+		id, err := p.IdentityMapper(r)
+		require.NoError(t, err)
+		assert.Equal(t, "trusty-client/est-client", id.String())
+	})
 }
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE public.certificates
+    ALTER COLUMN sn TYPE character varying(64) COLLATE pg_catalog."default";
+
+ALTER TABLE public.revoked
+    ALTER COLUMN sn TYPE character varying(64) COLLATE pg_catalog."default";
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS public.crls
+(
+    id bigint NOT NULL,
+    ikid character varying(64) COLLATE pg_catalog."default" NOT NULL,
+    thisupdate timestamp with time zone,
+    nextupdate timestamp with time zone,
+    issuer character varying(260) COLLATE pg_catalog."default" NOT NULL,
+    pem text COLLATE pg_catalog."default" NOT NULL,
+    CONSTRAINT crls_pkey PRIMARY KEY (id),
+    CONSTRAINT crls_ikid UNIQUE (ikid)
+)
+WITH (
+    OIDS = FALSE
+);
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS public.blocked_keys
+(
+    id bigint NOT NULL,
+    spki_hash character varying(64) COLLATE pg_catalog."default" NOT NULL,
+    reason integer,
+    requestor character varying(160) COLLATE pg_catalog."default",
+    created_at timestamp with time zone,
+    CONSTRAINT blocked_keys_pkey PRIMARY KEY (id),
+    CONSTRAINT blocked_keys_spki_hash UNIQUE (spki_hash)
+)
+WITH (
+    OIDS = FALSE
+);
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS public.enrollment_tokens
+(
+    id bigint NOT NULL,
+    token_hash character varying(64) COLLATE pg_catalog."default" NOT NULL,
+    profile character varying(32) COLLATE pg_catalog."default" NOT NULL,
+    issuer_label character varying(64) COLLATE pg_catalog."default",
+    name_pattern character varying(256) COLLATE pg_catalog."default",
+    created_by character varying(160) COLLATE pg_catalog."default",
+    created_at timestamp with time zone,
+    expires_at timestamp with time zone NOT NULL,
+    used_at timestamp with time zone,
+    CONSTRAINT enrollment_tokens_pkey PRIMARY KEY (id),
+    CONSTRAINT enrollment_tokens_token_hash UNIQUE (token_hash)
+)
+WITH (
+    OIDS = FALSE
+);
+
+COMMIT;
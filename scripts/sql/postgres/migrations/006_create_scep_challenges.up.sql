@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS public.scep_challenges
+(
+    id bigint NOT NULL,
+    challenge_hash character varying(64) COLLATE pg_catalog."default" NOT NULL,
+    profile character varying(32) COLLATE pg_catalog."default" NOT NULL,
+    created_by character varying(160) COLLATE pg_catalog."default",
+    created_at timestamp with time zone,
+    expires_at timestamp with time zone NOT NULL,
+    used_at timestamp with time zone,
+    CONSTRAINT scep_challenges_pkey PRIMARY KEY (id),
+    CONSTRAINT scep_challenges_challenge_hash UNIQUE (challenge_hash)
+)
+WITH (
+    OIDS = FALSE
+);
+
+COMMIT;
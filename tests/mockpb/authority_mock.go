@@ -42,6 +42,14 @@ func (m *MockAuthorityServer) CreateCertificate(context.Context, *trustypb.Creat
 	return m.Resps[0].(*trustypb.CertificateBundle), nil
 }
 
+// EnrollCertificate returns the certificate, authorized by the enrollment token
+func (m *MockAuthorityServer) EnrollCertificate(context.Context, *trustypb.CreateCertificateRequest) (*trustypb.CertificateBundle, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Resps[0].(*trustypb.CertificateBundle), nil
+}
+
 // Issuers returns the issuing CAs
 func (m *MockAuthorityServer) Issuers(context.Context, *trustypb.EmptyRequest) (*trustypb.IssuersInfoResponse, error) {
 	if m.Err != nil {
@@ -49,3 +57,43 @@ func (m *MockAuthorityServer) Issuers(context.Context, *trustypb.EmptyRequest) (
 	}
 	return m.Resps[0].(*trustypb.IssuersInfoResponse), nil
 }
+
+// RevokeCertificate returns the revoked certificate
+func (m *MockAuthorityServer) RevokeCertificate(context.Context, *trustypb.RevokeCertificateRequest) (*trustypb.RevokedCertificate, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Resps[0].(*trustypb.RevokedCertificate), nil
+}
+
+// BlockKey adds the public key to the list of keys, that are not allowed to be certified
+func (m *MockAuthorityServer) BlockKey(context.Context, *trustypb.BlockKeyRequest) (*trustypb.BlockedKey, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Resps[0].(*trustypb.BlockedKey), nil
+}
+
+// CreateEnrollmentToken returns one-time token, that authorizes CreateCertificate request
+func (m *MockAuthorityServer) CreateEnrollmentToken(context.Context, *trustypb.CreateEnrollmentTokenRequest) (*trustypb.EnrollmentToken, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Resps[0].(*trustypb.EnrollmentToken), nil
+}
+
+// ListEnrollmentTokens returns the enrollment tokens, that are not expired
+func (m *MockAuthorityServer) ListEnrollmentTokens(context.Context, *trustypb.EmptyRequest) (*trustypb.EnrollmentTokensResponse, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Resps[0].(*trustypb.EnrollmentTokensResponse), nil
+}
+
+// RevokeEnrollmentToken removes the enrollment token
+func (m *MockAuthorityServer) RevokeEnrollmentToken(context.Context, *trustypb.RevokeEnrollmentTokenRequest) (*trustypb.EnrollmentToken, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Resps[0].(*trustypb.EnrollmentToken), nil
+}